@@ -10,7 +10,6 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"path/filepath"
 	"strings"
 
@@ -29,21 +28,14 @@ type Account struct {
 
 // NewAccount - create new account
 func NewAccount() (*Account, error) {
-	account := &Account{
-		Address: common.Address{'\r'}, // Set mock address
-	} // Init account buffer
-
-	for bytes.Contains(account.Address.Bytes(), []byte{'\r'}) { // Generate accounts until valid
-		privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
-		if err != nil {                                                    // Check for errors
-			return &Account{}, err // Return error
-		}
-
-		account, err = AccountFromKey(privateKey) // Generate account from key
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		return &Account{}, err // Return error
+	}
 
-		if err != nil { // Check for errors
-			return &Account{}, err // Return error
-		}
+	account, err := AccountFromKey(privateKey) // Generate account from key
+	if err != nil {                            // Check for errors
+		return &Account{}, err // Return error
 	}
 
 	chain, err := types.NewChain(account.Address) // Init account chain
@@ -60,10 +52,11 @@ func NewAccount() (*Account, error) {
 	return account, nil // Return initialized account
 }
 
-// NewContractAccount - create new account for contract
-func NewContractAccount(contractSource []byte, deployingAccountAddress *common.Address) (*Account, error) {
-	deployingAccount, err := ReadAccountFromMemory(*deployingAccountAddress) // Read account private key
-	if err != nil {                                                          // Check for errors
+// NewContractAccount - create new account for contract, attaching a given ABI descriptor (may be nil). The deploying account must
+// be unlocked.
+func NewContractAccount(contractSource []byte, abi *types.ABI, deployingAccountAddress *common.Address) (*Account, error) {
+	deployingAccount, err := GetUnlockedAccount(*deployingAccountAddress) // Get account private key
+	if err != nil {                                                       // Check for errors
 		return &Account{}, err // Return error
 	}
 
@@ -77,8 +70,8 @@ func NewContractAccount(contractSource []byte, deployingAccountAddress *common.A
 		return &Account{}, err // Return error
 	}
 
-	deploymentTransaction, err := types.NewTransaction(0, nil, &deployingAccount.Address, &account.Address, big.NewFloat(0), nil) // Initialize transaction
-	if err != nil {                                                                                                               // Check for errors
+	deploymentTransaction, err := types.NewTransaction(0, nil, &deployingAccount.Address, &account.Address, common.NewAmount(nil), nil) // Initialize transaction
+	if err != nil {                                                                                                                     // Check for errors
 		return &Account{}, err // Return error
 	}
 
@@ -88,8 +81,8 @@ func NewContractAccount(contractSource []byte, deployingAccountAddress *common.A
 		return &Account{}, err // Return error
 	}
 
-	chain, err := types.NewContractChain(account.Address, contractSource, deploymentTransaction) // Init contract chain
-	if err != nil {                                                                              // Check for errors
+	chain, err := types.NewContractChain(account.Address, contractSource, abi, deploymentTransaction) // Init contract chain
+	if err != nil {                                                                                   // Check for errors
 		return &Account{}, err // Return error
 	}
 
@@ -146,24 +139,24 @@ func GetAllAccounts() ([]string, error) {
 func GetAllContracts(deployingAccount common.Address) ([]string, error) {
 	buffer := []string{} // Init buffer
 
-	files, err := ioutil.ReadDir(filepath.FromSlash(fmt.Sprintf("%s/db/chain", common.DataDir))) // Walk chain dir
-	if err != nil {                                                                              // Check for errors
+	store, err := types.GetChainStore() // Get working chain store
+	if err != nil {                     // Check for errors
 		return []string{}, err // Return found error
 	}
 
-	for _, file := range files { // Iterate through files
-		chainBytes, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/db/chain/%s", common.DataDir, file.Name()))) // Read file
-		if err != nil {                                                                                                    // Check for errors
-			return []string{}, err // Return found error
-		}
+	addresses, err := store.GetChainAddresses() // Get all chain addresses
+	if err != nil {                             // Check for errors
+		return []string{}, err // Return found error
+	}
 
-		chain, err := types.FromBytes(chainBytes) // Get chain
-		if err != nil {                           // Check for errors
+	for _, address := range addresses { // Iterate through chains
+		chain, err := store.ReadChain(address) // Read chain
+		if err != nil {                        // Check for errors
 			return []string{}, err // Return found error
 		}
 
-		if chain.ContractSource != nil && *chain.Transactions[0].Sender == deployingAccount { // Check is contract from account
-			buffer = append(buffer, strings.Split(strings.Split(file.Name(), "chain_")[1], ".json")[0]) // Append to buffer
+		if chain.ContractSource != nil && len(chain.Transactions) > 0 && *chain.Transactions[0].Sender == deployingAccount { // Check is contract from account
+			buffer = append(buffer, address.String()) // Append to buffer
 		}
 	}
 
@@ -59,6 +59,22 @@ func TestNewContractAccount(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
+	err = account.WriteToMemory("test") // Write to memory
+
+	if err != nil { // Check for errors
+		t.Error(err) // Log found error
+		t.FailNow()  // Panic
+	}
+
+	err = Unlock(account.Address, "test", 0) // Unlock deploying account
+
+	if err != nil { // Check for errors
+		t.Error(err) // Log found error
+		t.FailNow()  // Panic
+	}
+
+	defer Lock(account.Address) // Lock deploying account
+
 	path, _ := filepath.Abs(filepath.FromSlash("../types/main.wasm")) // Get path
 
 	contractSource, err := ioutil.ReadFile(path) // Read contract source
@@ -67,8 +83,8 @@ func TestNewContractAccount(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
-	contractInstance, err := NewContractAccount(contractSource, &account.Address) // Deploy contract
-	if err != nil {                                                               // Check for errors
+	contractInstance, err := NewContractAccount(contractSource, nil, &account.Address) // Deploy contract
+	if err != nil {                                                                    // Check for errors
 		t.Error(err) // Log found error
 		t.FailNow()  // Panic
 	}
@@ -101,7 +117,7 @@ func TestGetAllAccounts(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
-	err = account.WriteToMemory() // Make sure we have at least one account to walk
+	err = account.WriteToMemory("test") // Make sure we have at least one account to walk
 
 	if err != nil { // Check for errors
 		t.Error(err) // Log found error
@@ -138,13 +154,22 @@ func TestGetAllContracts(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
-	err = account.WriteToMemory() // Make sure we have at least one account to walk
+	err = account.WriteToMemory("test") // Make sure we have at least one account to walk
+
+	if err != nil { // Check for errors
+		t.Error(err) // Log found error
+		t.FailNow()  // Panic
+	}
+
+	err = Unlock(account.Address, "test", 0) // Unlock deploying account
 
 	if err != nil { // Check for errors
 		t.Error(err) // Log found error
 		t.FailNow()  // Panic
 	}
 
+	defer Lock(account.Address) // Lock deploying account
+
 	path, _ := filepath.Abs(filepath.FromSlash("../types/main.wasm")) // Get path
 
 	contractSource, err := ioutil.ReadFile(path) // Read contract source
@@ -153,7 +178,7 @@ func TestGetAllContracts(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
-	_, err = NewContractAccount(contractSource, &account.Address) // Deploy contract
+	_, err = NewContractAccount(contractSource, nil, &account.Address) // Deploy contract
 
 	if err != nil { // Check for errors
 		t.Error(err) // Log found error
@@ -4,58 +4,168 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 
 	"github.com/SummerCash/go-summercash/common"
 )
 
-// WriteToMemory - write given account to persistent memory
-func (account *Account) WriteToMemory() error {
-	err := account.MakeEncodingSafe() // Make safe for encoding
-	if err != nil {                   // Check for errors
+/* BEGIN EXPORTED METHODS */
+
+// WriteToMemory - encrypt given account's private key with a given passphrase, and write it to persistent memory. Keystore
+// files are only readable by the current user.
+func (account *Account) WriteToMemory(passphrase string) error {
+	encryptedKey, err := EncryptKey(account, passphrase) // Encrypt key
+	if err != nil {                                      // Check for errors
+		return err // Return found error
+	}
+
+	return writeEncryptedKey(keystorePath(account.Address.String()), encryptedKey) // Write key
+}
+
+// ReadAccountFromMemory - read account with address from persistent memory, decrypting its private key with a given passphrase.
+// Legacy unencrypted keystore files are not read (see MigrateAccount).
+func ReadAccountFromMemory(address common.Address, passphrase string) (*Account, error) {
+	return ReadAccountFromFile(accountPath(address), passphrase) // Read account
+}
+
+// ReadAccountFromFile - read the account stored in a given keystore file (e.g. one copied to an offline machine), decrypting its
+// private key with a given passphrase. The file is never modified: legacy unencrypted keystore files are rejected with
+// ErrLegacyKeystore (see MigrateAccount).
+func ReadAccountFromFile(path string, passphrase string) (*Account, error) {
+	data, err := ioutil.ReadFile(path) // Read account file
+	if err != nil {                    // Check for errors
+		return &Account{}, err // Return error
+	}
+
+	var header struct {
+		Version int `json:"version"` // Keystore format version
+	} // Init header buffer
+
+	err = json.Unmarshal(data, &header) // Unmarshal header
+
+	if err != nil { // Check for errors
+		return &Account{}, err // Return error
+	}
+
+	if header.Version == 0 { // Check legacy unencrypted file
+		return &Account{}, ErrLegacyKeystore // Return error
+	}
+
+	encryptedKey := &EncryptedKey{} // Init key buffer
+
+	err = json.Unmarshal(data, encryptedKey) // Unmarshal into buffer
+
+	if err != nil { // Check for errors
+		return &Account{}, err // Return error
+	}
+
+	return DecryptKey(encryptedKey, passphrase) // Decrypt key
+}
+
+// MigrateAccount - overwrite the legacy unencrypted keystore file of the account with a given address with its private key
+// encrypted with a given passphrase. The passphrase must match a given confirmation (so that a mistyped passphrase can't
+// lock the account). Does nothing if the account's keystore file is already encrypted.
+func MigrateAccount(address common.Address, passphrase string, confirmation string) error {
+	if passphrase != confirmation { // Check passphrase not confirmed
+		return ErrPassphraseMismatch // Return error
+	}
+
+	path := accountPath(address) // Get keystore path
+
+	data, err := ioutil.ReadFile(path) // Read account file
+	if err != nil {                    // Check for errors
 		return err // Return error
 	}
 
-	err = common.CreateDirIfDoesNotExist(filepath.FromSlash(fmt.Sprintf("%s/keystore", common.DataDir))) // Create dir if necessary
+	var header struct {
+		Version int `json:"version"` // Keystore format version
+	} // Init header buffer
+
+	err = json.Unmarshal(data, &header) // Unmarshal header
 
 	if err != nil { // Check for errors
 		return err // Return error
 	}
 
-	json, err := json.MarshalIndent(*account, "", "  ") // Marshal account
-	if err != nil {                                     // Check for errors
+	if header.Version != 0 { // Check already encrypted
+		return nil // Nothing to migrate
+	}
+
+	buffer := &Account{} // Init buffer
+
+	err = json.Unmarshal(data, buffer) // Unmarshal into buffer
+
+	if err != nil { // Check for errors
 		return err // Return error
 	}
 
-	err = ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/keystore/account_%s.json", common.DataDir, account.Address.String())), json, 0644) // Write json
+	err = buffer.RecoverSafeEncoding() // Recover
 
 	if err != nil { // Check for errors
+		return err // Return error
+	}
+
+	encryptedKey, err := EncryptKey(buffer, passphrase) // Encrypt key
+	if err != nil {                                     // Check for errors
 		return err // Return found error
 	}
 
-	return account.RecoverSafeEncoding() // Recover
+	return writeEncryptedKey(path, encryptedKey) // Overwrite legacy file
 }
 
-// ReadAccountFromMemory - read account with address from persistent memory
-func ReadAccountFromMemory(address common.Address) (*Account, error) {
-	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/keystore/account_%s.json", common.DataDir, address.String()))) // Read account file
-	if err != nil {                                                                                                                // Check for errors
-		return &Account{}, err // Return error
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// keystorePath - get the path of the node keystore file of the account with a given address string
+func keystorePath(address string) string {
+	return filepath.Join(keystoreDir(), fmt.Sprintf("account_%s.json", address)) // Return path
+}
+
+// keystoreDir - get the path of the node keystore
+func keystoreDir() string {
+	return filepath.FromSlash(fmt.Sprintf("%s/keystore", common.DataDir)) // Return path
+}
+
+// accountPath - get the path of the node keystore file of the account with a given address, falling back to the legacy
+// address format if no file is stored under the current format
+func accountPath(address common.Address) string {
+	path := keystorePath(address.String()) // Get keystore path
+
+	if _, err := os.Stat(path); os.IsNotExist(err) { // Check no account file
+		path = keystorePath(address.LegacyString()) // Get keystore path under legacy address format
 	}
 
-	buffer := &Account{} // Init buffer
+	return path // Return path
+}
 
-	err = json.Unmarshal(data, buffer) // Unmarshal into buffer
+// writeEncryptedKey - write a given encrypted key to a given node keystore path, restricting the node keystore to the
+// current user
+func writeEncryptedKey(path string, encryptedKey *EncryptedKey) error {
+	err := common.CreateDirIfDoesNotExist(keystoreDir()) // Create keystore dir if necessary
+	if err != nil {                                      // Check for errors
+		return err // Return error
+	}
+
+	err = os.Chmod(keystoreDir(), 0700) // Restrict keystore dir to current user
 
 	if err != nil { // Check for errors
-		return &Account{}, err // Return error
+		return err // Return found error
 	}
 
-	err = buffer.RecoverSafeEncoding() // Recover
+	json, err := json.MarshalIndent(encryptedKey, "", "  ") // Marshal key
+	if err != nil {                                         // Check for errors
+		return err // Return error
+	}
+
+	err = ioutil.WriteFile(path, json, 0600) // Write json
 
 	if err != nil { // Check for errors
-		return &Account{}, err // Return error
+		return err // Return found error
 	}
 
-	return buffer, nil // Return read account
+	return os.Chmod(path, 0600) // Restrict files written before keystore encryption to current user
 }
+
+/* END INTERNAL METHODS */
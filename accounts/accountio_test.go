@@ -10,7 +10,7 @@ func TestWriteToMemory(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
-	err = account.WriteToMemory() // Write to memory
+	err = account.WriteToMemory("test") // Write to memory
 
 	if err != nil { // Check for errors
 		t.Error(err) // Log found error
@@ -28,14 +28,14 @@ func TestReadFromMemory(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
-	err = account.WriteToMemory() // Write to memory
+	err = account.WriteToMemory("test") // Write to memory
 
 	if err != nil { // Check for errors
 		t.Error(err) // Log found error
 		t.FailNow()  // Panic
 	}
 
-	account, err = ReadAccountFromMemory(account.Address) // Read account from persistent memory
+	account, err = ReadAccountFromMemory(account.Address, "test") // Read account from persistent memory
 
 	if err != nil { // Check for errors
 		t.Error(err) // Log found error
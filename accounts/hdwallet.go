@@ -0,0 +1,280 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/crypto"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+// WalletVersion - version of the encrypted wallet file format
+const WalletVersion = 1
+
+// WalletGapLimit - number of consecutive unused derived accounts after which a wallet restore stops searching for used accounts
+const WalletGapLimit = 20
+
+// hdSeedKey - HMAC key used to derive the master key from a wallet seed (as in SLIP-10, which extends BIP-32 to NIST curves)
+const hdSeedKey = "SummerCash P-521 seed"
+
+// hardenedIndexOffset - offset of hardened child indexes (all wallet accounts are hardened children of the master key)
+const hardenedIndexOffset = 1 << 31
+
+// hdKeySize - number of HMAC bytes reduced to each derived P-521 scalar (66 bytes cover the 521-bit curve order, and the
+// remaining 16 bytes make the bias of the reduction negligible)
+const hdKeySize = 66 + 16
+
+// Wallet - hierarchical deterministic wallet, deriving any number of accounts from a single BIP-39 seed. Account n is derived
+// at path m/n' (hardened) from the seed's P-521 master key. As P-521 scalars are wider than a single HMAC-SHA512 half, every
+// key is derived from two HMAC-SHA512 blocks (see hdExpand), so derived keys have the curve's full security level.
+type Wallet struct {
+	Seed      []byte `json:"-"`         // BIP-39 seed (never encoded; see WriteToMemory)
+	NextIndex uint32 `json:"nextIndex"` // Index of the next account to derive
+}
+
+// EncryptedWallet - wallet with a passphrase-encrypted seed, as stored in persistent memory
+type EncryptedWallet struct {
+	Version   int    `json:"version"`   // Wallet format version
+	NextIndex uint32 `json:"nextIndex"` // Index of the next account to derive
+
+	Crypto EncryptedKeyCrypto `json:"crypto"` // Encrypted seed
+}
+
+var (
+	// ErrInvalidAccountIndex - error definition describing a derived account index that does not fit in a hardened child index
+	ErrInvalidAccountIndex = errors.New("account index must be less than 2^31")
+
+	// ErrInvalidDerivedKey - error definition describing an index that derives an invalid (zero) private key
+	ErrInvalidDerivedKey = errors.New("index derives an invalid key; use the next index")
+
+	// ErrWalletExists - error definition describing an attempt to replace the local wallet
+	ErrWalletExists = errors.New("a wallet already exists in the data directory")
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// WalletFromMnemonic - initialize a wallet from a given BIP-39 mnemonic seed phrase
+func WalletFromMnemonic(mnemonic string) (*Wallet, error) {
+	seed, err := MnemonicToSeed(mnemonic, "") // Derive seed
+	if err != nil {                           // Check for errors
+		return &Wallet{}, err // Return found error
+	}
+
+	return &Wallet{Seed: seed}, nil // Return wallet
+}
+
+// DeriveAccount - derive the account at a given index in the wallet
+func (wallet *Wallet) DeriveAccount(index uint32) (*Account, error) {
+	if index >= hardenedIndexOffset { // Check invalid index
+		return &Account{}, ErrInvalidAccountIndex // Return error
+	}
+
+	masterKey, chainCode := hdExpand([]byte(hdSeedKey), wallet.Seed) // Derive master key
+
+	if masterKey.Sign() == 0 { // Check invalid master key
+		return &Account{}, ErrInvalidDerivedKey // Return error
+	}
+
+	data := make([]byte, 1+66+4) // Init child derivation data buffer (0x00 || 66-byte parent key || index)
+
+	masterKeyBytes := masterKey.Bytes() // Get master key bytes
+
+	copy(data[1+66-len(masterKeyBytes):1+66], masterKeyBytes) // Set parent key
+
+	binary.BigEndian.PutUint32(data[1+66:], index+hardenedIndexOffset) // Set hardened index
+
+	tweak, _ := hdExpand(chainCode, data) // Derive child tweak
+
+	childKey := tweak.Add(tweak, masterKey) // Add parent key
+
+	childKey.Mod(childKey, elliptic.P521().Params().N) // Reduce
+
+	if childKey.Sign() == 0 { // Check invalid child key
+		return &Account{}, ErrInvalidDerivedKey // Return error
+	}
+
+	privateKey := &ecdsa.PrivateKey{D: childKey} // Init private key
+
+	privateKey.PublicKey.Curve = elliptic.P521() // Set curve
+
+	privateKey.PublicKey.X, privateKey.PublicKey.Y = elliptic.P521().ScalarBaseMult(childKey.Bytes()) // Derive public key
+
+	return AccountFromKey(privateKey) // Return account
+}
+
+// NextAccount - derive the wallet's next unused account, initializing its chain
+func (wallet *Wallet) NextAccount() (*Account, error) {
+	account, err := wallet.DeriveAccount(wallet.NextIndex) // Derive account
+	if err != nil {                                        // Check for errors
+		return &Account{}, err // Return found error
+	}
+
+	wallet.NextIndex++ // Increment next index
+
+	if _, err := types.ReadChainFromMemory(account.Address); err != nil { // Check no chain
+		chain, err := types.NewChain(account.Address) // Init account chain
+		if err != nil {                               // Check for errors
+			return &Account{}, err // Return error
+		}
+
+		err = chain.WriteToMemory() // Write chain to memory
+
+		if err != nil { // Check for errors
+			return &Account{}, err // Return error
+		}
+	}
+
+	return account, nil // Return account
+}
+
+// Restore - derive every used account in the wallet (stopping after WalletGapLimit consecutive accounts without a local chain),
+// and write them to the keystore, encrypted with a given passphrase. The first account is always restored. Accounts are only
+// found once their chains have been synced.
+func (wallet *Wallet) Restore(passphrase string) ([]*Account, error) {
+	restored := []*Account{} // Init restored buffer
+
+	wallet.NextIndex = 0 // Reset next index
+
+	for index, gap := uint32(0), 0; gap < WalletGapLimit && index < hardenedIndexOffset; index++ { // Derive accounts until gap
+		account, err := wallet.DeriveAccount(index) // Derive account
+		if err == ErrInvalidDerivedKey {            // Check invalid index
+			continue // Skip index
+		} else if err != nil { // Check for errors
+			return nil, err // Return found error
+		}
+
+		if _, err := types.ReadChainFromMemory(account.Address); err != nil && index != 0 { // Check unused
+			gap++ // Increment gap
+
+			continue // Continue
+		}
+
+		gap = 0 // Reset gap
+
+		wallet.NextIndex = index // Skip to account
+
+		account, err = wallet.NextAccount() // Derive account, initializing its chain if necessary
+		if err != nil {                     // Check for errors
+			return nil, err // Return found error
+		}
+
+		err = account.WriteToMemory(passphrase) // Write account to keystore
+
+		if err != nil { // Check for errors
+			return nil, err // Return found error
+		}
+
+		restored = append(restored, account) // Append restored account
+	}
+
+	return restored, nil // Return restored accounts
+}
+
+// WriteToMemory - encrypt given wallet's seed with a given passphrase, and write it to persistent memory
+func (wallet *Wallet) WriteToMemory(passphrase string) error {
+	encryptedSeed, err := encryptWithPassphrase(wallet.Seed, nil, passphrase) // Encrypt seed
+	if err != nil {                                                           // Check for errors
+		return err // Return found error
+	}
+
+	json, err := json.MarshalIndent(&EncryptedWallet{Version: WalletVersion, NextIndex: wallet.NextIndex, Crypto: *encryptedSeed}, "", "  ") // Marshal wallet
+	if err != nil {                                                                                                                          // Check for errors
+		return err // Return found error
+	}
+
+	err = common.CreateDirIfDoesNotExist(filepath.FromSlash(fmt.Sprintf("%s/wallet", common.DataDir))) // Create dir if necessary
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	err = os.Chmod(filepath.FromSlash(fmt.Sprintf("%s/wallet", common.DataDir)), 0700) // Restrict wallet dir to current user
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	return ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/wallet/wallet.json", common.DataDir)), json, 0600) // Write wallet
+}
+
+// HasWallet - check whether a wallet has been written to persistent memory
+func HasWallet() bool {
+	_, err := os.Stat(filepath.FromSlash(fmt.Sprintf("%s/wallet/wallet.json", common.DataDir))) // Stat wallet file
+
+	return err == nil // Return has wallet
+}
+
+// ReadWalletFromMemory - read the local wallet from persistent memory, decrypting its seed with a given passphrase
+func ReadWalletFromMemory(passphrase string) (*Wallet, error) {
+	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/wallet/wallet.json", common.DataDir))) // Read wallet file
+	if err != nil {                                                                                        // Check for errors
+		return &Wallet{}, err // Return found error
+	}
+
+	encryptedWallet := &EncryptedWallet{} // Init wallet buffer
+
+	err = json.Unmarshal(data, encryptedWallet) // Unmarshal into buffer
+
+	if err != nil { // Check for errors
+		return &Wallet{}, err // Return found error
+	}
+
+	if encryptedWallet.Version != WalletVersion { // Check supported
+		return &Wallet{}, ErrUnsupportedKeystore // Return error
+	}
+
+	seed, err := decryptWithPassphrase(&encryptedWallet.Crypto, nil, passphrase) // Decrypt seed
+	if err != nil {                                                              // Check for errors
+		return &Wallet{}, err // Return found error
+	}
+
+	return &Wallet{Seed: seed, NextIndex: encryptedWallet.NextIndex}, nil // Return wallet
+}
+
+// String - convert given wallet to a string safe for logging (the seed is not included)
+func (wallet *Wallet) String() string {
+	masterKey, _ := hdExpand([]byte(hdSeedKey), wallet.Seed) // Derive master key
+
+	fingerprint := crypto.Sha3(masterKey.Bytes()) // Fingerprint master key
+
+	return fmt.Sprintf("wallet %s (next account: %d)", hex.EncodeToString(fingerprint[:4]), wallet.NextIndex) // Return string
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// hdHMAC - calculate the HMAC-SHA512 of given data with a given key
+func hdHMAC(key []byte, data []byte) []byte {
+	mac := hmac.New(sha512.New, key) // Init HMAC
+
+	mac.Write(data) // Write data
+
+	return mac.Sum(nil) // Return HMAC
+}
+
+// hdExpand - derive a P-521 scalar and a 32-byte chain code from given data with a given key. The HMAC-SHA512 of the data
+// followed by a 0x00 byte and of the data followed by a 0x01 byte are concatenated: the first hdKeySize bytes are reduced
+// modulo the curve order to give the scalar, and the last 32 bytes are the chain code.
+func hdExpand(key []byte, data []byte) (*big.Int, []byte) {
+	expanded := hdHMAC(key, append(append([]byte{}, data...), 0x00))                     // Derive first block
+	expanded = append(expanded, hdHMAC(key, append(append([]byte{}, data...), 0x01))...) // Derive second block
+
+	scalar := new(big.Int).SetBytes(expanded[:hdKeySize]) // Get scalar
+
+	return scalar.Mod(scalar, elliptic.P521().Params().N), expanded[len(expanded)-32:] // Return scalar, chain code
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,97 @@
+package accounts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestDeriveAccount - test that accounts are derived deterministically from a mnemonic
+func TestDeriveAccount(t *testing.T) {
+	wallet, err := WalletFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about") // Init wallet
+	if err != nil {                                                                                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	first, err := wallet.DeriveAccount(0) // Derive first account
+	if err != nil {                       // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if first.Address.String() != "0x9572B3187B652824C4BFF8519C98069777D85D27" { // Check golden address (changing derivation loses funds)
+		t.Fatalf("unexpected first account %s", first.Address.String()) // Panic
+	}
+
+	if first.PrivateKey.D.BitLen() <= 256 { // Check key uses the full width of the curve order
+		t.Fatalf("expected a full-width P-521 key, got a %d-bit key", first.PrivateKey.D.BitLen()) // Panic
+	}
+
+	again, err := wallet.DeriveAccount(0) // Derive first account again
+	if err != nil {                       // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	second, err := wallet.DeriveAccount(1) // Derive second account
+	if err != nil {                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if again.Address != first.Address || again.PrivateKey.D.Cmp(first.PrivateKey.D) != 0 { // Check deterministic
+		t.Fatal("expected derivation to be deterministic") // Panic
+	}
+
+	if second.Address == first.Address { // Check distinct
+		t.Fatal("expected distinct accounts at distinct indexes") // Panic
+	}
+
+	if !first.PrivateKey.Curve.IsOnCurve(first.PrivateKey.X, first.PrivateKey.Y) { // Check valid public key
+		t.Fatal("derived public key is not on curve") // Panic
+	}
+
+	if _, err := wallet.DeriveAccount(1 << 31); err != ErrInvalidAccountIndex { // Check invalid index rejected
+		t.Fatalf("expected invalid index, got %v", err) // Panic
+	}
+}
+
+// TestWalletWriteToMemory - test that wallets survive an encrypted round trip through persistent memory
+func TestWalletWriteToMemory(t *testing.T) {
+	mnemonic, err := NewMnemonic(128) // Generate mnemonic
+	if err != nil {                   // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	wallet, err := WalletFromMnemonic(mnemonic) // Init wallet
+	if err != nil {                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	wallet.NextIndex = 3 // Set next index
+
+	err = wallet.WriteToMemory("test") // Write wallet
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.Remove(filepath.FromSlash(fmt.Sprintf("%s/wallet/wallet.json", common.DataDir))) // Remove wallet
+
+	if _, err := ReadWalletFromMemory("wrong"); err != ErrInvalidPassphrase { // Check wrong passphrase rejected
+		t.Fatalf("expected invalid passphrase, got %v", err) // Panic
+	}
+
+	read, err := ReadWalletFromMemory("test") // Read wallet
+	if err != nil {                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if read.String() != wallet.String() { // Check read wallet
+		t.Fatalf("expected %s, got %s", wallet.String(), read.String()) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
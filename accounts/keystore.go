@@ -0,0 +1,316 @@
+package accounts
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KeystoreVersion - version of the encrypted keystore file format
+const KeystoreVersion = 1
+
+// EncryptedKey - encrypted, passphrase-protected account private key, as stored in the keystore
+type EncryptedKey struct {
+	Address string `json:"address"` // Account address
+	Version int    `json:"version"` // Keystore format version
+
+	Crypto EncryptedKeyCrypto `json:"crypto"` // Encrypted private key
+}
+
+// EncryptedKeyCrypto - AEAD-encrypted private key, along with the parameters used to derive its key from a passphrase
+type EncryptedKeyCrypto struct {
+	Cipher     string `json:"cipher"`     // Cipher used to encrypt key (aes-256-gcm)
+	CipherText string `json:"ciphertext"` // Hex-encoded encrypted x509 private key
+	Nonce      string `json:"nonce"`      // Hex-encoded AEAD nonce
+
+	KDF       string          `json:"kdf"`       // Passphrase key derivation function (scrypt)
+	KDFParams ScryptKDFParams `json:"kdfparams"` // Key derivation parameters
+}
+
+// ScryptKDFParams - scrypt key derivation parameters
+type ScryptKDFParams struct {
+	N      int    `json:"n"`      // CPU/memory cost
+	R      int    `json:"r"`      // Block size
+	P      int    `json:"p"`      // Parallelization
+	KeyLen int    `json:"keyLen"` // Derived key length
+	Salt   string `json:"salt"`   // Hex-encoded salt
+}
+
+// unlockedAccount - account unlocked in the working process, along with the timer that will lock it again (if any)
+type unlockedAccount struct {
+	account *Account    // Account
+	timer   *time.Timer // Lock timer
+}
+
+var (
+	// ErrInvalidPassphrase - error definition describing a passphrase that could not decrypt a keystore file
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+
+	// ErrEmptyPassphrase - error definition describing an attempt to encrypt a key with an empty passphrase
+	ErrEmptyPassphrase = errors.New("passphrase must not be empty")
+
+	// ErrAccountLocked - error definition describing an account that must be unlocked before it can be used
+	ErrAccountLocked = errors.New("account is locked")
+
+	// ErrUnsupportedKeystore - error definition describing a keystore file with an unknown version, cipher, or KDF
+	ErrUnsupportedKeystore = errors.New("unsupported keystore format")
+
+	// ErrLegacyKeystore - error definition describing a legacy unencrypted keystore file, which must be migrated before it is used
+	ErrLegacyKeystore = errors.New("legacy unencrypted keystore file; migrate it first (--migrate-keystore)")
+
+	// ErrPassphraseMismatch - error definition describing a passphrase that does not match its confirmation
+	ErrPassphraseMismatch = errors.New("passphrase does not match confirmation")
+
+	// scryptN - scrypt CPU/memory cost parameter used for newly encrypted keys
+	scryptN = 1 << 18
+
+	// scryptR - scrypt block size parameter used for newly encrypted keys
+	scryptR = 8
+
+	// scryptP - scrypt parallelization parameter used for newly encrypted keys
+	scryptP = 1
+
+	unlocked      = make(map[common.Address]*unlockedAccount) // Accounts unlocked in the working process
+	unlockedMutex sync.Mutex                                  // Unlocked accounts lock
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// EncryptKey - encrypt given account's private key with a given passphrase
+func EncryptKey(account *Account, passphrase string) (*EncryptedKey, error) {
+	privateKey, err := x509.MarshalECPrivateKey(account.PrivateKey) // Marshal private key
+	if err != nil {                                                 // Check for errors
+		return &EncryptedKey{}, err // Return found error
+	}
+
+	address := account.Address.String() // Get address
+
+	crypto, err := encryptWithPassphrase(privateKey, []byte(address), passphrase) // Encrypt key, authenticating address
+	if err != nil {                                                               // Check for errors
+		return &EncryptedKey{}, err // Return found error
+	}
+
+	return &EncryptedKey{
+		Address: address,         // Set address
+		Version: KeystoreVersion, // Set version
+		Crypto:  *crypto,         // Set encrypted key
+	}, nil // Return encrypted key
+}
+
+// DecryptKey - decrypt a given encrypted key with a given passphrase
+func DecryptKey(key *EncryptedKey, passphrase string) (*Account, error) {
+	if key.Version != KeystoreVersion { // Check supported
+		return &Account{}, ErrUnsupportedKeystore // Return error
+	}
+
+	privateKeyBytes, err := decryptWithPassphrase(&key.Crypto, []byte(key.Address), passphrase) // Decrypt key
+	if err != nil {                                                                             // Check for errors
+		return &Account{}, err // Return found error
+	}
+
+	privateKey, err := x509.ParseECPrivateKey(privateKeyBytes) // Parse private key
+	if err != nil {                                            // Check for errors
+		return &Account{}, err // Return found error
+	}
+
+	address, err := common.StringToAddress(key.Address) // Get address
+	if err != nil {                                     // Check for errors
+		return &Account{}, err // Return found error
+	}
+
+	if !address.IsDerivedFrom(&privateKey.PublicKey) { // Check key does not belong to address
+		return &Account{}, ErrInvalidPassphrase // Return error
+	}
+
+	return &Account{Address: address, PrivateKey: privateKey}, nil // Return decrypted account (keeping legacy addresses)
+}
+
+// Unlock - decrypt the account with a given address using a given passphrase, and keep it available to the working process
+// (see GetUnlockedAccount) until Lock is called, or until a given timeout elapses (if the timeout is non-zero)
+func Unlock(address common.Address, passphrase string, timeout time.Duration) error {
+	account, err := ReadAccountFromMemory(address, passphrase) // Decrypt account
+	if err != nil {                                            // Check for errors
+		return err // Return found error
+	}
+
+	unlockedMutex.Lock() // Lock
+
+	defer unlockedMutex.Unlock() // Unlock
+
+	if existing, ok := unlocked[address]; ok && existing.timer != nil { // Check already unlocked with timeout
+		existing.timer.Stop() // Stop existing timer
+	}
+
+	entry := &unlockedAccount{account: account} // Init entry
+
+	if timeout > 0 { // Check has timeout
+		entry.timer = time.AfterFunc(timeout, func() { lockEntry(address, entry) }) // Lock after timeout
+	}
+
+	unlocked[address] = entry // Set unlocked
+
+	return nil // No error occurred, return nil
+}
+
+// Lock - drop the decrypted account with a given address from the working process
+func Lock(address common.Address) {
+	unlockedMutex.Lock() // Lock
+
+	defer unlockedMutex.Unlock() // Unlock
+
+	if entry, ok := unlocked[address]; ok { // Check unlocked
+		if entry.timer != nil { // Check has timer
+			entry.timer.Stop() // Stop timer
+		}
+
+		delete(unlocked, address) // Lock account
+	}
+}
+
+// IsUnlocked - check whether the account with a given address is unlocked in the working process
+func IsUnlocked(address common.Address) bool {
+	unlockedMutex.Lock() // Lock
+
+	defer unlockedMutex.Unlock() // Unlock
+
+	_, ok := unlocked[address] // Check unlocked
+
+	return ok // Return is unlocked
+}
+
+// GetUnlockedAccount - get a copy of the unlocked account with a given address (returns ErrAccountLocked if the account is locked)
+func GetUnlockedAccount(address common.Address) (*Account, error) {
+	unlockedMutex.Lock() // Lock
+
+	defer unlockedMutex.Unlock() // Unlock
+
+	entry, ok := unlocked[address] // Get entry
+	if !ok {                       // Check locked
+		return &Account{}, ErrAccountLocked // Return error
+	}
+
+	privateKey := *entry.account.PrivateKey // Copy private key (MakeEncodingSafe clears the key in place)
+
+	return &Account{Address: entry.account.Address, PrivateKey: &privateKey}, nil // Return account
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// encryptWithPassphrase - encrypt a given plaintext with a key derived from a given passphrase, authenticating (but not
+// encrypting) given additional data
+func encryptWithPassphrase(plaintext []byte, additionalData []byte, passphrase string) (*EncryptedKeyCrypto, error) {
+	if passphrase == "" { // Check empty passphrase
+		return nil, ErrEmptyPassphrase // Return error
+	}
+
+	salt := make([]byte, 32) // Init salt buffer
+
+	_, err := rand.Read(salt) // Generate salt
+
+	if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	params := ScryptKDFParams{N: scryptN, R: scryptR, P: scryptP, KeyLen: 32, Salt: hex.EncodeToString(salt)} // Init KDF params
+
+	aead, err := newKeystoreAEAD(passphrase, params) // Derive key
+	if err != nil {                                  // Check for errors
+		return nil, err // Return found error
+	}
+
+	nonce := make([]byte, aead.NonceSize()) // Init nonce buffer
+
+	_, err = rand.Read(nonce) // Generate nonce
+
+	if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	return &EncryptedKeyCrypto{
+		Cipher:     "aes-256-gcm",                                                        // Set cipher
+		CipherText: hex.EncodeToString(aead.Seal(nil, nonce, plaintext, additionalData)), // Encrypt
+		Nonce:      hex.EncodeToString(nonce),                                            // Set nonce
+		KDF:        "scrypt",                                                             // Set KDF
+		KDFParams:  params,                                                               // Set KDF params
+	}, nil // Return encrypted plaintext
+}
+
+// decryptWithPassphrase - decrypt a given ciphertext (see encryptWithPassphrase) with a given passphrase
+func decryptWithPassphrase(crypto *EncryptedKeyCrypto, additionalData []byte, passphrase string) ([]byte, error) {
+	if crypto.Cipher != "aes-256-gcm" || crypto.KDF != "scrypt" { // Check supported
+		return nil, ErrUnsupportedKeystore // Return error
+	}
+
+	cipherText, err := hex.DecodeString(crypto.CipherText) // Decode ciphertext
+	if err != nil {                                        // Check for errors
+		return nil, err // Return found error
+	}
+
+	nonce, err := hex.DecodeString(crypto.Nonce) // Decode nonce
+	if err != nil {                              // Check for errors
+		return nil, err // Return found error
+	}
+
+	aead, err := newKeystoreAEAD(passphrase, crypto.KDFParams) // Derive key
+	if err != nil {                                            // Check for errors
+		return nil, err // Return found error
+	}
+
+	if len(nonce) != aead.NonceSize() { // Check invalid nonce
+		return nil, ErrUnsupportedKeystore // Return error
+	}
+
+	plaintext, err := aead.Open(nil, nonce, cipherText, additionalData) // Decrypt
+	if err != nil {                                                     // Check for errors
+		return nil, ErrInvalidPassphrase // Return error
+	}
+
+	return plaintext, nil // Return plaintext
+}
+
+// newKeystoreAEAD - derive a key from a given passphrase and initialize an AES-GCM AEAD with it
+func newKeystoreAEAD(passphrase string, params ScryptKDFParams) (cipher.AEAD, error) {
+	salt, err := hex.DecodeString(params.Salt) // Decode salt
+	if err != nil {                            // Check for errors
+		return nil, err // Return found error
+	}
+
+	if params.KeyLen != 32 { // Check not AES-256 key
+		return nil, ErrUnsupportedKeystore // Return error
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.KeyLen) // Derive key
+	if err != nil {                                                                                      // Check for errors
+		return nil, err // Return found error
+	}
+
+	block, err := aes.NewCipher(derivedKey) // Init cipher
+	if err != nil {                         // Check for errors
+		return nil, err // Return found error
+	}
+
+	return cipher.NewGCM(block) // Return AEAD
+}
+
+// lockEntry - lock the account with a given address, if it is still unlocked by a given entry
+func lockEntry(address common.Address, entry *unlockedAccount) {
+	unlockedMutex.Lock() // Lock
+
+	defer unlockedMutex.Unlock() // Unlock
+
+	if unlocked[address] == entry { // Check not unlocked again since
+		delete(unlocked, address) // Lock account
+	}
+}
+
+/* END INTERNAL METHODS */
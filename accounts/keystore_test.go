@@ -0,0 +1,292 @@
+package accounts
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+func init() {
+	scryptN = 1 << 12 // Use cheap KDF params in tests
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// TestEncryptKey - test that encrypted keys only decrypt with the passphrase they were encrypted with
+func TestEncryptKey(t *testing.T) {
+	account, err := newTestAccount() // Generate account
+	if err != nil {                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := EncryptKey(account, ""); err != ErrEmptyPassphrase { // Check empty passphrase rejected
+		t.Fatalf("expected empty passphrase to be rejected, got %v", err) // Panic
+	}
+
+	encryptedKey, err := EncryptKey(account, "test") // Encrypt key
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	decrypted, err := DecryptKey(encryptedKey, "test") // Decrypt key
+	if err != nil {                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if decrypted.Address != account.Address || decrypted.PrivateKey.D.Cmp(account.PrivateKey.D) != 0 { // Check decrypted key
+		t.Fatal("decrypted key does not match encrypted key") // Panic
+	}
+
+	if _, err := DecryptKey(encryptedKey, "wrong"); err != ErrInvalidPassphrase { // Check wrong passphrase rejected
+		t.Fatalf("expected wrong passphrase to be rejected, got %v", err) // Panic
+	}
+
+	encryptedKey.Address = (&common.Address{0x1}).String() // Move key to another address
+
+	if _, err := DecryptKey(encryptedKey, "test"); err != ErrInvalidPassphrase { // Check address is authenticated
+		t.Fatalf("expected moved key to be rejected, got %v", err) // Panic
+	}
+}
+
+// TestWriteToMemoryPermissions - test that keystore files are encrypted, and only readable by the current user
+func TestWriteToMemoryPermissions(t *testing.T) {
+	account, err := newTestAccount() // Generate account
+	if err != nil {                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = account.WriteToMemory("test") // Write to memory
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	path := filepath.FromSlash(fmt.Sprintf("%s/keystore/account_%s.json", common.DataDir, account.Address.String())) // Get keystore path
+
+	defer os.Remove(path) // Remove keystore file
+
+	info, err := os.Stat(path) // Stat keystore file
+	if err != nil {            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if info.Mode().Perm() != 0600 { // Check permissions
+		t.Fatalf("expected keystore file mode 0600, got %o", info.Mode().Perm()) // Panic
+	}
+
+	if _, err := ReadAccountFromMemory(account.Address, "wrong"); err != ErrInvalidPassphrase { // Check wrong passphrase rejected
+		t.Fatalf("expected wrong passphrase to be rejected, got %v", err) // Panic
+	}
+}
+
+// TestReadLegacyAccount - test that legacy unencrypted keystore files are left untouched when read, and are only encrypted
+// once migrated with a confirmed passphrase
+func TestReadLegacyAccount(t *testing.T) {
+	account, err := newTestAccount() // Generate account
+	if err != nil {                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = account.MakeEncodingSafe() // Make safe for encoding
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	legacy, err := json.MarshalIndent(*account, "", "  ") // Marshal legacy account
+	if err != nil {                                       // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = account.RecoverSafeEncoding() // Recover
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	path := filepath.FromSlash(fmt.Sprintf("%s/keystore/account_%s.json", common.DataDir, account.Address.String())) // Get keystore path
+
+	defer os.Remove(path) // Remove keystore file
+
+	err = common.CreateDirIfDoesNotExist(filepath.Dir(path)) // Create keystore dir
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = ioutil.WriteFile(path, legacy, 0644) // Write legacy account
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := ReadAccountFromMemory(account.Address, "test"); err != ErrLegacyKeystore { // Check legacy file rejected
+		t.Fatalf("expected legacy keystore, got %v", err) // Panic
+	}
+
+	if data, err := ioutil.ReadFile(path); err != nil || !bytes.Equal(data, legacy) { // Check file untouched
+		t.Fatalf("expected legacy file to be left untouched (%v)", err) // Panic
+	}
+
+	if err := MigrateAccount(account.Address, "test", "tset"); err != ErrPassphraseMismatch { // Check unconfirmed passphrase rejected
+		t.Fatalf("expected passphrase mismatch, got %v", err) // Panic
+	}
+
+	err = MigrateAccount(account.Address, "test", "test") // Migrate legacy account
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	read, err := ReadAccountFromMemory(account.Address, "test") // Read migrated account
+	if err != nil {                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if read.PrivateKey.D.Cmp(account.PrivateKey.D) != 0 { // Check read key
+		t.Fatal("read key does not match legacy key") // Panic
+	}
+
+	if _, err := ReadAccountFromMemory(account.Address, "wrong"); err != ErrInvalidPassphrase { // Check file was encrypted
+		t.Fatalf("expected migrated file to require passphrase, got %v", err) // Panic
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 { // Check permissions
+		t.Fatalf("expected migrated keystore file mode 0600 (%v)", err) // Panic
+	}
+}
+
+// TestReadLegacyAccountFromFile - test that reading a legacy unencrypted keystore file copied outside of the node keystore
+// (e.g. for offline signing) fails without modifying the file or its directory
+func TestReadLegacyAccountFromFile(t *testing.T) {
+	account, err := newTestAccount() // Generate account
+	if err != nil {                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = account.MakeEncodingSafe() // Make safe for encoding
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	legacy, err := json.MarshalIndent(*account, "", "  ") // Marshal legacy account
+	if err != nil {                                       // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	dir, err := ioutil.TempDir("", "keystore") // Create dir outside of node keystore
+	if err != nil {                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dir) // Remove dir
+
+	err = os.Chmod(dir, 0755) // Make dir world-readable
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	path := filepath.Join(dir, "account.json") // Get keystore file path
+
+	err = ioutil.WriteFile(path, legacy, 0644) // Write legacy account
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := ReadAccountFromFile(path, "test"); err != ErrLegacyKeystore { // Check legacy file rejected
+		t.Fatalf("expected legacy keystore, got %v", err) // Panic
+	}
+
+	if data, err := ioutil.ReadFile(path); err != nil || !bytes.Equal(data, legacy) { // Check file untouched
+		t.Fatalf("expected legacy file to be left untouched (%v)", err) // Panic
+	}
+
+	if info, err := os.Stat(dir); err != nil || info.Mode().Perm() != 0755 { // Check dir permissions untouched
+		t.Fatalf("expected dir mode 0755 to be left untouched (%v)", err) // Panic
+	}
+}
+
+// TestUnlock - test account unlock, lock, and unlock timeouts
+func TestUnlock(t *testing.T) {
+	account, err := newTestAccount() // Generate account
+	if err != nil {                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = account.WriteToMemory("test") // Write to memory
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.Remove(filepath.FromSlash(fmt.Sprintf("%s/keystore/account_%s.json", common.DataDir, account.Address.String()))) // Remove keystore file
+
+	if _, err := GetUnlockedAccount(account.Address); err != ErrAccountLocked { // Check locked by default
+		t.Fatalf("expected account to be locked, got %v", err) // Panic
+	}
+
+	if err := Unlock(account.Address, "wrong", 0); err != ErrInvalidPassphrase || IsUnlocked(account.Address) { // Check wrong passphrase rejected
+		t.Fatalf("expected wrong passphrase to be rejected, got %v", err) // Panic
+	}
+
+	err = Unlock(account.Address, "test", 0) // Unlock
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if unlocked, err := GetUnlockedAccount(account.Address); err != nil || unlocked.PrivateKey.D.Cmp(account.PrivateKey.D) != 0 { // Check unlocked
+		t.Fatalf("expected account to be unlocked (%v)", err) // Panic
+	}
+
+	Lock(account.Address) // Lock
+
+	if IsUnlocked(account.Address) { // Check locked
+		t.Fatal("expected account to be locked") // Panic
+	}
+
+	err = Unlock(account.Address, "test", 50*time.Millisecond) // Unlock with timeout
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if !IsUnlocked(account.Address) { // Check unlocked
+		t.Fatal("expected account to be unlocked") // Panic
+	}
+
+	time.Sleep(200 * time.Millisecond) // Wait for timeout
+
+	if IsUnlocked(account.Address) { // Check locked after timeout
+		t.Fatal("expected account to be locked after timeout") // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newTestAccount - generate an account without initializing its chain
+func newTestAccount() (*Account, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		return &Account{}, err // Return found error
+	}
+
+	return AccountFromKey(privateKey) // Return account
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,148 @@
+package accounts
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	// ErrInvalidEntropySize - error definition describing mnemonic entropy that is not 128-256 bits in 32-bit steps
+	ErrInvalidEntropySize = errors.New("mnemonic entropy must be 128-256 bits, in steps of 32 bits")
+
+	// ErrInvalidMnemonic - error definition describing a mnemonic with an unknown word or an invalid number of words
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+	// ErrInvalidMnemonicChecksum - error definition describing a mnemonic whose checksum does not match its entropy
+	ErrInvalidMnemonicChecksum = errors.New("invalid mnemonic checksum")
+
+	mnemonicWordIndexes = indexMnemonicWords() // Word indexes
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMnemonic - generate a new BIP-39 mnemonic seed phrase from a given number of bits of random entropy (128 bits gives 12
+// words, 256 bits gives 24 words)
+func NewMnemonic(entropyBits int) (string, error) {
+	if entropyBits < 128 || entropyBits > 256 || entropyBits%32 != 0 { // Check invalid size
+		return "", ErrInvalidEntropySize // Return error
+	}
+
+	entropy := make([]byte, entropyBits/8) // Init entropy buffer
+
+	_, err := rand.Read(entropy) // Generate entropy
+
+	if err != nil { // Check for errors
+		return "", err // Return found error
+	}
+
+	return MnemonicFromEntropy(entropy) // Return mnemonic
+}
+
+// MnemonicFromEntropy - encode given entropy as a BIP-39 mnemonic seed phrase
+func MnemonicFromEntropy(entropy []byte) (string, error) {
+	if len(entropy) < 16 || len(entropy) > 32 || len(entropy)%4 != 0 { // Check invalid size
+		return "", ErrInvalidEntropySize // Return error
+	}
+
+	checksumBits := uint(len(entropy) / 4) // Get checksum size (1 bit per 32 bits of entropy)
+
+	checksum := sha256.Sum256(entropy) // Calculate checksum
+
+	bits := new(big.Int).SetBytes(entropy) // Get entropy bits
+
+	bits.Lsh(bits, checksumBits) // Make room for checksum
+
+	bits.Or(bits, big.NewInt(int64(checksum[0]>>(8-checksumBits)))) // Append checksum
+
+	words := make([]string, (uint(len(entropy))*8+checksumBits)/11) // Init words buffer
+
+	index := new(big.Int) // Init index buffer
+
+	for x := len(words) - 1; x >= 0; x-- { // Iterate through words, last first
+		index.And(bits, big.NewInt(2047)) // Get last 11 bits
+
+		words[x] = mnemonicWords[index.Int64()] // Set word
+
+		bits.Rsh(bits, 11) // Next word
+	}
+
+	return strings.Join(words, " "), nil // Return mnemonic
+}
+
+// EntropyFromMnemonic - decode a given BIP-39 mnemonic seed phrase into its entropy, validating its checksum
+func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
+	words := strings.Fields(strings.ToLower(mnemonic)) // Get words
+
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 { // Check invalid length
+		return nil, ErrInvalidMnemonic // Return error
+	}
+
+	bits := new(big.Int) // Init bits buffer
+
+	for _, word := range words { // Iterate through words
+		index, ok := mnemonicWordIndexes[word] // Get word index
+		if !ok {                               // Check unknown word
+			return nil, ErrInvalidMnemonic // Return error
+		}
+
+		bits.Lsh(bits, 11) // Make room for word
+
+		bits.Or(bits, big.NewInt(int64(index))) // Append word
+	}
+
+	checksumBits := uint(len(words) / 3) // Get checksum size
+
+	checksum := new(big.Int).And(bits, big.NewInt(int64(1<<checksumBits-1))) // Get checksum
+
+	bits.Rsh(bits, checksumBits) // Remove checksum
+
+	entropy := make([]byte, len(words)*4/3) // Init entropy buffer
+
+	entropyBytes := bits.Bytes() // Get entropy bytes
+
+	copy(entropy[len(entropy)-len(entropyBytes):], entropyBytes) // Restore leading zeros
+
+	expected := sha256.Sum256(entropy) // Calculate checksum
+
+	if checksum.Int64() != int64(expected[0]>>(8-checksumBits)) { // Check invalid checksum
+		return nil, ErrInvalidMnemonicChecksum // Return error
+	}
+
+	return entropy, nil // Return entropy
+}
+
+// MnemonicToSeed - derive the 64-byte BIP-39 seed for a given mnemonic seed phrase and (optional) seed passphrase. The
+// passphrase is used as given; non-ASCII passphrases must be NFKD-normalized by the caller to match other BIP-39 wallets.
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	_, err := EntropyFromMnemonic(mnemonic) // Validate mnemonic
+	if err != nil {                         // Check for errors
+		return nil, err // Return found error
+	}
+
+	normalized := strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ") // Normalize whitespace, case
+
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), 2048, 64, sha512.New), nil // Return seed
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// indexMnemonicWords - get the index of each word in the BIP-39 wordlist
+func indexMnemonicWords() map[string]int {
+	indexes := make(map[string]int, len(mnemonicWords)) // Init indexes buffer
+
+	for x, word := range mnemonicWords { // Iterate through words
+		indexes[word] = x // Set index
+	}
+
+	return indexes // Return indexes
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,117 @@
+package accounts
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// mnemonicVector is a BIP-39 test vector (from the reference implementation's vectors.json, seed passphrase "TREZOR").
+type mnemonicVector struct {
+	entropy  string // Hex-encoded entropy
+	mnemonic string // Expected mnemonic
+	seed     string // Expected hex-encoded seed
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// TestMnemonicFromEntropy - test that mnemonics and seeds match the BIP-39 test vectors
+func TestMnemonicFromEntropy(t *testing.T) {
+	for _, vector := range mnemonicVectors() { // Iterate through vectors
+		entropy, _ := hex.DecodeString(vector.entropy) // Decode entropy
+
+		mnemonic, err := MnemonicFromEntropy(entropy) // Encode mnemonic
+		if err != nil {                               // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if mnemonic != vector.mnemonic { // Check mnemonic
+			t.Fatalf("expected mnemonic %q, got %q", vector.mnemonic, mnemonic) // Panic
+		}
+
+		decoded, err := EntropyFromMnemonic(mnemonic) // Decode mnemonic
+		if err != nil {                               // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if hex.EncodeToString(decoded) != vector.entropy { // Check round trip
+			t.Fatalf("expected entropy %s, got %x", vector.entropy, decoded) // Panic
+		}
+
+		seed, err := MnemonicToSeed(mnemonic, "TREZOR") // Derive seed
+		if err != nil {                                 // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if hex.EncodeToString(seed) != vector.seed { // Check seed
+			t.Fatalf("expected seed %s, got %x", vector.seed, seed) // Panic
+		}
+	}
+}
+
+// TestNewMnemonic - test mnemonic generation and validation
+func TestNewMnemonic(t *testing.T) {
+	if _, err := NewMnemonic(100); err != ErrInvalidEntropySize { // Check invalid size rejected
+		t.Fatalf("expected invalid entropy size, got %v", err) // Panic
+	}
+
+	mnemonic, err := NewMnemonic(256) // Generate mnemonic
+	if err != nil {                   // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	words := strings.Fields(mnemonic) // Get words
+
+	if len(words) != 24 { // Check length
+		t.Fatalf("expected 24 words, got %d", len(words)) // Panic
+	}
+
+	if _, err := EntropyFromMnemonic(mnemonic); err != nil { // Check valid
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := EntropyFromMnemonic(strings.Join(words[:23], " ") + " notaword"); err != ErrInvalidMnemonic { // Check unknown word rejected
+		t.Fatalf("expected invalid mnemonic, got %v", err) // Panic
+	}
+
+	if _, err := EntropyFromMnemonic(strings.Repeat("abandon ", 12)); err != ErrInvalidMnemonicChecksum { // Check bad checksum rejected
+		t.Fatalf("expected invalid checksum, got %v", err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// mnemonicVectors gets a subset of the BIP-39 English test vectors.
+func mnemonicVectors() []mnemonicVector {
+	return []mnemonicVector{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			entropy:  "80808080808080808080808080808080",
+			mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+			seed:     "d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+		},
+		{
+			entropy:  "ffffffffffffffffffffffffffffffff",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			seed:     "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+		},
+		{
+			entropy:  "0000000000000000000000000000000000000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			seed:     "bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+		},
+	}
+}
+
+/* END INTERNAL METHODS */
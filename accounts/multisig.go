@@ -0,0 +1,82 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMultisigAccount - create a multisig account spendable with signatures from a given number of a given set of public keys, writing
+// its spending policy to persistent memory. No private key is stored: each co-signer keeps their own account.
+func NewMultisigAccount(threshold uint32, publicKeys []*ecdsa.PublicKey) (*types.MultisigPolicy, error) {
+	policy, err := types.NewMultisigPolicy(threshold, publicKeys) // Init policy
+	if err != nil {                                               // Check for errors
+		return &types.MultisigPolicy{}, err // Return found error
+	}
+
+	if _, err := types.ReadChainFromMemory(policy.Address()); err != nil { // Check no chain
+		chain, err := types.NewChain(policy.Address()) // Init account chain
+		if err != nil {                                // Check for errors
+			return &types.MultisigPolicy{}, err // Return error
+		}
+
+		err = chain.WriteToMemory() // Write chain to memory
+
+		if err != nil { // Check for errors
+			return &types.MultisigPolicy{}, err // Return error
+		}
+	}
+
+	return policy, WriteMultisigPolicy(policy) // Write policy
+}
+
+// WriteMultisigPolicy - write a given multisig spending policy to persistent memory
+func WriteMultisigPolicy(policy *types.MultisigPolicy) error {
+	json, err := json.MarshalIndent(policy, "", "  ") // Marshal policy
+	if err != nil {                                   // Check for errors
+		return err // Return found error
+	}
+
+	err = common.CreateDirIfDoesNotExist(filepath.FromSlash(fmt.Sprintf("%s/multisig", common.DataDir))) // Create dir if necessary
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	return ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/multisig/multisig_%s.json", common.DataDir, policy.Address().String())), json, 0644) // Write policy
+}
+
+// ReadMultisigPolicy - read the spending policy of the multisig account with a given address from persistent memory
+func ReadMultisigPolicy(address common.Address) (*types.MultisigPolicy, error) {
+	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/multisig/multisig_%s.json", common.DataDir, address.String()))) // Read policy
+	if err != nil {                                                                                                                 // Check for errors
+		return &types.MultisigPolicy{}, err // Return found error
+	}
+
+	policy := &types.MultisigPolicy{} // Init policy buffer
+
+	err = json.Unmarshal(data, policy) // Unmarshal into buffer
+
+	if err != nil { // Check for errors
+		return &types.MultisigPolicy{}, err // Return found error
+	}
+
+	if err := policy.Validate(); err != nil { // Check invalid policy
+		return &types.MultisigPolicy{}, err // Return found error
+	}
+
+	if policy.Address() != address { // Check policy not address'
+		return &types.MultisigPolicy{}, types.ErrMultisigPolicyMismatch // Return error
+	}
+
+	return policy, nil // Return policy
+}
+
+/* END EXPORTED METHODS */
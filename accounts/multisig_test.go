@@ -0,0 +1,48 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestNewMultisigAccount - test that multisig policies survive a round trip through persistent memory
+func TestNewMultisigAccount(t *testing.T) {
+	first, err := newTestAccount() // Init first co-signer
+	if err != nil {                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	second, err := newTestAccount() // Init second co-signer
+	if err != nil {                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	policy, err := NewMultisigAccount(1, []*ecdsa.PublicKey{&first.PrivateKey.PublicKey, &second.PrivateKey.PublicKey}) // Create multisig account
+	if err != nil {                                                                                                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.Remove(filepath.FromSlash(fmt.Sprintf("%s/multisig/multisig_%s.json", common.DataDir, policy.Address().String()))) // Remove policy
+
+	read, err := ReadMultisigPolicy(policy.Address()) // Read policy
+	if err != nil {                                   // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if read.String() != policy.String() { // Check read policy
+		t.Fatalf("expected %s, got %s", policy.String(), read.String()) // Panic
+	}
+
+	if _, err := ReadMultisigPolicy(first.Address); err == nil { // Check unknown account not multisig
+		t.Fatal("expected single-key account to have no multisig policy") // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
@@ -216,28 +216,80 @@ func handleAccounts(accountsClient *accountsProto.Accounts, methodname string, p
 	reflectParams = append(reflectParams, reflect.ValueOf(context.Background())) // Append request context
 
 	switch methodname {
-	case "NewAccount", "GetAllAccounts":
+	case "GetAllAccounts":
 		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{})) // Append params
-	case "MakeEncodingSafe", "RecoverSafeEncoding", "String", "Bytes", "ReadAccountFromMemory", "GetAllContracts":
+	case "NewAccount":
+		if len(params) != 1 { // Check for invalid parameters
+			return errors.New("invalid parameters (requires string passphrase)") // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{Passphrase: params[0]})) // Append params
+	case "MakeEncodingSafe", "RecoverSafeEncoding", "String", "Bytes", "ReadAccountFromMemory", "GetAllContracts", "Lock", "PublicKey":
 		if len(params) != 1 { // Check for invalid parameters
 			return errors.New("invalid parameters (requires string)") // Return error
 		}
 
 		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{Address: params[0]})) // Append params
 	case "AccountFromKey":
+		if len(params) != 2 { // Check for invalid parameters
+			return errors.New("invalid parameters (requires string, string passphrase)") // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{PrivateKey: params[0], Passphrase: params[1]})) // Append params
+	case "NewWalletFromMnemonic":
+		if len(params) < 1 { // Check for invalid parameters
+			return errors.New("invalid parameters (requires string passphrase, optionally followed by a mnemonic phrase to restore)") // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{Passphrase: params[0], Mnemonic: strings.Join(params[1:], " ")})) // Append params
+	case "DeriveAccount":
 		if len(params) != 1 { // Check for invalid parameters
-			return errors.New("invalid parameters (requires string)") // Return error
+			return errors.New("invalid parameters (requires string passphrase)") // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{Passphrase: params[0]})) // Append params
+	case "Unlock":
+		if len(params) != 2 && len(params) != 3 { // Check for invalid parameters
+			return errors.New("invalid parameters (requires string, string passphrase, optionally followed by uint64 timeout in seconds)") // Return error
+		}
+
+		timeout := uint64(0) // Init timeout buffer
+
+		if len(params) == 3 { // Check has timeout
+			parsedTimeout, err := strconv.ParseUint(params[2], 10, 64) // Parse timeout
+			if err != nil {                                            // Check for errors
+				return err // Return found error
+			}
+
+			timeout = parsedTimeout // Set timeout
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{Address: params[0], Passphrase: params[1], Timeout: timeout})) // Append params
+	case "NewMultisigAccount":
+		if len(params) < 2 { // Check for invalid parameters
+			return errors.New("invalid parameters (requires uint32 threshold, followed by hex-encoded public keys (see PublicKey()))") // Return error
+		}
+
+		threshold, err := strconv.ParseUint(params[0], 10, 32) // Parse threshold
+		if err != nil {                                        // Check for errors
+			return err // Return found error
 		}
 
-		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{PrivateKey: params[0]})) // Append params
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{Threshold: uint32(threshold), PublicKeys: params[1:]})) // Append params
 	case "NewContractAccount":
-		if len(params) != 2 { // Check for invalid parameters
-			return errors.New("invalid parameters (requires string, string)") // Return error
+		if len(params) != 2 && len(params) != 3 { // Check for invalid parameters
+			return errors.New("invalid parameters (requires string, string, optionally followed by string ABI path)") // Return error
+		}
+
+		abiPath := "" // Init ABI path buffer
+
+		if len(params) == 3 { // Check has ABI
+			abiPath = params[2] // Set ABI path
 		}
 
-		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{Address: params[0], PrivateKey: params[1]})) // Append params
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{Address: params[0], PrivateKey: params[1], Abi: abiPath})) // Append params
 	default:
-		return errors.New("illegal method: " + methodname + ", available methods: NewAccount(), NewContractAccount(), GetAllAccounts(), MakeEncodingSafe(), RecoverSafeEncoding(), String(), Bytes(), ReadAccountFromMemory()") // Return error
+		return errors.New("illegal method: " + methodname + ", available methods: NewAccount(), NewContractAccount(), AccountFromKey(), GetAllAccounts(), GetAllContracts(), MakeEncodingSafe(), RecoverSafeEncoding(), String(), Bytes(), ReadAccountFromMemory(), Unlock(), Lock(), NewWalletFromMnemonic(), DeriveAccount(), PublicKey(), NewMultisigAccount()") // Return error
 	}
 
 	result := reflect.ValueOf(*accountsClient).MethodByName(methodname).Call(reflectParams) // Call method
@@ -297,20 +349,28 @@ func handleTransaction(transactionClient *transactionProto.Transaction, methodna
 
 	switch methodname {
 	case "NewTransaction":
-		if len(params) != 4 { // Check for invalid parameters
-			return errors.New("invalid parameters (require string, string, float64, []byte)") // Return error
+		if len(params) != 4 && len(params) != 6 { // Check for invalid parameters
+			return errors.New("invalid parameters (require string, string, decimal amount, []byte, optionally followed by uint64 gas limit, decimal gas price)") // Return error
 		}
 
-		floatVal, _ := strconv.ParseFloat(params[2], 64) // Parse float
+		var gasLimit uint64 // Init gas limit buffer
+		var gasPrice string // Init gas price buffer
 
-		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{Address: params[0], Address2: params[1], Amount: floatVal, Payload: []byte(params[3])})) // Append params
+		if len(params) == 6 { // Check has gas params
+			gasLimit, _ = strconv.ParseUint(params[4], 10, 64) // Parse gas limit
+			gasPrice = params[5]                               // Set gas price
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{Address: params[0], Address2: params[1], Amount: params[2], Payload: []byte(params[3]), GasLimit: gasLimit, GasPrice: gasPrice})) // Append params
 	case "TransactionFromBytes":
 		if len(params) != 1 { // Check for invalid parameters
 			return errors.New("invalid parameters (require []byte)") // Return error
 		}
 
 		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{Payload: []byte(params[0])})) // Append params
-	case "Bytes", "String", "SignTransaction", "VerifyTransactionSignature":
+	case "GetPending":
+		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{})) // Append params
+	case "Bytes", "String", "SignTransaction", "VerifyTransactionSignature", "GetPendingBySender", "ExportUnsignedTransaction":
 		if len(params) != 1 {
 			return errors.New("invalid parameters (requires string)") // Return error
 		}
@@ -322,8 +382,20 @@ func handleTransaction(transactionClient *transactionProto.Transaction, methodna
 		}
 
 		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{Address: params[0], Address2: workingNetwork})) // Append params
+	case "PublishSignedTransaction":
+		if len(params) != 1 {
+			return errors.New("invalid parameters (requires string signed transaction blob)") // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{Payload: []byte(params[0]), Address2: workingNetwork})) // Append params
+	case "AddPartialSignatures":
+		if len(params) != 1 {
+			return errors.New("invalid parameters (requires string partially-signed transaction blob)") // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{Payload: []byte(params[0])})) // Append params
 	default:
-		return errors.New("illegal method: " + methodname + ", available methods: NewTransaction(), TransactionFromBytes(), Publish(), Bytes(), String(), SignTransaction(), VerifyTransactionSignature()") // Return error
+		return errors.New("illegal method: " + methodname + ", available methods: NewTransaction(), TransactionFromBytes(), Publish(), Bytes(), String(), SignTransaction(), VerifyTransactionSignature(), GetPending(), GetPendingBySender(), ExportUnsignedTransaction(), PublishSignedTransaction(), AddPartialSignatures()") // Return error
 	}
 
 	result := reflect.ValueOf(*transactionClient).MethodByName(methodname).Call(reflectParams) // Call method
@@ -346,14 +418,81 @@ func handleChain(chainClient *chainProto.Chain, methodname string, params []stri
 	reflectParams = append(reflectParams, reflect.ValueOf(context.Background())) // Append request context
 
 	switch methodname {
-	case "GetBalance", "Bytes", "String", "ReadChainFromMemory", "QueryTransaction", "GetNumTransactions":
+	case "GetBalance", "Bytes", "String", "ReadChainFromMemory", "QueryTransaction", "GetNumTransactions", "GetTransactionByHash", "GetFinality":
 		if len(params) != 1 {
 			return errors.New("invalid parameters (requires string)") // Return error
 		}
 
 		reflectParams = append(reflectParams, reflect.ValueOf(&chainProto.GeneralRequest{Address: params[0]})) // Append params
+	case "GetStateProof":
+		if len(params) != 2 {
+			return errors.New("invalid parameters (requires string, string)") // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&chainProto.GeneralRequest{Address: params[0], Key: []byte(params[1])})) // Append params
+	case "CallContract":
+		if len(params) < 2 || len(params) > 4 { // Check for invalid parameters
+			return errors.New("invalid parameters (require string, []byte, optionally followed by string sender, uint64 gas limit)") // Return error
+		}
+
+		var sender string   // Init sender buffer
+		var gasLimit uint64 // Init gas limit buffer
+
+		if len(params) > 2 { // Check has sender
+			sender = params[2] // Set sender
+		}
+
+		if len(params) == 4 { // Check has gas limit
+			gasLimit, _ = strconv.ParseUint(params[3], 10, 64) // Parse gas limit
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&chainProto.GeneralRequest{Address: params[0], Payload: []byte(params[1]), Sender: sender, GasLimit: gasLimit})) // Append params
+	case "GetLogs":
+		if len(params) == 0 { // Check for invalid parameters
+			return errors.New("invalid parameters (require string contract (empty for any), optionally followed by string topics)") // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&chainProto.GeneralRequest{Address: params[0], Topics: params[1:]})) // Append params
+	case "GetConflicts":
+		if len(params) > 1 { // Check for invalid parameters
+			return errors.New("invalid parameters (accepts optional string sender)") // Return error
+		}
+
+		var sender string // Init sender buffer
+
+		if len(params) == 1 { // Check has sender
+			sender = params[0] // Set sender
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&chainProto.GeneralRequest{Address: sender})) // Append params
+	case "ExportDag":
+		if len(params) == 0 { // Check for invalid parameters
+			return errors.New("invalid parameters (require string format (dot or json), optionally followed by address=, since=, until=, hash=, depth= filters)") // Return error
+		}
+
+		request := &chainProto.GeneralRequest{Format: params[0]} // Init request
+
+		err := parseGraphFilterParams(request, params[1:], true) // Parse filters
+		if err != nil {                                          // Check for errors
+			return err // Return found error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(request)) // Append params
+	case "ExportChain":
+		if len(params) < 2 { // Check for invalid parameters
+			return errors.New("invalid parameters (require string address, string format (dot or json), optionally followed by since=, until=, hash=, depth= filters)") // Return error
+		}
+
+		request := &chainProto.GeneralRequest{Address: params[0], Format: params[1]} // Init request
+
+		err := parseGraphFilterParams(request, params[2:], false) // Parse filters
+		if err != nil {                                           // Check for errors
+			return err // Return found error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(request)) // Append params
 	default:
-		return errors.New("illegal method: " + methodname + ", available methods: GetBalance(), Bytes(), String(), ReadChainFromMemory(), QueryTransaction(), GetNumTransactions()") // Return error
+		return errors.New("illegal method: " + methodname + ", available methods: GetBalance(), Bytes(), String(), ReadChainFromMemory(), QueryTransaction(), GetNumTransactions(), GetTransactionByHash(), GetStateProof(), CallContract(), GetLogs(), GetFinality(), GetConflicts(), ExportDag(), ExportChain()") // Return error
 	}
 
 	result := reflect.ValueOf(*chainClient).MethodByName(methodname).Call(reflectParams) // Call method
@@ -369,6 +508,48 @@ func handleChain(chainClient *chainProto.Chain, methodname string, params []stri
 	return nil // No error occurred, return nil
 }
 
+// parseGraphFilterParams - parse a given set of key=value graph export filters (address, since, until, hash, depth) into a given request
+func parseGraphFilterParams(request *chainProto.GeneralRequest, params []string, allowAddress bool) error {
+	for _, param := range params { // Iterate through params
+		keyValue := strings.SplitN(param, "=", 2) // Split key, value
+
+		if len(keyValue) != 2 { // Check not key=value
+			return fmt.Errorf("invalid filter %s (expected key=value)", param) // Return error
+		}
+
+		var err error // Init error buffer
+
+		switch keyValue[0] {
+		case "address":
+			if !allowAddress { // Check address not allowed
+				return errors.New("invalid filter address (an account chain is already filtered by address)") // Return error
+			}
+
+			request.Address = keyValue[1] // Set address
+		case "since":
+			request.Since, err = strconv.ParseInt(keyValue[1], 10, 64) // Parse start
+		case "until":
+			request.Until, err = strconv.ParseInt(keyValue[1], 10, 64) // Parse end
+		case "hash":
+			request.Hash = keyValue[1] // Set starting hash
+		case "depth":
+			var depth uint64 // Init depth buffer
+
+			depth, err = strconv.ParseUint(keyValue[1], 10, 32) // Parse depth
+
+			request.Depth = uint32(depth) // Set depth
+		default:
+			return fmt.Errorf("invalid filter %s (accepts address, since, until, hash, depth)", keyValue[0]) // Return error
+		}
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
 // handleCoordinationChain - handle chain receiver
 func handleCoordinationChain(chainClient *coordinationChainProto.CoordinationChain, methodname string, params []string) error {
 	reflectParams := []reflect.Value{} // Init buffer
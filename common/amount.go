@@ -0,0 +1,263 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// Decimals - number of decimal places a single coin is divisible into (a base unit is 10^-Decimals coins)
+const Decimals = 18
+
+// Amount - fixed-point amount of coins, counted in integer base units
+type Amount struct {
+	units *big.Int // Base units
+}
+
+var (
+	// ErrInvalidAmount - error definition describing an amount string that could not be parsed
+	ErrInvalidAmount = errors.New("invalid amount")
+
+	// ErrAmountTooPrecise - error definition describing an amount with more than Decimals decimal places
+	ErrAmountTooPrecise = errors.New("amount has too many decimal places")
+
+	// unitsPerCoin - number of base units in a single coin
+	unitsPerCoin = new(big.Int).Exp(big.NewInt(10), big.NewInt(Decimals), nil)
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// NewAmount - initialize amount with a given number of base units
+func NewAmount(units *big.Int) *Amount {
+	if units == nil { // Check nil units
+		return &Amount{units: new(big.Int)} // Return zero
+	}
+
+	return &Amount{units: new(big.Int).Set(units)} // Return amount
+}
+
+// Units - initialize amount with a given number of base units
+func Units(units int64) *Amount {
+	return &Amount{units: big.NewInt(units)} // Return amount
+}
+
+// Coins - initialize amount with a given number of whole coins
+func Coins(coins int64) *Amount {
+	return &Amount{units: new(big.Int).Mul(big.NewInt(coins), unitsPerCoin)} // Return amount
+}
+
+// ParseAmount - parse a decimal number of coins (e.g. "1.5") into an amount
+func ParseAmount(s string) (*Amount, error) {
+	s = strings.TrimSpace(s) // Trim whitespace
+
+	negative := strings.HasPrefix(s, "-") // Check is negative
+
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+") // Trim sign
+
+	parts := strings.SplitN(s, ".", 2) // Split whole, fractional coins
+
+	whole, fraction := parts[0], "" // Init parts buffers
+
+	if len(parts) == 2 { // Check has fraction
+		fraction = strings.TrimRight(parts[1], "0") // Set fraction
+	}
+
+	if whole == "" && (len(parts) == 1 || parts[1] == "") || !isDigits(whole) || len(parts) == 2 && !isDigits(parts[1]) { // Check invalid
+		return &Amount{}, ErrInvalidAmount // Return error
+	}
+
+	if len(fraction) > Decimals { // Check too precise
+		return &Amount{}, ErrAmountTooPrecise // Return error
+	}
+
+	units, ok := new(big.Int).SetString("0"+whole+fraction+strings.Repeat("0", Decimals-len(fraction)), 10) // Parse units
+	if !ok {                                                                                                // Check for errors
+		return &Amount{}, ErrInvalidAmount // Return error
+	}
+
+	if negative { // Check is negative
+		units.Neg(units) // Negate
+	}
+
+	return &Amount{units: units}, nil // Return amount
+}
+
+// ParseUnits - parse a decimal integer number of base units into an amount
+func ParseUnits(s string) (*Amount, error) {
+	units, ok := new(big.Int).SetString(s, 10) // Parse units
+	if !ok {                                   // Check for errors
+		return &Amount{}, ErrInvalidAmount // Return error
+	}
+
+	return &Amount{units: units}, nil // Return amount
+}
+
+// AmountFromRat - convert an exact number of coins to an amount, truncating anything smaller than a base unit
+func AmountFromRat(coins *big.Rat) *Amount {
+	units := new(big.Int).Mul(coins.Num(), unitsPerCoin) // Convert to base units
+
+	return &Amount{units: units.Quo(units, coins.Denom())} // Return amount
+}
+
+// AmountFromFloat - convert a legacy floating-point number of coins to an amount. The shortest decimal representation of the
+// float is used, so values parsed from decimal strings (e.g. 0.1) convert exactly.
+func AmountFromFloat(coins *big.Float) (*Amount, error) {
+	if coins == nil { // Check nil
+		return NewAmount(nil), nil // Return zero
+	}
+
+	if coins.IsInf() { // Check infinite
+		return &Amount{}, ErrInvalidAmount // Return error
+	}
+
+	rat, ok := new(big.Rat).SetString(coins.Text('g', -1)) // Parse shortest decimal representation
+	if !ok {                                               // Check for errors
+		return &Amount{}, ErrInvalidAmount // Return error
+	}
+
+	return AmountFromRat(rat), nil // Return amount
+}
+
+// BaseUnits - get the number of base units in a given amount
+func (amount *Amount) BaseUnits() *big.Int {
+	if amount == nil || amount.units == nil { // Check zero
+		return new(big.Int) // Return zero
+	}
+
+	return new(big.Int).Set(amount.units) // Return units
+}
+
+// Rat - get the exact number of coins in a given amount
+func (amount *Amount) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(amount.BaseUnits(), unitsPerCoin) // Return coins
+}
+
+// WholeCoins - get the number of whole coins in a given amount (truncated toward zero)
+func (amount *Amount) WholeCoins() *big.Int {
+	units := amount.BaseUnits() // Get units
+
+	return units.Quo(units, unitsPerCoin) // Return coins
+}
+
+// Add - calculate the sum of two amounts
+func (amount *Amount) Add(other *Amount) *Amount {
+	units := amount.BaseUnits() // Get units
+
+	return &Amount{units: units.Add(units, other.BaseUnits())} // Return sum
+}
+
+// Sub - calculate the difference of two amounts
+func (amount *Amount) Sub(other *Amount) *Amount {
+	units := amount.BaseUnits() // Get units
+
+	return &Amount{units: units.Sub(units, other.BaseUnits())} // Return difference
+}
+
+// Mul - calculate the product of an amount and a given integer (e.g. a price and a quantity)
+func (amount *Amount) Mul(n uint64) *Amount {
+	units := amount.BaseUnits() // Get units
+
+	return &Amount{units: units.Mul(units, new(big.Int).SetUint64(n))} // Return product
+}
+
+// MulRat - calculate the product of an amount and a given ratio, truncating anything smaller than a base unit
+func (amount *Amount) MulRat(ratio *big.Rat) *Amount {
+	units := amount.BaseUnits() // Get units
+
+	units.Mul(units, ratio.Num()) // Multiply by numerator
+
+	return &Amount{units: units.Quo(units, ratio.Denom())} // Return product
+}
+
+// Cmp - compare two amounts (-1 if amount < other, 0 if equal, 1 if amount > other)
+func (amount *Amount) Cmp(other *Amount) int {
+	return amount.BaseUnits().Cmp(other.BaseUnits()) // Compare units
+}
+
+// Sign - get the sign of a given amount (-1, 0 or 1)
+func (amount *Amount) Sign() int {
+	return amount.BaseUnits().Sign() // Return sign
+}
+
+// String - convert given amount to an exact decimal number of coins
+func (amount *Amount) String() string {
+	units := amount.BaseUnits() // Get units
+
+	sign := "" // Init sign buffer
+
+	if units.Sign() < 0 { // Check negative
+		sign = "-"       // Set sign
+		units.Neg(units) // Make positive
+	}
+
+	whole, fraction := new(big.Int).QuoRem(units, unitsPerCoin, new(big.Int)) // Split whole, fractional coins
+
+	if fraction.Sign() == 0 { // Check whole number of coins
+		return sign + whole.String() // Return coins
+	}
+
+	fractionString := fraction.String() // Get fraction digits
+
+	return sign + whole.String() + "." + strings.TrimRight(strings.Repeat("0", Decimals-len(fractionString))+fractionString, "0") // Return coins
+}
+
+// MarshalJSON - encode given amount as a JSON string of base units
+func (amount *Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(amount.BaseUnits().String()) // Marshal units
+}
+
+// UnmarshalJSON - decode a JSON string of base units into an amount
+func (amount *Amount) UnmarshalJSON(b []byte) error {
+	var units string // Init units buffer
+
+	err := json.Unmarshal(b, &units) // Decode string
+	if err != nil {                  // Check for errors
+		return err // Return found error
+	}
+
+	decoded, err := ParseUnits(units) // Parse units
+	if err != nil {                   // Check for errors
+		return err // Return found error
+	}
+
+	*amount = *decoded // Set amount
+
+	return nil // No error occurred, return nil
+}
+
+// GobEncode - encode given amount for gob
+func (amount *Amount) GobEncode() ([]byte, error) {
+	return amount.BaseUnits().GobEncode() // Encode units
+}
+
+// GobDecode - decode a gob-encoded amount
+func (amount *Amount) GobDecode(b []byte) error {
+	units := new(big.Int) // Init units buffer
+
+	err := units.GobDecode(b) // Decode units
+	if err != nil {           // Check for errors
+		return err // Return found error
+	}
+
+	amount.units = units // Set units
+
+	return nil // No error occurred, return nil
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// isDigits - check that a given string consists only of decimal digits
+func isDigits(s string) bool {
+	for _, char := range s { // Iterate through chars
+		if char < '0' || char > '9' { // Check not digit
+			return false // Not digits
+		}
+	}
+
+	return true // Digits
+}
+
+/* END INTERNAL METHODS */
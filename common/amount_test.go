@@ -0,0 +1,112 @@
+package common
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestParseAmount - test parsing of decimal coin amounts
+func TestParseAmount(t *testing.T) {
+	for input, expected := range map[string]string{"1.5": "1500000000000000000", "0.000000000000000001": "1", "-2": "-2000000000000000000", ".25": "250000000000000000", "3.10": "3100000000000000000"} { // Iterate through test cases
+		amount, err := ParseAmount(input) // Parse amount
+		if err != nil {                   // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if amount.BaseUnits().String() != expected { // Check units
+			t.Fatalf("invalid units for %s: %s", input, amount.BaseUnits().String()) // Panic
+		}
+	}
+
+	if _, err := ParseAmount("0.0000000000000000001"); err != ErrAmountTooPrecise { // Check too precise
+		t.Fatalf("expected too precise amount to be rejected, got %v", err) // Panic
+	}
+
+	for _, input := range []string{"", ".", "1e18", "1.2.3", "abc"} { // Iterate through invalid amounts
+		if _, err := ParseAmount(input); err != ErrInvalidAmount { // Check invalid
+			t.Fatalf("expected %q to be rejected, got %v", input, err) // Panic
+		}
+	}
+}
+
+// TestStringAmount - test conversion from amount to exact decimal string
+func TestStringAmount(t *testing.T) {
+	for _, input := range []string{"0", "1", "1.5", "0.000000000000000001", "-12.25", "123456789012345678901234567890.123456789012345678"} { // Iterate through test cases
+		amount, err := ParseAmount(input) // Parse amount
+		if err != nil {                   // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if amount.String() != input { // Check round trip
+			t.Fatalf("invalid string value for %s: %s", input, amount.String()) // Panic
+		}
+	}
+}
+
+// TestArithmeticAmount - test that amount arithmetic is exact, and doesn't modify operands
+func TestArithmeticAmount(t *testing.T) {
+	tenth, _ := ParseAmount("0.1") // Parse amount
+
+	sum := NewAmount(nil) // Init sum
+
+	for x := 0; x < 10; x++ { // Add a tenth ten times
+		sum = sum.Add(tenth) // Add
+	}
+
+	if sum.Cmp(Coins(1)) != 0 || tenth.String() != "0.1" { // Check exact
+		t.Fatalf("invalid sum: %s", sum.String()) // Panic
+	}
+
+	if fee := tenth.Mul(3); fee.String() != "0.3" { // Check product
+		t.Fatalf("invalid product: %s", fee.String()) // Panic
+	}
+
+	if third := Coins(1).MulRat(big.NewRat(1, 3)); third.BaseUnits().String() != "333333333333333333" { // Check truncated
+		t.Fatalf("invalid ratio: %s", third.BaseUnits().String()) // Panic
+	}
+
+	if Coins(1).Sub(Coins(2)).Sign() != -1 || (*Amount)(nil).Sign() != 0 { // Check signs
+		t.Fatal("invalid sign") // Panic
+	}
+}
+
+// TestJSONAmount - test that amounts are encoded as strings of base units
+func TestJSONAmount(t *testing.T) {
+	encoded, err := json.Marshal(Coins(2)) // Encode amount
+	if err != nil {                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if string(encoded) != `"2000000000000000000"` { // Check encoding
+		t.Fatalf("invalid encoding: %s", encoded) // Panic
+	}
+
+	decoded := &Amount{} // Init buffer
+
+	if err = json.Unmarshal(encoded, decoded); err != nil || decoded.Cmp(Coins(2)) != 0 { // Check decoded
+		t.Fatalf("invalid decoded amount: %s (%v)", decoded.String(), err) // Panic
+	}
+
+	if err = json.Unmarshal([]byte(`"1e+18"`), decoded); err != ErrInvalidAmount { // Check floating-point encoding rejected
+		t.Fatalf("expected floating-point amount to be rejected, got %v", err) // Panic
+	}
+}
+
+// TestAmountFromFloat - test conversion of legacy floating-point amounts
+func TestAmountFromFloat(t *testing.T) {
+	for input, expected := range map[float64]string{0.1: "0.1", 1e18: "1000000000000000000", 87.5: "87.5"} { // Iterate through test cases
+		amount, err := AmountFromFloat(big.NewFloat(input)) // Convert amount
+		if err != nil {                                     // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if amount.String() != expected { // Check amount
+			t.Fatalf("invalid amount for %f: %s", input, amount.String()) // Panic
+		}
+	}
+}
+
+/* END EXPORTED METHODS */
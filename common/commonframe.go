@@ -0,0 +1,55 @@
+package common
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// MaxMessageSize - maximum size (in bytes) of a single framed message
+const MaxMessageSize = 32 * 1024 * 1024
+
+// ErrMessageTooLarge - error definition describing a framed message exceeding MaxMessageSize
+var ErrMessageTooLarge = errors.New("message exceeds max message size")
+
+/* BEGIN EXPORTED METHODS */
+
+// WriteMessage - write given message to a given writer, prefixed by its length (as an unsigned varint). Messages may contain
+// arbitrary bytes.
+func WriteMessage(writer io.Writer, message []byte) error {
+	if len(message) > MaxMessageSize { // Check too large
+		return ErrMessageTooLarge // Return error
+	}
+
+	prefix := make([]byte, binary.MaxVarintLen64) // Init length prefix buffer
+
+	framed := append(prefix[:binary.PutUvarint(prefix, uint64(len(message)))], message...) // Frame message
+
+	_, err := writer.Write(framed) // Write message
+
+	return err // Return error (if any)
+}
+
+// ReadMessage - read a single length-prefixed message (see WriteMessage) from a given reader
+func ReadMessage(reader *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader) // Read length prefix
+	if err != nil {                           // Check for errors
+		return nil, err // Return found error
+	}
+
+	if length > MaxMessageSize { // Check too large
+		return nil, ErrMessageTooLarge // Return error
+	}
+
+	message := make([]byte, length) // Init message buffer
+
+	_, err = io.ReadFull(reader, message) // Read message
+	if err != nil {                       // Check for errors
+		return nil, err // Return found error
+	}
+
+	return message, nil // Return read message
+}
+
+/* END EXPORTED METHODS */
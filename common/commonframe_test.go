@@ -0,0 +1,63 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+/* BEGIN EXTERNAL METHODS */
+
+// TestWriteMessage - test that messages containing arbitrary bytes survive framing
+func TestWriteMessage(t *testing.T) {
+	messages := [][]byte{[]byte("test"), {}, {'\r', '\n', 0, '\r'}, bytes.Repeat([]byte{'\r'}, 300)} // Init messages
+
+	buffer := new(bytes.Buffer) // Init buffer
+
+	for _, message := range messages { // Iterate through messages
+		err := WriteMessage(buffer, message) // Write message
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	reader := bufio.NewReader(buffer) // Init reader
+
+	for _, message := range messages { // Iterate through messages
+		read, err := ReadMessage(reader) // Read message
+		if err != nil {                  // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if !bytes.Equal(read, message) { // Check message
+			t.Fatalf("expected message %q, got %q", message, read) // Panic
+		}
+	}
+
+	if _, err := ReadMessage(reader); err == nil { // Check no more messages
+		t.Fatal("expected error reading past last message") // Panic
+	}
+}
+
+// TestReadMessageTooLarge - test that oversized and truncated messages are rejected
+func TestReadMessageTooLarge(t *testing.T) {
+	if err := WriteMessage(new(bytes.Buffer), make([]byte, MaxMessageSize+1)); err != ErrMessageTooLarge { // Check oversized write rejected
+		t.Fatalf("expected oversized write to be rejected, got %v", err) // Panic
+	}
+
+	prefix := make([]byte, binary.MaxVarintLen64) // Init prefix buffer
+
+	prefix = prefix[:binary.PutUvarint(prefix, MaxMessageSize+1)] // Encode oversized length
+
+	if _, err := ReadMessage(bufio.NewReader(bytes.NewReader(prefix))); err != ErrMessageTooLarge { // Check oversized read rejected
+		t.Fatalf("expected oversized read to be rejected, got %v", err) // Panic
+	}
+
+	if _, err := ReadMessage(bufio.NewReader(bytes.NewReader([]byte{5, 't', 'e'}))); err == nil { // Check truncated read rejected
+		t.Fatal("expected truncated read to be rejected") // Panic
+	}
+}
+
+/* END EXTERNAL METHODS */
@@ -2,7 +2,6 @@ package common
 
 import (
 	"bufio"
-	"bytes"
 	"crypto/tls"
 	"errors"
 	"io/ioutil"
@@ -38,7 +37,7 @@ func SendBytes(b []byte, address string) error {
 
 	writer := bufio.NewWriter(connection) // Initialize writer
 
-	_, err = writer.Write(append(b, byte('\r'))) // Write data
+	err = WriteMessage(writer, b) // Write data
 
 	if err != nil { // Check for errors
 		return err // Return found errors
@@ -70,7 +69,7 @@ func SendBytesResult(b []byte, address string) ([]byte, error) {
 
 	readWriter := bufio.NewReadWriter(bufio.NewReader(connection), bufio.NewWriter(connection)) // Initialize read writer
 
-	_, err = readWriter.Write(append(b, byte('\r'))) // Write data to connection
+	err = WriteMessage(readWriter, b) // Write data to connection
 
 	if err != nil { // Check for errors
 		return nil, err // Return found error
@@ -78,24 +77,12 @@ func SendBytesResult(b []byte, address string) ([]byte, error) {
 
 	readWriter.Flush() // Flush
 
-	response, err := readWriter.ReadBytes('\r') // Read up to delimiter
-	if err != nil {                             // Check for errors
-		return nil, err // Return found error
-	}
-
-	return bytes.Trim(response, "\r"), nil // Return response
+	return ReadMessage(readWriter.Reader) // Read response
 }
 
 // ReadConnectionWaitAsyncNoTLS - attempt to read from connection in an asynchronous fashion, after waiting for peer to write
 func ReadConnectionWaitAsyncNoTLS(conn net.Conn) ([]byte, error) {
-	reader := bufio.NewReader(conn) // Initialize reader
-
-	readBytes, err := reader.ReadBytes('\r') // Read up to delimiter
-	if err != nil {                          // Check for errors
-		return nil, err // Return found error
-	}
-
-	return bytes.Trim(readBytes, "\r"), nil // Return read bytes w/trimmed delimiter
+	return ReadMessage(bufio.NewReader(conn)) // Read message
 }
 
 /*
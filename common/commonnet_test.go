@@ -28,7 +28,7 @@ func TestReadConnectionWaitAsyncNoTLS(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
-	_, err = connection.Write(append([]byte("test"), byte('\r'))) // Write test data to connection
+	err = WriteMessage(connection, []byte("test")) // Write test data to connection
 
 	if err != nil { // Check for errors
 		t.Error(err) // Log found error
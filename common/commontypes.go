@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"github.com/SummerCash/go-summercash/crypto"
 )
@@ -23,8 +24,22 @@ type AddressSpace struct {
 	ID        Hash      `json:"ID"`        // AddressSpace identifier
 }
 
-// ErrDuplicateAddress - error definition describing two addresses of equal value
-var ErrDuplicateAddress = errors.New("duplicate address")
+var (
+	// ErrDuplicateAddress - error definition describing two addresses of equal value
+	ErrDuplicateAddress = errors.New("duplicate address")
+
+	// ErrInvalidAddressPrefix - error definition describing an address string without a 0x prefix
+	ErrInvalidAddressPrefix = errors.New("invalid address: missing 0x prefix")
+
+	// ErrInvalidAddressLength - error definition describing an address string of the wrong length
+	ErrInvalidAddressLength = errors.New("invalid address: expected 0x followed by 40 hex characters")
+
+	// ErrInvalidAddressCharacter - error definition describing an address string containing non-hex characters
+	ErrInvalidAddressCharacter = errors.New("invalid address: contains non-hex characters")
+
+	// ErrInvalidAddressChecksum - error definition describing an address string with an invalid mixed-case checksum (e.g. a typo)
+	ErrInvalidAddressChecksum = errors.New("invalid address checksum: check the address for typos")
+)
 
 const (
 	// AddressLength - max addr length
@@ -42,19 +57,22 @@ const (
 
 // NewAddress - initialize new address
 func NewAddress(privateKey *ecdsa.PrivateKey) (Address, error) {
-	var address Address // Init buffer
+	return PublicKeyToAddress(&privateKey.PublicKey), nil // Return address
+}
 
-	marshaledPublicKey := elliptic.Marshal(privateKey.PublicKey, privateKey.PublicKey.X, privateKey.PublicKey.Y) // Marshal public key
+// PublicKeyToAddress - initialize new address with given public key (the last AddressLength bytes of the sha3 hash of the marshaled key)
+func PublicKeyToAddress(publicKey *ecdsa.PublicKey) Address {
+	var address Address // Init buffer
 
-	marshaledPublicKey = append([]byte("0x"), marshaledPublicKey...) // Prepend prefix
+	hash := crypto.Sha3(elliptic.Marshal(publicKey, publicKey.X, publicKey.Y)) // Hash marshaled public key
 
-	copy(address[:], marshaledPublicKey) // Copy marshaled
+	copy(address[:], hash[len(hash)-AddressLength:]) // Copy hash suffix
 
-	return address, nil // Return public key
+	return address // Return address
 }
 
-// PublicKeyToAddress - initialize new address with given public key
-func PublicKeyToAddress(publicKey *ecdsa.PublicKey) Address {
+// LegacyPublicKeyToAddress - initialize address with given public key using the legacy derivation ("0x" followed by a truncated marshaled key)
+func LegacyPublicKeyToAddress(publicKey *ecdsa.PublicKey) Address {
 	var address Address // Init buffer
 
 	marshaledPublicKey := elliptic.Marshal(publicKey, publicKey.X, publicKey.Y) // Marshal public key
@@ -63,39 +81,81 @@ func PublicKeyToAddress(publicKey *ecdsa.PublicKey) Address {
 
 	copy(address[:], marshaledPublicKey) // Copy marshaled
 
-	return address // Return public key
+	return address // Return address
 }
 
-// StringToAddress - convert string to address
+// StringToAddress - convert checksummed string (see Address.String) to address. All-lowercase and all-uppercase strings carry no
+// checksum, and are accepted as-is; legacy strings ("0x" followed by 36 hex characters) are also accepted.
 func StringToAddress(s string) (Address, error) {
 	var address Address // Init buffer
 
-	decoded, err := hex.DecodeString(s[2:]) // Decode string
-	if err != nil {                         // Check for errors
-		return Address{}, err // Return found error
+	if !strings.HasPrefix(s, "0x") { // Check no prefix
+		return Address{}, ErrInvalidAddressPrefix // Return error
 	}
 
-	copy(address[:], append([]byte("0x"), decoded...)) // Copy decoded
+	digits := s[2:] // Trim prefix
+
+	switch len(digits) {
+	case 2 * AddressLength: // Checksummed address
+		decoded, err := hex.DecodeString(digits) // Decode string
+		if err != nil {                          // Check for errors
+			return Address{}, ErrInvalidAddressCharacter // Return error
+		}
+
+		copy(address[:], decoded) // Copy decoded
+
+		if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && s != address.String() { // Check invalid checksum
+			return Address{}, ErrInvalidAddressChecksum // Return error
+		}
+	case 2 * (AddressLength - 2): // Legacy address
+		decoded, err := hex.DecodeString(digits) // Decode string
+		if err != nil {                          // Check for errors
+			return Address{}, ErrInvalidAddressCharacter // Return error
+		}
+
+		copy(address[:], append([]byte("0x"), decoded...)) // Copy decoded
+	default:
+		return Address{}, ErrInvalidAddressLength // Return error
+	}
 
 	return address, nil // Return address
 }
 
+// IsDerivedFrom - check that given address is derived from a given public key (under either the current or legacy derivation)
+func (address Address) IsDerivedFrom(publicKey *ecdsa.PublicKey) bool {
+	return address == PublicKeyToAddress(publicKey) || address == LegacyPublicKeyToAddress(publicKey) // Check derivations
+}
+
 // Bytes - convert given address to bytes
 func (address Address) Bytes() []byte {
 	return address[:] // Return byte val
 }
 
-// String - convert given address to string
+// String - convert given address to a checksummed string: "0x" followed by the hex-encoded address, where each letter is
+// uppercased if the corresponding nibble of the sha3 hash of the lowercase hex-encoded address is at least 8
 func (address Address) String() string {
-	noPrefix := address[2:] // Remove duplicate 0x prefix
+	enc := []byte(hex.EncodeToString(address[:])) // Encode address
 
-	enc := make([]byte, len(noPrefix)*2+2) // Init encoder buffer
+	checksum := crypto.Sha3(enc) // Hash lowercase encoding
 
-	copy(enc, "0x") // Copy prefix
+	for x, char := range enc { // Iterate through chars
+		nibble := checksum[x/2] >> 4 // Get high nibble
 
-	hex.Encode(enc[2:], noPrefix[:]) // Encode given byte array
+		if x%2 == 1 { // Check is odd char
+			nibble = checksum[x/2] & 0xf // Get low nibble
+		}
 
-	return string(enc) // Return string val
+		if char >= 'a' && nibble >= 8 { // Check should uppercase
+			enc[x] = char - ('a' - 'A') // Uppercase
+		}
+	}
+
+	return "0x" + string(enc) // Return string val
+}
+
+// LegacyString - convert given address to the legacy string format ("0x" followed by the hex encoding of all but the first two bytes)
+func (address Address) LegacyString() string {
+	return "0x" + hex.EncodeToString(address[2:]) // Return string val
 }
 
 /*
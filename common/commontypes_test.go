@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"strings"
 	"testing"
 
 	"github.com/SummerCash/go-summercash/crypto"
@@ -113,6 +114,79 @@ func TestString(t *testing.T) {
 	t.Log(address.String()) // Log success
 }
 
+// TestStringToAddressChecksum - test that address strings are checksummed, and that typos are rejected
+func TestStringToAddressChecksum(t *testing.T) {
+	address := Address{0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf, 0xab, 0xcd, 0xef, 0xff} // Init address
+
+	if address.String() != "0x000102030405060708090A0b0c0d0e0FAbcdefff" { // Check checksummed string
+		t.Fatalf("unexpected checksummed address %s", address.String()) // Panic
+	}
+
+	for _, s := range []string{address.String(), strings.ToLower(address.String()), "0x" + strings.ToUpper(address.String()[2:])} { // Iterate through valid strings
+		decoded, err := StringToAddress(s) // Decode string
+		if err != nil {                    // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if decoded != address { // Check for match
+			t.Fatalf("decoded %s to %s", s, decoded.String()) // Panic
+		}
+	}
+
+	typo := []byte(address.String()) // Init typo buffer
+
+	for x := len(typo) - 1; x > 1; x-- { // Iterate through chars
+		if typo[x] >= 'a' && typo[x] <= 'f' || typo[x] >= 'A' && typo[x] <= 'F' { // Check is letter
+			typo[x] ^= 'a' - 'A' // Flip case
+
+			break // Break
+		}
+	}
+
+	if _, err := StringToAddress(string(typo)); err != ErrInvalidAddressChecksum { // Check bad checksum rejected
+		t.Fatalf("expected checksum error for %s, got %v", typo, err) // Panic
+	}
+
+	if _, err := StringToAddress(address.String()[:41]); err != ErrInvalidAddressLength { // Check truncated address rejected
+		t.Fatalf("expected length error, got %v", err) // Panic
+	}
+
+	if _, err := StringToAddress("0x" + strings.Repeat("g", 40)); err != ErrInvalidAddressCharacter { // Check non-hex address rejected
+		t.Fatalf("expected character error, got %v", err) // Panic
+	}
+
+	if _, err := StringToAddress(address.String()[2:]); err != ErrInvalidAddressPrefix { // Check unprefixed address rejected
+		t.Fatalf("expected prefix error, got %v", err) // Panic
+	}
+}
+
+// TestLegacyAddress - test that addresses in the legacy format remain readable and verifiable
+func TestLegacyAddress(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	legacy := LegacyPublicKeyToAddress(&privateKey.PublicKey) // Derive legacy address
+
+	decoded, err := StringToAddress(legacy.LegacyString()) // Decode legacy string
+	if err != nil {                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if decoded != legacy { // Check for match
+		t.Fatalf("decoded legacy address %s to %s", legacy.LegacyString(), decoded.String()) // Panic
+	}
+
+	if !legacy.IsDerivedFrom(&privateKey.PublicKey) || !PublicKeyToAddress(&privateKey.PublicKey).IsDerivedFrom(&privateKey.PublicKey) { // Check both derivations accepted
+		t.Fatal("address not derived from public key") // Panic
+	}
+
+	if legacy == PublicKeyToAddress(&privateKey.PublicKey) { // Check derivation changed
+		t.Fatal("address derived using legacy derivation") // Panic
+	}
+}
+
 /*
 	END ADDRESS METHODS
 */
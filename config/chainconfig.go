@@ -5,8 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"path/filepath"
+	"sort"
 	"strconv"
 
 	"github.com/SummerCash/go-summercash/common"
@@ -15,11 +15,14 @@ import (
 
 // ChainConfig - chain configuration
 type ChainConfig struct {
-	Alloc map[string]*big.Float `json:"alloc"` // Account balances at genesis
+	Alloc map[string]*common.Amount `json:"alloc"` // Account balances at genesis
 
 	AllocAddresses []common.Address // Account addresses
 
-	InflationRate float64 `json:"inflation"` // Inflation rate
+	InflationRate   float64 `json:"inflation"`        // Fraction of the supply minted at the end of each issuance period
+	InflationPeriod uint64  `json:"inflation_period"` // Length of an issuance period (in seconds, DefaultInflationPeriod if 0)
+
+	FinalityThreshold float64 `json:"finality_threshold"` // Fraction of the supply that must witness a transaction for it to be final (DefaultFinalityThreshold if 0)
 
 	NetworkID    uint        `json:"network"` // Network ID (0: mainnet, 1: testnet, etc...)
 	ChainID      common.Hash `json:"id"`      // Hashed networkID, genesisSignature
@@ -44,14 +47,17 @@ func NewChainConfig(genesisFilePath string) (*ChainConfig, error) {
 		return &ChainConfig{}, err // Return error
 	}
 
-	alloc := make(map[string]*big.Float) // Init alloc map
+	alloc := make(map[string]*common.Amount) // Init alloc map
 
 	allocAddresses := []common.Address{}
 
 	x := 0 // Init iterator
 
 	for key, value := range readJSON["alloc"].(map[string]interface{}) { // Iterate through genesis addresses
-		floatVal, _, _ := big.ParseFloat(value.(map[string]interface{})["balance"].(string), 10, 350, big.ToNearestEven) // Parse float
+		balance, err := common.ParseAmount(value.(map[string]interface{})["balance"].(string)) // Parse balance (in coins)
+		if err != nil {                                                                        // Check for errors
+			return &ChainConfig{}, err // Return error
+		}
 
 		address, err := common.StringToAddress(key) // Get address value
 		if err != nil {                             // Check for errors
@@ -64,18 +70,28 @@ func NewChainConfig(genesisFilePath string) (*ChainConfig, error) {
 			allocAddresses = append(allocAddresses, address) // Append address
 		}
 
-		alloc[key] = floatVal // Set int val
+		alloc[address.String()] = balance // Set balance (keyed by checksummed address, even if read in the legacy format)
 
 		x++ // Increment iterator
 	}
 
+	sort.Slice(allocAddresses, func(i, j int) bool {
+		return bytes.Compare(allocAddresses[i][:], allocAddresses[j][:]) < 0 // Order by address
+	}) // Sort alloc addresses (alloc is decoded into an unordered map)
+
+	inflationRate, _ := readJSON["inflation"].(float64)             // Get inflation rate (0 if not specified)
+	inflationPeriod, _ := readJSON["inflationPeriod"].(float64)     // Get inflation period (default if not specified)
+	finalityThreshold, _ := readJSON["finalityThreshold"].(float64) // Get finality threshold (default if not specified)
+
 	config := &ChainConfig{ // Init config
-		Alloc:          alloc,
-		AllocAddresses: allocAddresses,
-		NetworkID:      uint(readJSON["networkID"].(float64)),
-		InflationRate:  readJSON["inflation"].(float64),
-		ChainID:        common.NewHash(crypto.Sha3(append(rawJSON, []byte(strconv.Itoa(int(readJSON["networkID"].(float64))))...))), // Generate chainID
-		ChainVersion:   Version,                                                                                                     // Set version
+		Alloc:             alloc,
+		AllocAddresses:    allocAddresses,
+		NetworkID:         uint(readJSON["networkID"].(float64)),
+		InflationRate:     inflationRate,
+		InflationPeriod:   uint64(inflationPeriod),
+		FinalityThreshold: finalityThreshold,
+		ChainID:           common.NewHash(crypto.Sha3(append(rawJSON, []byte(strconv.Itoa(int(readJSON["networkID"].(float64))))...))), // Generate chainID
+		ChainVersion:      Version,                                                                                                     // Set version
 	}
 
 	return config, nil // Return initialized chainConfig
@@ -150,7 +166,16 @@ func ReadChainConfigFromMemory() (*ChainConfig, error) {
 	err = json.Unmarshal(data, buffer) // Read json into buffer
 
 	if err != nil { // Check for errors
-		return &ChainConfig{}, err // Return error
+		buffer, err = fromFloatAllocBytes(data) // Try to read config with a legacy floating-point alloc
+		if err != nil {                         // Check for errors
+			return &ChainConfig{}, err // Return error
+		}
+
+		err = buffer.WriteToMemory() // Persist migrated config
+
+		if err != nil { // Check for errors
+			return &ChainConfig{}, err // Return error
+		}
 	}
 
 	return buffer, nil // No error occurred, return read config
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN INTERNAL METHODS */
+
+// fromFloatAllocBytes decodes a chain config written before amounts were stored as integer base units (i.e. with a
+// floating-point number of coins allocated to each genesis account).
+func fromFloatAllocBytes(b []byte) (*ChainConfig, error) {
+	var fields map[string]json.RawMessage // Init fields buffer
+
+	err := json.Unmarshal(b, &fields) // Decode fields
+	if err != nil {                   // Check for errors
+		return &ChainConfig{}, err // Return found error
+	}
+
+	var legacyAlloc map[string]json.RawMessage // Init alloc buffer
+
+	if rawAlloc, ok := fields["alloc"]; ok { // Check has alloc
+		err = json.Unmarshal(rawAlloc, &legacyAlloc) // Decode alloc
+
+		if err != nil { // Check for errors
+			return &ChainConfig{}, err // Return found error
+		}
+	}
+
+	delete(fields, "alloc") // Remove alloc
+
+	withoutAlloc, err := json.Marshal(fields) // Encode remaining fields
+	if err != nil {                           // Check for errors
+		return &ChainConfig{}, err // Return found error
+	}
+
+	chainConfig := &ChainConfig{} // Init config buffer
+
+	err = json.Unmarshal(withoutAlloc, chainConfig) // Decode remaining fields
+
+	if err != nil { // Check for errors
+		return &ChainConfig{}, err // Return found error
+	}
+
+	chainConfig.Alloc = make(map[string]*common.Amount) // Init alloc
+
+	for address, rawValue := range legacyAlloc { // Iterate through alloc
+		coins, _, err := big.ParseFloat(strings.Trim(string(rawValue), `"`), 10, 350, big.ToNearestEven) // Parse legacy float (quoted big.Float, or float64)
+		if err != nil {                                                                                  // Check for errors
+			return &ChainConfig{}, err // Return found error
+		}
+
+		chainConfig.Alloc[address], err = common.AmountFromFloat(coins) // Convert to base units
+
+		if err != nil { // Check for errors
+			return &ChainConfig{}, err // Return found error
+		}
+	}
+
+	return chainConfig, nil // Return migrated config
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// TestFromFloatAllocBytes - test migration of chain configs with a floating-point alloc
+func TestFromFloatAllocBytes(t *testing.T) {
+	chainConfig, err := fromFloatAllocBytes([]byte(`{"alloc": {"0x01": 5000000000000, "0x02": "0.1"}, "network": 1, "version": "0.7.4"}`)) // Migrate config
+	if err != nil {                                                                                                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if chainConfig.Alloc["0x01"].Cmp(common.Coins(5000000000000)) != 0 || chainConfig.Alloc["0x02"].String() != "0.1" { // Check alloc
+		t.Fatalf("invalid migrated alloc: %s, %s", chainConfig.Alloc["0x01"].String(), chainConfig.Alloc["0x02"].String()) // Panic
+	}
+
+	if chainConfig.NetworkID != 1 || chainConfig.ChainVersion != "0.7.4" { // Check remaining fields
+		t.Fatal("migration dropped config fields") // Panic
+	}
+}
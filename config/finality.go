@@ -0,0 +1,41 @@
+package config
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// DefaultFinalityThreshold is the fraction of the supply that must witness a transaction for it to be final, for chain configs that
+// don't specify a finality threshold (exactly two thirds).
+var DefaultFinalityThreshold = big.NewRat(2, 3)
+
+/* BEGIN EXPORTED METHODS */
+
+// FinalityWeight calculates the total witness weight (stake) at which a transaction becomes final, given the current supply.
+func (chainConfig *ChainConfig) FinalityWeight(supply *common.Amount) *common.Amount {
+	return supply.MulRat(chainConfig.finalityThreshold()) // Return weight
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// finalityThreshold gets the exact ratio described by the shortest decimal representation of the finality threshold (see
+// inflationRate), falling back to DefaultFinalityThreshold if the threshold is unset, and capping it at the whole supply.
+func (chainConfig *ChainConfig) finalityThreshold() *big.Rat {
+	if chainConfig.FinalityThreshold <= 0 { // Check no threshold
+		return DefaultFinalityThreshold // Return default threshold
+	}
+
+	if chainConfig.FinalityThreshold >= 1 { // Check unreachable threshold
+		return big.NewRat(1, 1) // Require entire supply
+	}
+
+	threshold, _ := new(big.Rat).SetString(strconv.FormatFloat(chainConfig.FinalityThreshold, 'g', -1, 64)) // Parse threshold
+
+	return threshold // Return threshold
+}
+
+/* END INTERNAL METHODS */
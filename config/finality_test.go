@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestFinalityWeight - test that the finality weight is the configured fraction of the supply
+func TestFinalityWeight(t *testing.T) {
+	chainConfig := &ChainConfig{} // Init config without threshold
+
+	if weight := chainConfig.FinalityWeight(common.Coins(30)); weight.Cmp(common.Coins(20)) != 0 { // Check default threshold
+		t.Fatalf("invalid default finality weight: %s", weight.String()) // Panic
+	}
+
+	chainConfig.FinalityThreshold = 0.5 // Set threshold
+
+	if weight := chainConfig.FinalityWeight(common.Coins(30)); weight.Cmp(common.Coins(15)) != 0 { // Check threshold
+		t.Fatalf("invalid finality weight: %s", weight.String()) // Panic
+	}
+
+	chainConfig.FinalityThreshold = 2 // Set unreachable threshold
+
+	if weight := chainConfig.FinalityWeight(common.Coins(30)); weight.Cmp(common.Coins(30)) != 0 { // Check capped at supply
+		t.Fatalf("invalid capped finality weight: %s", weight.String()) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// DefaultInflationPeriod is the issuance period (in seconds) applied to chain configs that don't specify one (one year).
+const DefaultInflationPeriod uint64 = 365 * 24 * 60 * 60
+
+/* BEGIN EXPORTED METHODS */
+
+// InitialSupply calculates the total supply allocated at genesis.
+func (chainConfig *ChainConfig) InitialSupply() *common.Amount {
+	supply := common.NewAmount(nil) // Init supply
+
+	for _, address := range chainConfig.AllocAddresses { // Iterate through alloc
+		supply = supply.Add(chainConfig.Alloc[address.String()]) // Add alloc value
+	}
+
+	return supply // Return supply
+}
+
+// IssuancePeriod gets the length of a single issuance period.
+func (chainConfig *ChainConfig) IssuancePeriod() time.Duration {
+	if chainConfig.InflationPeriod == 0 { // Check no period
+		return time.Duration(DefaultInflationPeriod) * time.Second // Return default period
+	}
+
+	return time.Duration(chainConfig.InflationPeriod) * time.Second // Return period
+}
+
+// PeriodsElapsed calculates the number of complete issuance periods between a given genesis time and a given time.
+func (chainConfig *ChainConfig) PeriodsElapsed(genesis time.Time, now time.Time) uint64 {
+	if !now.After(genesis) { // Check no time elapsed
+		return 0 // No periods elapsed
+	}
+
+	return uint64(now.Sub(genesis) / chainConfig.IssuancePeriod()) // Return complete periods
+}
+
+// Issuance calculates the amount of coins minted at the end of a given issuance period (starting at 1). Each period mints
+// InflationRate times the supply at the start of the period, truncated to a whole number of base units.
+func (chainConfig *ChainConfig) Issuance(period uint64) *common.Amount {
+	if period == 0 || chainConfig.InflationRate <= 0 { // Check is genesis, or no inflation
+		return common.NewAmount(nil) // Nothing minted
+	}
+
+	return chainConfig.SupplyAfter(period - 1).MulRat(chainConfig.inflationRate()) // Return issuance
+}
+
+// SupplyAfter calculates the total supply after a given number of issuance periods.
+func (chainConfig *ChainConfig) SupplyAfter(periods uint64) *common.Amount {
+	supply := chainConfig.InitialSupply() // Get initial supply
+
+	if chainConfig.InflationRate <= 0 { // Check no inflation
+		return supply // Return initial supply
+	}
+
+	rate := chainConfig.inflationRate() // Get rate
+
+	for x := uint64(0); x < periods; x++ { // Compound periods
+		supply = supply.Add(supply.MulRat(rate)) // Add period issuance
+	}
+
+	return supply // Return supply
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// inflationRate gets the exact ratio described by the shortest decimal representation of the inflation rate (e.g. exactly 1/10
+// for 0.1), so that issuance doesn't depend on binary floating-point rounding.
+func (chainConfig *ChainConfig) inflationRate() *big.Rat {
+	rate, _ := new(big.Rat).SetString(strconv.FormatFloat(chainConfig.InflationRate, 'g', -1, 64)) // Parse rate
+
+	return rate // Return rate
+}
+
+/* END INTERNAL METHODS */
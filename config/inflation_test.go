@@ -0,0 +1,99 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestSupplyAfter - test that the total supply compounds exactly as configured
+func TestSupplyAfter(t *testing.T) {
+	chainConfig := newTestInflationConfig() // Init config
+
+	for period, expected := range []string{"1000", "1250", "1562.5", "1953.125"} { // Iterate through expected supplies
+		if supply := chainConfig.SupplyAfter(uint64(period)); supply.String() != expected { // Check supply
+			t.Fatalf("invalid supply after %d periods: %s", period, supply.String()) // Panic
+		}
+	}
+
+	minted := chainConfig.InitialSupply() // Init minted supply buffer
+
+	for period := uint64(1); period <= 3; period++ { // Iterate through periods
+		minted = minted.Add(chainConfig.Issuance(period)) // Add issuance
+	}
+
+	if minted.Cmp(chainConfig.SupplyAfter(3)) != 0 { // Check issuance adds up to supply
+		t.Fatalf("issuance does not add up to supply: %s", minted.String()) // Panic
+	}
+
+	if chainConfig.Issuance(0).Sign() != 0 { // Check nothing minted at genesis
+		t.Fatal("expected nothing to be minted at genesis") // Panic
+	}
+
+	chainConfig.InflationRate = 0 // Disable inflation
+
+	if chainConfig.SupplyAfter(3).Cmp(common.Coins(1000)) != 0 || chainConfig.Issuance(3).Sign() != 0 { // Check supply fixed
+		t.Fatal("expected fixed supply without inflation") // Panic
+	}
+}
+
+// TestPeriodsElapsed - test that only complete issuance periods are counted
+func TestPeriodsElapsed(t *testing.T) {
+	chainConfig := newTestInflationConfig() // Init config
+
+	genesis := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC) // Init genesis time
+
+	if periods := chainConfig.PeriodsElapsed(genesis, genesis.Add(-time.Hour)); periods != 0 { // Check before genesis
+		t.Fatalf("invalid periods before genesis: %d", periods) // Panic
+	}
+
+	if periods := chainConfig.PeriodsElapsed(genesis, genesis.Add(150*time.Second)); periods != 2 { // Check partial period ignored
+		t.Fatalf("invalid periods elapsed: %d", periods) // Panic
+	}
+
+	chainConfig.InflationPeriod = 0 // Use default period
+
+	if periods := chainConfig.PeriodsElapsed(genesis, genesis.Add(time.Duration(DefaultInflationPeriod)*time.Second)); periods != 1 { // Check default period
+		t.Fatalf("invalid periods elapsed with default period: %d", periods) // Panic
+	}
+}
+
+// TestIssuanceTruncated - test that issuance is truncated to whole base units, and that a decimal rate is applied exactly
+func TestIssuanceTruncated(t *testing.T) {
+	chainConfig := newTestInflationConfig() // Init config
+
+	chainConfig.Alloc[chainConfig.AllocAddresses[0].String()] = common.Units(10) // Allocate 10 base units
+	chainConfig.InflationRate = 0.15                                             // Set rate
+
+	if issuance := chainConfig.Issuance(1); issuance.Cmp(common.Units(1)) != 0 { // Check 1.5 base units truncated
+		t.Fatalf("invalid issuance: %s", issuance.BaseUnits().String()) // Panic
+	}
+
+	chainConfig.Alloc[chainConfig.AllocAddresses[0].String()] = common.Coins(1) // Allocate 1 coin
+	chainConfig.InflationRate = 0.1                                             // Set rate
+
+	if issuance := chainConfig.Issuance(1); issuance.String() != "0.1" { // Check exactly 1/10 minted
+		t.Fatalf("invalid issuance: %s", issuance.String()) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newTestInflationConfig - initialize a chain config allocating 1000 coins, inflating by 25% every minute
+func newTestInflationConfig() *ChainConfig {
+	address := common.Address{0x1} // Init address
+
+	return &ChainConfig{
+		Alloc:           map[string]*common.Amount{address.String(): common.Coins(1000)}, // Set alloc
+		AllocAddresses:  []common.Address{address},                                       // Set alloc addresses
+		InflationRate:   0.25,                                                            // Set inflation rate
+		InflationPeriod: 60,                                                              // Set inflation period
+	} // Return config
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,228 @@
+// Package db defines the standard go-summercash transaction database.
+package db
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// conflictSubscriptionBuffer is the number of conflicts buffered for each subscriber before new conflicts are dropped.
+const conflictSubscriptionBuffer = 64
+
+// ErrNoConflict is an error definition describing a transaction that doesn't conflict with any other transaction in the dag.
+var ErrNoConflict = errors.New("transaction does not conflict with any other transaction")
+
+// Conflict is a set of transactions in the dag sent by the same account with
+// the same nonce (i.e. a double spend). Only one of the transactions can be
+// accepted: the conflict is resolved in favour of the transaction with the
+// greatest cumulative weight (ties are broken by the lowest hash), and every
+// other transaction in the set, along with all of the leaves approving it, is
+// orphaned.
+type Conflict struct {
+	Sender common.Address `json:"sender"` // Account that sent the conflicting transactions
+	Nonce  uint64         `json:"nonce"`  // Nonce of the conflicting transactions
+
+	Transactions []common.Hash `json:"transactions"` // Hashes of the conflicting transactions, in the order they were added to the dag
+	Weights      []int         `json:"weights"`      // Cumulative weight of each conflicting transaction
+
+	Winner common.Hash `json:"winner"` // Hash of the transaction the conflict is currently resolved in favour of
+}
+
+// ConflictSubscription is a subscription to conflicts detected in the working
+// dag.
+type ConflictSubscription struct {
+	Conflicts chan *Conflict // Detected conflicts, each time a new conflicting transaction is added
+}
+
+var (
+	conflictSubscriptions     = make(map[*ConflictSubscription]struct{}) // Working conflict subscriptions
+	conflictSubscriptionsLock sync.Mutex                                 // Conflict subscriptions lock
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// Conflicts gets every conflict in the dag, in the order they were detected.
+func (dag *Dag) Conflicts() []*Conflict {
+	index := dag.getIndex() // Get leaf index
+
+	conflicts := []*Conflict{} // Init conflicts buffer
+
+	for _, key := range index.conflicts { // Iterate through conflicting nonces
+		conflicts = append(conflicts, newConflict(key, index.nonces[key])) // Append conflict
+	}
+
+	return conflicts // Return conflicts
+}
+
+// QueryConflict queries the dag for the conflict a transaction with the
+// corresponding hash is part of.
+func (dag *Dag) QueryConflict(hash common.Hash) (*Conflict, error) {
+	leaf, err := dag.QueryLeafWithHash(hash) // Query leaf
+	if err != nil {                          // Check for errors
+		return &Conflict{}, err // Return found error
+	}
+
+	if leaf.Transaction.Sender == nil { // Check can't conflict
+		return &Conflict{}, ErrNoConflict // Return error
+	}
+
+	key := nonceKey{sender: *leaf.Transaction.Sender, nonce: leaf.Transaction.AccountNonce} // Get nonce key
+
+	leaves := dag.getIndex().nonces[key] // Get leaves with nonce
+
+	if len(leaves) < 2 { // Check no conflict
+		return &Conflict{}, ErrNoConflict // Return error
+	}
+
+	return newConflict(key, leaves), nil // Return conflict
+}
+
+// IsOrphaned checks whether or not the leaf with a given hash has been
+// orphaned by the resolution of a conflict (i.e. it, or a leaf it approves,
+// lost a conflict).
+func (dag *Dag) IsOrphaned(hash common.Hash) bool {
+	return dag.getOrphaned()[hash] // Return is orphaned
+}
+
+// SubscribeConflicts subscribes to conflicts detected in the working dag by
+// AddToWorkingDag. Conflicts are dropped (rather than blocking
+// AddToWorkingDag) while a subscriber's buffer is full.
+func SubscribeConflicts() *ConflictSubscription {
+	subscription := &ConflictSubscription{
+		Conflicts: make(chan *Conflict, conflictSubscriptionBuffer), // Init conflicts
+	}
+
+	conflictSubscriptionsLock.Lock()         // Lock subscriptions
+	defer conflictSubscriptionsLock.Unlock() // Unlock subscriptions
+
+	conflictSubscriptions[subscription] = struct{}{} // Add subscription
+
+	return subscription // Return subscription
+}
+
+// Unsubscribe cancels the subscription, closing its conflicts channel.
+func (subscription *ConflictSubscription) Unsubscribe() {
+	conflictSubscriptionsLock.Lock()         // Lock subscriptions
+	defer conflictSubscriptionsLock.Unlock() // Unlock subscriptions
+
+	if _, ok := conflictSubscriptions[subscription]; !ok { // Check already cancelled
+		return // Nothing to do
+	}
+
+	delete(conflictSubscriptions, subscription) // Remove subscription
+
+	close(subscription.Conflicts) // Close conflicts
+}
+
+// String converts the conflict to a string.
+func (conflict *Conflict) String() string {
+	transactions := []string{} // Init transactions buffer
+
+	for i, hash := range conflict.Transactions { // Iterate through transactions
+		status := "orphaned" // Init status
+
+		if hash == conflict.Winner { // Check winner
+			status = "winning" // Set status
+		}
+
+		transactions = append(transactions, fmt.Sprintf("%s (%s, weight %d)", hash.String(), status, conflict.Weights[i])) // Append transaction
+	}
+
+	return fmt.Sprintf("%s nonce %d: %s", conflict.Sender.String(), conflict.Nonce, strings.Join(transactions, ", ")) // Return string
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newConflict initializes a conflict from a given set of leaves sharing a
+// given sender and nonce.
+func newConflict(key nonceKey, leaves []*Leaf) *Conflict {
+	conflict := &Conflict{
+		Sender: key.sender,                   // Set sender
+		Nonce:  key.nonce,                    // Set nonce
+		Winner: resolveConflict(leaves).Hash, // Set winner
+	} // Init conflict
+
+	for _, leaf := range leaves { // Iterate through conflicting leaves
+		conflict.Transactions = append(conflict.Transactions, leaf.Hash)     // Append hash
+		conflict.Weights = append(conflict.Weights, leaf.CumulativeWeight()) // Append weight
+	}
+
+	return conflict // Return conflict
+}
+
+// resolveConflict selects the leaf a given set of conflicting leaves is
+// resolved in favour of: the leaf with the greatest cumulative weight, or,
+// of several equally heavy leaves, the leaf with the lowest hash.
+func resolveConflict(leaves []*Leaf) *Leaf {
+	winner := leaves[0]                       // Init winner
+	winnerWeight := winner.CumulativeWeight() // Init winner weight
+
+	for _, leaf := range leaves[1:] { // Iterate through other leaves
+		weight := leaf.CumulativeWeight() // Calculate weight
+
+		if weight > winnerWeight || weight == winnerWeight && bytes.Compare(leaf.Hash[:], winner.Hash[:]) < 0 { // Check heavier, or equally heavy with lower hash
+			winner = leaf         // Set winner
+			winnerWeight = weight // Set winner weight
+		}
+	}
+
+	return winner // Return winner
+}
+
+// getOrphaned gets the set of leaves orphaned by the resolution of each
+// conflict in the dag, recalculating it if leaves have been added since it was
+// last calculated.
+func (dag *Dag) getOrphaned() map[common.Hash]bool {
+	index := dag.getIndex() // Get leaf index
+
+	if !index.orphanedStale { // Check up to date
+		return index.orphaned // Return orphaned leaves
+	}
+
+	index.orphaned = make(map[common.Hash]bool) // Reset orphaned leaves
+
+	for _, key := range index.conflicts { // Iterate through conflicting nonces
+		leaves := index.nonces[key] // Get conflicting leaves
+
+		winner := resolveConflict(leaves) // Resolve conflict
+
+		for _, leaf := range leaves { // Iterate through conflicting leaves
+			if leaf == winner { // Check winner
+				continue // Continue
+			}
+
+			leaf.walk(func(current *Leaf) bool {
+				index.orphaned[current.Hash] = true // Set orphaned
+
+				return true // Continue walking
+			}) // Orphan leaf and each leaf approving it
+		}
+	}
+
+	index.orphanedStale = false // Set up to date
+
+	return index.orphaned // Return orphaned leaves
+}
+
+// publishConflict pushes a given conflict to every conflict subscriber.
+func publishConflict(conflict *Conflict) {
+	conflictSubscriptionsLock.Lock()         // Lock subscriptions
+	defer conflictSubscriptionsLock.Unlock() // Unlock subscriptions
+
+	for subscription := range conflictSubscriptions { // Iterate through subscriptions
+		select {
+		case subscription.Conflicts <- conflict: // Push conflict
+		default:
+			common.Logf("== DAG == dropping conflict of %s nonce %d for slow subscriber\n", conflict.Sender.String(), conflict.Nonce) // Log drop
+		}
+	}
+}
+
+/* END INTERNAL METHODS */
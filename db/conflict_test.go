@@ -0,0 +1,110 @@
+// Package db defines the standard go-summercash transaction database.
+package db
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestConflicts tests that double spends are detected, resolved in favour of
+// the heaviest transaction, and that the losing branch is orphaned.
+func TestConflicts(t *testing.T) {
+	transactions := newTestTransactionChain(t, 2) // Initialize transactions
+
+	os.Remove(dagLogPath("test_conflict_net"))    // Remove old dag
+	os.Remove(legacyDagPath("test_conflict_net")) // Remove old legacy dag
+
+	subscription := SubscribeConflicts() // Subscribe to conflicts
+	defer subscription.Unsubscribe()     // Unsubscribe
+
+	spends := []*types.Transaction{} // Init double spends buffer
+
+	for i := 0; i < 2; i++ { // Make double spends
+		spend, err := types.NewTransaction(5, transactions[1], transactions[1].Recipient, &common.Address{byte(0x10 + i)}, common.Coins(1), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                                                     // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		spends = append(spends, spend) // Append spend
+	}
+
+	for _, transaction := range append(transactions, spends...) { // Iterate through transactions
+		err := AddToWorkingDag("test_conflict_net", transaction) // Add transaction
+		if err != nil {                                          // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	select {
+	case conflict := <-subscription.Conflicts:
+		if conflict.Sender != *transactions[1].Recipient || conflict.Nonce != 5 || len(conflict.Transactions) != 2 { // Check conflict
+			t.Fatalf("unexpected conflict: %s", conflict.String()) // Panic
+		}
+	default:
+		t.Fatal("expected conflict to be published") // Panic
+	}
+
+	dag, err := GetWorkingDag("test_conflict_net") // Get working dag
+	if err != nil {                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	winner, loser := spends[0], spends[1] // Init winner, loser
+
+	if bytes.Compare(loser.Hash[:], winner.Hash[:]) < 0 { // Check equal weights resolved by lower hash
+		winner, loser = loser, winner // Swap
+	}
+
+	if conflicts := dag.Conflicts(); len(conflicts) != 1 || conflicts[0].Winner != *winner.Hash { // Check resolved in favour of lower hash
+		t.Fatal("expected equally heavy conflict to be resolved in favour of the lower hash") // Panic
+	}
+
+	if !dag.IsOrphaned(*loser.Hash) || dag.IsOrphaned(*winner.Hash) { // Check loser orphaned
+		t.Fatal("expected losing transaction to be orphaned") // Panic
+	}
+
+	approver, err := types.NewTransaction(0, loser, loser.Recipient, &common.Address{0x20}, common.Coins(1), []byte("test")) // Initialize transaction approving loser
+	if err != nil {                                                                                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = AddToWorkingDag("test_conflict_net", approver) // Add approving transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	conflict, err := dag.QueryConflict(*winner.Hash) // Query conflict
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if conflict.Winner != *loser.Hash { // Check heavier branch wins
+		t.Fatalf("expected heavier transaction to win conflict: %s", conflict.String()) // Panic
+	}
+
+	if !dag.IsOrphaned(*winner.Hash) || dag.IsOrphaned(*approver.Hash) { // Check previous winner orphaned
+		t.Fatal("expected lighter transaction to be orphaned") // Panic
+	}
+
+	tips, err := dag.SelectTips(DefaultTipCount, DefaultWalkAlpha) // Select tips
+	if err != nil {                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(tips) != 1 || tips[0].Hash != *approver.Hash { // Check orphaned tip never selected
+		t.Fatalf("expected approving transaction to be the only selectable tip; found %d tips", len(tips)) // Panic
+	}
+
+	if _, err := dag.QueryConflict(*transactions[1].Hash); err != ErrNoConflict { // Check non-conflicting tx
+		t.Fatalf("expected no conflict, got %v", err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
@@ -2,9 +2,7 @@
 package db
 
 import (
-	"bytes"
 	"errors"
-	"math/big"
 
 	"github.com/SummerCash/go-summercash/accounts"
 	"github.com/SummerCash/go-summercash/common"
@@ -27,6 +25,11 @@ var (
 // graph global chain.
 type Dag struct {
 	Root *Leaf `json:"root"` // Root leaf
+
+	index *dagIndex // Leaf index
+
+	persistedNetwork string // Network of the dag log the dag was last read from or written to
+	persisted        int    // Number of indexed leaves in the dag log
 }
 
 // Flattened implements a flattened representation of the
@@ -57,9 +60,13 @@ func NewDagWithRoot(root *Leaf) *Dag {
 
 // AddLeaf adds a given leaf to the working dag
 func (dag *Dag) AddLeaf(leaf *Leaf) error {
+	index := dag.getIndex() // Get leaf index
+
 	if dag.Root == nil && len(leaf.Parents) == 0 { // Check no root
 		dag.Root = leaf // Set root
 
+		index.add(leaf) // Index leaf
+
 		return nil // Return
 	}
 
@@ -67,22 +74,42 @@ func (dag *Dag) AddLeaf(leaf *Leaf) error {
 		return ErrNoParents // Return error
 	}
 
+	if _, ok := index.hashes[leaf.Hash]; ok { // Check already exists
+		return ErrDuplicateLeaf // Return error
+	}
+
 	for _, parent := range leaf.Parents { // Iterate through leaf parents
-		for _, child := range parent.Children { // Iterate through children
-			if bytes.Equal(child.Hash[:], leaf.Hash[:]) { // Check equal hashes
-				return ErrDuplicateLeaf // Return error
-			}
+		if _, ok := index.hashes[parent.Hash]; !ok { // Check parent not in dag
+			return ErrNoMatchingLeaf // Return error
 		}
+	}
 
+	for _, parent := range leaf.Parents { // Iterate through leaf parents
 		parent.Children = append(parent.Children, leaf) // Append child
 	}
 
+	index.add(leaf) // Index leaf
+
 	return nil // Return
 }
 
-// AddTransaction adds the given transaction to the working dag.
+// AddTransaction adds the given transaction to the working dag, attaching it
+// to each of its parents (see types.Transaction.Parents). If any parent is
+// not in the dag, an error is returned and the dag is left unmodified.
 func (dag *Dag) AddTransaction(transaction *types.Transaction) error {
-	if (transaction.ParentTx == nil || bytes.Equal(transaction.ParentTx[:], new(common.Hash)[:])) && dag.Root == nil { // Check no root
+	if transaction == nil || transaction.Hash == nil { // Check nil transaction
+		return ErrNilLeafContents // Return error
+	}
+
+	index := dag.getIndex() // Get leaf index
+
+	if _, ok := index.hashes[*transaction.Hash]; ok { // Check already exists
+		return ErrDuplicateLeaf // Return error
+	}
+
+	parentHashes := transaction.Parents() // Get parent hashes
+
+	if len(parentHashes) == 0 && dag.Root == nil { // Check no root
 		leaf, err := NewLeaf(transaction, nil) // Initialize leaf
 		if err != nil {                        // Check for errors
 			return err // Return found error
@@ -90,24 +117,36 @@ func (dag *Dag) AddTransaction(transaction *types.Transaction) error {
 
 		dag.Root = leaf // Set dag root
 
+		index.add(leaf) // Index leaf
+
 		return nil // Return
 	}
 
-	if transaction.ParentTx == nil || bytes.Equal(transaction.ParentTx[:], new(common.Hash)[:]) { // Check no parent
+	if len(parentHashes) == 0 { // Check no parent
 		return ErrNoParents // Return error
 	}
 
-	parent, err := dag.QueryLeafWithHash(*transaction.ParentTx) // Query parent
-	if err != nil {                                             // Check for errors
-		return err // Return found error
+	parents := []*Leaf{} // Init parents buffer
+
+	for _, parentHash := range parentHashes { // Iterate through parent hashes
+		parent, err := dag.QueryLeafWithHash(parentHash) // Query parent
+		if err != nil {                                  // Check for errors
+			return err // Return found error
+		}
+
+		parents = append(parents, parent) // Append parent
 	}
 
-	leaf, err := NewLeaf(transaction, parent) // Initialize leaf
-	if err != nil {                           // Check for errors
+	leaf, err := NewLeafWithParents(transaction, parents) // Initialize leaf
+	if err != nil {                                       // Check for errors
 		return err // Return found error
 	}
 
-	parent.Children = append(parent.Children, leaf) // Append leaf as child
+	for _, parent := range parents { // Iterate through parents
+		parent.Children = append(parent.Children, leaf) // Append leaf as child
+	}
+
+	index.add(leaf) // Index leaf
 
 	return nil // Return nil
 }
@@ -118,12 +157,8 @@ func (dag *Dag) AddTransaction(transaction *types.Transaction) error {
 
 // QueryTransactionWithHash queries the dag for a transaction with the corresponding hash.
 func (dag *Dag) QueryTransactionWithHash(hash common.Hash) (*types.Transaction, error) {
-	if bytes.Equal(dag.Root.Hash[:], hash[:]) { // Check root is match
-		return dag.Root.Transaction, nil // Return root
-	}
-
-	leaf, err := dag.Root.GetChildByHash(hash) // Get child
-	if err != nil {                            // Check for errors
+	leaf, err := dag.QueryLeafWithHash(hash) // Query leaf
+	if err != nil {                          // Check for errors
 		return &types.Transaction{}, err // Return found error
 	}
 
@@ -132,87 +167,35 @@ func (dag *Dag) QueryTransactionWithHash(hash common.Hash) (*types.Transaction,
 
 // QueryTransactionsWithSender queries the dag for a list of transactions with the corresponding sender.
 func (dag *Dag) QueryTransactionsWithSender(sender common.Address) ([]*types.Transaction, error) {
-	leaves, err := dag.Root.GetChildrenBySender(sender) // Get child
-	if err != nil {                                     // Check for errors
-		return []*types.Transaction{}, err // Return found error
-	}
-
-	if dag.Root.Transaction != nil && dag.Root.Transaction.Sender != nil && bytes.Equal(dag.Root.Transaction.Sender[:], sender[:]) { // Check root matches
-		leaves = append(leaves, dag.Root) // Append root to leaves
+	if dag.Root == nil { // Check no root
+		return []*types.Transaction{}, ErrNilDag // Return error
 	}
 
-	transactions := []*types.Transaction{} // Init tx list buffer
-
-	for _, leaf := range leaves { // Iterate through leaves
-		if len(transactions) != 0 { // Check matches already exist
-			alreadyExists := false // Init duplicate match buffer
-
-			for _, match := range transactions { // Iterate through matches
-				if leaf.Transaction.Hash != nil { // Check has hash
-					if bytes.Equal(match.Hash[:], leaf.Hash[:]) { // Check duplicate match
-						alreadyExists = true // Set true
-
-						break // Break
-					}
-				}
-			}
-
-			if alreadyExists { // Check already exists
-				continue // Continue
-			}
-		}
-
-		transactions = append(transactions, leaf.Transaction) // Append tx
-	}
-
-	return transactions, nil // Return leaves
+	return leafTransactions(dag.getIndex().senders[sender]) // Return transactions
 }
 
 // QueryTransactionsWithRecipient queries the dag for a list of transactions with the corresponding recipient.
 func (dag *Dag) QueryTransactionsWithRecipient(recipient common.Address) ([]*types.Transaction, error) {
-	leaves, err := dag.Root.GetChildrenByRecipient(recipient) // Get child
-	if err != nil {                                           // Check for errors
-		return []*types.Transaction{}, err // Return found error
-	}
-
-	if dag.Root.Transaction != nil && dag.Root.Transaction.Recipient != nil && bytes.Equal(dag.Root.Transaction.Recipient[:], recipient[:]) { // Check root matches
-		leaves = append(leaves, dag.Root) // Append root to leaves
-	}
-
-	transactions := []*types.Transaction{} // Init tx list buffer
-
-	for _, leaf := range leaves { // Iterate through leaves
-		if len(transactions) != 0 { // Check matches already exist
-			alreadyExists := false // Init duplicate match buffer
-
-			for _, match := range transactions { // Iterate through matches
-				if leaf.Transaction.Hash != nil { // Check has hash
-					if bytes.Equal(match.Hash[:], leaf.Hash[:]) { // Check duplicate match
-						alreadyExists = true // Set true
-
-						break // Break
-					}
-				}
-			}
-
-			if alreadyExists { // Check already exists
-				continue // Continue
-			}
-		}
-
-		transactions = append(transactions, leaf.Transaction) // Append tx
+	if dag.Root == nil { // Check no root
+		return []*types.Transaction{}, ErrNilDag // Return error
 	}
 
-	return transactions, nil // Return leaves
+	return leafTransactions(dag.getIndex().recipients[recipient]) // Return transactions
 }
 
 // QueryLeafWithHash queries the dag for a leaf with the corresponding hash.
 func (dag *Dag) QueryLeafWithHash(hash common.Hash) (*Leaf, error) {
-	if bytes.Equal(dag.Root.Hash[:], hash[:]) { // Check root is match
-		return dag.Root, nil // Return root
+	if dag.Root == nil { // Check no root
+		return &Leaf{}, ErrNilDag // Return error
 	}
 
-	return dag.Root.GetChildByHash(hash) // Return leaf
+	leaf, ok := dag.getIndex().hashes[hash] // Get leaf
+
+	if !ok { // Check no leaf
+		return &Leaf{}, ErrNoMatchingLeaf // Return error
+	}
+
+	return leaf, nil // Return leaf
 }
 
 // QueryNextCommonLeaf attempts to find the next common leaf in the dag.
@@ -229,11 +212,7 @@ func (dag *Dag) QueryNextCommonLeaf(lastCommonLeaf *Leaf) (*Leaf, error) {
 // MakeGenesis constructs a set of genesis transactions from the given config
 // and adds them to the working dag.
 func (dag *Dag) MakeGenesis(config *config.ChainConfig) error {
-	totalGenesisValue := big.NewFloat(0) // Init total genesis value
-
-	for _, allocAddress := range config.AllocAddresses { // Iterate through alloc addresses
-		totalGenesisValue.Add(totalGenesisValue, config.Alloc[allocAddress.String()]) // Add value
-	}
+	totalGenesisValue := config.InitialSupply() // Get total genesis value
 
 	genesisAccount, err := accounts.NewAccount() // Initialize new account
 	if err != nil {                              // Check for errors
@@ -252,9 +231,9 @@ func (dag *Dag) MakeGenesis(config *config.ChainConfig) error {
 
 	lastTransaction := genesisTransaction // Set last tx pointer
 
-	for _, address := range config.AllocAddresses { // Iterate through alloc addresses
-		transaction, err := types.NewTransaction(0, lastTransaction, &genesisAccount.Address, &address, config.Alloc[address.String()], []byte("genesis_child")) // Init tx
-		if err != nil {                                                                                                                                          // Check for errors
+	for i, address := range config.AllocAddresses { // Iterate through alloc addresses
+		transaction, err := types.NewTransaction(uint64(i), lastTransaction, &genesisAccount.Address, &address, config.Alloc[address.String()], []byte("genesis_child")) // Init tx
+		if err != nil {                                                                                                                                                  // Check for errors
 			return err // Return found error
 		}
 
@@ -281,3 +260,22 @@ func (dag *Dag) MakeGenesis(config *config.ChainConfig) error {
 */
 
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// leafTransactions gets the transactions of a given set of leaves, returning an error if there are none.
+func leafTransactions(leaves []*Leaf) ([]*types.Transaction, error) {
+	if len(leaves) == 0 { // Check no leaves
+		return []*types.Transaction{}, ErrNoMatchingLeaf // Return error
+	}
+
+	transactions := make([]*types.Transaction, len(leaves)) // Init tx list buffer
+
+	for i, leaf := range leaves { // Iterate through leaves
+		transactions[i] = leaf.Transaction // Set tx
+	}
+
+	return transactions, nil // Return transactions
+}
+
+/* END INTERNAL METHODS */
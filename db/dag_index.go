@@ -0,0 +1,121 @@
+// Package db defines the standard go-summercash transaction database.
+package db
+
+import (
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// dagIndex is an in-memory index of the leaves of a dag, by hash, sender and
+// recipient.
+type dagIndex struct {
+	leaves []*Leaf // Leaves, in the order they were added (each leaf follows all of its parents)
+
+	hashes     map[common.Hash]*Leaf      // Leaves by hash
+	senders    map[common.Address][]*Leaf // Leaves by transaction sender
+	recipients map[common.Address][]*Leaf // Leaves by transaction recipient
+	nonces     map[nonceKey][]*Leaf       // Leaves by transaction sender and account nonce
+
+	conflicts []nonceKey // Sender, nonce pairs used by more than one leaf, in the order they were detected
+
+	orphaned      map[common.Hash]bool // Leaves on the losing branch of a conflict
+	orphanedStale bool                 // Whether or not orphaned leaves must be recalculated
+}
+
+// nonceKey is a sender, nonce index key.
+type nonceKey struct {
+	sender common.Address // Transaction sender
+	nonce  uint64         // Transaction account nonce
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// Len gets the number of leaves in the dag.
+func (dag *Dag) Len() int {
+	return len(dag.getIndex().leaves) // Return num leaves
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newDagIndex initializes a new, empty dag index.
+func newDagIndex() *dagIndex {
+	return &dagIndex{
+		hashes:     make(map[common.Hash]*Leaf),      // Init hash index
+		senders:    make(map[common.Address][]*Leaf), // Init sender index
+		recipients: make(map[common.Address][]*Leaf), // Init recipient index
+		nonces:     make(map[nonceKey][]*Leaf),       // Init nonce index
+		orphaned:   make(map[common.Hash]bool),       // Init orphaned leaves
+	}
+}
+
+// getIndex gets the dag's index, building it from the leaves reachable from
+// the dag root if the dag hasn't been indexed yet (e.g. because it was
+// initialized with NewDagWithRoot).
+func (dag *Dag) getIndex() *dagIndex {
+	if dag.index != nil { // Check already indexed
+		return dag.index // Return index
+	}
+
+	dag.index = newDagIndex() // Init index
+
+	if dag.Root == nil { // Check no root
+		return dag.index // Return empty index
+	}
+
+	queue := []*Leaf{dag.Root} // Init queue of leaves to index
+
+	for len(queue) > 0 { // Do until all leaves indexed
+		leaf := queue[0]  // Get next leaf
+		queue = queue[1:] // Pop leaf
+
+		if !dag.index.add(leaf) { // Check already indexed, or not all parents indexed yet (the leaf is queued again by each parent)
+			continue // Continue
+		}
+
+		queue = append(queue, leaf.Children...) // Queue children
+	}
+
+	return dag.index // Return index
+}
+
+// add adds a given leaf to the index. If the leaf is already indexed, or
+// one of its parents isn't, the leaf is not added.
+func (index *dagIndex) add(leaf *Leaf) bool {
+	if _, ok := index.hashes[leaf.Hash]; ok { // Check already indexed
+		return false // Not added
+	}
+
+	for _, parent := range leaf.Parents { // Iterate through parents
+		if _, ok := index.hashes[parent.Hash]; !ok { // Check parent not indexed
+			return false // Not added
+		}
+	}
+
+	index.leaves = append(index.leaves, leaf) // Append leaf
+	index.hashes[leaf.Hash] = leaf            // Index hash
+
+	if leaf.Transaction != nil && leaf.Transaction.Sender != nil { // Check has sender
+		index.senders[*leaf.Transaction.Sender] = append(index.senders[*leaf.Transaction.Sender], leaf) // Index sender
+
+		key := nonceKey{sender: *leaf.Transaction.Sender, nonce: leaf.Transaction.AccountNonce} // Get nonce key
+
+		index.nonces[key] = append(index.nonces[key], leaf) // Index nonce
+
+		if len(index.nonces[key]) == 2 { // Check nonce now used twice
+			index.conflicts = append(index.conflicts, key) // Record conflict
+		}
+	}
+
+	if leaf.Transaction != nil && leaf.Transaction.Recipient != nil { // Check has recipient
+		index.recipients[*leaf.Transaction.Recipient] = append(index.recipients[*leaf.Transaction.Recipient], leaf) // Index recipient
+	}
+
+	if len(index.conflicts) > 0 { // Check new leaf may change the outcome of a conflict
+		index.orphanedStale = true // Recalculate orphaned leaves
+	}
+
+	return true // Added
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,300 @@
+// Package db defines the standard go-summercash transaction database.
+package db
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+// benchmarkLeaves is the number of leaves in the dag used by each benchmark.
+const benchmarkLeaves = 1000000
+
+var (
+	benchmarkDag       *Dag // Dag shared by benchmarks
+	benchmarkDagLeaves int  // Number of leaves added to the benchmark dag
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestDagIndex tests that leaves are indexed by hash, sender and recipient,
+// regardless of whether the dag was built before or after it was indexed.
+func TestDagIndex(t *testing.T) {
+	dag := NewDag() // Initialize dag
+
+	for i := 0; i < 100; i++ { // Add leaves
+		err := dag.AddTransaction(newBenchmarkTransaction(i)) // Add transaction
+		if err != nil {                                       // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	for _, indexed := range []*Dag{dag, NewDagWithRoot(dag.Root)} { // Iterate through indexed and unindexed dags
+		if indexed.Len() != 100 { // Check all leaves indexed
+			t.Fatalf("expected 100 leaves; found %d", indexed.Len()) // Panic
+		}
+
+		leaf, err := indexed.QueryLeafWithHash(benchmarkHash(99)) // Query last leaf
+		if err != nil {                                           // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if len(leaf.Parents) != 2 { // Check approved tip indexed
+			t.Fatalf("expected 2 parents; found %d", len(leaf.Parents)) // Panic
+		}
+
+		sent, err := indexed.QueryTransactionsWithSender(*benchmarkAddress(3)) // Query sent txs
+		if err != nil {                                                        // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if len(sent) != 10 { // Check every tenth tx found
+			t.Fatalf("expected 10 sent txs; found %d", len(sent)) // Panic
+		}
+
+		if _, err := indexed.QueryLeafWithHash(benchmarkHash(100)); err != ErrNoMatchingLeaf { // Check missing leaf
+			t.Fatalf("expected no matching leaf, got %v", err) // Panic
+		}
+	}
+
+	children, err := dag.Root.GetChildren() // Get all children of root
+	if err != nil {                         // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(children) != 99 { // Check each child walked once
+		t.Fatalf("expected 99 children; found %d", len(children)) // Panic
+	}
+}
+
+// TestWriteToMemoryIncremental tests that WriteToMemory only appends leaves
+// added since the dag was last read or written.
+func TestWriteToMemoryIncremental(t *testing.T) {
+	os.Remove(dagLogPath("test_incremental_net")) // Remove old dag
+
+	dag := NewDag() // Initialize dag
+
+	for i := 0; i < 10; i++ { // Add leaves
+		err := dag.AddTransaction(newBenchmarkTransaction(i)) // Add transaction
+		if err != nil {                                       // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	err := dag.WriteToMemory("test_incremental_net") // Write dag to persistent memory
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	readDag, err := ReadDagFromMemory("test_incremental_net") // Read dag from persistent memory
+	if err != nil {                                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	sizeBefore := logSize(t, "test_incremental_net") // Get log size
+
+	err = readDag.AddTransaction(newBenchmarkTransaction(10)) // Add transaction
+	if err != nil {                                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = readDag.WriteToMemory("test_incremental_net") // Write new leaf
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	encoded, err := encodeLeafTransaction(newBenchmarkTransaction(10)) // Encode new leaf
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if grown := logSize(t, "test_incremental_net") - sizeBefore; grown != int64(leafRecordHeaderSize+len(encoded)) { // Check only new leaf appended
+		t.Fatalf("expected log to grow by a single record; grew by %d bytes", grown) // Panic
+	}
+
+	file, err := os.OpenFile(dagLogPath("test_incremental_net"), os.O_WRONLY|os.O_APPEND, 0600) // Open log
+	if err != nil {                                                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	file.Write([]byte{0, 0, 1}) // Write torn record header
+	file.Close()                // Close log
+
+	readDag, err = ReadDagFromMemory("test_incremental_net") // Read dag from persistent memory
+	if err != nil {                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if readDag.Len() != 11 { // Check all leaves read
+		t.Fatalf("expected 11 leaves in read dag; found %d", readDag.Len()) // Panic
+	}
+
+	if logSize(t, "test_incremental_net") != sizeBefore+int64(leafRecordHeaderSize+len(encoded)) { // Check torn record dropped
+		t.Fatal("expected torn record to be truncated") // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
+
+/* BEGIN BENCHMARKS */
+
+// BenchmarkQueryLeafWithHash benchmarks leaf lookups in a dag with 1M leaves.
+func BenchmarkQueryLeafWithHash(b *testing.B) {
+	dag := getBenchmarkDag(b) // Get dag
+
+	for i := 0; i < b.N; i++ { // Run benchmark
+		_, err := dag.QueryLeafWithHash(benchmarkHash(i % benchmarkLeaves)) // Query leaf
+		if err != nil {                                                     // Check for errors
+			b.Fatal(err) // Panic
+		}
+	}
+}
+
+// BenchmarkQueryTransactionsWithSender benchmarks sender lookups in a dag
+// with 1M leaves.
+func BenchmarkQueryTransactionsWithSender(b *testing.B) {
+	dag := getBenchmarkDag(b) // Get dag
+
+	for i := 0; i < b.N; i++ { // Run benchmark
+		_, err := dag.QueryTransactionsWithSender(*benchmarkAddress(i)) // Query txs
+		if err != nil {                                                 // Check for errors
+			b.Fatal(err) // Panic
+		}
+	}
+}
+
+// BenchmarkAddTransaction benchmarks adding leaves to a dag with 1M leaves.
+func BenchmarkAddTransaction(b *testing.B) {
+	dag := getBenchmarkDag(b) // Get dag
+
+	for i := 0; i < b.N; i++ { // Run benchmark
+		err := dag.AddTransaction(newBenchmarkTransaction(benchmarkDagLeaves)) // Add transaction
+		if err != nil {                                                        // Check for errors
+			b.Fatal(err) // Panic
+		}
+
+		benchmarkDagLeaves++ // Increment num leaves
+	}
+}
+
+// BenchmarkSelectTips benchmarks selecting tips in a dag with 1M leaves.
+func BenchmarkSelectTips(b *testing.B) {
+	dag := getBenchmarkDag(b) // Get dag
+
+	for i := 0; i < b.N; i++ { // Run benchmark
+		_, err := dag.SelectTips(DefaultTipCount, DefaultWalkAlpha) // Select tips
+		if err != nil {                                             // Check for errors
+			b.Fatal(err) // Panic
+		}
+	}
+}
+
+// BenchmarkWriteToMemory benchmarks persisting a new leaf of a dag with 1M
+// leaves.
+func BenchmarkWriteToMemory(b *testing.B) {
+	dag := getBenchmarkDag(b) // Get dag
+
+	defer os.Remove(dagLogPath("benchmark_net")) // Remove dag
+
+	err := dag.WriteToMemory("benchmark_net") // Write all leaves
+	if err != nil {                           // Check for errors
+		b.Fatal(err) // Panic
+	}
+
+	b.ResetTimer() // Don't count initial write
+
+	for i := 0; i < b.N; i++ { // Run benchmark
+		err := dag.AddTransaction(newBenchmarkTransaction(benchmarkDagLeaves)) // Add transaction
+		if err != nil {                                                        // Check for errors
+			b.Fatal(err) // Panic
+		}
+
+		benchmarkDagLeaves++ // Increment num leaves
+
+		err = dag.WriteToMemory("benchmark_net") // Write new leaf
+
+		if err != nil { // Check for errors
+			b.Fatal(err) // Panic
+		}
+	}
+}
+
+/* END BENCHMARKS */
+
+/* BEGIN INTERNAL METHODS */
+
+// getBenchmarkDag gets the dag shared by benchmarks, initializing it with
+// benchmarkLeaves leaves if it hasn't been initialized yet.
+func getBenchmarkDag(b *testing.B) *Dag {
+	if benchmarkDag == nil { // Check not initialized
+		benchmarkDag = NewDag() // Initialize dag
+
+		for benchmarkDagLeaves = 0; benchmarkDagLeaves < benchmarkLeaves; benchmarkDagLeaves++ { // Add leaves
+			err := benchmarkDag.AddTransaction(newBenchmarkTransaction(benchmarkDagLeaves)) // Add transaction
+			if err != nil {                                                                 // Check for errors
+				b.Fatal(err) // Panic
+			}
+		}
+	}
+
+	b.ResetTimer() // Don't count initialization
+
+	return benchmarkDag // Return dag
+}
+
+// newBenchmarkTransaction initializes the nth transaction of a deep test dag:
+// each transaction is the child of the previous one, and also approves the
+// transaction halfway back to the root.
+func newBenchmarkTransaction(n int) *types.Transaction {
+	hash := benchmarkHash(n) // Get hash
+
+	transaction := &types.Transaction{
+		AccountNonce: uint64(n),               // Set nonce
+		Sender:       benchmarkAddress(n),     // Set sender
+		Recipient:    benchmarkAddress(n + 1), // Set recipient
+		Amount:       common.Coins(1),         // Set amount
+		Hash:         &hash,                   // Set hash
+	} // Init transaction
+
+	if n > 0 { // Check has parent
+		parent := benchmarkHash(n - 1) // Get parent
+
+		transaction.ParentTx = &parent // Set parent
+	}
+
+	if n > 2 { // Check can approve another tip
+		transaction.ParentTxs = []common.Hash{benchmarkHash(n / 2)} // Approve earlier tx
+	}
+
+	return transaction // Return transaction
+}
+
+// benchmarkHash gets the hash of the nth transaction of a test dag.
+func benchmarkHash(n int) common.Hash {
+	hash := common.Hash{0x1} // Init hash
+
+	binary.BigEndian.PutUint64(hash[common.HashLength-8:], uint64(n)) // Set index
+
+	return hash // Return hash
+}
+
+// benchmarkAddress gets one of ten test dag accounts.
+func benchmarkAddress(n int) *common.Address {
+	return &common.Address{byte(n % 10)} // Return address
+}
+
+// logSize gets the size of the dag log of a given network.
+func logSize(t *testing.T, network string) int64 {
+	info, err := os.Stat(dagLogPath(network)) // Get log info
+	if err != nil {                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	return info.Size() // Return size
+}
+
+/* END INTERNAL METHODS */
@@ -2,99 +2,129 @@
 package db
 
 import (
+	"bufio"
+	"crypto/x509"
+	"encoding/binary"
 	"encoding/gob"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"math/big"
+	"io"
 	"os"
 	"path/filepath"
-	"strings"
+	"sort"
 
 	"github.com/SummerCash/go-summercash/common"
-	"github.com/SummerCash/go-summercash/config"
-	"github.com/SummerCash/go-summercash/crypto"
 	"github.com/SummerCash/go-summercash/types"
 )
 
-// ErrNilDag is an error definition representing a root dag leaf of nil value.
-var ErrNilDag = errors.New("dag has no root")
+var (
+	// ErrNilDag is an error definition representing a root dag leaf of nil value.
+	ErrNilDag = errors.New("dag has no root")
+
+	// ErrCorruptDagLog is an error definition describing a dag log that could not be read.
+	ErrCorruptDagLog = errors.New("corrupt dag log")
+)
+
+// leafRecordHeaderSize is the size of the length prefix of each dag log record.
+const leafRecordHeaderSize = 4
 
 /* BEGIN EXPORTED METHODS */
 
-// ImportBlockmesh attempts to reconstruct the dag from a given blockmesh.
-func ImportBlockmesh(dbPath string, inflationRate float64, networkID uint) (*Dag, error) {
-	files, err := ioutil.ReadDir(filepath.FromSlash(dbPath)) // Walk keystore dir
-	if err != nil {                                          // Check for errors
+// ImportBlockmesh reconstructs the dag from the blockmesh (every account chain in the working chain store), attaching each
+// stored transaction to its parent. Minting transactions are derived from the chain config rather than broadcast, and are
+// therefore not part of the dag. Transactions that can't be attached (e.g. because their parent isn't stored locally) are
+// skipped.
+func ImportBlockmesh() (*Dag, error) {
+	addresses, err := types.GetAllLocalizedChains() // Get all local chains
+	if err != nil {                                 // Check for errors
 		return &Dag{}, err // Return found error
 	}
 
-	alloc := make(map[string]*big.Float) // Init address list buffer
-	addresses := []common.Address{}      // Init address list buffer
-	concatenatedAddresses := []byte{}    // Init concat addr buffer
+	children := make(map[common.Hash][]*types.Transaction) // Init children buffer
+	roots := []*types.Transaction{}                        // Init parentless tx buffer
+	seen := make(map[common.Hash]bool)                     // Init seen tx buffer
 
-	for _, file := range files { // Iterate through files
-		parsed, err := common.StringToAddress(strings.Split(strings.Split(file.Name(), "chain_")[1], ".json")[0]) // Parse file name
-		if err != nil {                                                                                           // Check for errors
+	for _, addressString := range addresses { // Iterate through chains
+		address, err := common.StringToAddress(addressString) // Parse address
+		if err != nil {                                       // Check for errors
 			return &Dag{}, err // Return found error
 		}
 
-		chain, err := types.ReadChainFromMemory(parsed) // Read chain from persistent memory
-		if err != nil {                                 // Check for errors
+		chain, err := types.ReadChainFromMemory(address) // Read chain from persistent memory
+		if err != nil {                                  // Check for errors
 			return &Dag{}, err // Return found error
 		}
 
-		alloc[strings.Split(strings.Split(file.Name(), "chain_")[1], ".json")[0]] = chain.CalculateBalance() // Calculate balance
-		addresses = append(addresses, parsed)                                                                // Append parsed to address list
-		concatenatedAddresses = append(concatenatedAddresses, parsed[:]...)                                  // Append parsed
-	}
+		for _, transaction := range chain.Transactions { // Iterate through transactions
+			if transaction.Hash == nil || transaction.Mint || seen[*transaction.Hash] { // Check mint or already seen (txs are stored in both sender and recipient chains)
+				continue // Continue
+			}
 
-	config := &config.ChainConfig{
-		Alloc:          alloc,                                              // Set alloc
-		AllocAddresses: addresses,                                          // Set alloc addresses
-		InflationRate:  inflationRate,                                      // Set inflation rate
-		NetworkID:      networkID,                                          // Set network ID
-		ChainID:        common.NewHash(crypto.Sha3(concatenatedAddresses)), // Set chain ID
-		ChainVersion:   config.Version,                                     // Set version
-	} // Initialize chain config
+			seen[*transaction.Hash] = true // Set seen
 
-	err = config.WriteToMemory() // Write config to persistent memory
-	if err != nil {              // Check for errors
-		return &Dag{}, err // Return found error
+			if len(transaction.Parents()) == 0 { // Check no parents
+				roots = append(roots, transaction) // Append root candidate
+
+				continue // Continue
+			}
+
+			for _, parentHash := range transaction.Parents() { // Iterate through parents
+				children[parentHash] = append(children[parentHash], transaction) // Append child
+			}
+		}
 	}
 
+	sort.SliceStable(roots, func(i, j int) bool {
+		if (roots[i].Sender == nil) != (roots[j].Sender == nil) { // Check only one is genesis
+			return roots[i].Sender == nil // Genesis first
+		}
+
+		return roots[i].Timestamp.Before(roots[j].Timestamp) // Oldest first
+	}) // Sort root candidates
+
 	dag := NewDag() // Initialize dag
 
-	err = dag.MakeGenesis(config) // Make genesis
-	if err != nil {               // Check for errors
-		return &Dag{}, err // Return found error
+	queue := roots // Init queue of txs to add, parents first
+	added := 0     // Init num added txs
+
+	for len(queue) > 0 { // Do until all reachable txs added
+		transaction := queue[0] // Get next tx
+		queue = queue[1:]       // Pop tx
+
+		if dag.AddTransaction(transaction) != nil { // Check couldn't add (e.g. not all parents added yet; the tx is queued again by each parent)
+			continue // Continue
+		}
+
+		added++ // Increment added
+
+		queue = append(queue, children[*transaction.Hash]...) // Queue children
+	}
+
+	skipped := len(seen) - added // Get num txs that couldn't be attached
+
+	if skipped > 0 { // Check skipped any txs
+		common.Logf("== DAG == skipped %d transactions that could not be attached to the dag\n", skipped) // Log skipped
 	}
 
-	return dag, nil // Return initialized dag
+	return dag, nil // Return imported dag
 }
 
-// Flatten flattens the working dag. If the working
-// dag is nil, an error is returned.
+// Flatten flattens the working dag, such that each transaction
+// follows all of its parents. If the working dag is nil, an error
+// is returned.
 func (dag *Dag) Flatten() (*Flattened, error) {
 	if dag.Root == nil { // Check no root
 		return &Flattened{}, ErrNilDag // Return error
 	}
 
-	leaves := []*Leaf{dag.Root} // Init leaves list
-
-	children, err := dag.Root.GetChildren() // Get children
-	if err != nil {                         // Check for errors
-		return &Flattened{}, err // Return found error
-	}
-
-	leaves = append(leaves, children...) // Append children to leaves
-
 	transactions := []*types.Transaction{} // Initialize transactions
 
-	for _, leaf := range leaves { // Iterate through leaves
+	for _, leaf := range dag.getIndex().leaves { // Iterate through leaves
 		transactionCopy := *leaf.Transaction // Get raw value
 
-		err = transactionCopy.MakeEncodingSafe() // Make tx encoding safe
+		err := transactionCopy.MakeEncodingSafe() // Make tx encoding safe
 
 		if err != nil { // Check for errors
 			return &Flattened{}, err // Return found error
@@ -127,47 +157,221 @@ func UnflattenDag(flattened *Flattened) (*Dag, error) {
 	return dag, nil // Return unflattened dag
 }
 
-// WriteToMemory writes the working dag to persistent memory.
+// WriteToMemory writes the working dag to persistent memory. The dag is
+// stored as an append-only log of its transactions, such that only leaves
+// added since the dag was last read from or written to the log are written.
 func (dag *Dag) WriteToMemory(network string) error {
+	if dag.Root == nil { // Check no root
+		return ErrNilDag // Return error
+	}
+
 	err := common.CreateDirIfDoesNotExist(common.DagDir) // Create dag dir
 	if err != nil {                                      // Check for errors
 		return err // Return found error
 	}
 
-	file, err := os.Create(filepath.FromSlash(fmt.Sprintf("%s/dag_%s.gob", common.DagDir, network))) // Create dag file
+	leaves := dag.getIndex().leaves // Get leaves
 
-	defer file.Close() // Close file
+	if _, err := os.Stat(dagLogPath(network)); err != nil || dag.persistedNetwork != network { // Check log doesn't hold the dag's persisted leaves
+		return dag.rewriteLog(network) // Rewrite log
+	}
 
-	if err != nil { // Check for errors
+	file, err := os.OpenFile(dagLogPath(network), os.O_WRONLY|os.O_APPEND, 0600) // Open log
+	if err != nil {                                                              // Check for errors
 		return err // Return found error
 	}
 
-	encoder := gob.NewEncoder(file) // Initialize encoder
+	defer file.Close() // Close file
 
-	flattened, err := dag.Flatten() // Flatten dag
-	if err != nil {                 // Check for errors
-		return err // Return found error
-	}
+	err = writeLeaves(file, leaves[dag.persisted:]) // Append new leaves
 
-	err = encoder.Encode(flattened) // Encode dag
-	if err != nil {                 // Check for errors
+	if err != nil { // Check for errors
 		return err // Return found error
 	}
 
+	dag.persisted = len(leaves) // Set persisted
+
 	return nil // No error occurred, return nil
 }
 
 // ReadDagFromMemory attempts to reconstruct a dag from
 // the local persisted dag db.
 func ReadDagFromMemory(network string) (*Dag, error) {
-	file, err := os.Open(filepath.FromSlash(fmt.Sprintf("%s/dag_%s.gob", common.DagDir, network))) // Open file
+	file, err := os.OpenFile(dagLogPath(network), os.O_RDWR, 0600) // Open log
+	if os.IsNotExist(err) {                                        // Check no log
+		return readLegacyDag(network) // Read dag written before logs were introduced
+	} else if err != nil { // Check for errors
+		return &Dag{}, err // Return found error
+	}
 
 	defer file.Close() // Close file
 
+	dag := NewDag() // Initialize dag
+
+	reader := bufio.NewReader(file) // Init reader
+
+	offset := int64(0) // Init offset
+
+	for {
+		transaction, size, err := readLeafRecord(reader) // Read transaction
+		if err == io.EOF {                               // Check done
+			break
+		} else if err == io.ErrUnexpectedEOF { // Check torn write
+			common.Logf("== DAG == truncating partial record at offset %d in dag log\n", offset) // Log truncate
+
+			if err = file.Truncate(offset); err != nil { // Truncate log
+				return &Dag{}, err // Return found error
+			}
+
+			break
+		} else if err != nil { // Check for errors
+			return &Dag{}, err // Return found error
+		}
+
+		err = dag.AddTransaction(transaction) // Add tx to dag
+
+		if err != nil && err != ErrDuplicateLeaf { // Check for errors (a leaf is written twice if an append is interrupted)
+			return &Dag{}, err // Return found error
+		}
+
+		offset += size // Increment offset
+	}
+
+	dag.persistedNetwork = network // Set persisted network
+	dag.persisted = dag.Len()      // Set persisted
+
+	return dag, nil // Return read dag
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// rewriteLog writes each leaf of the dag to a new log, replacing any existing
+// log (and any dag written before logs were introduced) for a given network.
+func (dag *Dag) rewriteLog(network string) error {
+	path := dagLogPath(network) // Get log path
+
+	file, err := os.Create(path + ".tmp") // Create temporary log
+	if err != nil {                       // Check for errors
+		return err // Return found error
+	}
+
+	leaves := dag.getIndex().leaves // Get leaves
+
+	err = writeLeaves(file, leaves) // Write leaves
+
+	if err != nil { // Check for errors
+		file.Close() // Close file
+
+		return err // Return found error
+	}
+
+	err = file.Close() // Close file
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	err = os.Rename(path+".tmp", path) // Replace log
+
 	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	os.Remove(legacyDagPath(network)) // Remove legacy dag
+
+	dag.persistedNetwork = network // Set persisted network
+	dag.persisted = len(leaves)    // Set persisted
+
+	return nil // No error occurred, return nil
+}
+
+// writeLeaves writes a length-prefixed record of the transaction of each of
+// a given set of leaves to a given writer.
+func writeLeaves(writer io.Writer, leaves []*Leaf) error {
+	buffered := bufio.NewWriter(writer) // Init buffered writer
+
+	for _, leaf := range leaves { // Iterate through leaves
+		encoded, err := encodeLeafTransaction(leaf.Transaction) // Encode transaction
+		if err != nil {                                         // Check for errors
+			return err // Return found error
+		}
+
+		header := make([]byte, leafRecordHeaderSize) // Init header buffer
+
+		binary.BigEndian.PutUint32(header, uint32(len(encoded))) // Write length
+
+		buffered.Write(header) // Write header
+
+		_, err = buffered.Write(encoded) // Write record
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+	}
+
+	return buffered.Flush() // Flush records
+}
+
+// readLeafRecord reads a single length-prefixed leaf record, returning the
+// record's transaction and its size in the log.
+func readLeafRecord(reader io.Reader) (*types.Transaction, int64, error) {
+	header := make([]byte, leafRecordHeaderSize) // Init header buffer
+
+	_, err := io.ReadFull(reader, header) // Read header
+	if err != nil {                       // Check for errors
+		return nil, 0, err // Return found error
+	}
+
+	encoded := make([]byte, binary.BigEndian.Uint32(header)) // Init record buffer
+
+	_, err = io.ReadFull(reader, encoded) // Read record
+	if err == io.EOF {                    // Check header without body
+		return nil, 0, io.ErrUnexpectedEOF // Return torn write
+	} else if err != nil { // Check for errors
+		return nil, 0, err // Return found error
+	}
+
+	transaction, err := types.TransactionFromJSON(encoded) // Decode transaction
+	if err != nil || transaction.Hash == nil {             // Check for errors
+		return nil, 0, ErrCorruptDagLog // Return error
+	}
+
+	return transaction, int64(leafRecordHeaderSize + len(encoded)), nil // Return transaction
+}
+
+// encodeLeafTransaction encodes a transaction in its safe form without
+// modifying it (signing keys are frequently shared between transactions).
+func encodeLeafTransaction(transaction *types.Transaction) ([]byte, error) {
+	safeTransaction := *transaction // Copy transaction
+
+	if transaction.Signature != nil && transaction.Signature.PublicKey != nil { // Check has signature
+		signature := *transaction.Signature // Copy signature
+
+		encoded, err := x509.MarshalPKIXPublicKey(signature.PublicKey) // Encode public key
+		if err != nil {                                                // Check for errors
+			return nil, err // Return found error
+		}
+
+		signature.SerializedPublicKey = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encoded}) // Encode PEM
+
+		safeTransaction.Signature = &signature // Set signature
+	}
+
+	return json.Marshal(safeTransaction) // Encode transaction
+}
+
+// readLegacyDag reads a dag written as a single flattened gob (as dags were
+// written before logs were introduced).
+func readLegacyDag(network string) (*Dag, error) {
+	file, err := os.Open(legacyDagPath(network)) // Open file
+	if err != nil {                              // Check for errors
 		return &Dag{}, err // Return found error
 	}
 
+	defer file.Close() // Close file
+
 	decoder := gob.NewDecoder(file) // Initialize decoder
 
 	buffer := &Flattened{} // Init flattened dag buffer
@@ -180,4 +384,14 @@ func ReadDagFromMemory(network string) (*Dag, error) {
 	return UnflattenDag(buffer) // Return decoded, unflattened dag
 }
 
-/* END EXPORTED METHODS */
+// dagLogPath gets the path of the dag log of a given network.
+func dagLogPath(network string) string {
+	return filepath.FromSlash(fmt.Sprintf("%s/dag_%s.log", common.DagDir, network)) // Return path
+}
+
+// legacyDagPath gets the path of the flattened gob dag of a given network.
+func legacyDagPath(network string) string {
+	return filepath.FromSlash(fmt.Sprintf("%s/dag_%s.gob", common.DagDir, network)) // Return path
+}
+
+/* END INTERNAL METHODS */
@@ -6,7 +6,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-	"math/big"
 	"testing"
 
 	"github.com/SummerCash/go-summercash/common"
@@ -27,8 +26,8 @@ func TestWriteToMemory(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                     // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -46,8 +45,8 @@ func TestWriteToMemory(t *testing.T) {
 	lastLeaf := root // Set last leaf
 
 	for i := 0; i < 1000; i++ { // Lol
-		newTransaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-		if err != nil {                                                                                                  // Check for errors
+		newTransaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                        // Check for errors
 			t.Fatal(err) // Panic
 		}
 
@@ -83,8 +82,8 @@ func TestReadFromMemory(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                     // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -102,8 +101,8 @@ func TestReadFromMemory(t *testing.T) {
 	lastLeaf := root // Set last leaf
 
 	for i := 0; i < 1000; i++ { // Lol
-		newTransaction, err := types.NewTransaction(uint64(i+1), lastLeaf.Transaction, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-		if err != nil {                                                                                                                   // Check for errors
+		newTransaction, err := types.NewTransaction(uint64(i+1), lastLeaf.Transaction, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                                         // Check for errors
 			t.Fatal(err) // Panic
 		}
 
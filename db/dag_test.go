@@ -6,7 +6,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-	"math/big"
 	"testing"
 
 	"github.com/SummerCash/go-summercash/common"
@@ -36,8 +35,8 @@ func TestNewDagWithRoot(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                     // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -65,8 +64,8 @@ func TestAddLeaf(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                     // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -84,8 +83,8 @@ func TestAddLeaf(t *testing.T) {
 	lastLeaf := root // Set last leaf
 
 	for i := 0; i < 1000; i++ { // Lol
-		newTransaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-		if err != nil {                                                                                                  // Check for errors
+		newTransaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                        // Check for errors
 			t.Fatal(err) // Panic
 		}
 
@@ -124,8 +123,8 @@ func TestQueryTransactionsWithSender(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                     // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -143,8 +142,8 @@ func TestQueryTransactionsWithSender(t *testing.T) {
 	lastLeaf := root // Set last leaf
 
 	for i := 0; i < 1000; i++ { // Lol
-		newTransaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-		if err != nil {                                                                                                  // Check for errors
+		newTransaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                        // Check for errors
 			t.Fatal(err) // Panic
 		}
 
@@ -215,8 +214,8 @@ func TestQueryTransactionsWithRecipient(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &recipient, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                        // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &recipient, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                              // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -234,8 +233,8 @@ func TestQueryTransactionsWithRecipient(t *testing.T) {
 	lastLeaf := root // Set last leaf
 
 	for i := 0; i < 1000; i++ { // Lol
-		newTransaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &recipient, big.NewFloat(0), []byte("test")) // Initialize transaction
-		if err != nil {                                                                                                     // Check for errors
+		newTransaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &recipient, common.NewAmount(nil), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                           // Check for errors
 			t.Fatal(err) // Panic
 		}
 
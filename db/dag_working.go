@@ -0,0 +1,230 @@
+// Package db defines the standard go-summercash transaction database.
+package db
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+// MaxOrphans is the maximum number of transactions held while waiting for their parent to be added to the working dag.
+const MaxOrphans = 4096
+
+var (
+	workingDag        *Dag                                 // Working dag
+	workingDagNetwork string                               // Network of the working dag
+	workingDagOrphans map[common.Hash][]*types.Transaction // Transactions waiting on a parent not yet in the working dag, by parent hash
+	workingDagLock    sync.Mutex                           // Working dag lock
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// GetWorkingDag gets the node's working dag for a given network, reading it from persistent memory (or initializing an
+// empty dag) if it has not been loaded yet. The returned dag must only be modified through AddToWorkingDag.
+func GetWorkingDag(network string) (*Dag, error) {
+	workingDagLock.Lock()         // Lock working dag
+	defer workingDagLock.Unlock() // Unlock working dag
+
+	return getWorkingDag(network) // Return working dag
+}
+
+// AddToWorkingDag adds a given accepted transaction to the working dag of a given network. A transaction whose parent
+// has not been added yet is held, and added once its parent is. Transactions already in the dag are ignored.
+//
+// The working dag indexes transactions that have already been committed to their account chains: balances, nonces and
+// transaction validation are still derived from the account chains (see types.Chain), so a transaction that can't be
+// added to the dag is still accepted.
+func AddToWorkingDag(network string, transaction *types.Transaction) error {
+	if transaction == nil || transaction.Hash == nil { // Check nil transaction
+		return ErrNilLeafContents // Return error
+	}
+
+	if transaction.Mint { // Check is mint
+		return nil // Mints are derived from the chain config, not part of the dag
+	}
+
+	workingDagLock.Lock()         // Lock working dag
+	defer workingDagLock.Unlock() // Unlock working dag
+
+	dag, err := getWorkingDag(network) // Get working dag
+	if err != nil {                    // Check for errors
+		return err // Return found error
+	}
+
+	err = dag.AddTransaction(transaction) // Add transaction
+
+	if err == ErrDuplicateLeaf { // Check already added
+		return nil // Nothing to do
+	} else if err == ErrNoMatchingLeaf || err == ErrNilDag { // Check parent not added yet
+		holdOrphan(dag, transaction) // Wait for parent
+
+		return nil // Return
+	} else if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	adopted := []*types.Transaction{transaction} // Init added buffer
+
+	for len(adopted) > 0 { // Do until no waiting children
+		parent := adopted[0]                       // Get next added tx
+		adopted = adopted[1:]                      // Pop tx
+		orphans := workingDagOrphans[*parent.Hash] // Get waiting children
+
+		if conflict, err := dag.QueryConflict(*parent.Hash); err == nil { // Check tx is a double spend
+			common.Logf("== DAG == detected conflicting transactions: %s\n", conflict.String()) // Log conflict
+
+			publishConflict(conflict) // Notify subscribers
+		}
+
+		delete(workingDagOrphans, *parent.Hash) // Stop waiting
+
+		for _, orphan := range orphans { // Iterate through waiting children
+			err = dag.AddTransaction(orphan) // Add child
+
+			if err == nil { // Check added
+				adopted = append(adopted, orphan) // Add orphan's waiting children
+			} else if err == ErrNoMatchingLeaf { // Check still waiting on another parent
+				holdOrphan(dag, orphan) // Wait for next parent
+			}
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// SelectWorkingDagTips selects up to a given number of tips of the loaded working dag for a new transaction to approve (see
+// Dag.SelectTips). If no working dag has been loaded yet, or it is empty, no tips are selected.
+func SelectWorkingDagTips(n int) ([]common.Hash, error) {
+	workingDagLock.Lock()         // Lock working dag
+	defer workingDagLock.Unlock() // Unlock working dag
+
+	if workingDag == nil || workingDag.Root == nil { // Check no dag
+		return []common.Hash{}, nil // No tips
+	}
+
+	tips, err := workingDag.SelectTips(n, DefaultWalkAlpha) // Select tips
+	if err != nil {                                         // Check for errors
+		return []common.Hash{}, err // Return found error
+	}
+
+	hashes := []common.Hash{} // Init hashes buffer
+
+	for _, tip := range tips { // Iterate through tips
+		hashes = append(hashes, tip.Hash) // Append hash
+	}
+
+	return hashes, nil // Return tip hashes
+}
+
+// GetWorkingDagConflicts gets every conflict in the loaded working dag (see Dag.Conflicts).
+func GetWorkingDagConflicts() []*Conflict {
+	workingDagLock.Lock()         // Lock working dag
+	defer workingDagLock.Unlock() // Unlock working dag
+
+	if workingDag == nil { // Check no dag
+		return []*Conflict{} // No conflicts
+	}
+
+	return workingDag.Conflicts() // Return conflicts
+}
+
+// ExportWorkingDagGraph exports the subgraph of the loaded working dag matching a given filter (see Dag.ExportGraph).
+func ExportWorkingDagGraph(filter *GraphFilter) (*Graph, error) {
+	workingDagLock.Lock()         // Lock working dag
+	defer workingDagLock.Unlock() // Unlock working dag
+
+	if workingDag == nil { // Check no dag
+		return &Graph{}, ErrNilDag // Return error
+	}
+
+	return workingDag.ExportGraph(filter) // Return graph
+}
+
+// WriteWorkingDag persists the working dag (if one has been loaded) to persistent memory.
+func WriteWorkingDag() error {
+	workingDagLock.Lock()         // Lock working dag
+	defer workingDagLock.Unlock() // Unlock working dag
+
+	if workingDag == nil || workingDag.Root == nil { // Check nothing to write
+		return nil // Nothing to do
+	}
+
+	return workingDag.WriteToMemory(workingDagNetwork) // Write dag
+}
+
+// StartIntermittentCheckpoint persists the working dag at a given interval.
+func StartIntermittentCheckpoint(duration time.Duration) {
+	for range time.Tick(duration) { // Checkpoint every duration seconds
+		err := WriteWorkingDag() // Write dag
+		if err != nil {          // Check for errors
+			common.Logf("== DAG == intermittent checkpoint errored: %s\n", err.Error()) // Log error
+		}
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// getWorkingDag implements the functionality of GetWorkingDag, assuming the working dag lock is held.
+func getWorkingDag(network string) (*Dag, error) {
+	if workingDag != nil && workingDagNetwork == network { // Check already loaded
+		return workingDag, nil // Return working dag
+	}
+
+	if workingDag != nil && workingDag.Root != nil { // Check switching networks
+		err := workingDag.WriteToMemory(workingDagNetwork) // Persist previous dag
+		if err != nil {                                    // Check for errors
+			return &Dag{}, err // Return found error
+		}
+	}
+
+	dag, err := ReadDagFromMemory(network) // Read dag
+	if os.IsNotExist(err) {                // Check no dag
+		dag = NewDag() // Init dag
+	} else if err != nil { // Check for errors
+		return &Dag{}, err // Return found error
+	}
+
+	workingDag = dag                                               // Set working dag
+	workingDagNetwork = network                                    // Set network
+	workingDagOrphans = make(map[common.Hash][]*types.Transaction) // Reset orphans
+
+	return dag, nil // Return dag
+}
+
+// holdOrphan holds a given transaction until its first parent missing from a given working dag is added to it, assuming the
+// working dag lock is held. If too many transactions are already waiting, the transaction is dropped (it can still be
+// recovered from the account chains with ImportBlockmesh).
+func holdOrphan(dag *Dag, transaction *types.Transaction) {
+	held := 0 // Init num held txs
+
+	for _, orphans := range workingDagOrphans { // Iterate through waiting txs
+		for _, orphan := range orphans { // Iterate through txs waiting on parent
+			if *orphan.Hash == *transaction.Hash { // Check already held
+				return // Nothing to do
+			}
+
+			held++ // Increment held
+		}
+	}
+
+	if held >= MaxOrphans { // Check too many held
+		common.Logf("== DAG == dropping tx %s: too many transactions waiting on a parent\n", transaction.Hash.String()) // Log drop
+
+		return // Return
+	}
+
+	for _, parentHash := range transaction.Parents() { // Iterate through parent hashes
+		if _, err := dag.QueryLeafWithHash(parentHash); err != nil { // Check parent missing
+			workingDagOrphans[parentHash] = append(workingDagOrphans[parentHash], transaction) // Hold tx
+
+			return // Return
+		}
+	}
+}
+
+/* END INTERNAL METHODS */
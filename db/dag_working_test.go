@@ -0,0 +1,149 @@
+// Package db defines the standard go-summercash transaction database.
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestAddToWorkingDag tests the functionality of the AddToWorkingDag helper method.
+func TestAddToWorkingDag(t *testing.T) {
+	os.Remove(dagLogPath("test_working_net"))    // Remove old dag
+	os.Remove(legacyDagPath("test_working_net")) // Remove old legacy dag
+
+	transactions := newTestTransactionChain(t, 3) // Initialize transactions
+
+	for i := len(transactions) - 1; i >= 0; i-- { // Add transactions, children first
+		err := AddToWorkingDag("test_working_net", transactions[i]) // Add transaction
+		if err != nil {                                             // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	err := AddToWorkingDag("test_working_net", transactions[1]) // Add duplicate transaction
+	if err != nil {                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = WriteWorkingDag() // Write working dag
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	readDag, err := ReadDagFromMemory("test_working_net") // Read dag from persistent memory
+	if err != nil {                                       // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	flattened, err := readDag.Flatten() // Flatten read dag
+	if err != nil {                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(flattened.Transactions) != len(transactions) { // Check all held transactions added once
+		t.Fatalf("expected %d txs in dag; found %d", len(transactions), len(flattened.Transactions)) // Panic
+	}
+
+	if readDag.Root.Hash != *transactions[0].Hash { // Check root
+		t.Fatal("expected genesis transaction to be the dag root") // Panic
+	}
+}
+
+// TestImportBlockmesh tests the functionality of the ImportBlockmesh helper method.
+func TestImportBlockmesh(t *testing.T) {
+	path := filepath.FromSlash(fmt.Sprintf("%s/db/test_blockmesh.log", common.DataDir)) // Get store path
+
+	os.Remove(path) // Remove old store
+
+	store, err := types.NewLogChainStore(path) // Open store
+	if err != nil {                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = types.SetChainStore(store) // Set working store
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer types.CloseChainStore() // Close store
+
+	transactions := newTestTransactionChain(t, 3) // Initialize transactions
+
+	orphan, err := types.NewTransaction(0, transactions[2], transactions[2].Recipient, transactions[2].Recipient, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                                                                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	orphan.ParentTx = &common.Hash{0x1} // Set unknown parent
+
+	mint := &types.Transaction{Recipient: transactions[0].Recipient, Amount: common.Coins(1), Mint: true, Hash: &common.Hash{0x2}} // Initialize mint
+
+	for _, chain := range []*types.Chain{
+		{Account: *transactions[0].Recipient, Transactions: []*types.Transaction{transactions[0], mint, transactions[1]}}, // Genesis account chain
+		{Account: *transactions[1].Recipient, Transactions: []*types.Transaction{transactions[1], transactions[2]}},       // Intermediate account chain
+		{Account: *transactions[2].Recipient, Transactions: []*types.Transaction{transactions[2], orphan}},                // Last account chain
+	} { // Iterate through account chains
+		err = store.WriteChain(chain) // Write chain
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	dag, err := ImportBlockmesh() // Import blockmesh
+	if err != nil {               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	flattened, err := dag.Flatten() // Flatten dag
+	if err != nil {                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(flattened.Transactions) != len(transactions) { // Check each attachable transaction imported once
+		t.Fatalf("expected %d txs in dag; found %d", len(transactions), len(flattened.Transactions)) // Panic
+	}
+
+	if dag.Root.Hash != *transactions[0].Hash { // Check root
+		t.Fatal("expected genesis transaction to be the dag root") // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newTestTransactionChain initializes a genesis transaction followed by a given number of transactions, each sent by
+// the recipient of its parent to a new account.
+func newTestTransactionChain(t *testing.T, n int) []*types.Transaction {
+	transactions := []*types.Transaction{} // Init transaction buffer
+
+	var lastTransaction *types.Transaction // Init parent buffer
+	var sender *common.Address             // Init sender buffer
+
+	for i := 0; i < n; i++ { // Make transactions
+		recipient := common.Address{byte(i + 1)} // Get recipient
+
+		transaction, err := types.NewTransaction(uint64(i), lastTransaction, sender, &recipient, common.Coins(1), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                           // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		transactions = append(transactions, transaction) // Append transaction
+		lastTransaction = transaction                    // Set parent
+		sender = transaction.Recipient                   // Set sender
+	}
+
+	return transactions // Return transactions
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,426 @@
+// Package db defines the standard go-summercash transaction database.
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+const (
+	// DOTGraphFormat is the name of the Graphviz DOT graph export format.
+	DOTGraphFormat = "dot"
+
+	// JSONGraphFormat is the name of the JSON node and edge list graph export format.
+	JSONGraphFormat = "json"
+
+	// ParentEdge is the kind of a graph edge from a transaction to its account chain parent.
+	ParentEdge = "parent"
+
+	// ApprovalEdge is the kind of a graph edge from a transaction to an additional parent (a dag tip it approved).
+	ApprovalEdge = "approves"
+)
+
+// ErrUnknownGraphFormat is an error definition describing a graph export format other than DOTGraphFormat or JSONGraphFormat.
+var ErrUnknownGraphFormat = errors.New("unknown graph format (expected dot or json)")
+
+// GraphFilter describes a subgraph of the dag or of an account chain.
+type GraphFilter struct {
+	Address *common.Address `json:"address"` // Account that sent or received each transaction (nil matches any account)
+
+	Since time.Time `json:"since"` // Earliest transaction timestamp (zero if unbounded)
+	Until time.Time `json:"until"` // Latest transaction timestamp (zero if unbounded)
+
+	From  *common.Hash `json:"from"`  // Transaction the subgraph is centred on (nil for the whole graph)
+	Depth int          `json:"depth"` // Maximum number of parent or child links between each transaction and From (0 if unbounded)
+}
+
+// Graph is an exported subgraph of the dag or of an account chain, as a list
+// of transactions and the links between them.
+type Graph struct {
+	Nodes []*GraphNode `json:"nodes"` // Transactions
+	Edges []*GraphEdge `json:"edges"` // Links from each transaction to its parents
+}
+
+// GraphNode is a single transaction in an exported graph.
+type GraphNode struct {
+	Hash string `json:"hash"` // Transaction hash
+
+	Sender    string `json:"sender"`    // Transaction sender (empty if none)
+	Recipient string `json:"recipient"` // Transaction recipient (empty if none)
+	Amount    string `json:"amount"`    // Amount of coins sent in transaction
+	Nonce     uint64 `json:"nonce"`     // Sender account nonce
+
+	Timestamp time.Time `json:"time"` // Transaction timestamp
+
+	Genesis      bool `json:"genesis"`       // Whether the transaction is a genesis transaction
+	ContractCall bool `json:"contract_call"` // Whether the transaction deploys or calls a contract
+	Conflicting  bool `json:"conflicting"`   // Whether the transaction is part of a conflict (see Conflict)
+	Orphaned     bool `json:"orphaned"`      // Whether the transaction has been orphaned by the resolution of a conflict
+}
+
+// GraphEdge is a link from a transaction to one of its parents in an exported
+// graph.
+type GraphEdge struct {
+	From string `json:"from"` // Child transaction hash
+	To   string `json:"to"`   // Parent transaction hash
+	Kind string `json:"kind"` // Edge kind (ParentEdge or ApprovalEdge)
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// ParseGraphFilter parses a graph filter from a given set of key=value
+// parameters (address, since and until as unix timestamps, hash, depth).
+func ParseGraphFilter(params []string) (*GraphFilter, error) {
+	filter := &GraphFilter{} // Init filter
+
+	for _, param := range params { // Iterate through params
+		keyValue := strings.SplitN(strings.TrimSpace(param), "=", 2) // Split key, value
+
+		if len(keyValue) != 2 { // Check not key=value
+			return &GraphFilter{}, fmt.Errorf("invalid graph filter %s (expected key=value)", param) // Return error
+		}
+
+		switch keyValue[0] {
+		case "address":
+			address, err := common.StringToAddress(keyValue[1]) // Get address primitive value
+			if err != nil {                                     // Check for errors
+				return &GraphFilter{}, err // Return found error
+			}
+
+			filter.Address = &address // Set address
+		case "since", "until":
+			timestamp, err := strconv.ParseInt(keyValue[1], 10, 64) // Parse timestamp
+			if err != nil {                                         // Check for errors
+				return &GraphFilter{}, err // Return found error
+			}
+
+			if keyValue[0] == "since" { // Check is start
+				filter.Since = time.Unix(timestamp, 0) // Set start
+			} else {
+				filter.Until = time.Unix(timestamp, 0) // Set end
+			}
+		case "hash":
+			hash, err := common.StringToHash(keyValue[1]) // Get hash primitive value
+			if err != nil {                               // Check for errors
+				return &GraphFilter{}, err // Return found error
+			}
+
+			filter.From = &hash // Set starting hash
+		case "depth":
+			depth, err := strconv.ParseUint(keyValue[1], 10, 32) // Parse depth
+			if err != nil {                                      // Check for errors
+				return &GraphFilter{}, err // Return found error
+			}
+
+			filter.Depth = int(depth) // Set depth
+		default:
+			return &GraphFilter{}, fmt.Errorf("invalid graph filter %s (accepts address, since, until, hash, depth)", keyValue[0]) // Return error
+		}
+	}
+
+	return filter, nil // Return filter
+}
+
+// ExportGraph exports the subgraph of the dag matching a given filter.
+func (dag *Dag) ExportGraph(filter *GraphFilter) (*Graph, error) {
+	if dag.Root == nil { // Check no root
+		return &Graph{}, ErrNilDag // Return error
+	}
+
+	index := dag.getIndex() // Get leaf index
+
+	transactions := make([]*types.Transaction, len(index.leaves)) // Init transactions buffer
+
+	for i, leaf := range index.leaves { // Iterate through leaves
+		transactions[i] = leaf.Transaction // Set transaction
+	}
+
+	return newGraph(transactions, filter, dag.Conflicts(), dag.getOrphaned()) // Return graph
+}
+
+// ExportChainGraph exports the subgraph of a given account chain (linked by
+// each transaction's parents) matching a given filter. Transactions that are
+// part of any of a given set of conflicts are marked as conflicting.
+func ExportChainGraph(chain *types.Chain, filter *GraphFilter, conflicts []*Conflict) (*Graph, error) {
+	return newGraph(chain.Transactions, filter, conflicts, nil) // Return graph
+}
+
+// DOT encodes the graph in the Graphviz DOT language. Genesis transactions are
+// filled gold, contract calls are filled blue, conflicting transactions are
+// outlined red, and orphaned transactions are dashed.
+func (graph *Graph) DOT() string {
+	buffer := new(bytes.Buffer) // Init buffer
+
+	buffer.WriteString("digraph summercash {\n")                                    // Write header
+	buffer.WriteString("\trankdir=BT;\n")                                           // Draw parents above children
+	buffer.WriteString("\tnode [shape=box, fontname=\"monospace\", style=\"\"];\n") // Write node defaults
+
+	for _, node := range graph.Nodes { // Iterate through nodes
+		sender := node.Sender // Get sender
+
+		if sender == "" { // Check no sender
+			sender = "(none)" // Set sender
+		}
+
+		label := fmt.Sprintf("%s\nnonce %d: %s -> %s\n%s", shortHash(node.Hash), node.Nonce, sender, node.Recipient, node.Amount) // Init label
+
+		attributes := fmt.Sprintf("label=%q", label) // Init attributes
+
+		if style := nodeStyle(node); style != "" { // Check has style
+			attributes += ", " + style // Append style
+		}
+
+		fmt.Fprintf(buffer, "\t%q [%s];\n", node.Hash, attributes) // Write node
+	}
+
+	for _, edge := range graph.Edges { // Iterate through edges
+		if edge.Kind == ApprovalEdge { // Check approval
+			fmt.Fprintf(buffer, "\t%q -> %q [style=dashed];\n", edge.From, edge.To) // Write dashed edge
+
+			continue // Continue
+		}
+
+		fmt.Fprintf(buffer, "\t%q -> %q;\n", edge.From, edge.To) // Write edge
+	}
+
+	buffer.WriteString("}\n") // Write footer
+
+	return buffer.String() // Return DOT
+}
+
+// JSON encodes the graph as a JSON node and edge list.
+func (graph *Graph) JSON() ([]byte, error) {
+	return json.MarshalIndent(*graph, "", "  ") // Encode graph
+}
+
+// Encode encodes the graph in a given format (DOTGraphFormat, or
+// JSONGraphFormat if no format is given).
+func (graph *Graph) Encode(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case DOTGraphFormat:
+		return graph.DOT(), nil // Return DOT
+	case JSONGraphFormat, "":
+		encoded, err := graph.JSON() // Encode graph
+		if err != nil {              // Check for errors
+			return "", err // Return found error
+		}
+
+		return string(encoded), nil // Return JSON
+	default:
+		return "", ErrUnknownGraphFormat // Return error
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newGraph initializes a graph from the transactions in a given list matching
+// a given filter, linking each transaction to its parents in the list.
+func newGraph(transactions []*types.Transaction, filter *GraphFilter, conflicts []*Conflict, orphaned map[common.Hash]bool) (*Graph, error) {
+	if filter == nil { // Check no filter
+		filter = &GraphFilter{} // Match everything
+	}
+
+	byHash := make(map[common.Hash]*types.Transaction) // Init transactions by hash
+	children := make(map[common.Hash][]common.Hash)    // Init child hashes by parent hash
+
+	for _, transaction := range transactions { // Iterate through transactions
+		if transaction.Hash == nil || byHash[*transaction.Hash] != nil { // Check no hash, or already added
+			continue // Continue
+		}
+
+		byHash[*transaction.Hash] = transaction // Set transaction
+
+		for _, parent := range transaction.Parents() { // Iterate through parents
+			children[parent] = append(children[parent], *transaction.Hash) // Append child
+		}
+	}
+
+	inRange := map[common.Hash]bool(nil) // Init transactions within depth of filter hash (nil if unbounded)
+
+	if filter.From != nil { // Check centred on transaction
+		if byHash[*filter.From] == nil { // Check no transaction
+			return &Graph{}, ErrNoMatchingLeaf // Return error
+		}
+
+		inRange = withinDepth(*filter.From, filter.Depth, byHash, children) // Get transactions within depth
+	}
+
+	conflicting := make(map[common.Hash]bool) // Init conflicting transactions
+
+	for _, conflict := range conflicts { // Iterate through conflicts
+		for _, hash := range conflict.Transactions { // Iterate through conflicting transactions
+			conflicting[hash] = true // Set conflicting
+		}
+	}
+
+	graph := &Graph{Nodes: []*GraphNode{}, Edges: []*GraphEdge{}} // Init graph
+	included := make(map[common.Hash]bool)                        // Init included transactions
+
+	for _, transaction := range transactions { // Iterate through transactions
+		if transaction.Hash == nil || included[*transaction.Hash] || inRange != nil && !inRange[*transaction.Hash] || !filter.matches(transaction) { // Check no match
+			continue // Continue
+		}
+
+		included[*transaction.Hash] = true // Set included
+
+		graph.Nodes = append(graph.Nodes, newGraphNode(transaction, conflicting[*transaction.Hash], orphaned[*transaction.Hash])) // Append node
+	}
+
+	linked := make(map[common.Hash]bool) // Init linked transactions
+
+	for _, transaction := range transactions { // Iterate through transactions
+		if transaction.Hash == nil || !included[*transaction.Hash] || linked[*transaction.Hash] { // Check not included, or already linked
+			continue // Continue
+		}
+
+		linked[*transaction.Hash] = true // Set linked
+
+		for _, parent := range transaction.Parents() { // Iterate through parents
+			if !included[parent] { // Check parent not included
+				continue // Continue
+			}
+
+			kind := ApprovalEdge // Init kind
+
+			if transaction.ParentTx != nil && *transaction.ParentTx == parent { // Check account chain parent
+				kind = ParentEdge // Set kind
+			}
+
+			graph.Edges = append(graph.Edges, &GraphEdge{From: transaction.Hash.String(), To: parent.String(), Kind: kind}) // Append edge
+		}
+	}
+
+	return graph, nil // Return graph
+}
+
+// withinDepth gets the set of transactions within a given number of parent or
+// child links of the transaction with a given hash (any number if depth is 0).
+func withinDepth(from common.Hash, depth int, byHash map[common.Hash]*types.Transaction, children map[common.Hash][]common.Hash) map[common.Hash]bool {
+	distances := map[common.Hash]int{from: 0} // Init distances from transaction
+
+	queue := []common.Hash{from} // Init queue of transactions to expand
+
+	for len(queue) > 0 { // Do until all transactions within depth expanded
+		hash := queue[0]  // Get next transaction
+		queue = queue[1:] // Pop transaction
+
+		if depth > 0 && distances[hash] == depth { // Check at max depth
+			continue // Continue
+		}
+
+		neighbours := append(append([]common.Hash{}, byHash[hash].Parents()...), children[hash]...) // Get parents and children
+
+		for _, neighbour := range neighbours { // Iterate through neighbours
+			if _, ok := distances[neighbour]; ok || byHash[neighbour] == nil { // Check already found, or not in graph
+				continue // Continue
+			}
+
+			distances[neighbour] = distances[hash] + 1 // Set distance
+
+			queue = append(queue, neighbour) // Queue neighbour
+		}
+	}
+
+	inRange := make(map[common.Hash]bool) // Init in range buffer
+
+	for hash := range distances { // Iterate through found transactions
+		inRange[hash] = true // Set in range
+	}
+
+	return inRange // Return transactions in range
+}
+
+// matches checks whether a given transaction matches the filter's address and
+// time range.
+func (filter *GraphFilter) matches(transaction *types.Transaction) bool {
+	if filter.Address != nil && (transaction.Sender == nil || *transaction.Sender != *filter.Address) && (transaction.Recipient == nil || *transaction.Recipient != *filter.Address) { // Check not sent or received by address
+		return false // No match
+	}
+
+	if !filter.Since.IsZero() && transaction.Timestamp.Before(filter.Since) { // Check too early
+		return false // No match
+	}
+
+	if !filter.Until.IsZero() && transaction.Timestamp.After(filter.Until) { // Check too late
+		return false // No match
+	}
+
+	return true // Match
+}
+
+// newGraphNode initializes a graph node for a given transaction.
+func newGraphNode(transaction *types.Transaction, conflicting bool, orphaned bool) *GraphNode {
+	node := &GraphNode{
+		Hash:         transaction.Hash.String(),                                                           // Set hash
+		Nonce:        transaction.AccountNonce,                                                            // Set nonce
+		Timestamp:    transaction.Timestamp,                                                               // Set timestamp
+		Genesis:      transaction.Genesis || transaction.Sender == nil && len(transaction.Parents()) == 0, // Set is genesis
+		ContractCall: transaction.ContractCreation || transaction.State != nil,                            // Set is contract call
+		Conflicting:  conflicting,                                                                         // Set conflicting
+		Orphaned:     orphaned,                                                                            // Set orphaned
+	} // Init node
+
+	if transaction.Sender != nil { // Check has sender
+		node.Sender = transaction.Sender.String() // Set sender
+	}
+
+	if transaction.Recipient != nil { // Check has recipient
+		node.Recipient = transaction.Recipient.String() // Set recipient
+	}
+
+	if transaction.Amount != nil { // Check has amount
+		node.Amount = transaction.Amount.String() // Set amount
+	}
+
+	return node // Return node
+}
+
+// nodeStyle gets the DOT attributes marking a given node as genesis, a
+// contract call, conflicting or orphaned.
+func nodeStyle(node *GraphNode) string {
+	styles := []string{} // Init styles buffer
+	attributes := ""     // Init attributes buffer
+
+	if node.Genesis { // Check genesis
+		styles = append(styles, "filled")    // Fill
+		attributes += ", fillcolor=\"gold\"" // Set fill
+	} else if node.ContractCall { // Check contract call
+		styles = append(styles, "filled")         // Fill
+		attributes += ", fillcolor=\"lightblue\"" // Set fill
+	}
+
+	if node.Conflicting { // Check conflicting
+		attributes += ", color=\"red\", penwidth=2" // Set outline
+	}
+
+	if node.Orphaned { // Check orphaned
+		styles = append(styles, "dashed") // Dash outline
+	}
+
+	if len(styles) == 0 && attributes == "" { // Check unmarked
+		return "" // No style
+	}
+
+	return fmt.Sprintf("style=%q%s", strings.Join(styles, ","), attributes) // Return style
+}
+
+// shortHash abbreviates a given hash string for display.
+func shortHash(hash string) string {
+	if len(hash) <= 10 { // Check already short
+		return hash // Return hash
+	}
+
+	return hash[:10] // Return prefix
+}
+
+/* END INTERNAL METHODS */
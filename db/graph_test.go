@@ -0,0 +1,194 @@
+// Package db defines the standard go-summercash transaction database.
+package db
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestExportGraph tests that dag subgraphs are filtered by address, time range
+// and depth, and that genesis, contract and conflicting transactions are marked.
+func TestExportGraph(t *testing.T) {
+	dag := NewDag() // Initialize dag
+
+	transactions := []*types.Transaction{} // Init transactions buffer
+
+	for i := 0; i < 10; i++ { // Add leaves
+		transaction := newBenchmarkTransaction(i) // Initialize transaction
+
+		transaction.Timestamp = time.Unix(int64(i*10), 0) // Set timestamp
+		transaction.Genesis = i == 0                      // Set is genesis
+		transaction.ContractCreation = i == 7             // Set is contract creation
+
+		err := dag.AddTransaction(transaction) // Add transaction
+		if err != nil {                        // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		transactions = append(transactions, transaction) // Append transaction
+	}
+
+	graph, err := dag.ExportGraph(nil) // Export whole dag
+	if err != nil {                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(graph.Nodes) != 10 || len(graph.Edges) != 16 { // Check every parent and approval linked
+		t.Fatalf("expected 10 nodes and 16 edges; found %d nodes and %d edges", len(graph.Nodes), len(graph.Edges)) // Panic
+	}
+
+	if !graph.Nodes[0].Genesis || !graph.Nodes[7].ContractCall || graph.Nodes[1].Genesis || graph.Nodes[1].ContractCall { // Check markings
+		t.Fatal("expected genesis and contract transactions to be marked") // Panic
+	}
+
+	graph, err = dag.ExportGraph(&GraphFilter{Address: benchmarkAddress(3)}) // Export txs sent or received by account
+	if err != nil {                                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(graph.Nodes) != 2 || len(graph.Edges) != 1 || graph.Edges[0].Kind != ParentEdge { // Check only edges between matching txs kept
+		t.Fatalf("expected 2 nodes linked by a parent edge; found %d nodes and %d edges", len(graph.Nodes), len(graph.Edges)) // Panic
+	}
+
+	graph, err = dag.ExportGraph(&GraphFilter{Since: time.Unix(30, 0), Until: time.Unix(50, 0)}) // Export txs in time range
+	if err != nil {                                                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(graph.Nodes) != 3 { // Check time range
+		t.Fatalf("expected 3 nodes in time range; found %d", len(graph.Nodes)) // Panic
+	}
+
+	from := benchmarkHash(5) // Get starting hash
+
+	graph, err = dag.ExportGraph(&GraphFilter{From: &from, Depth: 1}) // Export direct parents and children
+	if err != nil {                                                   // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(graph.Nodes) != 4 { // Check parent, approved tx and child found
+		t.Fatalf("expected 4 nodes within depth 1; found %d", len(graph.Nodes)) // Panic
+	}
+
+	missing := benchmarkHash(100) // Get missing hash
+
+	if _, err := dag.ExportGraph(&GraphFilter{From: &missing}); err != ErrNoMatchingLeaf { // Check missing starting hash
+		t.Fatalf("expected no matching leaf, got %v", err) // Panic
+	}
+
+	doubleSpend := newBenchmarkTransaction(9) // Initialize double spend of last tx
+	doubleSpend.Hash = &common.Hash{0x2}      // Set different hash
+
+	err = dag.AddTransaction(doubleSpend) // Add double spend
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	graph, err = dag.ExportGraph(&GraphFilter{Address: benchmarkAddress(9)}) // Export conflicting txs
+	if err != nil {                                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	orphaned := 0 // Init num orphaned
+
+	for _, node := range graph.Nodes { // Iterate through nodes
+		if node.Sender == benchmarkAddress(9).String() && !node.Conflicting { // Check conflict not marked
+			t.Fatalf("expected %s to be marked conflicting", node.Hash) // Panic
+		}
+
+		if node.Orphaned { // Check orphaned
+			orphaned++ // Increment num orphaned
+		}
+	}
+
+	if orphaned != 1 { // Check losing tx marked
+		t.Fatalf("expected 1 orphaned node; found %d", orphaned) // Panic
+	}
+
+	chainGraph, err := ExportChainGraph(&types.Chain{Transactions: transactions}, nil, dag.Conflicts()) // Export chain
+	if err != nil {                                                                                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(chainGraph.Nodes) != 10 || !chainGraph.Nodes[9].Conflicting || chainGraph.Nodes[8].Conflicting { // Check conflicts marked in chain
+		t.Fatal("expected conflicting chain transaction to be marked") // Panic
+	}
+}
+
+// TestEncodeGraph tests that graphs are encoded as DOT and JSON.
+func TestEncodeGraph(t *testing.T) {
+	dag := NewDag() // Initialize dag
+
+	for i := 0; i < 4; i++ { // Add leaves
+		transaction := newBenchmarkTransaction(i) // Initialize transaction
+
+		transaction.Genesis = i == 0 // Set is genesis
+
+		err := dag.AddTransaction(transaction) // Add transaction
+		if err != nil {                        // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	graph, err := dag.ExportGraph(nil) // Export dag
+	if err != nil {                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	dot, err := graph.Encode(DOTGraphFormat) // Encode graph as DOT
+	if err != nil {                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if !strings.HasPrefix(dot, "digraph") || !strings.Contains(dot, "fillcolor=\"gold\"") || !strings.Contains(dot, "[style=dashed]") { // Check genesis and approval marked
+		t.Fatalf("unexpected DOT graph:\n%s", dot) // Panic
+	}
+
+	encoded, err := graph.Encode(JSONGraphFormat) // Encode graph as JSON
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	decoded := &Graph{} // Init decoded graph buffer
+
+	err = json.Unmarshal([]byte(encoded), decoded) // Decode graph
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(decoded.Nodes) != 4 || len(decoded.Edges) != 4 || decoded.Nodes[0].Hash != benchmarkHash(0).String() { // Check decoded graph
+		t.Fatalf("expected 4 nodes and 4 edges; found %d nodes and %d edges", len(decoded.Nodes), len(decoded.Edges)) // Panic
+	}
+
+	if _, err := graph.Encode("xml"); err != ErrUnknownGraphFormat { // Check unknown format
+		t.Fatalf("expected unknown graph format, got %v", err) // Panic
+	}
+}
+
+// TestParseGraphFilter tests that graph filters are parsed from key=value
+// parameters.
+func TestParseGraphFilter(t *testing.T) {
+	filter, err := ParseGraphFilter([]string{"address=" + benchmarkAddress(3).String(), " since=10", "depth=2", "hash=" + benchmarkHash(1).String()}) // Parse filter
+	if err != nil {                                                                                                                                   // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *filter.Address != *benchmarkAddress(3) || filter.Since.Unix() != 10 || !filter.Until.IsZero() || filter.Depth != 2 || *filter.From != benchmarkHash(1) { // Check filter
+		t.Fatal("unexpected filter") // Panic
+	}
+
+	if _, err := ParseGraphFilter([]string{"colour=red"}); err == nil { // Check unknown key
+		t.Fatal("expected unknown filter key to be rejected") // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
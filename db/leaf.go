@@ -53,6 +53,23 @@ func NewLeaf(transaction *types.Transaction, parent *Leaf) (*Leaf, error) {
 	}, nil // Return leaf
 }
 
+// NewLeafWithParents initializes a new leaf with the given transaction,
+// attached to each of the given parents.
+func NewLeafWithParents(transaction *types.Transaction, parents []*Leaf) (*Leaf, error) {
+	if len(parents) == 0 { // Check no parents
+		return NewLeaf(transaction, nil) // Return leaf without parents
+	}
+
+	leaf, err := NewLeaf(transaction, parents[0]) // Initialize leaf
+	if err != nil {                               // Check for errors
+		return &Leaf{}, err // Return found error
+	}
+
+	leaf.Parents = append([]*Leaf{}, parents...) // Set parents
+
+	return leaf, nil // Return leaf
+}
+
 /*
 	BEGIN HELPER METHODS
 */
@@ -82,22 +99,28 @@ func (leaf *Leaf) GetOnlyChild() (*Leaf, error) {
 }
 
 // GetChildByHash queries the leaf's children for a particular hash.
-// If the hash does not exist, an error is returned.
+// If the hash does not exist, an error is returned. Leaves with
+// several parents are only searched once.
 func (leaf *Leaf) GetChildByHash(hash common.Hash) (*Leaf, error) {
-	if child, err := leaf.GetDirectChildByHash(hash); err == nil { // Check has child
-		return child, nil // Return child
-	}
+	visited := make(map[common.Hash]bool) // Init visited leaves buffer
+
+	queue := append([]*Leaf{}, leaf.Children...) // Init queue of leaves to search
 
-	for i, child := range leaf.Children { // Iterate through children
-		match, err := child.GetChildByHash(hash) // Get child
+	for len(queue) > 0 { // Do until all children searched
+		child := queue[0] // Get next leaf
+		queue = queue[1:] // Pop leaf
 
-		if err != nil && i == len(leaf.Children)-1 { // Check for errors
-			return &Leaf{}, err // Return found error
+		if visited[child.Hash] { // Check already searched
+			continue // Continue
 		}
 
-		if match != nil { // Check for match
-			return match, nil // Return match
+		visited[child.Hash] = true // Set visited
+
+		if bytes.Equal(child.Hash[:], hash[:]) { // Check for match
+			return child, nil // Return child
 		}
+
+		queue = append(queue, child.Children...) // Queue child's children
 	}
 
 	return &Leaf{}, ErrNoMatchingLeaf // Return error
@@ -119,21 +142,15 @@ func (leaf *Leaf) GetDirectChildByHash(hash common.Hash) (*Leaf, error) {
 // GetChildrenBySender queries the leaf's children for a particular sender.
 // If a transaction with the corresponding sender does not exist, an error is returned.
 func (leaf *Leaf) GetChildrenBySender(sender common.Address) ([]*Leaf, error) {
-	children, _ := leaf.GetDirectChildrenBySender(sender) // Get children
-
-	for i, child := range leaf.Children { // Iterate through children
-		matches, err := child.GetChildrenBySender(sender) // Get child
+	children := leaf.findChildren(func(child *Leaf) bool {
+		return child.Transaction.Sender != nil && bytes.Equal(child.Transaction.Sender[:], sender[:]) // Check for match
+	}) // Get children
 
-		if err != nil && i == len(leaf.Children)-1 && (children == nil || len(children) == 0) { // Check for errors
-			return []*Leaf{}, err // Return found error
-		}
-
-		if matches != nil && len(matches) != 0 { // Check for match
-			children = append(children, matches...) // Append matches
-		}
+	if len(children) == 0 { // Check no children
+		return []*Leaf{}, ErrNoMatchingLeaf // Return error
 	}
 
-	return children, nil // Return error
+	return children, nil // Return children
 }
 
 // GetDirectChildrenBySender searches the set of immediate children for the
@@ -160,21 +177,15 @@ func (leaf *Leaf) GetDirectChildrenBySender(sender common.Address) ([]*Leaf, err
 // GetChildrenByRecipient queries the leaf's children for a particular recipient.
 // If a transaction with the corresponding recipient does not exist, an error is returned.
 func (leaf *Leaf) GetChildrenByRecipient(recipient common.Address) ([]*Leaf, error) {
-	children, _ := leaf.GetDirectChildrenByRecipient(recipient) // Get children
-
-	for i, child := range leaf.Children { // Iterate through children
-		matches, err := child.GetChildrenByRecipient(recipient) // Get child
-
-		if err != nil && i == len(leaf.Children)-1 && (children == nil || len(children) == 0) { // Check for errors
-			return []*Leaf{}, err // Return found error
-		}
+	children := leaf.findChildren(func(child *Leaf) bool {
+		return child.Transaction.Recipient != nil && bytes.Equal(child.Transaction.Recipient[:], recipient[:]) // Check for match
+	}) // Get children
 
-		if matches != nil && len(matches) != 0 { // Check for match
-			children = append(children, matches...) // Append matches
-		}
+	if len(children) == 0 { // Check no children
+		return []*Leaf{}, ErrNoMatchingLeaf // Return error
 	}
 
-	return children, nil // Return error
+	return children, nil // Return children
 }
 
 // GetDirectChildrenByRecipient searches the set of immediate children for the
@@ -184,7 +195,7 @@ func (leaf *Leaf) GetDirectChildrenByRecipient(recipient common.Address) ([]*Lea
 	matchingChildren := []*Leaf{} // Init buffer
 
 	for _, child := range leaf.Children { // Iterate through children
-		if child.Transaction.Sender != nil { // Check has sender
+		if child.Transaction.Recipient != nil { // Check has recipient
 			if bytes.Equal(child.Transaction.Recipient[:], recipient[:]) { // Check for match
 				matchingChildren = append(matchingChildren, child) // Append child
 			}
@@ -201,39 +212,28 @@ func (leaf *Leaf) GetDirectChildrenByRecipient(recipient common.Address) ([]*Lea
 // GetNextCommonLeaf implements the functionality of the DAG
 // QueryNextCommonLeaf method.
 func (leaf *Leaf) GetNextCommonLeaf() (*Leaf, error) {
-	if child, err := leaf.GetOnlyChild(); err == nil { // Check has child
-		return child, nil // Return child
-	}
+	var commonLeaf *Leaf // Init common leaf buffer
 
-	for i, child := range leaf.Children { // Iterate through children
-		commonLeaf, err := child.GetNextCommonLeaf() // Get next common leaf
+	leaf.walk(func(current *Leaf) bool {
+		if child, err := current.GetOnlyChild(); err == nil { // Check has only child
+			commonLeaf = child // Set common leaf
 
-		if err != nil && i == len(leaf.Children)-1 { // Check for errors
-			return &Leaf{}, err // Return found error
+			return false // Stop walking
 		}
 
-		if commonLeaf != nil { // Check has leaf
-			return commonLeaf, nil // Return common leaf
-		}
+		return true // Continue walking
+	}) // Walk leaf and its children
+
+	if commonLeaf == nil { // Check no common leaf
+		return &Leaf{}, ErrNoCommonLeaf // Return error
 	}
 
-	return &Leaf{}, ErrNoCommonLeaf // Return error
+	return commonLeaf, nil // Return common leaf
 }
 
 // GetChildren gets all children of the given leaf.
 func (leaf *Leaf) GetChildren() ([]*Leaf, error) {
-	children := leaf.Children // Get direct children
-
-	for _, child := range children { // Iterate through children
-		children, err := child.GetChildren() // Get children
-		if err != nil {                      // Check for errors
-			return []*Leaf{}, err // Return found error
-		}
-
-		children = append(children, children...) // Append children
-	}
-
-	return children, nil // Return children
+	return leaf.findChildren(func(*Leaf) bool { return true }), nil // Return children
 }
 
 /*
@@ -241,3 +241,51 @@ func (leaf *Leaf) GetChildren() ([]*Leaf, error) {
 */
 
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// findChildren gets all children of the given leaf matching a given condition,
+// in depth-first order. Leaves with several parents are only visited once.
+func (leaf *Leaf) findChildren(matches func(child *Leaf) bool) []*Leaf {
+	children := []*Leaf{} // Init children buffer
+
+	leaf.walk(func(current *Leaf) bool {
+		if current != leaf && matches(current) { // Check is matching child
+			children = append(children, current) // Append child
+		}
+
+		return true // Continue walking
+	}) // Walk leaf and its children
+
+	return children // Return children
+}
+
+// walk visits the given leaf and each of its children in depth-first order,
+// until a given visitor returns false. Leaves with several parents are only
+// visited once. The walk is iterative, so deep dags can't overflow the stack.
+func (leaf *Leaf) walk(visit func(current *Leaf) bool) {
+	visited := make(map[common.Hash]bool) // Init visited leaves buffer
+
+	stack := []*Leaf{leaf} // Init stack of leaves to visit
+
+	for len(stack) > 0 { // Do until all leaves visited
+		current := stack[len(stack)-1] // Get next leaf
+		stack = stack[:len(stack)-1]   // Pop leaf
+
+		if visited[current.Hash] { // Check already visited
+			continue // Continue
+		}
+
+		visited[current.Hash] = true // Set visited
+
+		if !visit(current) { // Check should stop
+			return // Stop walking
+		}
+
+		for i := len(current.Children) - 1; i >= 0; i-- { // Iterate through children in reverse (visiting the first child first)
+			stack = append(stack, current.Children[i]) // Push child
+		}
+	}
+}
+
+/* END INTERNAL METHODS */
@@ -5,7 +5,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-	"math/big"
 	"testing"
 
 	"github.com/SummerCash/go-summercash/common"
@@ -26,8 +25,8 @@ func TestNewLeaf(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                     // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -53,8 +52,8 @@ func TestIsOnlyChild(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                     // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -93,8 +92,8 @@ func TestGetOnlyChild(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                     // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -132,8 +131,8 @@ func TestGetChildByHash(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                     // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -143,8 +142,8 @@ func TestGetChildByHash(t *testing.T) {
 	}
 
 	for i := 0; i < 3; i++ { // Add children
-		transaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-		if err != nil {                                                                                               // Check for errors
+		transaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                     // Check for errors
 			t.Fatal(err) // Panic
 		}
 
@@ -178,8 +177,8 @@ func TestGetNextCommonLeaf(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	transaction, err := types.NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                     // Check for errors
+	transaction, err := types.NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
@@ -189,8 +188,8 @@ func TestGetNextCommonLeaf(t *testing.T) {
 	}
 
 	for i := 0; i < 3; i++ { // Add children
-		transaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-		if err != nil {                                                                                               // Check for errors
+		transaction, err := types.NewTransaction(uint64(i+1), nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                     // Check for errors
 			t.Fatal(err) // Panic
 		}
 
@@ -211,8 +210,8 @@ func TestGetNextCommonLeaf(t *testing.T) {
 		}
 	}
 
-	newTransaction, err := types.NewTransaction(4, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                        // Check for errors
+	newTransaction, err := types.NewTransaction(4, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                              // Check for errors
 		t.Fatal(err) // Panic
 	}
 
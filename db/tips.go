@@ -0,0 +1,249 @@
+// Package db defines the standard go-summercash transaction database.
+package db
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+const (
+	// DefaultTipCount is the number of tips approved by each new transaction.
+	DefaultTipCount = 2
+
+	// DefaultWalkAlpha is the default bias of the tip selection random walk towards heavier children. An alpha of
+	// zero results in an unweighted random walk, whereas a large alpha always follows the heaviest child.
+	DefaultWalkAlpha = 0.5
+
+	// walkWindow is the number of most recently added leaves tip selection walks pass through.
+	walkWindow = 1000
+)
+
+// tipWalk holds the state shared by the tip selection walks of a single
+// SelectTips call.
+type tipWalk struct {
+	starts  []*Leaf             // Oldest leaves in the window (leaves without parents in the window), excluding orphaned leaves
+	weights map[common.Hash]int // Cumulative weights of the leaves in the window
+
+	orphaned map[common.Hash]bool // Orphaned leaves
+	fallback *Leaf                // Leaf returned if there are no starting leaves
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// CumulativeWeight calculates the cumulative weight of the leaf: the number of
+// leaves that directly or indirectly approve it, plus one for the leaf itself.
+func (leaf *Leaf) CumulativeWeight() int {
+	visited := map[common.Hash]bool{leaf.Hash: true} // Init visited leaves buffer
+
+	queue := append([]*Leaf{}, leaf.Children...) // Init queue of approving leaves
+
+	for len(queue) > 0 { // Do until all approvers counted
+		child := queue[0] // Get next leaf
+		queue = queue[1:] // Pop leaf
+
+		if visited[child.Hash] { // Check already counted
+			continue // Continue
+		}
+
+		visited[child.Hash] = true // Set visited
+
+		queue = append(queue, child.Children...) // Queue child's approvers
+	}
+
+	return len(visited) // Return weight
+}
+
+// IsTip checks whether or not the leaf is a tip (a leaf that no other leaf
+// approves yet).
+func (leaf *Leaf) IsTip() bool {
+	return len(leaf.Children) == 0 // Return has no children
+}
+
+// SelectTips selects up to a given number of distinct tips for a new
+// transaction to approve, with weighted random walks through the most
+// recently added leaves (see SelectTip). Fewer tips are returned if the walks
+// keep arriving at the same tips (e.g. because the dag has fewer tips than
+// requested).
+func (dag *Dag) SelectTips(n int, alpha float64) ([]*Leaf, error) {
+	if dag.Root == nil { // Check no root
+		return []*Leaf{}, ErrNilDag // Return error
+	}
+
+	walk := dag.newTipWalk() // Calculate walk weights once for every walk
+
+	tips := []*Leaf{}                      // Init tips buffer
+	selected := make(map[common.Hash]bool) // Init selected tips buffer
+
+	for walks := 0; len(tips) < n && walks < 4*n; walks++ { // Walk until enough tips found, giving up on repeated tips
+		tip := walk.selectTip(alpha) // Select tip
+
+		if selected[tip.Hash] { // Check already selected
+			continue // Continue
+		}
+
+		selected[tip.Hash] = true // Set selected
+
+		tips = append(tips, tip) // Append tip
+	}
+
+	return tips, nil // Return tips
+}
+
+// SelectTip performs a random walk to a tip, moving from each leaf to one of
+// its children with a probability proportional to exp(alpha * cumulative
+// weight of the child). Walks therefore favour branches that are approved by
+// most of the network, so that tips left on lazy branches are rarely
+// approved. Walks never step onto a branch orphaned by the resolution of a
+// conflict (see Conflict).
+//
+// So that the cost of a walk doesn't grow with the dag, walks only pass
+// through the walkWindow most recently added leaves: each walk starts at one
+// of the oldest leaves in the window (the dag root, in a dag with fewer
+// leaves), chosen the same way as each step. Tips older than the window are
+// never selected.
+func (dag *Dag) SelectTip(alpha float64) *Leaf {
+	return dag.newTipWalk().selectTip(alpha) // Return tip
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newTipWalk initializes a tip walk through the walkWindow most recently added
+// leaves of the dag, calculating the cumulative weight of each leaf in the
+// window. As every leaf approving a leaf in the window was added after it,
+// these weights are exact.
+func (dag *Dag) newTipWalk() *tipWalk {
+	index := dag.getIndex() // Get leaf index
+
+	first := len(index.leaves) - walkWindow // Get index of oldest leaf in window
+
+	if first < 0 { // Check dag smaller than window
+		first = 0 // Walk through whole dag
+	}
+
+	window := index.leaves[first:] // Get window
+
+	walk := &tipWalk{
+		weights:  make(map[common.Hash]int, len(window)), // Init weights
+		orphaned: dag.getOrphaned(),                      // Set orphaned leaves
+		fallback: dag.Root,                               // Set fallback
+	} // Init walk
+
+	positions := make(map[common.Hash]int, len(window)) // Init window positions
+
+	for i, leaf := range window { // Iterate through window
+		positions[leaf.Hash] = i // Set position
+	}
+
+	words := (len(window) + 63) / 64 // Get approver set size
+
+	approvers := make([][]uint64, len(window)) // Init approver sets (by window position)
+
+	for i := len(window) - 1; i >= 0; i-- { // Iterate through window, children first
+		approvers[i] = make([]uint64, words)      // Init approver set
+		approvers[i][i/64] |= 1 << (uint(i) % 64) // Count leaf itself
+
+		for _, child := range window[i].Children { // Iterate through children
+			j, ok := positions[child.Hash] // Get child position
+
+			if !ok { // Check not in window
+				continue // Continue
+			}
+
+			for word := range approvers[i] { // Iterate through words
+				approvers[i][word] |= approvers[j][word] // Add child's approvers
+			}
+		}
+
+		weight := 0 // Init weight
+
+		for _, word := range approvers[i] { // Iterate through words
+			weight += bits.OnesCount64(word) // Count approvers
+		}
+
+		walk.weights[window[i].Hash] = weight // Set weight
+	}
+
+	for _, leaf := range window { // Iterate through window
+		if walk.orphaned[leaf.Hash] { // Check orphaned
+			continue // Continue
+		}
+
+		start := true // Init is start
+
+		for _, parent := range leaf.Parents { // Iterate through parents
+			if _, ok := positions[parent.Hash]; ok { // Check parent in window
+				start = false // Not start
+			}
+		}
+
+		if start { // Check no parents in window
+			walk.starts = append(walk.starts, leaf) // Append start
+		}
+	}
+
+	return walk // Return walk
+}
+
+// selectTip performs a single weighted random walk to a tip.
+func (walk *tipWalk) selectTip(alpha float64) *Leaf {
+	if len(walk.starts) == 0 { // Check nowhere to start
+		return walk.fallback // Return fallback
+	}
+
+	leaf := walk.starts[walk.weightedLeaf(walk.starts, alpha)] // Choose start
+
+	for { // Walk until a tip is reached
+		children := []*Leaf{} // Init children buffer
+
+		for _, child := range leaf.Children { // Iterate through children
+			if _, ok := walk.weights[child.Hash]; ok && !walk.orphaned[child.Hash] { // Check in window and not orphaned
+				children = append(children, child) // Append child
+			}
+		}
+
+		if len(children) == 0 { // Check is tip
+			return leaf // Return tip
+		}
+
+		leaf = children[walk.weightedLeaf(children, alpha)] // Step to child
+	}
+}
+
+// weightedLeaf selects the index of one of a given set of leaves, with a
+// probability proportional to exp(alpha * cumulative weight of the leaf).
+func (walk *tipWalk) weightedLeaf(leaves []*Leaf, alpha float64) int {
+	weights := make([]float64, len(leaves)) // Init weights buffer
+	maxWeight := 0                          // Init heaviest weight buffer
+
+	for _, leaf := range leaves { // Iterate through leaves
+		if walk.weights[leaf.Hash] > maxWeight { // Check heaviest
+			maxWeight = walk.weights[leaf.Hash] // Set heaviest
+		}
+	}
+
+	total := 0.0 // Init total weight
+
+	for i, leaf := range leaves { // Iterate through leaves
+		weights[i] = math.Exp(alpha * float64(walk.weights[leaf.Hash]-maxWeight)) // Calculate relative transition weight (relative to the heaviest leaf to avoid overflow)
+		total += weights[i]                                                       // Add to total
+	}
+
+	target := rand.Float64() * total // Pick point in total weight
+
+	for i, weight := range weights { // Iterate through weights
+		if target < weight { // Check point is within leaf's weight
+			return i // Return leaf
+		}
+
+		target -= weight // Move to next leaf
+	}
+
+	return len(leaves) - 1 // Return last leaf (floating point rounding)
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,129 @@
+// Package db defines the standard go-summercash transaction database.
+package db
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestSelectTips tests the functionality of the SelectTips helper method.
+func TestSelectTips(t *testing.T) {
+	rand.Seed(1) // Seed walks
+
+	root := newTestTransactionChain(t, 1)[0] // Initialize root transaction
+
+	dag := NewDag() // Initialize dag
+
+	if _, err := dag.SelectTips(DefaultTipCount, DefaultWalkAlpha); err != ErrNilDag { // Check empty dag has no tips
+		t.Fatalf("expected nil dag, got %v", err) // Panic
+	}
+
+	err := dag.AddTransaction(root) // Add root
+	if err != nil {                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	children := []*types.Transaction{} // Init children buffer
+
+	for i := 0; i < 2; i++ { // Make children of root
+		child, err := types.NewTransaction(uint64(i+1), root, root.Recipient, &common.Address{byte(i + 2)}, common.Coins(1), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                                      // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		err = dag.AddTransaction(child) // Add child
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		children = append(children, child) // Append child
+	}
+
+	tips, err := dag.SelectTips(DefaultTipCount, DefaultWalkAlpha) // Select tips
+	if err != nil {                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(tips) != 2 || tips[0].Hash == tips[1].Hash { // Check both children selected
+		t.Fatalf("expected 2 distinct tips; found %d", len(tips)) // Panic
+	}
+
+	approver, err := types.NewTransaction(0, children[0], children[0].Recipient, &common.Address{0x4}, common.Coins(1), []byte("test")) // Initialize approving transaction
+	if err != nil {                                                                                                                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = approver.ApproveTips([]common.Hash{*children[0].Hash, *children[1].Hash}) // Approve both tips
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = dag.AddTransaction(approver) // Add approving transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	tips, err = dag.SelectTips(DefaultTipCount, DefaultWalkAlpha) // Select tips
+	if err != nil {                                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(tips) != 1 || tips[0].Hash != *approver.Hash { // Check only approver is a tip
+		t.Fatalf("expected approving transaction to be the only tip; found %d tips", len(tips)) // Panic
+	}
+
+	if weight := dag.Root.CumulativeWeight(); weight != 4 { // Check approver only counted once
+		t.Fatalf("expected root weight 4; found %d", weight) // Panic
+	}
+
+	flattened, err := dag.Flatten() // Flatten dag
+	if err != nil {                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	unflattened, err := UnflattenDag(flattened) // Unflatten dag
+	if err != nil {                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	leaf, err := unflattened.QueryLeafWithHash(*approver.Hash) // Query approving leaf
+	if err != nil {                                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(leaf.Parents) != 2 { // Check approved tips survived flattening
+		t.Fatalf("expected 2 parents; found %d", len(leaf.Parents)) // Panic
+	}
+}
+
+// TestSelectTipsWindow tests that tips are still found in a dag with more
+// leaves than tip selection walks pass through.
+func TestSelectTipsWindow(t *testing.T) {
+	dag := NewDag() // Initialize dag
+
+	for i := 0; i < 2*walkWindow+10; i++ { // Add leaves
+		err := dag.AddTransaction(newBenchmarkTransaction(i)) // Add transaction
+		if err != nil {                                       // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	tips, err := dag.SelectTips(DefaultTipCount, DefaultWalkAlpha) // Select tips
+	if err != nil {                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(tips) != 1 || tips[0].Hash != benchmarkHash(2*walkWindow+9) { // Check only tip found
+		t.Fatalf("expected latest leaf to be the only tip; found %d tips", len(tips)) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
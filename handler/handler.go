@@ -3,7 +3,6 @@ package handler
 import (
 	"errors"
 	"net"
-	"strings"
 
 	"github.com/SummerCash/go-summercash/common"
 	"github.com/SummerCash/go-summercash/config"
@@ -54,6 +53,29 @@ func handleConnection(conn net.Conn, isArchival bool) error {
 		return ErrInvalidConnectionHeader // Return found error
 	}
 
+	if data[0] == types.TransactionEncodingVersion || data[0] == types.MultiParentTransactionEncodingVersion { // Check transaction
+		transaction, err := types.TransactionFromBytes(data) // Decode transaction
+		if err != nil {                                      // Check for errors
+			return err // Return found error
+		}
+
+		if transaction.ContractCreation { // Check is contract creation
+			common.Logf("== NETWORK == received contract creation from peer %s\n", conn.RemoteAddr().String()) // Log tx
+
+			err = types.HandleReceivedContractCreation(data) // Handle received data
+		} else {
+			common.Logf("== NETWORK == received transaction from peer %s\n", conn.RemoteAddr().String()) // Log tx
+
+			err = types.HandleReceivedTransaction(data) // Handle received data
+		}
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+
+		return conn.Close() // Close connection
+	}
+
 	switch string(data)[0:9] { // Handle signatures
 	case "{" + `"` + "address": // Check coordinationNode
 		common.Logf("== NETWORK == received peer coordination node info %s\n", string(data)) // Log node
@@ -77,29 +99,7 @@ func handleConnection(conn net.Conn, isArchival bool) error {
 
 		common.Logf("== NETWORK == responding to chain request from peer %s\n", conn.RemoteAddr().String()) // Log request
 
-		_, err = conn.Write(append(chain.Bytes(), byte('\r'))) // Write chain
-
-		if err != nil { // Check for errors
-			return err // Return found error
-		}
-
-		return conn.Close() // Close connection
-	case "{" + `"` + "nonce" + `"` + ":": // Check transaction
-		if strings.Contains(string(data), `"`+"is-init-contract"+`"`+":true") { // Check is contract creation
-			common.Logf("== NETWORK == received contract creation from peer %s\n", conn.RemoteAddr().String()) // Log tx
-
-			err = types.HandleReceivedContractCreation(data) // Handle received data
-
-			if err != nil { // Check for errors
-				return err // Return found error
-			}
-
-			return conn.Close() // Close connection
-		}
-
-		common.Logf("== NETWORK == received transaction from peer %s\n", conn.RemoteAddr().String()) // Log tx
-
-		err = types.HandleReceivedTransaction(data) // Handle received data
+		err = common.WriteMessage(conn, chain.Bytes()) // Write chain
 
 		if err != nil { // Check for errors
 			return err // Return found error
@@ -118,7 +118,7 @@ func handleConnection(conn net.Conn, isArchival bool) error {
 
 		common.Logf("== NETWORK == responding to coordination chain request from peer %s\n", conn.RemoteAddr().String()) // Log request
 
-		_, err = conn.Write(append(chainBytes, byte('\r'))) // Write chain
+		err = common.WriteMessage(conn, chainBytes) // Write chain
 
 		if err != nil { // Check for errors
 			return err // Return found error
@@ -137,7 +137,7 @@ func handleConnection(conn net.Conn, isArchival bool) error {
 
 		common.Logf("== NETWORK == responding to chain config request from peer %s\n", conn.RemoteAddr().String()) // Log request
 
-		_, err = conn.Write(append(configBytes, byte('\r'))) // Write chain
+		err = common.WriteMessage(conn, configBytes) // Write config
 
 		if err != nil { // Check for errors
 			return err // Return found error
@@ -156,7 +156,7 @@ func handleConnection(conn net.Conn, isArchival bool) error {
 
 		common.Logf("== NETWORK == responding to state request from peer %s\n", conn.RemoteAddr().String()) // Log request
 
-		_, err = conn.Write(append(state, byte('\r'))) // Write chain
+		err = common.WriteMessage(conn, state) // Write state
 
 		if err != nil { // Check for errors
 			return err // Return found error
@@ -2,52 +2,69 @@ package accounts
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/SummerCash/go-summercash/accounts"
 	"github.com/SummerCash/go-summercash/common"
 	accountsProto "github.com/SummerCash/go-summercash/intrnl/rpc/proto/accounts"
+	"github.com/SummerCash/go-summercash/types"
 )
 
 // Server - RPC server
 type Server struct{}
 
-// NewAccount - accounts.NewAccount RPC handler
+// NewAccount - accounts.NewAccount RPC handler. The new account's private key is only written to the encrypted keystore (it can be
+// exported with ReadAccountFromMemory once the account is unlocked).
 func (server *Server) NewAccount(ctx context.Context, req *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	if req.Passphrase == "" { // Check no passphrase (before creating the account's chain)
+		return &accountsProto.GeneralResponse{}, accounts.ErrEmptyPassphrase // Return error
+	}
+
 	account, err := accounts.NewAccount() // Create new account
 	if err != nil {                       // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	err = account.WriteToMemory() // Write to persistent memory
+	err = account.WriteToMemory(req.Passphrase) // Encrypt and write to persistent memory
 
 	if err != nil { // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	marshaledPrivateKey, err := x509.MarshalECPrivateKey(account.PrivateKey) // Marshal private key
-	if err != nil {                                                          // Check for errors
-		return &accountsProto.GeneralResponse{}, err // Return found error
-	}
-
-	pemEncoded := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: marshaledPrivateKey}) // Encode to memory
-
-	stringEncoded, err := common.EncodeString(pemEncoded) // Encode to string
-	if err != nil {                                       // Check for errors
-		return &accountsProto.GeneralResponse{}, err // Return found error
-	}
-
-	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\nAddress: %s, PrivateKey: %s", account.Address.String(), stringEncoded)}, nil // No error occurred, return response
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\nAddress: %s", account.Address.String())}, nil // No error occurred, return response
 }
 
 // NewContractAccount - accounts.NewContractAccount RPC handler
 func (server *Server) NewContractAccount(ctx context.Context, req *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	var abi *types.ABI // Init ABI buffer
+
+	if req.Abi != "" { // Check has ABI
+		abiPath, err := filepath.Abs(filepath.FromSlash(req.Abi)) // Get ABI path
+		if err != nil {                                           // Check for errors
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+
+		encodedABI, err := ioutil.ReadFile(abiPath) // Read ABI descriptor
+		if err != nil {                             // Check for errors
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+
+		abi, err = types.ABIFromBytes(encodedABI) // Decode ABI
+
+		if err != nil { // Check for errors
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+	}
+
 	filepath, err := filepath.Abs(filepath.FromSlash(req.Address)) // Get filepath
 	if err != nil {                                                // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
@@ -63,8 +80,8 @@ func (server *Server) NewContractAccount(ctx context.Context, req *accountsProto
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	contractInstance, err := accounts.NewContractAccount(contractSource, &address) // Deploy from contract source
-	if err != nil {                                                                // Check for errors
+	contractInstance, err := accounts.NewContractAccount(contractSource, abi, &address) // Deploy from contract source
+	if err != nil {                                                                     // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -104,7 +121,7 @@ func (server *Server) AccountFromKey(ctx context.Context, req *accountsProto.Gen
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	err = account.WriteToMemory() // Write to persistent memory
+	err = account.WriteToMemory(req.Passphrase) // Encrypt and write to persistent memory
 
 	if err != nil { // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
@@ -149,8 +166,8 @@ func (server *Server) MakeEncodingSafe(ctx context.Context, req *accountsProto.G
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	account, err := accounts.ReadAccountFromMemory(address) // Read account
-	if err != nil {                                         // Check for errors
+	account, err := accounts.GetUnlockedAccount(address) // Get unlocked account
+	if err != nil {                                      // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -175,8 +192,8 @@ func (server *Server) RecoverSafeEncoding(ctx context.Context, req *accountsProt
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	account, err := accounts.ReadAccountFromMemory(address) // Read account
-	if err != nil {                                         // Check for errors
+	account, err := accounts.GetUnlockedAccount(address) // Get unlocked account
+	if err != nil {                                      // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -201,8 +218,8 @@ func (server *Server) String(ctx context.Context, req *accountsProto.GeneralRequ
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	account, err := accounts.ReadAccountFromMemory(address) // Read account
-	if err != nil {                                         // Check for errors
+	account, err := accounts.GetUnlockedAccount(address) // Get unlocked account
+	if err != nil {                                      // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -216,8 +233,8 @@ func (server *Server) Bytes(ctx context.Context, req *accountsProto.GeneralReque
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	account, err := accounts.ReadAccountFromMemory(address) // Read account
-	if err != nil {                                         // Check for errors
+	account, err := accounts.GetUnlockedAccount(address) // Get unlocked account
+	if err != nil {                                      // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -236,8 +253,8 @@ func (server *Server) ReadAccountFromMemory(ctx context.Context, req *accountsPr
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	account, err := accounts.ReadAccountFromMemory(address) // Read account
-	if err != nil {                                         // Check for errors
+	account, err := accounts.GetUnlockedAccount(address) // Get unlocked account
+	if err != nil {                                      // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -260,3 +277,159 @@ func (server *Server) ReadAccountFromMemory(ctx context.Context, req *accountsPr
 
 	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\nAddress: %s, PrivateKey: %s", account.Address, encoded)}, nil // No error occurred, return response
 }
+
+// Unlock - accounts.Unlock RPC handler
+func (server *Server) Unlock(ctx context.Context, req *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	address, err := common.StringToAddress(req.Address) // Get address
+	if err != nil {                                     // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = accounts.Unlock(address, req.Passphrase, time.Duration(req.Timeout)*time.Second) // Unlock account
+
+	if err != nil { // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	if req.Timeout == 0 { // Check no timeout
+		return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\nunlocked account %s", address.String())}, nil // No error occurred, return response
+	}
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\nunlocked account %s for %d seconds", address.String(), req.Timeout)}, nil // No error occurred, return response
+}
+
+// Lock - accounts.Lock RPC handler
+func (server *Server) Lock(ctx context.Context, req *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	address, err := common.StringToAddress(req.Address) // Get address
+	if err != nil {                                     // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	accounts.Lock(address) // Lock account
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\nlocked account %s", address.String())}, nil // No error occurred, return response
+}
+
+// NewWalletFromMnemonic - accounts.NewWalletFromMnemonic RPC handler
+func (server *Server) NewWalletFromMnemonic(ctx context.Context, req *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	if accounts.HasWallet() { // Check already has wallet
+		return &accountsProto.GeneralResponse{}, accounts.ErrWalletExists // Return error
+	}
+
+	mnemonic := req.Mnemonic // Get mnemonic
+
+	if mnemonic == "" { // Check must generate mnemonic
+		generated, err := accounts.NewMnemonic(256) // Generate mnemonic
+		if err != nil {                             // Check for errors
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+
+		mnemonic = generated // Set mnemonic
+	}
+
+	wallet, err := accounts.WalletFromMnemonic(mnemonic) // Init wallet
+	if err != nil {                                      // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	restored, err := wallet.Restore(req.Passphrase) // Restore accounts
+	if err != nil {                                 // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = wallet.WriteToMemory(req.Passphrase) // Write wallet to persistent memory
+
+	if err != nil { // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	addresses := []string{} // Init addresses buffer
+
+	for _, account := range restored { // Iterate through restored accounts
+		addresses = append(addresses, account.Address.String()) // Append address
+	}
+
+	if req.Mnemonic == "" { // Check generated mnemonic
+		return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\nMnemonic: %s (keep this phrase safe; it restores every account in the wallet)\nAddress: %s", mnemonic, strings.Join(addresses, ", "))}, nil // No error occurred, return response
+	}
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\nrestored accounts: %s", strings.Join(addresses, ", "))}, nil // No error occurred, return response
+}
+
+// DeriveAccount - accounts.DeriveAccount RPC handler
+func (server *Server) DeriveAccount(ctx context.Context, req *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	wallet, err := accounts.ReadWalletFromMemory(req.Passphrase) // Read wallet
+	if err != nil {                                              // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	account, err := wallet.NextAccount() // Derive account
+	if err != nil {                      // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = account.WriteToMemory(req.Passphrase) // Encrypt and write to persistent memory
+
+	if err != nil { // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = wallet.WriteToMemory(req.Passphrase) // Persist next index
+
+	if err != nil { // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\nAddress: %s (account %d)", account.Address.String(), wallet.NextIndex-1)}, nil // No error occurred, return response
+}
+
+// PublicKey - accounts.PublicKey RPC handler
+func (server *Server) PublicKey(ctx context.Context, req *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	address, err := common.StringToAddress(req.Address) // Get address
+	if err != nil {                                     // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	account, err := accounts.GetUnlockedAccount(address) // Get unlocked account
+	if err != nil {                                      // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	encoded, err := x509.MarshalPKIXPublicKey(&account.PrivateKey.PublicKey) // Encode public key
+	if err != nil {                                                          // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\n%s", hex.EncodeToString(encoded))}, nil // No error occurred, return response
+}
+
+// NewMultisigAccount - accounts.NewMultisigAccount RPC handler
+func (server *Server) NewMultisigAccount(ctx context.Context, req *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	publicKeys := []*ecdsa.PublicKey{} // Init public keys buffer
+
+	for _, encodedPublicKey := range req.PublicKeys { // Iterate through public keys
+		decoded, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(encodedPublicKey), "0x")) // Decode public key
+		if err != nil {                                                                                 // Check for errors
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+
+		genericPublicKey, err := x509.ParsePKIXPublicKey(decoded) // Parse public key
+		if err != nil {                                           // Check for errors
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+
+		publicKey, ok := genericPublicKey.(*ecdsa.PublicKey) // Get public key value
+		if !ok {                                             // Check not ecdsa
+			return &accountsProto.GeneralResponse{}, types.ErrInvalidPublicKey // Return error
+		}
+
+		publicKeys = append(publicKeys, publicKey) // Append public key
+	}
+
+	policy, err := accounts.NewMultisigAccount(req.Threshold, publicKeys) // Create multisig account
+	if err != nil {                                                       // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("\nAddress: %s (%d-of-%d multisig)", policy.Address().String(), policy.Threshold, len(policy.PublicKeys))}, nil // No error occurred, return response
+}
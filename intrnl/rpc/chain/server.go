@@ -2,12 +2,14 @@ package chain
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
-	"io/ioutil"
-	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/config"
+	"github.com/SummerCash/go-summercash/db"
 	chainProto "github.com/SummerCash/go-summercash/intrnl/rpc/proto/chain"
 	"github.com/SummerCash/go-summercash/types"
 )
@@ -29,7 +31,7 @@ func (server *Server) GetBalance(ctx context.Context, req *chainProto.GeneralReq
 
 	balance := chain.CalculateBalance() // Get balance
 
-	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\nbalance: %f", balance)}, nil // Return response
+	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\nbalance: %s", balance.String())}, nil // Return response
 }
 
 // Bytes - chain.Bytes RPC handler
@@ -84,34 +86,22 @@ func (server *Server) ReadChainFromMemory(ctx context.Context, req *chainProto.G
 
 // QueryTransaction - chain.QueryTransaction RPC handler
 func (server *Server) QueryTransaction(ctx context.Context, req *chainProto.GeneralRequest) (*chainProto.GeneralResponse, error) {
-	files, err := ioutil.ReadDir(filepath.FromSlash(fmt.Sprintf("%s/db/chain", common.DataDir))) // Walk chain dir
-	if err != nil {                                                                              // Check for errors
-		return &chainProto.GeneralResponse{}, err // Return found error
-	}
-
 	hash, err := common.StringToHash(req.Address) // Get hash value
 	if err != nil {                               // Check for errors
 		return &chainProto.GeneralResponse{}, err // Return found error
 	}
 
-	for _, file := range files { // Iterate through files
-		address, err := common.StringToAddress(strings.Split(strings.Split(file.Name(), "chain_")[1], ".json")[0]) // Get address value
-
-		if err == nil { // Check for success
-			chain, err := types.ReadChainFromMemory(address) // Read chain
-
-			if err == nil { // Check successfully read
-				transaction, err := chain.QueryTransaction(hash) // Query for transaction
-				if err != nil {                                  // Check for errors
-					continue
-				}
+	store, err := types.GetChainStore() // Get working chain store
+	if err != nil {                     // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
 
-				return &chainProto.GeneralResponse{Message: fmt.Sprintf("\n%s", transaction.String())}, nil // Return response
-			}
-		}
+	transaction, err := store.QueryTransactionByHash(hash) // Query for transaction
+	if err != nil {                                        // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
 	}
 
-	return &chainProto.GeneralResponse{}, types.ErrNilTransaction // Return error
+	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\n%s", transaction.String())}, nil // Return response
 }
 
 // GetNumTransactions - get total number of transactions in given account chain
@@ -134,3 +124,233 @@ func (server *Server) GetNumTransactions(ctx context.Context, req *chainProto.Ge
 
 	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\n%d", numTx)}, nil // Return response
 }
+
+// GetTransactionByHash - chain.GetTransactionByHash RPC handler
+func (server *Server) GetTransactionByHash(ctx context.Context, req *chainProto.GeneralRequest) (*chainProto.GeneralResponse, error) {
+	hash, err := common.StringToHash(req.Address) // Get hash value
+	if err != nil {                               // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	transaction, locations, err := types.QueryTransactionByHash(hash) // Query global transaction index
+	if err != nil {                                                   // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	locationStrings := []string{} // Init location buffer
+
+	for _, location := range locations { // Iterate through locations
+		locationStrings = append(locationStrings, location.String()) // Append location
+	}
+
+	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\n%s\n\nfound in: \n%s", transaction.String(), strings.Join(locationStrings, "\n"))}, nil // Return response
+}
+
+// GetStateProof - chain.GetStateProof RPC handler
+func (server *Server) GetStateProof(ctx context.Context, req *chainProto.GeneralRequest) (*chainProto.GeneralResponse, error) {
+	address, err := common.StringToAddress(req.Address) // Get address primitive value
+	if err != nil {                                     // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	chain, err := types.ReadChainFromMemory(address) // Read chain from persistent memory
+	if err != nil {                                  // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	proof, err := chain.ProveState(req.Key) // Prove key
+	if err != nil {                         // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	nodes := []string{} // Init node buffer
+
+	for _, node := range proof.Nodes { // Iterate through proof nodes
+		nodes = append(nodes, hex.EncodeToString(node)) // Append node
+	}
+
+	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\nroot: %s\nvalue: %s\nproof: \n%s", proof.Root.String(), hex.EncodeToString(proof.Value), strings.Join(nodes, "\n"))}, nil // Return response
+}
+
+// CallContract - chain.CallContract RPC handler
+func (server *Server) CallContract(ctx context.Context, req *chainProto.GeneralRequest) (*chainProto.GeneralResponse, error) {
+	address, err := common.StringToAddress(req.Address) // Get address primitive value
+	if err != nil {                                     // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	var sender *common.Address // Init sender buffer
+
+	if req.Sender != "" { // Check has sender
+		senderAddress, err := common.StringToAddress(req.Sender) // Get sender address value
+		if err != nil {                                          // Check for errors
+			return &chainProto.GeneralResponse{}, err // Return found error
+		}
+
+		sender = &senderAddress // Set sender
+	}
+
+	chain, err := types.ReadChainFromMemory(address) // Read chain from persistent memory
+	if err != nil {                                  // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	result, err := chain.CallContract(sender, req.Payload, req.GasLimit) // Call contract
+	if err != nil {                                                      // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\n%s", result.String())}, nil // Return response
+}
+
+// GetLogs - chain.GetLogs RPC handler
+func (server *Server) GetLogs(ctx context.Context, req *chainProto.GeneralRequest) (*chainProto.GeneralResponse, error) {
+	filter, err := newLogFilter(req.Address, req.Topics, req.Since, req.Until) // Init filter
+	if err != nil {                                                            // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	logs, err := types.QueryLogs(filter) // Query logs
+	if err != nil {                      // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	logStrings := []string{} // Init log buffer
+
+	for _, log := range logs { // Iterate through logs
+		logStrings = append(logStrings, log.String()) // Append log
+	}
+
+	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\n[%s]", strings.Join(logStrings, ", "))}, nil // Return response
+}
+
+// GetFinality - chain.GetFinality RPC handler
+func (server *Server) GetFinality(ctx context.Context, req *chainProto.GeneralRequest) (*chainProto.GeneralResponse, error) {
+	hash, err := common.StringToHash(req.Address) // Get hash value
+	if err != nil {                               // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	if _, _, err := types.QueryTransactionByHash(hash); err != nil { // Check unknown transaction
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	chainConfig, err := config.ReadChainConfigFromMemory() // Read chain config
+	if err != nil {                                        // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	finality, err := types.GetFinality(hash, chainConfig) // Get finality
+	if err != nil {                                       // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\n%s", finality.String())}, nil // Return response
+}
+
+// GetConflicts - chain.GetConflicts RPC handler
+func (server *Server) GetConflicts(ctx context.Context, req *chainProto.GeneralRequest) (*chainProto.GeneralResponse, error) {
+	var sender *common.Address // Init sender buffer
+
+	if req.Address != "" { // Check has sender
+		address, err := common.StringToAddress(req.Address) // Get address primitive value
+		if err != nil {                                     // Check for errors
+			return &chainProto.GeneralResponse{}, err // Return found error
+		}
+
+		sender = &address // Set sender
+	}
+
+	conflictStrings := []string{} // Init conflict strings buffer
+
+	for _, conflict := range db.GetWorkingDagConflicts() { // Iterate through conflicts
+		if sender == nil || conflict.Sender == *sender { // Check matches sender
+			conflictStrings = append(conflictStrings, conflict.String()) // Append conflict
+		}
+	}
+
+	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\n[%s]", strings.Join(conflictStrings, ", "))}, nil // Return response
+}
+
+// ExportDag - chain.ExportDag RPC handler
+func (server *Server) ExportDag(ctx context.Context, req *chainProto.GeneralRequest) (*chainProto.GeneralResponse, error) {
+	filter, err := newGraphFilter(req.Address, req.Hash, req.Depth, req.Since, req.Until) // Init filter
+	if err != nil {                                                                       // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	graph, err := db.ExportWorkingDagGraph(filter) // Export graph
+	if err != nil {                                // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	encoded, err := graph.Encode(req.Format) // Encode graph
+	if err != nil {                          // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\n%s", encoded)}, nil // Return response
+}
+
+// ExportChain - chain.ExportChain RPC handler
+func (server *Server) ExportChain(ctx context.Context, req *chainProto.GeneralRequest) (*chainProto.GeneralResponse, error) {
+	address, err := common.StringToAddress(req.Address) // Get address primitive value
+	if err != nil {                                     // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	filter, err := newGraphFilter("", req.Hash, req.Depth, req.Since, req.Until) // Init filter
+	if err != nil {                                                              // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	chain, err := types.ReadChainFromMemory(address) // Read chain from persistent memory
+	if err != nil {                                  // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	graph, err := db.ExportChainGraph(chain, filter, db.GetWorkingDagConflicts()) // Export graph
+	if err != nil {                                                               // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	encoded, err := graph.Encode(req.Format) // Encode graph
+	if err != nil {                          // Check for errors
+		return &chainProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &chainProto.GeneralResponse{Message: fmt.Sprintf("\n%s", encoded)}, nil // Return response
+}
+
+// newGraphFilter - initialize a graph export filter from a given address, starting hash, depth and time range
+func newGraphFilter(address string, hash string, depth uint32, since int64, until int64) (*db.GraphFilter, error) {
+	filter := &db.GraphFilter{Depth: int(depth)} // Init filter
+
+	if address != "" { // Check has address
+		addressValue, err := common.StringToAddress(address) // Get address primitive value
+		if err != nil {                                      // Check for errors
+			return &db.GraphFilter{}, err // Return found error
+		}
+
+		filter.Address = &addressValue // Set address
+	}
+
+	if hash != "" { // Check has starting hash
+		hashValue, err := common.StringToHash(hash) // Get hash primitive value
+		if err != nil {                             // Check for errors
+			return &db.GraphFilter{}, err // Return found error
+		}
+
+		filter.From = &hashValue // Set starting hash
+	}
+
+	if since != 0 { // Check has start
+		filter.Since = time.Unix(since, 0) // Set start
+	}
+
+	if until != 0 { // Check has end
+		filter.Until = time.Unix(until, 0) // Set end
+	}
+
+	return filter, nil // Return filter
+}
@@ -0,0 +1,172 @@
+package chain
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/db"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+const (
+	// LogSubscriptionPath is the path of the contract log subscription endpoint.
+	LogSubscriptionPath = "/subscribe/logs"
+
+	// ConflictSubscriptionPath is the path of the double spend subscription endpoint.
+	ConflictSubscriptionPath = "/subscribe/conflicts"
+)
+
+// LogSubscriptionHandler streams contract logs to HTTP clients as they are committed. A subscription is opened with a GET
+// request to LogSubscriptionPath, filtered by the optional contract, topic (repeatable), since and until (unix timestamp) query
+// parameters; matching logs are written as newline-delimited JSON until the client disconnects.
+type LogSubscriptionHandler struct{}
+
+// ConflictSubscriptionHandler streams conflicts (double spends) to HTTP clients as they are detected in the working dag, e.g. so
+// that exchanges can pause deposits from the sending account. A subscription is opened with a GET request to
+// ConflictSubscriptionPath, optionally filtered by the sender query parameter; matching conflicts are written as newline-delimited
+// JSON until the client disconnects.
+type ConflictSubscriptionHandler struct{}
+
+/* BEGIN EXPORTED METHODS */
+
+// ServeHTTP serves a single log subscription.
+func (handler *LogSubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher) // Get flusher
+
+	if !ok { // Check can't stream
+		http.Error(w, "streaming not supported", http.StatusInternalServerError) // Write error
+
+		return // Return
+	}
+
+	query := r.URL.Query() // Get query
+
+	since, _ := strconv.ParseInt(query.Get("since"), 10, 64) // Parse since
+	until, _ := strconv.ParseInt(query.Get("until"), 10, 64) // Parse until
+
+	filter, err := newLogFilter(query.Get("contract"), query["topic"], since, until) // Init filter
+	if err != nil {                                                                  // Check for errors
+		http.Error(w, err.Error(), http.StatusBadRequest) // Write error
+
+		return // Return
+	}
+
+	subscription := types.SubscribeLogs(filter) // Subscribe
+	defer subscription.Unsubscribe()            // Unsubscribe
+
+	w.Header().Set("Content-Type", "application/x-ndjson") // Set content type
+	w.WriteHeader(http.StatusOK)                           // Write header
+	flusher.Flush()                                        // Flush header
+
+	encoder := json.NewEncoder(w) // Init encoder
+
+	for {
+		select {
+		case log := <-subscription.Logs:
+			err = encoder.Encode(log) // Write log
+
+			if err != nil { // Check for errors
+				return // Client disconnected
+			}
+
+			flusher.Flush() // Flush log
+		case <-r.Context().Done():
+			return // Client disconnected
+		}
+	}
+}
+
+// ServeHTTP serves a single conflict subscription.
+func (handler *ConflictSubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher) // Get flusher
+
+	if !ok { // Check can't stream
+		http.Error(w, "streaming not supported", http.StatusInternalServerError) // Write error
+
+		return // Return
+	}
+
+	var sender *common.Address // Init sender buffer
+
+	if senderString := r.URL.Query().Get("sender"); senderString != "" { // Check has sender
+		address, err := common.StringToAddress(senderString) // Get address primitive value
+		if err != nil {                                      // Check for errors
+			http.Error(w, err.Error(), http.StatusBadRequest) // Write error
+
+			return // Return
+		}
+
+		sender = &address // Set sender
+	}
+
+	subscription := db.SubscribeConflicts() // Subscribe
+	defer subscription.Unsubscribe()        // Unsubscribe
+
+	w.Header().Set("Content-Type", "application/x-ndjson") // Set content type
+	w.WriteHeader(http.StatusOK)                           // Write header
+	flusher.Flush()                                        // Flush header
+
+	encoder := json.NewEncoder(w) // Init encoder
+
+	for {
+		select {
+		case conflict := <-subscription.Conflicts:
+			if sender != nil && conflict.Sender != *sender { // Check no match
+				continue // Continue
+			}
+
+			err := encoder.Encode(conflict) // Write conflict
+
+			if err != nil { // Check for errors
+				return // Client disconnected
+			}
+
+			flusher.Flush() // Flush conflict
+		case <-r.Context().Done():
+			return // Client disconnected
+		}
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newLogFilter initializes a log filter from a given contract address (empty for any contract), topics, and unix time range
+// (0 if unbounded).
+func newLogFilter(contract string, topics []string, since int64, until int64) (*types.LogFilter, error) {
+	filter := &types.LogFilter{} // Init filter
+
+	if contract != "" { // Check has contract
+		address, err := common.StringToAddress(contract) // Get address primitive value
+		if err != nil {                                  // Check for errors
+			return &types.LogFilter{}, err // Return found error
+		}
+
+		filter.Contract = &address // Set contract
+	}
+
+	for _, topic := range topics { // Iterate through topics
+		hash, err := common.StringToHash(topic) // Get topic hash value
+		if err != nil {                         // Check for errors
+			return &types.LogFilter{}, err // Return found error
+		}
+
+		filter.Topics = append(filter.Topics, hash) // Append topic
+	}
+
+	if since != 0 { // Check has start
+		filter.Since = time.Unix(since, 0) // Set start
+	}
+
+	if until != 0 { // Check has end
+		filter.Until = time.Unix(until, 0) // Set end
+	}
+
+	return filter, nil // Return filter
+}
+
+/* END INTERNAL METHODS */
@@ -3,12 +3,12 @@ package config
 import (
 	"context"
 	"fmt"
-	"math/big"
 	"path/filepath"
 
 	"github.com/SummerCash/go-summercash/common"
 	"github.com/SummerCash/go-summercash/config"
 	configProto "github.com/SummerCash/go-summercash/intrnl/rpc/proto/config"
+	"github.com/SummerCash/go-summercash/types"
 )
 
 // Server - RPC server
@@ -106,11 +106,11 @@ func (server *Server) GetTotalSupply(ctx context.Context, req *configProto.Gener
 		return &configProto.GeneralResponse{}, err // Return found error
 	}
 
-	supply := big.NewFloat(0) // Init supply
+	supply := chainConfig.InitialSupply() // Init supply
 
-	for _, address := range chainConfig.AllocAddresses { // Iterate through alloc
-		supply.Add(supply, chainConfig.Alloc[address.String()]) // Add alloc value
+	if genesisChain, err := types.ReadGenesisChainFromMemory(chainConfig); err == nil && len(genesisChain.Transactions) > 0 { // Check has genesis chain
+		supply = supply.Add(genesisChain.Minted(chainConfig)) // Add committed mints
 	}
 
-	return &configProto.GeneralResponse{Message: fmt.Sprintf("\n%f", supply)}, nil // Return response
+	return &configProto.GeneralResponse{Message: fmt.Sprintf("\n%s", supply.String())}, nil // Return response
 }
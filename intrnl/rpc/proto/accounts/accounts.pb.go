@@ -24,6 +24,12 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type GeneralRequest struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	PrivateKey           string   `protobuf:"bytes,2,opt,name=privateKey,proto3" json:"privateKey,omitempty"`
+	Abi                  string   `protobuf:"bytes,3,opt,name=abi,proto3" json:"abi,omitempty"`
+	Passphrase           string   `protobuf:"bytes,4,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Timeout              uint64   `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Mnemonic             string   `protobuf:"bytes,6,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	Threshold            uint32   `protobuf:"varint,7,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	PublicKeys           []string `protobuf:"bytes,8,rep,name=publicKeys,proto3" json:"publicKeys,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -72,6 +78,48 @@ func (m *GeneralRequest) GetPrivateKey() string {
 	return ""
 }
 
+func (m *GeneralRequest) GetAbi() string {
+	if m != nil {
+		return m.Abi
+	}
+	return ""
+}
+
+func (m *GeneralRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+func (m *GeneralRequest) GetTimeout() uint64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetMnemonic() string {
+	if m != nil {
+		return m.Mnemonic
+	}
+	return ""
+}
+
+func (m *GeneralRequest) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetPublicKeys() []string {
+	if m != nil {
+		return m.PublicKeys
+	}
+	return nil
+}
+
 type GeneralResponse struct {
 	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -123,23 +171,32 @@ func init() {
 func init() { proto.RegisterFile("accounts.proto", fileDescriptor_e1e7723af4c007b7) }
 
 var fileDescriptor_e1e7723af4c007b7 = []byte{
-	// 279 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0xd3, 0x4f, 0x4b, 0xc3, 0x30,
-	0x18, 0x06, 0x70, 0x27, 0x6c, 0xce, 0xf7, 0x30, 0x25, 0x22, 0x54, 0x0f, 0x22, 0x3d, 0x09, 0xc2,
-	0x0e, 0x7a, 0x57, 0xe6, 0xd0, 0xaa, 0x75, 0x3d, 0x74, 0x9f, 0x20, 0xa6, 0x8f, 0x63, 0xd8, 0x26,
-	0x35, 0xc9, 0x36, 0xfa, 0x75, 0xfd, 0x24, 0xd2, 0xd9, 0x94, 0x09, 0x9e, 0xd2, 0xe3, 0xd3, 0x17,
-	0x7e, 0x7d, 0xff, 0x10, 0x1a, 0x71, 0x21, 0xd4, 0x4a, 0x5a, 0x33, 0x2e, 0xb5, 0xb2, 0x8a, 0x0d,
-	0x5d, 0x0e, 0x5f, 0x69, 0x14, 0x41, 0x42, 0xf3, 0x3c, 0xc5, 0xd7, 0x0a, 0xc6, 0xb2, 0x80, 0x0e,
-	0x78, 0x96, 0x69, 0x18, 0x13, 0xf4, 0x2e, 0x7b, 0x57, 0x87, 0xa9, 0x8b, 0xec, 0x82, 0xa8, 0xd4,
-	0xcb, 0x35, 0xb7, 0x88, 0x51, 0x05, 0xfb, 0xdb, 0xe2, 0xce, 0x97, 0xf0, 0x9a, 0x8e, 0x5a, 0xcb,
-	0x94, 0x4a, 0x1a, 0xd4, 0x58, 0x01, 0x63, 0xf8, 0x02, 0x0e, 0x6b, 0xe2, 0xcd, 0x77, 0x9f, 0x86,
-	0x93, 0xa6, 0x0b, 0x36, 0x25, 0x4a, 0xb0, 0x69, 0x22, 0x0b, 0xc6, 0x6d, 0xbb, 0x7f, 0x7b, 0x3b,
-	0x3f, 0xfb, 0xa7, 0xf2, 0xfb, 0xa7, 0x70, 0x8f, 0xc5, 0xc4, 0x12, 0x6c, 0xa6, 0x4a, 0x5a, 0xcd,
-	0x85, 0xed, 0x88, 0x45, 0x34, 0x6a, 0x84, 0x27, 0xad, 0x8a, 0x18, 0x55, 0x07, 0x28, 0x82, 0x9d,
-	0xe4, 0x79, 0x3b, 0xac, 0x27, 0xf4, 0x5c, 0x6f, 0xb7, 0x86, 0xdc, 0x84, 0xde, 0xd2, 0x0b, 0x1d,
-	0xcf, 0xf8, 0x27, 0x1e, 0xa5, 0x50, 0xd9, 0x52, 0x2e, 0xe6, 0xfc, 0x03, 0xbe, 0xd4, 0x1b, 0x9d,
-	0xa4, 0x10, 0x6a, 0x0d, 0x5d, 0x2b, 0x4e, 0xf4, 0xd5, 0xee, 0x69, 0x30, 0xb7, 0xba, 0x03, 0x70,
-	0x47, 0xfd, 0x87, 0xca, 0xc2, 0x7b, 0x33, 0x09, 0x9d, 0xa6, 0xe0, 0xd9, 0xce, 0xe5, 0x67, 0x28,
-	0x94, 0xf6, 0x3d, 0xfe, 0xfb, 0x60, 0xfb, 0xdc, 0x6e, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x4e,
-	0xb7, 0xd3, 0xaa, 0x80, 0x03, 0x00, 0x00,
+	// 424 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xcf, 0x6a, 0x1b, 0x31,
+	0x10, 0xc6, 0xbb, 0xb5, 0xe3, 0xd8, 0x03, 0x71, 0x82, 0x4a, 0x40, 0x0d, 0xa5, 0x18, 0x9f, 0x0c,
+	0x85, 0x1c, 0xda, 0x73, 0x5b, 0x92, 0xb4, 0x71, 0x8b, 0xe3, 0xa5, 0x6c, 0x28, 0x3d, 0xcb, 0xda,
+	0xa9, 0x2d, 0xa2, 0x95, 0xb6, 0x92, 0xd6, 0x66, 0x9f, 0xa1, 0x2f, 0xdb, 0x47, 0x28, 0xfb, 0x47,
+	0xdb, 0x14, 0x72, 0xd2, 0xde, 0xf4, 0xcd, 0x30, 0xbf, 0xd1, 0x37, 0x62, 0x04, 0x53, 0xc6, 0xb9,
+	0x2e, 0x94, 0xb3, 0x97, 0xb9, 0xd1, 0x4e, 0x93, 0xb1, 0xd7, 0xf3, 0x3f, 0x11, 0x4c, 0x97, 0xa8,
+	0xd0, 0x30, 0x99, 0xe0, 0xaf, 0x02, 0xad, 0x23, 0x14, 0x8e, 0x59, 0x9a, 0x1a, 0xb4, 0x96, 0x46,
+	0xb3, 0x68, 0x31, 0x49, 0xbc, 0x24, 0xaf, 0x01, 0x72, 0x23, 0xf6, 0xcc, 0xe1, 0x0a, 0x4b, 0xfa,
+	0xbc, 0x4e, 0x3e, 0x8a, 0x90, 0x33, 0x18, 0xb0, 0x8d, 0xa0, 0x83, 0x3a, 0x51, 0x1d, 0xeb, 0x0a,
+	0x66, 0x6d, 0xbe, 0x33, 0xcc, 0x22, 0x1d, 0xb6, 0x15, 0x5d, 0xa4, 0xea, 0xe5, 0x44, 0x86, 0xba,
+	0x70, 0xf4, 0x68, 0x16, 0x2d, 0x86, 0x89, 0x97, 0xe4, 0x02, 0xc6, 0x99, 0xc2, 0x4c, 0x2b, 0xc1,
+	0xe9, 0xa8, 0xae, 0xeb, 0x34, 0x79, 0x05, 0x13, 0xb7, 0x33, 0x68, 0x77, 0x5a, 0xa6, 0xf4, 0x78,
+	0x16, 0x2d, 0x4e, 0x92, 0x7f, 0x81, 0xba, 0x67, 0xb1, 0x91, 0x82, 0xaf, 0xb0, 0xb4, 0x74, 0x3c,
+	0x1b, 0xd4, 0x3d, 0xbb, 0xc8, 0xfc, 0x0d, 0x9c, 0x76, 0x8e, 0x6d, 0xae, 0x55, 0x73, 0x8d, 0x0c,
+	0xad, 0x65, 0x5b, 0xf4, 0x96, 0x5b, 0xf9, 0xf6, 0xf7, 0x04, 0xc6, 0x57, 0xed, 0xb0, 0xc8, 0x0d,
+	0x40, 0x8c, 0x87, 0x56, 0x12, 0x7a, 0xd9, 0x4d, 0xf5, 0xff, 0x09, 0x5e, 0xbc, 0x7c, 0x22, 0xd3,
+	0x74, 0x9a, 0x3f, 0x23, 0x2b, 0x20, 0x31, 0x1e, 0x6e, 0xb4, 0x72, 0x86, 0x71, 0xd7, 0x13, 0xb6,
+	0x84, 0x69, 0x4b, 0xb8, 0x35, 0x3a, 0xab, 0xde, 0x20, 0x1c, 0xb4, 0x44, 0x77, 0x25, 0x65, 0x67,
+	0x36, 0x10, 0xf4, 0x05, 0x4e, 0x1b, 0x90, 0x77, 0x18, 0x4c, 0xfa, 0x0a, 0x67, 0x6b, 0xf6, 0x80,
+	0x9f, 0x15, 0xd7, 0xa9, 0x50, 0xdb, 0x7b, 0xf6, 0x13, 0x43, 0x51, 0x77, 0xf0, 0x22, 0x41, 0xae,
+	0xf7, 0x68, 0x2a, 0x8a, 0x27, 0x86, 0xd2, 0x3e, 0xc2, 0xe8, 0xde, 0x99, 0x1e, 0x80, 0x0f, 0x70,
+	0x74, 0x5d, 0x3a, 0x0c, 0x9e, 0x4c, 0x0c, 0xe7, 0x09, 0xb2, 0xf4, 0xd1, 0xcb, 0xaf, 0x31, 0xd3,
+	0xa6, 0xec, 0x61, 0xe8, 0xbb, 0x92, 0x9a, 0x3f, 0x84, 0x02, 0xde, 0xc3, 0xf0, 0xae, 0x47, 0x79,
+	0x0c, 0xe7, 0x31, 0x1e, 0x7e, 0x30, 0x29, 0xb1, 0x71, 0xe3, 0x17, 0x3d, 0x90, 0x77, 0x0b, 0x27,
+	0x9f, 0xd0, 0x88, 0x3d, 0xf6, 0xdc, 0xae, 0x6b, 0x98, 0x7c, 0xf3, 0xff, 0x46, 0xbf, 0x75, 0x5f,
+	0x17, 0xd2, 0x09, 0x2b, 0xb6, 0xfd, 0x2e, 0xb4, 0x19, 0xd5, 0xdf, 0xf7, 0xbb, 0xbf, 0x03, 0x00,
+	0xed, 0xd7, 0x28, 0x1d, 0xd0, 0x05, 0x00, 0x00,
 }
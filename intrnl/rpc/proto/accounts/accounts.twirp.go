@@ -52,6 +52,18 @@ type Accounts interface {
 	Bytes(context.Context, *GeneralRequest) (*GeneralResponse, error)
 
 	ReadAccountFromMemory(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	Unlock(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	Lock(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	NewWalletFromMnemonic(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	DeriveAccount(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	PublicKey(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	NewMultisigAccount(context.Context, *GeneralRequest) (*GeneralResponse, error)
 }
 
 // ========================
@@ -60,14 +72,14 @@ type Accounts interface {
 
 type accountsProtobufClient struct {
 	client HTTPClient
-	urls   [10]string
+	urls   [16]string
 }
 
 // NewAccountsProtobufClient creates a Protobuf client that implements the Accounts interface.
 // It communicates using Protobuf and can be configured with a custom HTTPClient.
 func NewAccountsProtobufClient(addr string, client HTTPClient) Accounts {
 	prefix := urlBase(addr) + AccountsPathPrefix
-	urls := [10]string{
+	urls := [16]string{
 		prefix + "NewAccount",
 		prefix + "NewContractAccount",
 		prefix + "AccountFromKey",
@@ -78,6 +90,12 @@ func NewAccountsProtobufClient(addr string, client HTTPClient) Accounts {
 		prefix + "String",
 		prefix + "Bytes",
 		prefix + "ReadAccountFromMemory",
+		prefix + "Unlock",
+		prefix + "Lock",
+		prefix + "NewWalletFromMnemonic",
+		prefix + "DeriveAccount",
+		prefix + "PublicKey",
+		prefix + "NewMultisigAccount",
 	}
 	if httpClient, ok := client.(*http.Client); ok {
 		return &accountsProtobufClient{
@@ -211,20 +229,92 @@ func (c *accountsProtobufClient) ReadAccountFromMemory(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *accountsProtobufClient) Unlock(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "Unlock")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[10], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsProtobufClient) Lock(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "Lock")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[11], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsProtobufClient) NewWalletFromMnemonic(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "NewWalletFromMnemonic")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[12], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsProtobufClient) DeriveAccount(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "DeriveAccount")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[13], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsProtobufClient) PublicKey(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "PublicKey")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[14], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsProtobufClient) NewMultisigAccount(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "NewMultisigAccount")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[15], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ====================
 // Accounts JSON Client
 // ====================
 
 type accountsJSONClient struct {
 	client HTTPClient
-	urls   [10]string
+	urls   [16]string
 }
 
 // NewAccountsJSONClient creates a JSON client that implements the Accounts interface.
 // It communicates using JSON and can be configured with a custom HTTPClient.
 func NewAccountsJSONClient(addr string, client HTTPClient) Accounts {
 	prefix := urlBase(addr) + AccountsPathPrefix
-	urls := [10]string{
+	urls := [16]string{
 		prefix + "NewAccount",
 		prefix + "NewContractAccount",
 		prefix + "AccountFromKey",
@@ -235,6 +325,12 @@ func NewAccountsJSONClient(addr string, client HTTPClient) Accounts {
 		prefix + "String",
 		prefix + "Bytes",
 		prefix + "ReadAccountFromMemory",
+		prefix + "Unlock",
+		prefix + "Lock",
+		prefix + "NewWalletFromMnemonic",
+		prefix + "DeriveAccount",
+		prefix + "PublicKey",
+		prefix + "NewMultisigAccount",
 	}
 	if httpClient, ok := client.(*http.Client); ok {
 		return &accountsJSONClient{
@@ -368,6 +464,78 @@ func (c *accountsJSONClient) ReadAccountFromMemory(ctx context.Context, in *Gene
 	return out, nil
 }
 
+func (c *accountsJSONClient) Unlock(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "Unlock")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[10], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsJSONClient) Lock(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "Lock")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[11], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsJSONClient) NewWalletFromMnemonic(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "NewWalletFromMnemonic")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[12], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsJSONClient) DeriveAccount(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "DeriveAccount")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[13], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsJSONClient) PublicKey(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "PublicKey")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[14], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsJSONClient) NewMultisigAccount(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "accounts")
+	ctx = ctxsetters.WithServiceName(ctx, "Accounts")
+	ctx = ctxsetters.WithMethodName(ctx, "NewMultisigAccount")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[15], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // =======================
 // Accounts Server Handler
 // =======================
@@ -446,6 +614,24 @@ func (s *accountsServer) ServeHTTP(resp http.ResponseWriter, req *http.Request)
 	case "/twirp/accounts.Accounts/ReadAccountFromMemory":
 		s.serveReadAccountFromMemory(ctx, resp, req)
 		return
+	case "/twirp/accounts.Accounts/Unlock":
+		s.serveUnlock(ctx, resp, req)
+		return
+	case "/twirp/accounts.Accounts/Lock":
+		s.serveLock(ctx, resp, req)
+		return
+	case "/twirp/accounts.Accounts/NewWalletFromMnemonic":
+		s.serveNewWalletFromMnemonic(ctx, resp, req)
+		return
+	case "/twirp/accounts.Accounts/DeriveAccount":
+		s.serveDeriveAccount(ctx, resp, req)
+		return
+	case "/twirp/accounts.Accounts/PublicKey":
+		s.servePublicKey(ctx, resp, req)
+		return
+	case "/twirp/accounts.Accounts/NewMultisigAccount":
+		s.serveNewMultisigAccount(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -1894,95 +2080,959 @@ func (s *accountsServer) serveReadAccountFromMemoryProtobuf(ctx context.Context,
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *accountsServer) ServiceDescriptor() ([]byte, int) {
-	return twirpFileDescriptor0, 0
+func (s *accountsServer) serveUnlock(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUnlockJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUnlockProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
 }
 
-func (s *accountsServer) ProtocGenTwirpVersion() string {
-	return "v5.4.2"
-}
+func (s *accountsServer) serveUnlockJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Unlock")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
 
-// =====
-// Utils
-// =====
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
 
-// HTTPClient is the interface used by generated clients to send HTTP requests.
-// It is fulfilled by *(net/http).Client, which is sufficient for most users.
-// Users can provide their own implementation for special retry policies.
-//
-// HTTPClient implementations should not follow redirects. Redirects are
-// automatically disabled if *(net/http).Client is passed to client
-// constructors. See the withoutRedirects function in this file for more
-// details.
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
-}
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.Unlock(ctx, reqContent)
+	}()
 
-// TwirpServer is the interface generated server structs will support: they're
-// HTTP handlers with additional methods for accessing metadata about the
-// service. Those accessors are a low-level API for building reflection tools.
-// Most people can think of TwirpServers as just http.Handlers.
-type TwirpServer interface {
-	http.Handler
-	// ServiceDescriptor returns gzipped bytes describing the .proto file that
-	// this service was generated from. Once unzipped, the bytes can be
-	// unmarshalled as a
-	// github.com/golang/protobuf/protoc-gen-go/descriptor.FileDescriptorProto.
-	//
-	// The returned integer is the index of this particular service within that
-	// FileDescriptorProto's 'Service' slice of ServiceDescriptorProtos. This is a
-	// low-level field, expected to be used for reflection.
-	ServiceDescriptor() ([]byte, int)
-	// ProtocGenTwirpVersion is the semantic version string of the version of
-	// twirp used to generate this file.
-	ProtocGenTwirpVersion() string
-}
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling Unlock. nil responses are not supported"))
+		return
+	}
 
-// WriteError writes an HTTP response with a valid Twirp error format.
-// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
-func WriteError(resp http.ResponseWriter, err error) {
-	writeError(context.Background(), resp, err, nil)
-}
+	ctx = callResponsePrepared(ctx, s.hooks)
 
-// writeError writes Twirp errors in the response and triggers hooks.
-func writeError(ctx context.Context, resp http.ResponseWriter, err error, hooks *twirp.ServerHooks) {
-	// Non-twirp errors are wrapped as Internal (default)
-	twerr, ok := err.(twirp.Error)
-	if !ok {
-		twerr = twirp.InternalErrorWith(err)
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
 	}
 
-	statusCode := twirp.ServerHTTPStatusFromErrorCode(twerr.Code())
-	ctx = ctxsetters.WithStatusCode(ctx, statusCode)
-	ctx = callError(ctx, hooks, twerr)
-
-	resp.Header().Set("Content-Type", "application/json") // Error responses are always JSON (instead of protobuf)
-	resp.WriteHeader(statusCode)                          // HTTP response status code
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
 
-	respBody := marshalErrorToJSON(twerr)
-	_, writeErr := resp.Write(respBody)
-	if writeErr != nil {
-		// We have three options here. We could log the error, call the Error
-		// hook, or just silently ignore the error.
-		//
-		// Logging is unacceptable because we don't have a user-controlled
-		// logger; writing out to stderr without permission is too rude.
-		//
-		// Calling the Error hook would confuse users: it would mean the Error
-		// hook got called twice for one request, which is likely to lead to
-		// duplicated log messages and metrics, no matter how well we document
-		// the behavior.
-		//
-		// Silently ignoring the error is our least-bad option. It's highly
-		// likely that the connection is broken and the original 'err' says
-		// so anyway.
-		_ = writeErr
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
 	}
-
-	callResponseSent(ctx, hooks)
+	callResponseSent(ctx, s.hooks)
 }
 
-// urlBase helps ensure that addr specifies a scheme. If it is unparsable
+func (s *accountsServer) serveUnlockProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Unlock")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.Unlock(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling Unlock. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) serveLock(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveLockJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveLockProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *accountsServer) serveLockJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Lock")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.Lock(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling Lock. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) serveLockProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Lock")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.Lock(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling Lock. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) serveNewWalletFromMnemonic(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveNewWalletFromMnemonicJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveNewWalletFromMnemonicProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *accountsServer) serveNewWalletFromMnemonicJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "NewWalletFromMnemonic")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.NewWalletFromMnemonic(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling NewWalletFromMnemonic. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) serveNewWalletFromMnemonicProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "NewWalletFromMnemonic")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.NewWalletFromMnemonic(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling NewWalletFromMnemonic. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) serveDeriveAccount(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeriveAccountJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeriveAccountProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *accountsServer) serveDeriveAccountJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeriveAccount")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.DeriveAccount(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling DeriveAccount. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) serveDeriveAccountProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeriveAccount")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.DeriveAccount(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling DeriveAccount. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) servePublicKey(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePublicKeyJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePublicKeyProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *accountsServer) servePublicKeyJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PublicKey")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.PublicKey(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling PublicKey. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) servePublicKeyProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PublicKey")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.PublicKey(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling PublicKey. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) serveNewMultisigAccount(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveNewMultisigAccountJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveNewMultisigAccountProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *accountsServer) serveNewMultisigAccountJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "NewMultisigAccount")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.NewMultisigAccount(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling NewMultisigAccount. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) serveNewMultisigAccountProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "NewMultisigAccount")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Accounts.NewMultisigAccount(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling NewMultisigAccount. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *accountsServer) ServiceDescriptor() ([]byte, int) {
+	return twirpFileDescriptor0, 0
+}
+
+func (s *accountsServer) ProtocGenTwirpVersion() string {
+	return "v5.4.2"
+}
+
+// =====
+// Utils
+// =====
+
+// HTTPClient is the interface used by generated clients to send HTTP requests.
+// It is fulfilled by *(net/http).Client, which is sufficient for most users.
+// Users can provide their own implementation for special retry policies.
+//
+// HTTPClient implementations should not follow redirects. Redirects are
+// automatically disabled if *(net/http).Client is passed to client
+// constructors. See the withoutRedirects function in this file for more
+// details.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// TwirpServer is the interface generated server structs will support: they're
+// HTTP handlers with additional methods for accessing metadata about the
+// service. Those accessors are a low-level API for building reflection tools.
+// Most people can think of TwirpServers as just http.Handlers.
+type TwirpServer interface {
+	http.Handler
+	// ServiceDescriptor returns gzipped bytes describing the .proto file that
+	// this service was generated from. Once unzipped, the bytes can be
+	// unmarshalled as a
+	// github.com/golang/protobuf/protoc-gen-go/descriptor.FileDescriptorProto.
+	//
+	// The returned integer is the index of this particular service within that
+	// FileDescriptorProto's 'Service' slice of ServiceDescriptorProtos. This is a
+	// low-level field, expected to be used for reflection.
+	ServiceDescriptor() ([]byte, int)
+	// ProtocGenTwirpVersion is the semantic version string of the version of
+	// twirp used to generate this file.
+	ProtocGenTwirpVersion() string
+}
+
+// WriteError writes an HTTP response with a valid Twirp error format.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func WriteError(resp http.ResponseWriter, err error) {
+	writeError(context.Background(), resp, err, nil)
+}
+
+// writeError writes Twirp errors in the response and triggers hooks.
+func writeError(ctx context.Context, resp http.ResponseWriter, err error, hooks *twirp.ServerHooks) {
+	// Non-twirp errors are wrapped as Internal (default)
+	twerr, ok := err.(twirp.Error)
+	if !ok {
+		twerr = twirp.InternalErrorWith(err)
+	}
+
+	statusCode := twirp.ServerHTTPStatusFromErrorCode(twerr.Code())
+	ctx = ctxsetters.WithStatusCode(ctx, statusCode)
+	ctx = callError(ctx, hooks, twerr)
+
+	resp.Header().Set("Content-Type", "application/json") // Error responses are always JSON (instead of protobuf)
+	resp.WriteHeader(statusCode)                          // HTTP response status code
+
+	respBody := marshalErrorToJSON(twerr)
+	_, writeErr := resp.Write(respBody)
+	if writeErr != nil {
+		// We have three options here. We could log the error, call the Error
+		// hook, or just silently ignore the error.
+		//
+		// Logging is unacceptable because we don't have a user-controlled
+		// logger; writing out to stderr without permission is too rude.
+		//
+		// Calling the Error hook would confuse users: it would mean the Error
+		// hook got called twice for one request, which is likely to lead to
+		// duplicated log messages and metrics, no matter how well we document
+		// the behavior.
+		//
+		// Silently ignoring the error is our least-bad option. It's highly
+		// likely that the connection is broken and the original 'err' says
+		// so anyway.
+		_ = writeErr
+	}
+
+	callResponseSent(ctx, hooks)
+}
+
+// urlBase helps ensure that addr specifies a scheme. If it is unparsable
 // as a URL, it returns addr unchanged.
 func urlBase(addr string) string {
 	// If the addr specifies a scheme, use it. If not, default to
@@ -2138,7 +3188,6 @@ func twirpErrorFromIntermediary(status int, msg string, bodyOrLocation string) t
 	}
 	return twerr
 }
-
 func isHTTPRedirect(status int) bool {
 	return status >= 300 && status <= 399
 }
@@ -2324,23 +3373,32 @@ func callError(ctx context.Context, h *twirp.ServerHooks, err twirp.Error) conte
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 279 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0xd3, 0x4f, 0x4b, 0xc3, 0x30,
-	0x18, 0x06, 0x70, 0x27, 0x6c, 0xce, 0xf7, 0x30, 0x25, 0x22, 0x54, 0x0f, 0x22, 0x3d, 0x09, 0xc2,
-	0x0e, 0x7a, 0x57, 0xe6, 0xd0, 0xaa, 0x75, 0x3d, 0x74, 0x9f, 0x20, 0xa6, 0x8f, 0x63, 0xd8, 0x26,
-	0x35, 0xc9, 0x36, 0xfa, 0x75, 0xfd, 0x24, 0xd2, 0xd9, 0x94, 0x09, 0x9e, 0xd2, 0xe3, 0xd3, 0x17,
-	0x7e, 0x7d, 0xff, 0x10, 0x1a, 0x71, 0x21, 0xd4, 0x4a, 0x5a, 0x33, 0x2e, 0xb5, 0xb2, 0x8a, 0x0d,
-	0x5d, 0x0e, 0x5f, 0x69, 0x14, 0x41, 0x42, 0xf3, 0x3c, 0xc5, 0xd7, 0x0a, 0xc6, 0xb2, 0x80, 0x0e,
-	0x78, 0x96, 0x69, 0x18, 0x13, 0xf4, 0x2e, 0x7b, 0x57, 0x87, 0xa9, 0x8b, 0xec, 0x82, 0xa8, 0xd4,
-	0xcb, 0x35, 0xb7, 0x88, 0x51, 0x05, 0xfb, 0xdb, 0xe2, 0xce, 0x97, 0xf0, 0x9a, 0x8e, 0x5a, 0xcb,
-	0x94, 0x4a, 0x1a, 0xd4, 0x58, 0x01, 0x63, 0xf8, 0x02, 0x0e, 0x6b, 0xe2, 0xcd, 0x77, 0x9f, 0x86,
-	0x93, 0xa6, 0x0b, 0x36, 0x25, 0x4a, 0xb0, 0x69, 0x22, 0x0b, 0xc6, 0x6d, 0xbb, 0x7f, 0x7b, 0x3b,
-	0x3f, 0xfb, 0xa7, 0xf2, 0xfb, 0xa7, 0x70, 0x8f, 0xc5, 0xc4, 0x12, 0x6c, 0xa6, 0x4a, 0x5a, 0xcd,
-	0x85, 0xed, 0x88, 0x45, 0x34, 0x6a, 0x84, 0x27, 0xad, 0x8a, 0x18, 0x55, 0x07, 0x28, 0x82, 0x9d,
-	0xe4, 0x79, 0x3b, 0xac, 0x27, 0xf4, 0x5c, 0x6f, 0xb7, 0x86, 0xdc, 0x84, 0xde, 0xd2, 0x0b, 0x1d,
-	0xcf, 0xf8, 0x27, 0x1e, 0xa5, 0x50, 0xd9, 0x52, 0x2e, 0xe6, 0xfc, 0x03, 0xbe, 0xd4, 0x1b, 0x9d,
-	0xa4, 0x10, 0x6a, 0x0d, 0x5d, 0x2b, 0x4e, 0xf4, 0xd5, 0xee, 0x69, 0x30, 0xb7, 0xba, 0x03, 0x70,
-	0x47, 0xfd, 0x87, 0xca, 0xc2, 0x7b, 0x33, 0x09, 0x9d, 0xa6, 0xe0, 0xd9, 0xce, 0xe5, 0x67, 0x28,
-	0x94, 0xf6, 0x3d, 0xfe, 0xfb, 0x60, 0xfb, 0xdc, 0x6e, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x4e,
-	0xb7, 0xd3, 0xaa, 0x80, 0x03, 0x00, 0x00,
+	// 424 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xcf, 0x6a, 0x1b, 0x31,
+	0x10, 0xc6, 0xbb, 0xb5, 0xe3, 0xd8, 0x03, 0x71, 0x82, 0x4a, 0x40, 0x0d, 0xa5, 0x18, 0x9f, 0x0c,
+	0x85, 0x1c, 0xda, 0x73, 0x5b, 0x92, 0xb4, 0x71, 0x8b, 0xe3, 0xa5, 0x6c, 0x28, 0x3d, 0xcb, 0xda,
+	0xa9, 0x2d, 0xa2, 0x95, 0xb6, 0x92, 0xd6, 0x66, 0x9f, 0xa1, 0x2f, 0xdb, 0x47, 0x28, 0xfb, 0x47,
+	0xdb, 0x14, 0x72, 0xd2, 0xde, 0xf4, 0xcd, 0x30, 0xbf, 0xd1, 0x37, 0x62, 0x04, 0x53, 0xc6, 0xb9,
+	0x2e, 0x94, 0xb3, 0x97, 0xb9, 0xd1, 0x4e, 0x93, 0xb1, 0xd7, 0xf3, 0x3f, 0x11, 0x4c, 0x97, 0xa8,
+	0xd0, 0x30, 0x99, 0xe0, 0xaf, 0x02, 0xad, 0x23, 0x14, 0x8e, 0x59, 0x9a, 0x1a, 0xb4, 0x96, 0x46,
+	0xb3, 0x68, 0x31, 0x49, 0xbc, 0x24, 0xaf, 0x01, 0x72, 0x23, 0xf6, 0xcc, 0xe1, 0x0a, 0x4b, 0xfa,
+	0xbc, 0x4e, 0x3e, 0x8a, 0x90, 0x33, 0x18, 0xb0, 0x8d, 0xa0, 0x83, 0x3a, 0x51, 0x1d, 0xeb, 0x0a,
+	0x66, 0x6d, 0xbe, 0x33, 0xcc, 0x22, 0x1d, 0xb6, 0x15, 0x5d, 0xa4, 0xea, 0xe5, 0x44, 0x86, 0xba,
+	0x70, 0xf4, 0x68, 0x16, 0x2d, 0x86, 0x89, 0x97, 0xe4, 0x02, 0xc6, 0x99, 0xc2, 0x4c, 0x2b, 0xc1,
+	0xe9, 0xa8, 0xae, 0xeb, 0x34, 0x79, 0x05, 0x13, 0xb7, 0x33, 0x68, 0x77, 0x5a, 0xa6, 0xf4, 0x78,
+	0x16, 0x2d, 0x4e, 0x92, 0x7f, 0x81, 0xba, 0x67, 0xb1, 0x91, 0x82, 0xaf, 0xb0, 0xb4, 0x74, 0x3c,
+	0x1b, 0xd4, 0x3d, 0xbb, 0xc8, 0xfc, 0x0d, 0x9c, 0x76, 0x8e, 0x6d, 0xae, 0x55, 0x73, 0x8d, 0x0c,
+	0xad, 0x65, 0x5b, 0xf4, 0x96, 0x5b, 0xf9, 0xf6, 0xf7, 0x04, 0xc6, 0x57, 0xed, 0xb0, 0xc8, 0x0d,
+	0x40, 0x8c, 0x87, 0x56, 0x12, 0x7a, 0xd9, 0x4d, 0xf5, 0xff, 0x09, 0x5e, 0xbc, 0x7c, 0x22, 0xd3,
+	0x74, 0x9a, 0x3f, 0x23, 0x2b, 0x20, 0x31, 0x1e, 0x6e, 0xb4, 0x72, 0x86, 0x71, 0xd7, 0x13, 0xb6,
+	0x84, 0x69, 0x4b, 0xb8, 0x35, 0x3a, 0xab, 0xde, 0x20, 0x1c, 0xb4, 0x44, 0x77, 0x25, 0x65, 0x67,
+	0x36, 0x10, 0xf4, 0x05, 0x4e, 0x1b, 0x90, 0x77, 0x18, 0x4c, 0xfa, 0x0a, 0x67, 0x6b, 0xf6, 0x80,
+	0x9f, 0x15, 0xd7, 0xa9, 0x50, 0xdb, 0x7b, 0xf6, 0x13, 0x43, 0x51, 0x77, 0xf0, 0x22, 0x41, 0xae,
+	0xf7, 0x68, 0x2a, 0x8a, 0x27, 0x86, 0xd2, 0x3e, 0xc2, 0xe8, 0xde, 0x99, 0x1e, 0x80, 0x0f, 0x70,
+	0x74, 0x5d, 0x3a, 0x0c, 0x9e, 0x4c, 0x0c, 0xe7, 0x09, 0xb2, 0xf4, 0xd1, 0xcb, 0xaf, 0x31, 0xd3,
+	0xa6, 0xec, 0x61, 0xe8, 0xbb, 0x92, 0x9a, 0x3f, 0x84, 0x02, 0xde, 0xc3, 0xf0, 0xae, 0x47, 0x79,
+	0x0c, 0xe7, 0x31, 0x1e, 0x7e, 0x30, 0x29, 0xb1, 0x71, 0xe3, 0x17, 0x3d, 0x90, 0x77, 0x0b, 0x27,
+	0x9f, 0xd0, 0x88, 0x3d, 0xf6, 0xdc, 0xae, 0x6b, 0x98, 0x7c, 0xf3, 0xff, 0x46, 0xbf, 0x75, 0x5f,
+	0x17, 0xd2, 0x09, 0x2b, 0xb6, 0xfd, 0x2e, 0xb4, 0x19, 0xd5, 0xdf, 0xf7, 0xbb, 0xbf, 0x03, 0x00,
+	0xed, 0xd7, 0x28, 0x1d, 0xd0, 0x05, 0x00, 0x00,
 }
@@ -23,6 +23,16 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type GeneralRequest struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Sender               string   `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
+	Payload              []byte   `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	GasLimit             uint64   `protobuf:"varint,5,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	Topics               []string `protobuf:"bytes,6,rep,name=topics,proto3" json:"topics,omitempty"`
+	Since                int64    `protobuf:"varint,7,opt,name=since,proto3" json:"since,omitempty"`
+	Until                int64    `protobuf:"varint,8,opt,name=until,proto3" json:"until,omitempty"`
+	Hash                 string   `protobuf:"bytes,9,opt,name=hash,proto3" json:"hash,omitempty"`
+	Depth                uint32   `protobuf:"varint,10,opt,name=depth,proto3" json:"depth,omitempty"`
+	Format               string   `protobuf:"bytes,11,opt,name=format,proto3" json:"format,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -64,6 +74,76 @@ func (m *GeneralRequest) GetAddress() string {
 	return ""
 }
 
+func (m *GeneralRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *GeneralRequest) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *GeneralRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GeneralRequest) GetGasLimit() uint64 {
+	if m != nil {
+		return m.GasLimit
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *GeneralRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *GeneralRequest) GetDepth() uint32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
 type GeneralResponse struct {
 	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -115,20 +195,34 @@ func init() {
 func init() { proto.RegisterFile("chain.proto", fileDescriptor_d4d91b2d037e7a44) }
 
 var fileDescriptor_d4d91b2d037e7a44 = []byte{
-	// 226 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0x41, 0x4b, 0x87, 0x40,
-	0x10, 0xc5, 0xfb, 0x13, 0x1a, 0x4d, 0x50, 0xb1, 0x51, 0x48, 0xa7, 0xf0, 0x14, 0x05, 0x1e, 0xea,
-	0x52, 0x87, 0x2e, 0x4a, 0x7a, 0x2a, 0xc8, 0xfa, 0x02, 0x93, 0x0e, 0x26, 0xb8, 0xbb, 0x36, 0xb3,
-	0x1e, 0xfc, 0x14, 0x7d, 0xe5, 0x50, 0x33, 0xea, 0x7f, 0xdb, 0xe3, 0x5b, 0xde, 0x7b, 0xbf, 0x9d,
-	0x19, 0x38, 0xa8, 0x3e, 0xb0, 0x35, 0x49, 0xcf, 0xd6, 0x59, 0x15, 0xcc, 0x22, 0xbe, 0x82, 0xc3,
-	0x82, 0x0c, 0x31, 0x76, 0x25, 0x7d, 0x0e, 0x24, 0x4e, 0x45, 0xb0, 0x87, 0x75, 0xcd, 0x24, 0x12,
-	0x6d, 0x2e, 0x36, 0x97, 0xfb, 0xe5, 0x2a, 0xe3, 0x6b, 0x38, 0xfa, 0xf5, 0x4a, 0x6f, 0x8d, 0xd0,
-	0x64, 0xd6, 0x24, 0x82, 0x0d, 0xad, 0xe6, 0x1f, 0x79, 0xf3, 0xb5, 0x0b, 0x41, 0x36, 0x21, 0xd4,
-	0x03, 0x40, 0x41, 0x2e, 0xc5, 0x0e, 0x4d, 0x45, 0xea, 0x34, 0x59, 0x7e, 0xf1, 0x9f, 0x7a, 0x7e,
-	0xb6, 0xfd, 0xbc, 0x00, 0xe2, 0x1d, 0x75, 0x07, 0x41, 0x3a, 0x3a, 0x12, 0xff, 0xe4, 0x3d, 0x84,
-	0xaf, 0x8e, 0x5b, 0xd3, 0xf8, 0x47, 0x73, 0x38, 0x29, 0x09, 0xeb, 0x79, 0x80, 0x9c, 0xad, 0x7e,
-	0x22, 0x6d, 0x79, 0xf4, 0xef, 0xc9, 0xe0, 0xf8, 0x65, 0x20, 0x1e, 0xdf, 0x18, 0x8d, 0x60, 0xe5,
-	0x5a, 0x6b, 0xfc, 0x4b, 0x1e, 0x41, 0x15, 0xe4, 0x9e, 0x07, 0xfd, 0xa7, 0xc5, 0x7f, 0x1d, 0xef,
-	0xe1, 0x7c, 0xf8, 0xdb, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x6c, 0xe7, 0x3f, 0x86, 0x07, 0x02,
-	0x00, 0x00,
+	// 449 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0x4f, 0x6f, 0xd3, 0x40,
+	0x10, 0xc5, 0x71, 0xd3, 0x24, 0xcd, 0xa4, 0x85, 0x6a, 0x29, 0xd5, 0x0a, 0x2e, 0x56, 0x4e, 0x91,
+	0x90, 0x7a, 0x80, 0x0b, 0x20, 0xfe, 0x29, 0xa6, 0x31, 0x87, 0x82, 0xc0, 0xe5, 0x8e, 0x06, 0x7b,
+	0xe2, 0xac, 0xb0, 0x77, 0xcd, 0xee, 0x44, 0xc2, 0x1f, 0x83, 0x0b, 0x9f, 0x17, 0xad, 0xb7, 0x41,
+	0x85, 0x9b, 0x7d, 0xdb, 0xdf, 0x68, 0xe6, 0xed, 0x9b, 0x27, 0x7b, 0x61, 0x9e, 0x6f, 0x51, 0xe9,
+	0x8b, 0xc6, 0x1a, 0x36, 0x62, 0xdc, 0xc1, 0xe2, 0xd7, 0x01, 0xdc, 0x4d, 0x49, 0x93, 0xc5, 0x2a,
+	0xa3, 0x1f, 0x3b, 0x72, 0x2c, 0x24, 0x4c, 0xb1, 0x28, 0x2c, 0x39, 0x27, 0xa3, 0x38, 0x5a, 0xce,
+	0xb2, 0x3d, 0x8a, 0x53, 0x18, 0x7d, 0xa7, 0x56, 0x1e, 0xc4, 0xd1, 0xf2, 0x38, 0xf3, 0x47, 0x71,
+	0x0e, 0x13, 0x47, 0xba, 0x20, 0x2b, 0x47, 0x5d, 0xeb, 0x0d, 0x79, 0x8d, 0x06, 0xdb, 0xca, 0x60,
+	0x21, 0x0f, 0xbb, 0xee, 0x3d, 0x8a, 0x47, 0x30, 0x2b, 0xd1, 0x7d, 0xad, 0x54, 0xad, 0x58, 0x8e,
+	0xe3, 0x68, 0x79, 0x98, 0x1d, 0x95, 0xe8, 0xae, 0x3c, 0x7b, 0x39, 0x36, 0x8d, 0xca, 0x9d, 0x9c,
+	0xc4, 0x23, 0x2f, 0x17, 0x48, 0x9c, 0xc1, 0xd8, 0x29, 0x9d, 0x93, 0x9c, 0xc6, 0xd1, 0x72, 0x94,
+	0x05, 0xf0, 0xd5, 0x9d, 0x66, 0x55, 0xc9, 0xa3, 0x50, 0xed, 0x40, 0x08, 0x38, 0xdc, 0xa2, 0xdb,
+	0xca, 0x59, 0x67, 0xa8, 0x3b, 0xfb, 0xce, 0x82, 0x1a, 0xde, 0x4a, 0x88, 0xa3, 0xe5, 0x49, 0x16,
+	0xc0, 0xdf, 0xb6, 0x31, 0xb6, 0x46, 0x96, 0xf3, 0x60, 0x3e, 0xd0, 0xe2, 0x31, 0xdc, 0xfb, 0x1b,
+	0x89, 0x6b, 0x8c, 0x76, 0xe4, 0xf7, 0xa9, 0xc9, 0x39, 0x2c, 0x69, 0x9f, 0xc9, 0x0d, 0x3e, 0xf9,
+	0x3d, 0x85, 0x71, 0xe2, 0xa3, 0x14, 0xaf, 0x00, 0x52, 0xe2, 0x15, 0x56, 0xe8, 0xcd, 0x3d, 0xb8,
+	0x08, 0x69, 0xff, 0x1b, 0xee, 0xc3, 0xf3, 0xff, 0xcb, 0xe1, 0x82, 0xc5, 0x1d, 0xf1, 0x0c, 0xc6,
+	0xab, 0x96, 0xc9, 0xf5, 0x9f, 0x7c, 0x0e, 0x93, 0x6b, 0xb6, 0x4a, 0x97, 0xfd, 0x47, 0xd7, 0x70,
+	0x3f, 0x23, 0x2c, 0xba, 0x05, 0xd6, 0xd6, 0xd4, 0x1f, 0xa8, 0x36, 0xb6, 0xed, 0xaf, 0x93, 0xc0,
+	0xe9, 0xe7, 0x1d, 0xd9, 0xf6, 0x8b, 0x45, 0xed, 0x30, 0x67, 0x65, 0x74, 0x7f, 0x91, 0x4b, 0x10,
+	0x29, 0xf1, 0xc7, 0x5d, 0x7d, 0x4b, 0x65, 0x40, 0x1c, 0x29, 0x9c, 0xa5, 0xc4, 0xb7, 0x34, 0x56,
+	0xed, 0x7b, 0xff, 0x11, 0xf4, 0x16, 0x7a, 0x0b, 0x27, 0x29, 0xf1, 0x35, 0x23, 0xd3, 0x27, 0x6b,
+	0xcc, 0xa6, 0xbf, 0xc2, 0x1b, 0x38, 0x4e, 0xb0, 0xaa, 0x12, 0xa3, 0xd9, 0x62, 0xce, 0xfd, 0x05,
+	0x5e, 0xc0, 0x34, 0x25, 0xbe, 0x32, 0xe5, 0x80, 0x1c, 0x5e, 0xc3, 0x3c, 0x25, 0x5e, 0x2b, 0x8d,
+	0x95, 0xe2, 0x76, 0x90, 0xf9, 0x94, 0x38, 0x31, 0x7a, 0x53, 0xa9, 0x9c, 0x07, 0x18, 0x78, 0x09,
+	0xb3, 0xcb, 0x9f, 0x8d, 0xb1, 0xfc, 0x0e, 0xcb, 0x41, 0xf6, 0xc3, 0x74, 0xf8, 0xbb, 0xfa, 0xce,
+	0x7f, 0x9b, 0x74, 0xef, 0xdc, 0xd3, 0x3f, 0x03, 0x00, 0x2e, 0xe5, 0x14, 0x9b, 0xf6, 0x04, 0x00,
+	0x00,
 }
@@ -25,8 +25,10 @@ type GeneralRequest struct {
 	Nonce                uint32   `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
 	Address2             string   `protobuf:"bytes,3,opt,name=address2,proto3" json:"address2,omitempty"`
-	Amount               float64  `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Amount               string   `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
 	Payload              []byte   `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	GasLimit             uint64   `protobuf:"varint,6,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	GasPrice             string   `protobuf:"bytes,9,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -82,11 +84,11 @@ func (m *GeneralRequest) GetAddress2() string {
 	return ""
 }
 
-func (m *GeneralRequest) GetAmount() float64 {
+func (m *GeneralRequest) GetAmount() string {
 	if m != nil {
 		return m.Amount
 	}
-	return 0
+	return ""
 }
 
 func (m *GeneralRequest) GetPayload() []byte {
@@ -96,6 +98,20 @@ func (m *GeneralRequest) GetPayload() []byte {
 	return nil
 }
 
+func (m *GeneralRequest) GetGasLimit() uint64 {
+	if m != nil {
+		return m.GasLimit
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetGasPrice() string {
+	if m != nil {
+		return m.GasPrice
+	}
+	return ""
+}
+
 type GeneralResponse struct {
 	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -147,24 +163,30 @@ func init() {
 func init() { proto.RegisterFile("transaction.proto", fileDescriptor_2cc4e03d2c28c490) }
 
 var fileDescriptor_2cc4e03d2c28c490 = []byte{
-	// 289 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xc1, 0x4a, 0xf3, 0x40,
-	0x10, 0xc7, 0xbf, 0xfd, 0x6c, 0x52, 0x3b, 0xd5, 0x16, 0x97, 0x22, 0x4b, 0xf4, 0x10, 0x72, 0x0a,
-	0x08, 0x3d, 0xd4, 0x37, 0x10, 0xad, 0x27, 0x8b, 0x6c, 0x44, 0xf0, 0xb8, 0x6d, 0xc6, 0x18, 0x48,
-	0x76, 0xe3, 0xee, 0x06, 0xc9, 0x4b, 0xf8, 0x2e, 0xbe, 0xa1, 0x34, 0x69, 0x6a, 0x04, 0x6f, 0xe9,
-	0x6d, 0x7f, 0x33, 0xf0, 0x9b, 0xff, 0x0c, 0x2c, 0x9c, 0x59, 0x2d, 0xa4, 0x11, 0x1b, 0x9b, 0x2a,
-	0x39, 0x2f, 0xb4, 0xb2, 0x8a, 0x8e, 0x3b, 0xa5, 0xe0, 0x93, 0xc0, 0xe4, 0x1e, 0x25, 0x6a, 0x91,
-	0x71, 0x7c, 0x2f, 0xd1, 0x58, 0x3a, 0x03, 0x47, 0x2a, 0xb9, 0x41, 0x46, 0x7c, 0x12, 0x9e, 0xf2,
-	0x06, 0x28, 0x83, 0xa1, 0x88, 0x63, 0x8d, 0xc6, 0xb0, 0xff, 0x3e, 0x09, 0x47, 0xbc, 0x45, 0xea,
-	0xc1, 0xf1, 0xee, 0xb9, 0x60, 0x47, 0x75, 0x6b, 0xcf, 0xf4, 0x1c, 0x5c, 0x91, 0xab, 0x52, 0x5a,
-	0x36, 0xf0, 0x49, 0x48, 0xf8, 0x8e, 0xb6, 0xb6, 0x42, 0x54, 0x99, 0x12, 0x31, 0x73, 0x7c, 0x12,
-	0x9e, 0xf0, 0x16, 0x83, 0x2b, 0x98, 0xee, 0xf3, 0x98, 0x42, 0x49, 0x53, 0x8f, 0xce, 0xd1, 0x18,
-	0x91, 0x34, 0x91, 0x46, 0xbc, 0xc5, 0xc5, 0xd7, 0x00, 0xc6, 0x4f, 0x3f, 0xdb, 0xd0, 0x07, 0x98,
-	0xac, 0xf0, 0xa3, 0x5b, 0xb9, 0x98, 0x77, 0x0f, 0xf0, 0x7b, 0x53, 0xef, 0xf2, 0xef, 0x66, 0x33,
-	0x36, 0xf8, 0x47, 0x23, 0x98, 0x75, 0x5c, 0x4b, 0xad, 0xf2, 0x9b, 0xca, 0xa2, 0xe9, 0x27, 0x5d,
-	0xc2, 0xf0, 0xb1, 0x5c, 0x67, 0xa9, 0x79, 0xeb, 0xe7, 0xb9, 0x05, 0xe7, 0x00, 0x69, 0xee, 0xc0,
-	0x8d, 0xac, 0x4e, 0x65, 0xd2, 0x4f, 0xb3, 0x82, 0x69, 0x94, 0x26, 0xf2, 0x60, 0x97, 0x7f, 0x01,
-	0xef, 0x19, 0x75, 0xfa, 0x5a, 0x75, 0x8c, 0xdb, 0x01, 0xc2, 0x96, 0x1a, 0x7b, 0xa9, 0xd7, 0x6e,
-	0xfd, 0x0b, 0xae, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x00, 0x7e, 0x13, 0xf5, 0x1a, 0x03, 0x00,
-	0x00,
+	// 390 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0x4d, 0x6f, 0x95, 0x40,
+	0x14, 0x86, 0x45, 0xf9, 0x3c, 0xd5, 0xb6, 0x4e, 0x6e, 0xcc, 0x78, 0xeb, 0x82, 0xdc, 0x15, 0x89,
+	0x49, 0x17, 0xf5, 0x17, 0xd8, 0xd8, 0x36, 0x36, 0xda, 0x20, 0xf8, 0xb9, 0x32, 0xd3, 0x3b, 0x47,
+	0x9c, 0x04, 0x66, 0x70, 0x66, 0x88, 0xf2, 0x3f, 0x4d, 0xfc, 0x3b, 0x06, 0xb8, 0xdc, 0x62, 0xe2,
+	0x0e, 0x76, 0x3c, 0x73, 0x92, 0xe7, 0xf0, 0xbe, 0x4c, 0x80, 0xc7, 0x56, 0x33, 0x69, 0xd8, 0xd6,
+	0x0a, 0x25, 0x4f, 0x6b, 0xad, 0xac, 0x22, 0x07, 0x93, 0xa3, 0xcd, 0x6f, 0x07, 0x0e, 0xaf, 0x50,
+	0xa2, 0x66, 0x65, 0x86, 0x3f, 0x1a, 0x34, 0x96, 0xac, 0xc0, 0x93, 0x4a, 0x6e, 0x91, 0x3a, 0xb1,
+	0x93, 0x3c, 0xca, 0x06, 0x20, 0x14, 0x02, 0xc6, 0xb9, 0x46, 0x63, 0xe8, 0xfd, 0xd8, 0x49, 0xa2,
+	0x6c, 0x44, 0xb2, 0x86, 0x70, 0xf7, 0x78, 0x46, 0x1f, 0xf4, 0xa3, 0x3d, 0x93, 0x27, 0xe0, 0xb3,
+	0x4a, 0x35, 0xd2, 0xd2, 0xb0, 0x9f, 0xec, 0xa8, 0xb3, 0xd5, 0xac, 0x2d, 0x15, 0xe3, 0xd4, 0x8b,
+	0x9d, 0xe4, 0x61, 0x36, 0x22, 0x39, 0x81, 0xa8, 0x60, 0xe6, 0x6b, 0x29, 0x2a, 0x61, 0xa9, 0x1f,
+	0x3b, 0x89, 0x9b, 0x85, 0x05, 0x33, 0x6f, 0x3a, 0x1e, 0x87, 0xb5, 0x16, 0x5b, 0xa4, 0xd1, 0xb0,
+	0xab, 0x60, 0x26, 0xed, 0xf8, 0xda, 0x0d, 0xdd, 0x63, 0xef, 0xda, 0x0d, 0x83, 0xe3, 0x70, 0xf3,
+	0x1c, 0x8e, 0xf6, 0xa9, 0x4c, 0xad, 0xa4, 0xe9, 0x03, 0x54, 0x68, 0x0c, 0x2b, 0x86, 0x60, 0x51,
+	0x36, 0xe2, 0xd9, 0x9f, 0x00, 0x0e, 0xde, 0xdf, 0x75, 0x42, 0xde, 0xc2, 0xe1, 0x0d, 0xfe, 0x9c,
+	0x9e, 0x9c, 0x9c, 0x4e, 0x6b, 0xfc, 0xb7, 0xaf, 0xf5, 0xb3, 0xff, 0x0f, 0x87, 0xb5, 0x9b, 0x7b,
+	0x24, 0x87, 0xd5, 0xc4, 0x75, 0xa9, 0x55, 0x75, 0xde, 0x5a, 0x34, 0xf3, 0xa4, 0x97, 0x10, 0xa4,
+	0xcd, 0x6d, 0x29, 0xcc, 0xf7, 0x79, 0x9e, 0x57, 0xe0, 0x2d, 0xf0, 0x36, 0x17, 0xe0, 0xe7, 0x56,
+	0x0b, 0x59, 0xcc, 0xd3, 0xdc, 0xc0, 0x51, 0x2e, 0x0a, 0xb9, 0x58, 0xf3, 0x5f, 0x60, 0xfd, 0x11,
+	0xb5, 0xf8, 0xd6, 0x4e, 0x8c, 0xdd, 0x02, 0x66, 0x1b, 0x8d, 0xf3, 0xd4, 0xaf, 0x01, 0xae, 0xd0,
+	0xa6, 0x28, 0xf9, 0xec, 0xd4, 0xef, 0x80, 0xdc, 0xa9, 0xce, 0xdb, 0x1c, 0x25, 0x47, 0x3d, 0x4f,
+	0xf9, 0x19, 0x9e, 0x5e, 0xfc, 0xaa, 0x95, 0xb6, 0x1f, 0xa4, 0x11, 0x85, 0x44, 0xbe, 0x58, 0xa5,
+	0x9f, 0x80, 0xee, 0xee, 0x5d, 0xbe, 0xac, 0x38, 0x87, 0xd5, 0x4b, 0xce, 0x53, 0xa6, 0xad, 0x60,
+	0xe5, 0xfe, 0x23, 0xcd, 0xbb, 0x97, 0xb7, 0x7e, 0xff, 0xc7, 0x7b, 0xf1, 0x77, 0x00, 0xa7, 0xd2,
+	0xaa, 0xf8, 0x06, 0x05, 0x00, 0x00,
 }
@@ -46,6 +46,16 @@ type Transaction interface {
 	SignTransaction(context.Context, *GeneralRequest) (*GeneralResponse, error)
 
 	VerifyTransactionSignature(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	GetPending(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	GetPendingBySender(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	ExportUnsignedTransaction(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	PublishSignedTransaction(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	AddPartialSignatures(context.Context, *GeneralRequest) (*GeneralResponse, error)
 }
 
 // ===========================
@@ -54,14 +64,14 @@ type Transaction interface {
 
 type transactionProtobufClient struct {
 	client HTTPClient
-	urls   [7]string
+	urls   [12]string
 }
 
 // NewTransactionProtobufClient creates a Protobuf client that implements the Transaction interface.
 // It communicates using Protobuf and can be configured with a custom HTTPClient.
 func NewTransactionProtobufClient(addr string, client HTTPClient) Transaction {
 	prefix := urlBase(addr) + TransactionPathPrefix
-	urls := [7]string{
+	urls := [12]string{
 		prefix + "NewTransaction",
 		prefix + "TransactionFromBytes",
 		prefix + "Publish",
@@ -69,6 +79,11 @@ func NewTransactionProtobufClient(addr string, client HTTPClient) Transaction {
 		prefix + "String",
 		prefix + "SignTransaction",
 		prefix + "VerifyTransactionSignature",
+		prefix + "GetPending",
+		prefix + "GetPendingBySender",
+		prefix + "ExportUnsignedTransaction",
+		prefix + "PublishSignedTransaction",
+		prefix + "AddPartialSignatures",
 	}
 	if httpClient, ok := client.(*http.Client); ok {
 		return &transactionProtobufClient{
@@ -166,20 +181,80 @@ func (c *transactionProtobufClient) VerifyTransactionSignature(ctx context.Conte
 	return out, nil
 }
 
+func (c *transactionProtobufClient) GetPending(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "transaction")
+	ctx = ctxsetters.WithServiceName(ctx, "Transaction")
+	ctx = ctxsetters.WithMethodName(ctx, "GetPending")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[7], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionProtobufClient) GetPendingBySender(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "transaction")
+	ctx = ctxsetters.WithServiceName(ctx, "Transaction")
+	ctx = ctxsetters.WithMethodName(ctx, "GetPendingBySender")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[8], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionProtobufClient) ExportUnsignedTransaction(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "transaction")
+	ctx = ctxsetters.WithServiceName(ctx, "Transaction")
+	ctx = ctxsetters.WithMethodName(ctx, "ExportUnsignedTransaction")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[9], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionProtobufClient) PublishSignedTransaction(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "transaction")
+	ctx = ctxsetters.WithServiceName(ctx, "Transaction")
+	ctx = ctxsetters.WithMethodName(ctx, "PublishSignedTransaction")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[10], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionProtobufClient) AddPartialSignatures(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "transaction")
+	ctx = ctxsetters.WithServiceName(ctx, "Transaction")
+	ctx = ctxsetters.WithMethodName(ctx, "AddPartialSignatures")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[11], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // =======================
 // Transaction JSON Client
 // =======================
 
 type transactionJSONClient struct {
 	client HTTPClient
-	urls   [7]string
+	urls   [12]string
 }
 
 // NewTransactionJSONClient creates a JSON client that implements the Transaction interface.
 // It communicates using JSON and can be configured with a custom HTTPClient.
 func NewTransactionJSONClient(addr string, client HTTPClient) Transaction {
 	prefix := urlBase(addr) + TransactionPathPrefix
-	urls := [7]string{
+	urls := [12]string{
 		prefix + "NewTransaction",
 		prefix + "TransactionFromBytes",
 		prefix + "Publish",
@@ -187,6 +262,11 @@ func NewTransactionJSONClient(addr string, client HTTPClient) Transaction {
 		prefix + "String",
 		prefix + "SignTransaction",
 		prefix + "VerifyTransactionSignature",
+		prefix + "GetPending",
+		prefix + "GetPendingBySender",
+		prefix + "ExportUnsignedTransaction",
+		prefix + "PublishSignedTransaction",
+		prefix + "AddPartialSignatures",
 	}
 	if httpClient, ok := client.(*http.Client); ok {
 		return &transactionJSONClient{
@@ -284,6 +364,66 @@ func (c *transactionJSONClient) VerifyTransactionSignature(ctx context.Context,
 	return out, nil
 }
 
+func (c *transactionJSONClient) GetPending(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "transaction")
+	ctx = ctxsetters.WithServiceName(ctx, "Transaction")
+	ctx = ctxsetters.WithMethodName(ctx, "GetPending")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[7], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionJSONClient) GetPendingBySender(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "transaction")
+	ctx = ctxsetters.WithServiceName(ctx, "Transaction")
+	ctx = ctxsetters.WithMethodName(ctx, "GetPendingBySender")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[8], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionJSONClient) ExportUnsignedTransaction(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "transaction")
+	ctx = ctxsetters.WithServiceName(ctx, "Transaction")
+	ctx = ctxsetters.WithMethodName(ctx, "ExportUnsignedTransaction")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[9], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionJSONClient) PublishSignedTransaction(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "transaction")
+	ctx = ctxsetters.WithServiceName(ctx, "Transaction")
+	ctx = ctxsetters.WithMethodName(ctx, "PublishSignedTransaction")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[10], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionJSONClient) AddPartialSignatures(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "transaction")
+	ctx = ctxsetters.WithServiceName(ctx, "Transaction")
+	ctx = ctxsetters.WithMethodName(ctx, "AddPartialSignatures")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[11], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ==========================
 // Transaction Server Handler
 // ==========================
@@ -353,6 +493,21 @@ func (s *transactionServer) ServeHTTP(resp http.ResponseWriter, req *http.Reques
 	case "/twirp/transaction.Transaction/VerifyTransactionSignature":
 		s.serveVerifyTransactionSignature(ctx, resp, req)
 		return
+	case "/twirp/transaction.Transaction/GetPending":
+		s.serveGetPending(ctx, resp, req)
+		return
+	case "/twirp/transaction.Transaction/GetPendingBySender":
+		s.serveGetPendingBySender(ctx, resp, req)
+		return
+	case "/twirp/transaction.Transaction/ExportUnsignedTransaction":
+		s.serveExportUnsignedTransaction(ctx, resp, req)
+		return
+	case "/twirp/transaction.Transaction/PublishSignedTransaction":
+		s.servePublishSignedTransaction(ctx, resp, req)
+		return
+	case "/twirp/transaction.Transaction/AddPartialSignatures":
+		s.serveAddPartialSignatures(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -1369,114 +1524,834 @@ func (s *transactionServer) serveVerifyTransactionSignatureProtobuf(ctx context.
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *transactionServer) ServiceDescriptor() ([]byte, int) {
-	return twirpFileDescriptor0, 0
+func (s *transactionServer) serveGetPending(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetPendingJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetPendingProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
 }
 
-func (s *transactionServer) ProtocGenTwirpVersion() string {
-	return "v5.4.2"
-}
+func (s *transactionServer) serveGetPendingJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetPending")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
 
-// =====
-// Utils
-// =====
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
 
-// HTTPClient is the interface used by generated clients to send HTTP requests.
-// It is fulfilled by *(net/http).Client, which is sufficient for most users.
-// Users can provide their own implementation for special retry policies.
-//
-// HTTPClient implementations should not follow redirects. Redirects are
-// automatically disabled if *(net/http).Client is passed to client
-// constructors. See the withoutRedirects function in this file for more
-// details.
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
-}
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Transaction.GetPending(ctx, reqContent)
+	}()
 
-// TwirpServer is the interface generated server structs will support: they're
-// HTTP handlers with additional methods for accessing metadata about the
-// service. Those accessors are a low-level API for building reflection tools.
-// Most people can think of TwirpServers as just http.Handlers.
-type TwirpServer interface {
-	http.Handler
-	// ServiceDescriptor returns gzipped bytes describing the .proto file that
-	// this service was generated from. Once unzipped, the bytes can be
-	// unmarshalled as a
-	// github.com/golang/protobuf/protoc-gen-go/descriptor.FileDescriptorProto.
-	//
-	// The returned integer is the index of this particular service within that
-	// FileDescriptorProto's 'Service' slice of ServiceDescriptorProtos. This is a
-	// low-level field, expected to be used for reflection.
-	ServiceDescriptor() ([]byte, int)
-	// ProtocGenTwirpVersion is the semantic version string of the version of
-	// twirp used to generate this file.
-	ProtocGenTwirpVersion() string
-}
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling GetPending. nil responses are not supported"))
+		return
+	}
 
-// WriteError writes an HTTP response with a valid Twirp error format.
-// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
-func WriteError(resp http.ResponseWriter, err error) {
-	writeError(context.Background(), resp, err, nil)
-}
+	ctx = callResponsePrepared(ctx, s.hooks)
 
-// writeError writes Twirp errors in the response and triggers hooks.
-func writeError(ctx context.Context, resp http.ResponseWriter, err error, hooks *twirp.ServerHooks) {
-	// Non-twirp errors are wrapped as Internal (default)
-	twerr, ok := err.(twirp.Error)
-	if !ok {
-		twerr = twirp.InternalErrorWith(err)
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
 	}
 
-	statusCode := twirp.ServerHTTPStatusFromErrorCode(twerr.Code())
-	ctx = ctxsetters.WithStatusCode(ctx, statusCode)
-	ctx = callError(ctx, hooks, twerr)
-
-	resp.Header().Set("Content-Type", "application/json") // Error responses are always JSON (instead of protobuf)
-	resp.WriteHeader(statusCode)                          // HTTP response status code
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
 
-	respBody := marshalErrorToJSON(twerr)
-	_, writeErr := resp.Write(respBody)
-	if writeErr != nil {
-		// We have three options here. We could log the error, call the Error
-		// hook, or just silently ignore the error.
-		//
-		// Logging is unacceptable because we don't have a user-controlled
-		// logger; writing out to stderr without permission is too rude.
-		//
-		// Calling the Error hook would confuse users: it would mean the Error
-		// hook got called twice for one request, which is likely to lead to
-		// duplicated log messages and metrics, no matter how well we document
-		// the behavior.
-		//
-		// Silently ignoring the error is our least-bad option. It's highly
-		// likely that the connection is broken and the original 'err' says
-		// so anyway.
-		_ = writeErr
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
 	}
-
-	callResponseSent(ctx, hooks)
+	callResponseSent(ctx, s.hooks)
 }
 
-// urlBase helps ensure that addr specifies a scheme. If it is unparsable
-// as a URL, it returns addr unchanged.
-func urlBase(addr string) string {
-	// If the addr specifies a scheme, use it. If not, default to
-	// http. If url.Parse fails on it, return it unchanged.
-	url, err := url.Parse(addr)
+func (s *transactionServer) serveGetPendingProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetPending")
+	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
-		return addr
-	}
-	if url.Scheme == "" {
-		url.Scheme = "http"
+		s.writeError(ctx, resp, err)
+		return
 	}
-	return url.String()
-}
 
-// getCustomHTTPReqHeaders retrieves a copy of any headers that are set in
-// a context through the twirp.WithHTTPRequestHeaders function.
-// If there are no headers set, or if they have the wrong type, nil is returned.
-func getCustomHTTPReqHeaders(ctx context.Context) http.Header {
-	header, ok := twirp.HTTPRequestHeaders(ctx)
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Transaction.GetPending(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling GetPending. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *transactionServer) serveGetPendingBySender(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetPendingBySenderJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetPendingBySenderProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *transactionServer) serveGetPendingBySenderJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetPendingBySender")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Transaction.GetPendingBySender(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling GetPendingBySender. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *transactionServer) serveGetPendingBySenderProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetPendingBySender")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Transaction.GetPendingBySender(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling GetPendingBySender. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *transactionServer) serveExportUnsignedTransaction(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveExportUnsignedTransactionJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveExportUnsignedTransactionProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *transactionServer) serveExportUnsignedTransactionJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ExportUnsignedTransaction")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Transaction.ExportUnsignedTransaction(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling ExportUnsignedTransaction. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *transactionServer) serveExportUnsignedTransactionProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ExportUnsignedTransaction")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Transaction.ExportUnsignedTransaction(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling ExportUnsignedTransaction. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *transactionServer) servePublishSignedTransaction(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePublishSignedTransactionJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePublishSignedTransactionProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *transactionServer) servePublishSignedTransactionJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PublishSignedTransaction")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Transaction.PublishSignedTransaction(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling PublishSignedTransaction. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *transactionServer) servePublishSignedTransactionProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PublishSignedTransaction")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Transaction.PublishSignedTransaction(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling PublishSignedTransaction. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *transactionServer) serveAddPartialSignatures(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveAddPartialSignaturesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveAddPartialSignaturesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *transactionServer) serveAddPartialSignaturesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "AddPartialSignatures")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Transaction.AddPartialSignatures(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling AddPartialSignatures. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *transactionServer) serveAddPartialSignaturesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "AddPartialSignatures")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Transaction.AddPartialSignatures(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling AddPartialSignatures. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *transactionServer) ServiceDescriptor() ([]byte, int) {
+	return twirpFileDescriptor0, 0
+}
+
+func (s *transactionServer) ProtocGenTwirpVersion() string {
+	return "v5.4.2"
+}
+
+// =====
+// Utils
+// =====
+
+// HTTPClient is the interface used by generated clients to send HTTP requests.
+// It is fulfilled by *(net/http).Client, which is sufficient for most users.
+// Users can provide their own implementation for special retry policies.
+//
+// HTTPClient implementations should not follow redirects. Redirects are
+// automatically disabled if *(net/http).Client is passed to client
+// constructors. See the withoutRedirects function in this file for more
+// details.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// TwirpServer is the interface generated server structs will support: they're
+// HTTP handlers with additional methods for accessing metadata about the
+// service. Those accessors are a low-level API for building reflection tools.
+// Most people can think of TwirpServers as just http.Handlers.
+type TwirpServer interface {
+	http.Handler
+	// ServiceDescriptor returns gzipped bytes describing the .proto file that
+	// this service was generated from. Once unzipped, the bytes can be
+	// unmarshalled as a
+	// github.com/golang/protobuf/protoc-gen-go/descriptor.FileDescriptorProto.
+	//
+	// The returned integer is the index of this particular service within that
+	// FileDescriptorProto's 'Service' slice of ServiceDescriptorProtos. This is a
+	// low-level field, expected to be used for reflection.
+	ServiceDescriptor() ([]byte, int)
+	// ProtocGenTwirpVersion is the semantic version string of the version of
+	// twirp used to generate this file.
+	ProtocGenTwirpVersion() string
+}
+
+// WriteError writes an HTTP response with a valid Twirp error format.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func WriteError(resp http.ResponseWriter, err error) {
+	writeError(context.Background(), resp, err, nil)
+}
+
+// writeError writes Twirp errors in the response and triggers hooks.
+func writeError(ctx context.Context, resp http.ResponseWriter, err error, hooks *twirp.ServerHooks) {
+	// Non-twirp errors are wrapped as Internal (default)
+	twerr, ok := err.(twirp.Error)
+	if !ok {
+		twerr = twirp.InternalErrorWith(err)
+	}
+
+	statusCode := twirp.ServerHTTPStatusFromErrorCode(twerr.Code())
+	ctx = ctxsetters.WithStatusCode(ctx, statusCode)
+	ctx = callError(ctx, hooks, twerr)
+
+	resp.Header().Set("Content-Type", "application/json") // Error responses are always JSON (instead of protobuf)
+	resp.WriteHeader(statusCode)                          // HTTP response status code
+
+	respBody := marshalErrorToJSON(twerr)
+	_, writeErr := resp.Write(respBody)
+	if writeErr != nil {
+		// We have three options here. We could log the error, call the Error
+		// hook, or just silently ignore the error.
+		//
+		// Logging is unacceptable because we don't have a user-controlled
+		// logger; writing out to stderr without permission is too rude.
+		//
+		// Calling the Error hook would confuse users: it would mean the Error
+		// hook got called twice for one request, which is likely to lead to
+		// duplicated log messages and metrics, no matter how well we document
+		// the behavior.
+		//
+		// Silently ignoring the error is our least-bad option. It's highly
+		// likely that the connection is broken and the original 'err' says
+		// so anyway.
+		_ = writeErr
+	}
+
+	callResponseSent(ctx, hooks)
+}
+
+// urlBase helps ensure that addr specifies a scheme. If it is unparsable
+// as a URL, it returns addr unchanged.
+func urlBase(addr string) string {
+	// If the addr specifies a scheme, use it. If not, default to
+	// http. If url.Parse fails on it, return it unchanged.
+	url, err := url.Parse(addr)
+	if err != nil {
+		return addr
+	}
+	if url.Scheme == "" {
+		url.Scheme = "http"
+	}
+	return url.String()
+}
+
+// getCustomHTTPReqHeaders retrieves a copy of any headers that are set in
+// a context through the twirp.WithHTTPRequestHeaders function.
+// If there are no headers set, or if they have the wrong type, nil is returned.
+func getCustomHTTPReqHeaders(ctx context.Context) http.Header {
+	header, ok := twirp.HTTPRequestHeaders(ctx)
 	if !ok || header == nil {
 		return nil
 	}
@@ -1613,7 +2488,6 @@ func twirpErrorFromIntermediary(status int, msg string, bodyOrLocation string) t
 	}
 	return twerr
 }
-
 func isHTTPRedirect(status int) bool {
 	return status >= 300 && status <= 399
 }
@@ -1799,24 +2673,30 @@ func callError(ctx context.Context, h *twirp.ServerHooks, err twirp.Error) conte
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 289 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xc1, 0x4a, 0xf3, 0x40,
-	0x10, 0xc7, 0xbf, 0xfd, 0x6c, 0x52, 0x3b, 0xd5, 0x16, 0x97, 0x22, 0x4b, 0xf4, 0x10, 0x72, 0x0a,
-	0x08, 0x3d, 0xd4, 0x37, 0x10, 0xad, 0x27, 0x8b, 0x6c, 0x44, 0xf0, 0xb8, 0x6d, 0xc6, 0x18, 0x48,
-	0x76, 0xe3, 0xee, 0x06, 0xc9, 0x4b, 0xf8, 0x2e, 0xbe, 0xa1, 0x34, 0x69, 0x6a, 0x04, 0x6f, 0xe9,
-	0x6d, 0x7f, 0x33, 0xf0, 0x9b, 0xff, 0x0c, 0x2c, 0x9c, 0x59, 0x2d, 0xa4, 0x11, 0x1b, 0x9b, 0x2a,
-	0x39, 0x2f, 0xb4, 0xb2, 0x8a, 0x8e, 0x3b, 0xa5, 0xe0, 0x93, 0xc0, 0xe4, 0x1e, 0x25, 0x6a, 0x91,
-	0x71, 0x7c, 0x2f, 0xd1, 0x58, 0x3a, 0x03, 0x47, 0x2a, 0xb9, 0x41, 0x46, 0x7c, 0x12, 0x9e, 0xf2,
-	0x06, 0x28, 0x83, 0xa1, 0x88, 0x63, 0x8d, 0xc6, 0xb0, 0xff, 0x3e, 0x09, 0x47, 0xbc, 0x45, 0xea,
-	0xc1, 0xf1, 0xee, 0xb9, 0x60, 0x47, 0x75, 0x6b, 0xcf, 0xf4, 0x1c, 0x5c, 0x91, 0xab, 0x52, 0x5a,
-	0x36, 0xf0, 0x49, 0x48, 0xf8, 0x8e, 0xb6, 0xb6, 0x42, 0x54, 0x99, 0x12, 0x31, 0x73, 0x7c, 0x12,
-	0x9e, 0xf0, 0x16, 0x83, 0x2b, 0x98, 0xee, 0xf3, 0x98, 0x42, 0x49, 0x53, 0x8f, 0xce, 0xd1, 0x18,
-	0x91, 0x34, 0x91, 0x46, 0xbc, 0xc5, 0xc5, 0xd7, 0x00, 0xc6, 0x4f, 0x3f, 0xdb, 0xd0, 0x07, 0x98,
-	0xac, 0xf0, 0xa3, 0x5b, 0xb9, 0x98, 0x77, 0x0f, 0xf0, 0x7b, 0x53, 0xef, 0xf2, 0xef, 0x66, 0x33,
-	0x36, 0xf8, 0x47, 0x23, 0x98, 0x75, 0x5c, 0x4b, 0xad, 0xf2, 0x9b, 0xca, 0xa2, 0xe9, 0x27, 0x5d,
-	0xc2, 0xf0, 0xb1, 0x5c, 0x67, 0xa9, 0x79, 0xeb, 0xe7, 0xb9, 0x05, 0xe7, 0x00, 0x69, 0xee, 0xc0,
-	0x8d, 0xac, 0x4e, 0x65, 0xd2, 0x4f, 0xb3, 0x82, 0x69, 0x94, 0x26, 0xf2, 0x60, 0x97, 0x7f, 0x01,
-	0xef, 0x19, 0x75, 0xfa, 0x5a, 0x75, 0x8c, 0xdb, 0x01, 0xc2, 0x96, 0x1a, 0x7b, 0xa9, 0xd7, 0x6e,
-	0xfd, 0x0b, 0xae, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x00, 0x7e, 0x13, 0xf5, 0x1a, 0x03, 0x00,
-	0x00,
+	// 390 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0x4d, 0x6f, 0x95, 0x40,
+	0x14, 0x86, 0x45, 0xf9, 0x3c, 0xd5, 0xb6, 0x4e, 0x6e, 0xcc, 0x78, 0xeb, 0x82, 0xdc, 0x15, 0x89,
+	0x49, 0x17, 0xf5, 0x17, 0xd8, 0xd8, 0x36, 0x36, 0xda, 0x20, 0xf8, 0xb9, 0x32, 0xd3, 0x3b, 0x47,
+	0x9c, 0x04, 0x66, 0x70, 0x66, 0x88, 0xf2, 0x3f, 0x4d, 0xfc, 0x3b, 0x06, 0xb8, 0xdc, 0x62, 0xe2,
+	0x0e, 0x76, 0x3c, 0x73, 0x92, 0xe7, 0xf0, 0xbe, 0x4c, 0x80, 0xc7, 0x56, 0x33, 0x69, 0xd8, 0xd6,
+	0x0a, 0x25, 0x4f, 0x6b, 0xad, 0xac, 0x22, 0x07, 0x93, 0xa3, 0xcd, 0x6f, 0x07, 0x0e, 0xaf, 0x50,
+	0xa2, 0x66, 0x65, 0x86, 0x3f, 0x1a, 0x34, 0x96, 0xac, 0xc0, 0x93, 0x4a, 0x6e, 0x91, 0x3a, 0xb1,
+	0x93, 0x3c, 0xca, 0x06, 0x20, 0x14, 0x02, 0xc6, 0xb9, 0x46, 0x63, 0xe8, 0xfd, 0xd8, 0x49, 0xa2,
+	0x6c, 0x44, 0xb2, 0x86, 0x70, 0xf7, 0x78, 0x46, 0x1f, 0xf4, 0xa3, 0x3d, 0x93, 0x27, 0xe0, 0xb3,
+	0x4a, 0x35, 0xd2, 0xd2, 0xb0, 0x9f, 0xec, 0xa8, 0xb3, 0xd5, 0xac, 0x2d, 0x15, 0xe3, 0xd4, 0x8b,
+	0x9d, 0xe4, 0x61, 0x36, 0x22, 0x39, 0x81, 0xa8, 0x60, 0xe6, 0x6b, 0x29, 0x2a, 0x61, 0xa9, 0x1f,
+	0x3b, 0x89, 0x9b, 0x85, 0x05, 0x33, 0x6f, 0x3a, 0x1e, 0x87, 0xb5, 0x16, 0x5b, 0xa4, 0xd1, 0xb0,
+	0xab, 0x60, 0x26, 0xed, 0xf8, 0xda, 0x0d, 0xdd, 0x63, 0xef, 0xda, 0x0d, 0x83, 0xe3, 0x70, 0xf3,
+	0x1c, 0x8e, 0xf6, 0xa9, 0x4c, 0xad, 0xa4, 0xe9, 0x03, 0x54, 0x68, 0x0c, 0x2b, 0x86, 0x60, 0x51,
+	0x36, 0xe2, 0xd9, 0x9f, 0x00, 0x0e, 0xde, 0xdf, 0x75, 0x42, 0xde, 0xc2, 0xe1, 0x0d, 0xfe, 0x9c,
+	0x9e, 0x9c, 0x9c, 0x4e, 0x6b, 0xfc, 0xb7, 0xaf, 0xf5, 0xb3, 0xff, 0x0f, 0x87, 0xb5, 0x9b, 0x7b,
+	0x24, 0x87, 0xd5, 0xc4, 0x75, 0xa9, 0x55, 0x75, 0xde, 0x5a, 0x34, 0xf3, 0xa4, 0x97, 0x10, 0xa4,
+	0xcd, 0x6d, 0x29, 0xcc, 0xf7, 0x79, 0x9e, 0x57, 0xe0, 0x2d, 0xf0, 0x36, 0x17, 0xe0, 0xe7, 0x56,
+	0x0b, 0x59, 0xcc, 0xd3, 0xdc, 0xc0, 0x51, 0x2e, 0x0a, 0xb9, 0x58, 0xf3, 0x5f, 0x60, 0xfd, 0x11,
+	0xb5, 0xf8, 0xd6, 0x4e, 0x8c, 0xdd, 0x02, 0x66, 0x1b, 0x8d, 0xf3, 0xd4, 0xaf, 0x01, 0xae, 0xd0,
+	0xa6, 0x28, 0xf9, 0xec, 0xd4, 0xef, 0x80, 0xdc, 0xa9, 0xce, 0xdb, 0x1c, 0x25, 0x47, 0x3d, 0x4f,
+	0xf9, 0x19, 0x9e, 0x5e, 0xfc, 0xaa, 0x95, 0xb6, 0x1f, 0xa4, 0x11, 0x85, 0x44, 0xbe, 0x58, 0xa5,
+	0x9f, 0x80, 0xee, 0xee, 0x5d, 0xbe, 0xac, 0x38, 0x87, 0xd5, 0x4b, 0xce, 0x53, 0xa6, 0xad, 0x60,
+	0xe5, 0xfe, 0x23, 0xcd, 0xbb, 0x97, 0xb7, 0x7e, 0xff, 0xc7, 0x7b, 0xf1, 0x77, 0x00, 0xa7, 0xd2,
+	0xaa, 0xf8, 0x06, 0x05, 0x00, 0x00,
 }
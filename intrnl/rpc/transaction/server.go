@@ -1,17 +1,17 @@
 package transaction
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"math/big"
 	"strings"
 	"time"
 
 	"github.com/SummerCash/go-summercash/accounts"
 	"github.com/SummerCash/go-summercash/common"
 	"github.com/SummerCash/go-summercash/config"
+	"github.com/SummerCash/go-summercash/db"
 	transactionProto "github.com/SummerCash/go-summercash/intrnl/rpc/proto/transaction"
+	"github.com/SummerCash/go-summercash/mempool"
 	"github.com/SummerCash/go-summercash/p2p"
 	"github.com/SummerCash/go-summercash/types"
 	"github.com/SummerCash/go-summercash/validator"
@@ -32,36 +32,69 @@ func (server *Server) NewTransaction(ctx context.Context, req *transactionProto.
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
+	amount, err := common.ParseAmount(req.Amount) // Parse amount
+	if err != nil {                               // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	gasPrice := common.NewAmount(nil) // Init gas price buffer
+
+	if req.GasPrice != "" { // Check has gas price
+		gasPrice, err = common.ParseAmount(req.GasPrice) // Parse gas price
+		if err != nil {                                  // Check for errors
+			return &transactionProto.GeneralResponse{}, err // Return found error
+		}
+	}
+
+	pool, err := mempool.GetWorkingMempool() // Get mempool
+	if err != nil {                          // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
 	transaction := types.Transaction{} // Init buffer
 
 	accountChain, err := types.ReadChainFromMemory(sender) // Read account chain from persistent memory
 
 	if err != nil { // Check for errors
-		newTransaction, err := types.NewTransaction(0, nil, &sender, &recipient, big.NewFloat(req.Amount), req.Payload) // Init transaction
-		if err != nil {                                                                                                 // Check for errors
+		newTransaction, err := types.NewTransactionWithGas(pool.NextNonce(sender, 0), nil, &sender, &recipient, amount, req.GasLimit, gasPrice, req.Payload) // Init transaction
+		if err != nil {                                                                                                                                      // Check for errors
 			return &transactionProto.GeneralResponse{}, err // Return found error
 		}
 
 		transaction = *newTransaction // Write tx to buffer
 	} else {
-		nonce := uint64(0)                      // Init nonce
-		lastTransaction := &types.Transaction{} // Init buffer
+		var lastTransaction *types.Transaction // Init parent buffer
 
-		for _, currentTransaction := range accountChain.Transactions { // Iterate through sender txs
-			if currentTransaction.AccountNonce > nonce && bytes.Equal(currentTransaction.Sender.Bytes(), accountChain.Account.Bytes()) { // Check greater than last nonce
-				nonce = currentTransaction.AccountNonce + 1 // Set last nonce
-			}
+		if len(accountChain.Transactions) > 0 { // Check has transactions
+			lastTransaction = accountChain.Transactions[len(accountChain.Transactions)-1] // Set parent to last transaction in chain
 		}
 
-		newTransaction, err := types.NewTransaction(nonce, lastTransaction, &sender, &recipient, big.NewFloat(req.Amount), req.Payload) // Init transaction
-		if err != nil {                                                                                                                 // Check for errors
+		nonce := pool.NextNonce(sender, accountChain.CalculateTargetNonce()) // Get nonce expected by validators, skipping nonces used by pending transactions
+
+		newTransaction, err := types.NewTransactionWithGas(nonce, lastTransaction, &sender, &recipient, amount, req.GasLimit, gasPrice, req.Payload) // Init transaction
+		if err != nil {                                                                                                                              // Check for errors
 			return &transactionProto.GeneralResponse{}, err // Return found error
 		}
 
 		transaction = *newTransaction // Write tx to buffer
 	}
 
-	err = transaction.WriteToMemory() // Write transaction to persistent memory
+	tips, err := db.SelectWorkingDagTips(db.DefaultTipCount) // Select dag tips to approve
+	if err != nil {                                          // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = transaction.ApproveTips(tips) // Approve tips
+
+	if err != nil { // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	if policy, err := accounts.ReadMultisigPolicy(sender); err == nil { // Check multisig sender
+		transaction.Multisig = &types.MultisigSignature{Policy: policy} // Attach policy for co-signers
+	}
+
+	err = pool.Add(&transaction) // Add transaction to mempool
 
 	if err != nil { // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
@@ -93,12 +126,73 @@ func (server *Server) Publish(ctx context.Context, req *transactionProto.General
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	transaction, err := types.ReadTransactionFromMemory(hash) // Read transaction from hash
-	if err != nil {                                           // Check for errors
+	transaction, err := readPendingTransaction(hash) // Read transaction from hash
+	if err != nil {                                  // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	if transaction.Multisig != nil { // Check multisig
+		if !transaction.IsSigned() { // Check threshold not met
+			return &transactionProto.GeneralResponse{}, types.ErrInsufficientSignatures // Return error
+		}
+	} else if transaction.Sender != nil && !accounts.IsUnlocked(*transaction.Sender) { // Check sender locked
+		return &transactionProto.GeneralResponse{}, accounts.ErrAccountLocked // Return error
+	}
+
+	return publishTransaction(ctx, transaction, network) // Publish transaction
+}
+
+// ExportUnsignedTransaction - transaction.ExportUnsignedTransaction RPC handler
+func (server *Server) ExportUnsignedTransaction(ctx context.Context, req *transactionProto.GeneralRequest) (*transactionProto.GeneralResponse, error) {
+	hash, err := common.StringToHash(req.Address) // String to hash
+	if err != nil {                               // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	transaction, err := readPendingTransaction(hash) // Read transaction from hash
+	if err != nil {                                  // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	if transaction.Payload != nil && strings.Contains(string(transaction.Payload), "(") { // Check is contract call
+	blob, err := transaction.UnsignedBlob() // Export transaction
+	if err != nil {                         // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &transactionProto.GeneralResponse{Message: fmt.Sprintf("\n%s", blob)}, nil // Return response
+}
+
+// PublishSignedTransaction - transaction.PublishSignedTransaction RPC handler
+func (server *Server) PublishSignedTransaction(ctx context.Context, req *transactionProto.GeneralRequest) (*transactionProto.GeneralResponse, error) {
+	network := req.Address2 // Get network
+
+	if network == "" { // Check network not set
+		network = "main_net" // Assume main net
+	}
+
+	transaction, err := types.TransactionFromSignedBlob(string(req.Payload)) // Decode transaction, verifying signature
+	if err != nil {                                                          // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	pool, err := mempool.GetWorkingMempool() // Get mempool
+	if err != nil {                          // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = pool.Add(transaction) // Replace unsigned pending transaction
+
+	if err != nil { // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	return publishTransaction(ctx, transaction, network) // Publish transaction
+}
+
+// publishTransaction validates a given signed transaction, adds it to the local sender and
+// recipient chains (and the dag, which indexes them), and broadcasts it to a given network.
+func publishTransaction(ctx context.Context, transaction *types.Transaction, network string) (*transactionProto.GeneralResponse, error) {
+	if isContractCall(transaction) { // Check is contract call
 		return handleContractCall(transaction) // Handle contract call
 	}
 
@@ -138,6 +232,12 @@ func (server *Server) Publish(ctx context.Context, req *transactionProto.General
 		if err != nil { // Check for errors
 			return &transactionProto.GeneralResponse{}, err // Return found error
 		}
+
+		err = db.AddToWorkingDag(network, transaction) // Add transaction to dag
+
+		if err != nil { // Check for errors (the transaction has already been committed to its chains, so must still be broadcast)
+			common.Logf("== DAG == error while adding published tx %s to dag: %s\n", transaction.Hash.String(), err.Error()) // Log error
+		}
 	}
 
 	publishCtx, cancel := context.WithCancel(ctx) // Get context
@@ -158,6 +258,12 @@ func (server *Server) Publish(ctx context.Context, req *transactionProto.General
 
 	err = client.PublishTransaction(publishCtx, transaction) // Publish transaction
 
+	if pool, poolErr := mempool.GetWorkingMempool(); poolErr == nil { // Check has mempool
+		pool.MarkBroadcast(*transaction.Hash, err == nil) // Record broadcast attempt (failed broadcasts are retried by the node)
+
+		pool.RemoveIncluded() // Drop transaction if broadcast
+	}
+
 	if err != nil { // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
@@ -165,6 +271,25 @@ func (server *Server) Publish(ctx context.Context, req *transactionProto.General
 	return &transactionProto.GeneralResponse{Message: fmt.Sprintf("\npublished transaction %s", transaction.Hash)}, nil // Return response
 }
 
+// isContractCall checks whether a given transaction calls a contract: that is, whether it isn't a contract creation and
+// either carries an ABI-encoded call payload or is sent to a contract chain.
+func isContractCall(transaction *types.Transaction) bool {
+	if transaction.ContractCreation || transaction.Recipient == nil { // Check can't be a call
+		return false // Not a call
+	}
+
+	if types.IsABIPayload(transaction.Payload) { // Check is ABI-encoded call
+		return true // Is call
+	}
+
+	chain, err := types.ReadChainFromMemory(*transaction.Recipient) // Read recipient chain
+	if err != nil {                                                 // Check for errors
+		return false // Not a call
+	}
+
+	return chain.ContractSource != nil // Return is contract
+}
+
 func handleContractCall(transaction *types.Transaction) (*transactionProto.GeneralResponse, error) {
 	err := transaction.Publish() // Publish transaction
 	if err != nil {              // Check for errors
@@ -198,7 +323,7 @@ func handleContractCall(transaction *types.Transaction) (*transactionProto.Gener
 	transaction = nil                   // Init tx buffer
 
 	if chain.ContractSource == nil { // Check not contract
-		return &transactionProto.GeneralResponse{Message: fmt.Sprintf("\npublished transaction %s", transaction.Hash)}, nil // Return response
+		return &transactionProto.GeneralResponse{Message: fmt.Sprintf("\npublished transaction %s", txHash.String())}, nil // Return response
 	}
 
 	for time.Now().Sub(startTime) < 5*time.Second { // Async read tx
@@ -233,8 +358,8 @@ func (server *Server) Bytes(ctx context.Context, req *transactionProto.GeneralRe
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	transaction, err := types.ReadTransactionFromMemory(hash) // Read transaction from hash
-	if err != nil {                                           // Check for errors
+	transaction, err := readPendingTransaction(hash) // Read transaction from hash
+	if err != nil {                                  // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -253,8 +378,8 @@ func (server *Server) String(ctx context.Context, req *transactionProto.GeneralR
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	transaction, err := types.ReadTransactionFromMemory(hash) // Read transaction from hash
-	if err != nil {                                           // Check for errors
+	transaction, err := readPendingTransaction(hash) // Read transaction from hash
+	if err != nil {                                  // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -268,13 +393,17 @@ func (server *Server) SignTransaction(ctx context.Context, req *transactionProto
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	transaction, err := types.ReadTransactionFromMemory(hash) // Read transaction from hash
-	if err != nil {                                           // Check for errors
+	transaction, err := readPendingTransaction(hash) // Read transaction from hash
+	if err != nil {                                  // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	account, err := accounts.ReadAccountFromMemory(*transaction.Sender) // Read account
-	if err != nil {                                                     // Check for errors
+	if transaction.Multisig != nil { // Check multisig
+		return signMultisigTransaction(transaction) // Add partial signatures
+	}
+
+	account, err := accounts.GetUnlockedAccount(*transaction.Sender) // Get unlocked sender account
+	if err != nil {                                                  // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -284,7 +413,12 @@ func (server *Server) SignTransaction(ctx context.Context, req *transactionProto
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	err = transaction.WriteToMemory() // Write to memory
+	pool, err := mempool.GetWorkingMempool() // Get mempool
+	if err != nil {                          // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = pool.Add(transaction) // Update pending transaction
 
 	if err != nil { // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
@@ -293,6 +427,30 @@ func (server *Server) SignTransaction(ctx context.Context, req *transactionProto
 	return &transactionProto.GeneralResponse{Message: fmt.Sprintf("\n%s", transaction.Signature.String())}, nil // Return response
 }
 
+// AddPartialSignatures - transaction.AddPartialSignatures RPC handler
+func (server *Server) AddPartialSignatures(ctx context.Context, req *transactionProto.GeneralRequest) (*transactionProto.GeneralResponse, error) {
+	signed, err := types.TransactionFromBlob(string(req.Payload)) // Decode partially-signed transaction
+	if err != nil {                                               // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	transaction, err := readPendingTransaction(*signed.Hash) // Read local copy of transaction
+	if err != nil {                                          // Check for errors
+		transaction = &types.Transaction{} // Init transaction buffer
+
+		*transaction = *signed // Collect signatures for transaction unknown to this node
+
+		transaction.Multisig = nil // Reset signatures (merged below)
+	}
+
+	added, err := types.MergePartialSignatures(transaction, signed) // Merge signatures
+	if err != nil {                                                 // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	return savePartiallySignedTransaction(transaction, added) // Update pending transaction
+}
+
 // VerifyTransactionSignature - transaction.VerifyTransactionSignature RPC handler
 func (server *Server) VerifyTransactionSignature(ctx context.Context, req *transactionProto.GeneralRequest) (*transactionProto.GeneralResponse, error) {
 	hash, err := common.StringToHash(req.Address) // Get hash value
@@ -300,8 +458,8 @@ func (server *Server) VerifyTransactionSignature(ctx context.Context, req *trans
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	transaction, err := types.ReadTransactionFromMemory(hash) // Read transaction from mempool
-	if err != nil {                                           // Check for errors
+	transaction, err := readPendingTransaction(hash) // Read transaction from mempool
+	if err != nil {                                  // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -312,3 +470,104 @@ func (server *Server) VerifyTransactionSignature(ctx context.Context, req *trans
 
 	return &transactionProto.GeneralResponse{Message: fmt.Sprintf("\n%t", verified)}, nil // Return response
 }
+
+// GetPending - transaction.GetPending RPC handler
+func (server *Server) GetPending(ctx context.Context, req *transactionProto.GeneralRequest) (*transactionProto.GeneralResponse, error) {
+	pool, err := mempool.GetWorkingMempool() // Get mempool
+	if err != nil {                          // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &transactionProto.GeneralResponse{Message: fmt.Sprintf("\n%s", stringPending(pool.Pending()))}, nil // Return response
+}
+
+// GetPendingBySender - transaction.GetPendingBySender RPC handler
+func (server *Server) GetPendingBySender(ctx context.Context, req *transactionProto.GeneralRequest) (*transactionProto.GeneralResponse, error) {
+	sender, err := common.StringToAddress(req.Address) // Get sender address
+	if err != nil {                                    // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	pool, err := mempool.GetWorkingMempool() // Get mempool
+	if err != nil {                          // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &transactionProto.GeneralResponse{Message: fmt.Sprintf("\n%s", stringPending(pool.PendingBySender(sender)))}, nil // Return response
+}
+
+// signMultisigTransaction adds a partial signature to a given pending multisig transaction
+// from each unlocked local account in the sender's spending policy.
+func signMultisigTransaction(transaction *types.Transaction) (*transactionProto.GeneralResponse, error) {
+	added := 0 // Init added counter
+
+	for i := range transaction.Multisig.Policy.PublicKeys { // Iterate through co-signers
+		publicKey, err := transaction.Multisig.Policy.PublicKey(uint32(i)) // Get public key
+		if err != nil {                                                    // Check for errors
+			return &transactionProto.GeneralResponse{}, err // Return found error
+		}
+
+		account, err := accounts.GetUnlockedAccount(common.PublicKeyToAddress(publicKey)) // Get unlocked co-signer account
+		if err != nil {                                                                   // Check not unlocked locally
+			continue // Skip co-signer
+		}
+
+		err = types.AddPartialSignature(transaction, account.PrivateKey) // Sign transaction
+
+		if err == types.ErrAlreadySigned { // Check already signed
+			continue // Skip co-signer
+		} else if err != nil { // Check for errors
+			return &transactionProto.GeneralResponse{}, err // Return found error
+		}
+
+		added++ // Increment added
+	}
+
+	if added == 0 { // Check no local co-signers
+		return &transactionProto.GeneralResponse{}, accounts.ErrAccountLocked // Return error
+	}
+
+	return savePartiallySignedTransaction(transaction, added) // Update pending transaction
+}
+
+// savePartiallySignedTransaction updates a given pending multisig transaction in the mempool,
+// reporting the number of signatures collected.
+func savePartiallySignedTransaction(transaction *types.Transaction, added int) (*transactionProto.GeneralResponse, error) {
+	pool, err := mempool.GetWorkingMempool() // Get mempool
+	if err != nil {                          // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = pool.Add(transaction) // Update pending transaction
+
+	if err != nil { // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &transactionProto.GeneralResponse{Message: fmt.Sprintf("\nadded %d signature(s); collected %d of %d required", added, len(transaction.Multisig.Signatures), transaction.Multisig.Policy.Threshold)}, nil // Return response
+}
+
+// readPendingTransaction reads a copy of a pending transaction from the mempool.
+func readPendingTransaction(hash common.Hash) (*types.Transaction, error) {
+	pool, err := mempool.GetWorkingMempool() // Get mempool
+	if err != nil {                          // Check for errors
+		return &types.Transaction{}, err // Return found error
+	}
+
+	return pool.Get(hash) // Get pending transaction
+}
+
+// stringPending converts a list of pending transactions to a string.
+func stringPending(transactions []*types.Transaction) string {
+	if len(transactions) == 0 { // Check no transactions
+		return "no pending transactions" // Return message
+	}
+
+	transactionStrings := []string{} // Init buffer
+
+	for _, transaction := range transactions { // Iterate through transactions
+		transactionStrings = append(transactionStrings, transaction.String()) // Append string
+	}
+
+	return strings.Join(transactionStrings, "\n") // Return joined
+}
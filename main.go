@@ -2,17 +2,24 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/SummerCash/go-summercash/accounts"
 	"github.com/SummerCash/go-summercash/cli"
 	"github.com/SummerCash/go-summercash/common"
 	"github.com/SummerCash/go-summercash/config"
+	"github.com/SummerCash/go-summercash/db"
 	accountsServer "github.com/SummerCash/go-summercash/intrnl/rpc/accounts"
 	chainServer "github.com/SummerCash/go-summercash/intrnl/rpc/chain"
 	commonServer "github.com/SummerCash/go-summercash/intrnl/rpc/common"
@@ -32,7 +39,9 @@ import (
 	transactionServer "github.com/SummerCash/go-summercash/intrnl/rpc/transaction"
 	upnpServer "github.com/SummerCash/go-summercash/intrnl/rpc/upnp"
 	"github.com/SummerCash/go-summercash/p2p"
+	"github.com/SummerCash/go-summercash/types"
 	"github.com/SummerCash/go-summercash/validator"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 var (
@@ -53,14 +62,25 @@ var (
 	disableLogTimeStamp = flag.Bool("silence-timestamps", false, "launch node without terminal timestamp output")                                                          // Init disable log timestamp flag
 	networkFlag         = flag.String("network", "main_net", "launch with a given network")                                                                                // Init network flag
 	skipSyncFlag        = flag.Bool("skip-sync", false, "skip an initial sync")                                                                                            // Init skip sync flag
+	unlockFlag          = flag.String("unlock", "", "comma-separated list of local accounts to unlock on launch (e.g. a genesis account)")                                 // Init unlock flag
+	passphraseFileFlag  = flag.String("passphrase-file", "", "path to a file containing the passphrase of the accounts given via --unlock or --keystore-file")             // Init passphrase file flag
+	signTransactionFlag = flag.String("sign-transaction", "", "sign a given unsigned transaction blob offline with --keystore-file, print the signed blob, and exit")      // Init offline signing flag
+	keystoreFileFlag    = flag.String("keystore-file", "", "path to the keystore file used to sign with --sign-transaction")                                               // Init keystore file flag
+	migrateKeystoreFlag = flag.String("migrate-keystore", "", "encrypt the legacy keystore files of a comma-separated list of local accounts, and exit")                   // Init migrate keystore flag
+	witnessFlag         = flag.String("witness", "", "witness received transactions with a given staking account (which must also be given via --unlock)")                 // Init witness flag
+	importBlockmeshFlag = flag.Bool("import-blockmesh", false, "build the --network dag from existing account chains (one-time migration), and exit")                      // Init import blockmesh flag
+	exportDagFlag       = flag.String("export-dag", "", "print the --network dag in a given format (dot or json), and exit")                                               // Init export dag flag
+	exportFilterFlag    = flag.String("export-filter", "", "comma-separated key=value filters applied by --export-dag (address, since, until, hash, depth)")               // Init export filter flag
 )
 
 func main() {
 	flag.Parse() // Parse flags
 
-	common.DataDir = *dataDirFlag   // Set data-dir
-	common.Silent = *silent         // Set is silent
-	common.NodePort = *nodePortFlag // Set node port
+	common.DataDir = *dataDirFlag                                           // Set data-dir
+	common.DbDir = filepath.FromSlash(fmt.Sprintf("%s/db", common.DataDir)) // Set db dir
+	common.DagDir = filepath.FromSlash(fmt.Sprintf("%s/dag", common.DbDir)) // Set dag dir
+	common.Silent = *silent                                                 // Set is silent
+	common.NodePort = *nodePortFlag                                         // Set node port
 
 	if *disableLogTimeStamp { // Check must disable timestamps
 		common.DisableTimestamps = true // Set timestamps disabled
@@ -72,6 +92,48 @@ func main() {
 		os.Exit(0) // Stop execution
 	}
 
+	if *signTransactionFlag != "" { // Check must sign offline
+		signedBlob, err := signTransactionOffline(*signTransactionFlag, *keystoreFileFlag, *passphraseFileFlag) // Sign transaction
+		if err != nil {                                                                                         // Check for errors
+			panic(err) // Panic
+		}
+
+		fmt.Println(signedBlob) // Log signed transaction
+
+		os.Exit(0) // Stop execution
+	}
+
+	if *migrateKeystoreFlag != "" { // Check must migrate keystore
+		err := migrateKeystore(strings.Split(*migrateKeystoreFlag, ",")) // Migrate keystore
+		if err != nil {                                                  // Check for errors
+			panic(err) // Panic
+		}
+
+		os.Exit(0) // Stop execution
+	}
+
+	if *importBlockmeshFlag { // Check must migrate
+		imported, err := importBlockmesh(*networkFlag) // Import blockmesh
+		if err != nil {                                // Check for errors
+			panic(err) // Panic
+		}
+
+		fmt.Printf("imported %d transactions into the %s dag\n", imported, *networkFlag) // Log import
+
+		os.Exit(0) // Stop execution
+	}
+
+	if *exportDagFlag != "" { // Check must export dag
+		exported, err := exportDag(*networkFlag, *exportDagFlag, *exportFilterFlag) // Export dag
+		if err != nil {                                                             // Check for errors
+			panic(err) // Panic
+		}
+
+		fmt.Println(exported) // Log graph
+
+		os.Exit(0) // Stop execution
+	}
+
 	if *privateNetworkFlag { // Check private network
 		common.ExtIPProviders = []string{} // Set nil providers
 	}
@@ -90,6 +152,14 @@ func main() {
 	}
 
 	if strings.Contains(*rpcAddrFlag, "localhost") { // Check for default RPC address
+		if *unlockFlag != "" { // Check must unlock accounts
+			err := unlockAccounts(strings.Split(*unlockFlag, ","), *passphraseFileFlag) // Unlock accounts
+
+			if err != nil { // Check for errors
+				panic(err) // Panic
+			}
+		}
+
 		startRPCServer() // Start RPC server
 
 		if !*terminalFlag { // Check only daemon
@@ -106,6 +176,151 @@ func main() {
 	}
 }
 
+// unlockAccounts - unlock local accounts with given addresses using the passphrase stored in a given file, until the node exits
+func unlockAccounts(addresses []string, passphraseFile string) error {
+	if passphraseFile == "" { // Check no passphrase file
+		return errors.New("--unlock requires a --passphrase-file") // Return error
+	}
+
+	passphrase, err := ioutil.ReadFile(passphraseFile) // Read passphrase
+	if err != nil {                                    // Check for errors
+		return err // Return found error
+	}
+
+	for _, addressString := range addresses { // Iterate through addresses
+		address, err := common.StringToAddress(strings.TrimSpace(addressString)) // Get address value
+		if err != nil {                                                          // Check for errors
+			return err // Return found error
+		}
+
+		err = accounts.Unlock(address, strings.TrimRight(string(passphrase), "\r\n"), 0) // Unlock account
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// signTransactionOffline - sign a given unsigned transaction blob with the account stored in a given keystore file, without
+// starting a node, touching the network, or modifying the keystore file
+func signTransactionOffline(blob string, keystoreFile string, passphraseFile string) (string, error) {
+	if keystoreFile == "" || passphraseFile == "" { // Check no key
+		return "", errors.New("--sign-transaction requires a --keystore-file and a --passphrase-file") // Return error
+	}
+
+	passphrase, err := ioutil.ReadFile(passphraseFile) // Read passphrase
+	if err != nil {                                    // Check for errors
+		return "", err // Return found error
+	}
+
+	account, err := accounts.ReadAccountFromFile(keystoreFile, strings.TrimRight(string(passphrase), "\r\n")) // Decrypt account
+	if err == accounts.ErrLegacyKeystore {                                                                    // Check legacy unencrypted file
+		return "", fmt.Errorf("%s is a legacy unencrypted keystore file; migrate it with --migrate-keystore on the node that owns it before signing offline", keystoreFile) // Return error
+	} else if err != nil { // Check for errors
+		return "", err // Return found error
+	}
+
+	return types.SignTransactionBlob(blob, account.PrivateKey) // Sign transaction
+}
+
+// migrateKeystore - encrypt the legacy unencrypted keystore files of local accounts with given addresses, prompting for each
+// account's passphrase (and its confirmation) on the terminal
+func migrateKeystore(addresses []string) error {
+	for _, addressString := range addresses { // Iterate through addresses
+		address, err := common.StringToAddress(strings.TrimSpace(addressString)) // Get address value
+		if err != nil {                                                          // Check for errors
+			return err // Return found error
+		}
+
+		fmt.Printf("passphrase for %s: ", address.String()) // Prompt passphrase
+
+		passphrase, err := terminal.ReadPassword(int(os.Stdin.Fd())) // Read passphrase
+		if err != nil {                                              // Check for errors
+			return err // Return found error
+		}
+
+		fmt.Printf("\nconfirm passphrase for %s: ", address.String()) // Prompt confirmation
+
+		confirmation, err := terminal.ReadPassword(int(os.Stdin.Fd())) // Read confirmation
+		if err != nil {                                                // Check for errors
+			return err // Return found error
+		}
+
+		fmt.Println() // End prompt
+
+		err = accounts.MigrateAccount(address, string(passphrase), string(confirmation)) // Migrate account
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+
+		fmt.Printf("migrated keystore file of %s\n", address.String()) // Log migration
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// importBlockmesh - build the dag of a given network from the local account chains, and persist it
+func importBlockmesh(network string) (int, error) {
+	dag, err := db.ImportBlockmesh() // Import blockmesh
+	if err != nil {                  // Check for errors
+		return 0, err // Return found error
+	}
+
+	err = dag.WriteToMemory(network) // Write dag to persistent memory
+
+	if err != nil { // Check for errors
+		return 0, err // Return found error
+	}
+
+	return dag.Len(), nil // Return num imported txs
+}
+
+// exportDag - encode the subgraph of the persisted dag of a given network matching a given comma-separated filter in a given format
+func exportDag(network string, format string, filter string) (string, error) {
+	params := []string{} // Init filter params buffer
+
+	if filter != "" { // Check has filter
+		params = strings.Split(filter, ",") // Split filter
+	}
+
+	graphFilter, err := db.ParseGraphFilter(params) // Parse filter
+	if err != nil {                                 // Check for errors
+		return "", err // Return found error
+	}
+
+	dag, err := db.ReadDagFromMemory(network) // Read dag from persistent memory
+	if err != nil {                           // Check for errors
+		return "", err // Return found error
+	}
+
+	graph, err := dag.ExportGraph(graphFilter) // Export graph
+	if err != nil {                            // Check for errors
+		return "", err // Return found error
+	}
+
+	return graph.Encode(format) // Encode graph
+}
+
+// persistDagOnShutdown - persist the working dag once the node is interrupted or terminated
+func persistDagOnShutdown() {
+	signals := make(chan os.Signal, 1) // Init signal buffer
+
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM) // Listen for shutdown
+
+	<-signals // Wait for shutdown
+
+	err := db.WriteWorkingDag() // Write dag
+
+	if err != nil { // Check for errors
+		common.Logf("== DAG == error while persisting dag on shutdown: %s\n", err.Error()) // Log error
+	}
+
+	os.Exit(0) // Stop execution
+}
+
 // startRPCServer - start RPC server
 func startRPCServer() {
 	err := common.GenerateTLSCertificates("term") // Generate certs
@@ -125,15 +340,17 @@ func startRPCServer() {
 
 	mux := http.NewServeMux() // Init mux
 
-	mux.Handle(cryptoProto.CryptoPathPrefix, cryptoHandler)                                  // Start mux node handler
-	mux.Handle(upnpProto.UpnpPathPrefix, upnpHandler)                                        // Start mux upnp handler
-	mux.Handle(accountsProto.AccountsPathPrefix, accountsHandler)                            // Start mux accounts handler
-	mux.Handle(configProto.ConfigPathPrefix, configHandler)                                  // Start mux config handler
-	mux.Handle(transactionProto.TransactionPathPrefix, transactionHandler)                   // Start mux config handler
-	mux.Handle(chainProto.ChainPathPrefix, chainHandler)                                     // Start mux chain handler
-	mux.Handle(coordinationChainProto.CoordinationChainPathPrefix, coordinationChainHandler) // Start mux coordinationChain handler
-	mux.Handle(commonProto.CommonPathPrefix, commonHandler)                                  // Start mux common handler
-	mux.Handle(p2pProto.P2PPathPrefix, p2pHandler)                                           // Start mux p2p handler
+	mux.Handle(cryptoProto.CryptoPathPrefix, cryptoHandler)                                      // Start mux node handler
+	mux.Handle(upnpProto.UpnpPathPrefix, upnpHandler)                                            // Start mux upnp handler
+	mux.Handle(accountsProto.AccountsPathPrefix, accountsHandler)                                // Start mux accounts handler
+	mux.Handle(configProto.ConfigPathPrefix, configHandler)                                      // Start mux config handler
+	mux.Handle(transactionProto.TransactionPathPrefix, transactionHandler)                       // Start mux config handler
+	mux.Handle(chainProto.ChainPathPrefix, chainHandler)                                         // Start mux chain handler
+	mux.Handle(coordinationChainProto.CoordinationChainPathPrefix, coordinationChainHandler)     // Start mux coordinationChain handler
+	mux.Handle(commonProto.CommonPathPrefix, commonHandler)                                      // Start mux common handler
+	mux.Handle(p2pProto.P2PPathPrefix, p2pHandler)                                               // Start mux p2p handler
+	mux.Handle(chainServer.LogSubscriptionPath, &chainServer.LogSubscriptionHandler{})           // Start mux log subscription handler
+	mux.Handle(chainServer.ConflictSubscriptionPath, &chainServer.ConflictSubscriptionHandler{}) // Start mux conflict subscription handler
 
 	go http.ListenAndServeTLS(":"+strconv.Itoa(*rpcPortFlag), "termCert.pem", "termKey.pem", mux) // Start server
 	go http.ListenAndServe(":"+strconv.Itoa(*rpcPortFlag+1), mux)                                 // Start server
@@ -171,10 +388,32 @@ func startNode(archivalNode bool) {
 		panic(err) // Panic
 	}
 
+	dag, err := db.GetWorkingDag(*networkFlag) // Load dag
+	if err != nil {                            // Check for errors
+		panic(err) // Panic
+	}
+
+	if localChains, _ := types.GetAllLocalizedChains(); dag.Root == nil && len(localChains) > 0 { // Check chains predate dag
+		common.Logf("== DAG == no dag found for existing chains; run with --import-blockmesh to build one\n") // Log migration hint
+	}
+
+	go persistDagOnShutdown() // Persist dag on shutdown
+
+	go db.StartIntermittentCheckpoint(5 * time.Minute) // Start checkpointing dag
+
 	validator := validator.Validator(validator.NewStandardValidator(config)) // Initialize validator
 
 	client := p2p.NewClient(host, &validator, *networkFlag) // Initialize client
 
+	if *witnessFlag != "" { // Check must witness
+		witness, err := common.StringToAddress(*witnessFlag) // Parse witness address
+		if err != nil {                                      // Check for errors
+			panic(err) // Panic
+		}
+
+		client.Witness = &witness // Set witness account
+	}
+
 	if p2p.GetBestBootstrapAddress(ctx, host, *networkFlag) != "localhost" && !*skipSyncFlag { // Check can sync
 		err = client.SyncNetwork() // Sync network
 
@@ -189,6 +428,8 @@ func startNode(archivalNode bool) {
 		panic(err) // Panic
 	}
 
+	go client.StartIntermittentRebroadcast(30 * time.Second) // Start retrying pending mempool transactions
+
 	if !*terminalFlag { // Check is not locally running terminal
 		client.StartIntermittentSync(60 * time.Second) // Start intermittent sync
 	} else { // Check local term
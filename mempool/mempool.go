@@ -0,0 +1,375 @@
+// Package mempool implements a bounded pool of pending (not yet included in any chain) transactions.
+package mempool
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+const (
+	// DefaultMaxSize is the default maximum number of transactions held in a mempool.
+	DefaultMaxSize = 4096
+
+	// DefaultMaxAge is the default maximum amount of time a transaction may be held in a mempool.
+	DefaultMaxAge = 24 * time.Hour
+)
+
+var (
+	// ErrNilSender is an error definition describing a transaction without a sender.
+	ErrNilSender = errors.New("transaction has no sender")
+
+	// ErrNilHash is an error definition describing a transaction without a hash.
+	ErrNilHash = errors.New("transaction has no hash")
+
+	// ErrReplacementUnderpriced is an error definition describing a transaction attempting to replace a pending
+	// transaction with the same sender and nonce without a higher amount.
+	ErrReplacementUnderpriced = errors.New("replacement transaction must have a higher amount than the pending transaction")
+
+	// ErrNotPending is an error definition describing a transaction that could not be found in the mempool.
+	ErrNotPending = errors.New("transaction is not pending")
+)
+
+// Entry is a single pending transaction, along with its broadcast metadata.
+type Entry struct {
+	Transaction *types.Transaction `json:"transaction"` // Pending transaction
+
+	Received time.Time `json:"received"` // Time at which the transaction entered the mempool
+
+	LastBroadcast     time.Time `json:"last_broadcast"`     // Time of the last broadcast attempt
+	BroadcastAttempts int       `json:"broadcast_attempts"` // Number of broadcast attempts
+	Broadcast         bool      `json:"broadcast"`          // Whether or not the transaction has been broadcast successfully
+}
+
+// Mempool is a pool of pending transactions keyed by sender and nonce.
+type Mempool struct {
+	MaxSize int           `json:"max_size"` // Maximum number of pending transactions
+	MaxAge  time.Duration `json:"max_age"`  // Maximum age of a pending transaction
+
+	Path string `json:"path"` // Path to persist the mempool to (in-memory only if empty)
+
+	entries map[entryKey]*Entry      // Pending transactions
+	hashes  map[common.Hash]entryKey // Entry keys by transaction hash
+
+	journal []*persistedRecord // Changes not yet appended to the log
+	logged  int                // Number of records in the log
+
+	lock sync.Mutex // Mempool lock
+}
+
+// entryKey is a sender, nonce mempool key.
+type entryKey struct {
+	sender common.Address // Transaction sender
+	nonce  uint64         // Transaction account nonce
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMempool initializes a new, empty in-memory mempool.
+func NewMempool(maxSize int, maxAge time.Duration) *Mempool {
+	return &Mempool{
+		MaxSize: maxSize,                        // Set max size
+		MaxAge:  maxAge,                         // Set max age
+		entries: make(map[entryKey]*Entry),      // Init entries
+		hashes:  make(map[common.Hash]entryKey), // Init hashes
+	} // Return mempool
+}
+
+// Add adds a given transaction to the mempool. A transaction with the same sender and
+// nonce as a pending transaction replaces it only if it has a higher amount (or is a
+// newer copy of the same transaction, e.g. once signed). When the mempool is full, the
+// oldest pending transaction is evicted.
+func (pool *Mempool) Add(transaction *types.Transaction) error {
+	if transaction.Sender == nil { // Check no sender
+		return ErrNilSender // Return error
+	} else if transaction.Hash == nil { // Check no hash
+		return ErrNilHash // Return error
+	}
+
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	pool.evictExpired() // Evict expired transactions
+
+	key := entryKey{sender: *transaction.Sender, nonce: transaction.AccountNonce} // Get key
+
+	if existing, ok := pool.entries[key]; ok { // Check already has pending transaction for nonce
+		if *existing.Transaction.Hash == *transaction.Hash { // Check same transaction
+			existing.Transaction = transaction // Update transaction
+
+			pool.logEntry(key) // Record change
+
+			return pool.save() // Persist
+		}
+
+		if transaction.Amount == nil || existing.Transaction.Amount != nil && transaction.Amount.Cmp(existing.Transaction.Amount) <= 0 { // Check not worth more
+			return ErrReplacementUnderpriced // Return error
+		}
+
+		common.Logf("== MEMPOOL == replacing pending transaction %s with %s\n", existing.Transaction.Hash.String(), transaction.Hash.String()) // Log replace
+
+		delete(pool.hashes, *existing.Transaction.Hash) // Remove replaced hash
+	} else if pool.MaxSize > 0 && len(pool.entries) >= pool.MaxSize { // Check full
+		pool.evictOldest() // Make room
+	}
+
+	pool.entries[key] = &Entry{
+		Transaction: transaction, // Set transaction
+		Received:    time.Now(),  // Set received
+	} // Set entry
+
+	pool.hashes[*transaction.Hash] = key // Index hash
+
+	pool.logEntry(key) // Record change
+
+	return pool.save() // Persist
+}
+
+// Get gets a copy of a pending transaction with a given hash. Changes to the copy
+// (e.g. signing it) are only kept once it is added back to the mempool.
+func (pool *Mempool) Get(hash common.Hash) (*types.Transaction, error) {
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	key, ok := pool.hashes[hash] // Get key
+
+	if !ok { // Check not pending
+		return &types.Transaction{}, ErrNotPending // Return error
+	}
+
+	return types.TransactionFromBytes(pool.entries[key].Transaction.Bytes()) // Return copy of transaction
+}
+
+// Remove removes a pending transaction with a given hash.
+func (pool *Mempool) Remove(hash common.Hash) error {
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	key, ok := pool.hashes[hash] // Get key
+
+	if !ok { // Check not pending
+		return ErrNotPending // Return error
+	}
+
+	pool.remove(key) // Remove entry
+
+	return pool.save() // Persist
+}
+
+// Pending gets all pending transactions, ordered by sender and nonce.
+func (pool *Mempool) Pending() []*types.Transaction {
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	pool.evictExpired() // Evict expired transactions
+
+	transactions := []*types.Transaction{} // Init buffer
+
+	for _, key := range pool.sortedKeys() { // Iterate through keys
+		transactions = append(transactions, pool.entries[key].Transaction) // Append transaction
+	}
+
+	return transactions // Return transactions
+}
+
+// PendingBySender gets all pending transactions sent by a given address, ordered by nonce.
+func (pool *Mempool) PendingBySender(sender common.Address) []*types.Transaction {
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	pool.evictExpired() // Evict expired transactions
+
+	transactions := []*types.Transaction{} // Init buffer
+
+	for _, key := range pool.sortedKeys() { // Iterate through keys
+		if key.sender == sender { // Check is sender
+			transactions = append(transactions, pool.entries[key].Transaction) // Append transaction
+		}
+	}
+
+	return transactions // Return transactions
+}
+
+// NextNonce calculates the next free nonce for a given sender, given the next nonce
+// of its chain.
+func (pool *Mempool) NextNonce(sender common.Address, chainNonce uint64) uint64 {
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	nonce := chainNonce // Init nonce
+
+	for key := range pool.entries { // Iterate through pending transactions
+		if key.sender == sender && key.nonce >= nonce { // Check nonce taken
+			nonce = key.nonce + 1 // Set nonce
+		}
+	}
+
+	return nonce // Return nonce
+}
+
+// DueForBroadcast gets all signed pending transactions that have not yet been broadcast
+// successfully, and that have not been attempted within the given interval, ordered by
+// sender and nonce.
+func (pool *Mempool) DueForBroadcast(interval time.Duration) []*types.Transaction {
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	pool.evictExpired() // Evict expired transactions
+
+	transactions := []*types.Transaction{} // Init buffer
+
+	for _, key := range pool.sortedKeys() { // Iterate through keys
+		entry := pool.entries[key] // Get entry
+
+		if entry.Transaction.IsSigned() && !entry.Broadcast && time.Since(entry.LastBroadcast) >= interval { // Check due
+			transactions = append(transactions, entry.Transaction) // Append transaction
+		}
+	}
+
+	return transactions // Return transactions
+}
+
+// MarkBroadcast records a broadcast attempt for a pending transaction with a given hash.
+func (pool *Mempool) MarkBroadcast(hash common.Hash, successful bool) error {
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	key, ok := pool.hashes[hash] // Get key
+
+	if !ok { // Check not pending
+		return ErrNotPending // Return error
+	}
+
+	entry := pool.entries[key] // Get entry
+
+	entry.LastBroadcast = time.Now() // Set last broadcast
+	entry.BroadcastAttempts++        // Increment attempts
+	entry.Broadcast = successful     // Set broadcast
+
+	pool.logEntry(key) // Record change
+
+	return pool.save() // Persist
+}
+
+// RemoveIncluded drops every pending transaction that has landed in a chain in the
+// working chain store, or whose sender and nonce have been taken by a transaction
+// that has. Transactions still awaiting a broadcast retry are kept until they have
+// been broadcast successfully.
+func (pool *Mempool) RemoveIncluded() error {
+	store, err := types.GetChainStore() // Get working chain store
+	if err != nil {                     // Check for errors
+		return err // Return found error
+	}
+
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	removed := 0 // Init removed counter
+
+	for key, entry := range pool.entries { // Iterate through pending transactions
+		if entry.BroadcastAttempts > 0 && !entry.Broadcast { // Check awaiting retry
+			continue // Skip
+		}
+
+		included, err := store.QueryTransactionByNonce(key.sender, key.nonce) // Query chain transaction with nonce
+		if err != nil {                                                       // Check not included
+			continue // Skip
+		}
+
+		if *included.Hash != *entry.Transaction.Hash { // Check nonce taken by another transaction
+			common.Logf("== MEMPOOL == dropping %s, nonce used by %s\n", entry.Transaction.Hash.String(), included.Hash.String()) // Log drop
+		}
+
+		pool.remove(key) // Remove entry
+
+		removed++ // Increment counter
+	}
+
+	if removed == 0 { // Check nothing changed
+		return nil // Nothing to persist
+	}
+
+	common.Logf("== MEMPOOL == dropped %d included transactions\n", removed) // Log remove
+
+	return pool.save() // Persist
+}
+
+// Len gets the number of pending transactions.
+func (pool *Mempool) Len() int {
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	return len(pool.entries) // Return length
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// evictExpired removes all entries older than the max age. Changes are persisted by the caller.
+func (pool *Mempool) evictExpired() {
+	if pool.MaxAge <= 0 { // Check no max age
+		return // Nothing to do
+	}
+
+	for key, entry := range pool.entries { // Iterate through entries
+		if time.Since(entry.Received) > pool.MaxAge { // Check expired
+			pool.remove(key) // Remove entry
+		}
+	}
+}
+
+// evictOldest removes the entry that has been pending the longest.
+func (pool *Mempool) evictOldest() {
+	var oldestKey entryKey // Init key buffer
+	var oldest *Entry      // Init entry buffer
+
+	for key, entry := range pool.entries { // Iterate through entries
+		if oldest == nil || entry.Received.Before(oldest.Received) { // Check older
+			oldestKey = key // Set key
+			oldest = entry  // Set entry
+		}
+	}
+
+	if oldest != nil { // Check found entry
+		common.Logf("== MEMPOOL == mempool full, evicting %s\n", oldest.Transaction.Hash.String()) // Log evict
+
+		pool.remove(oldestKey) // Remove entry
+	}
+}
+
+// remove removes an entry with a given key, recording the removal to be appended to the log when the mempool is next saved.
+func (pool *Mempool) remove(key entryKey) {
+	hash := *pool.entries[key].Transaction.Hash // Get hash
+
+	delete(pool.hashes, hash) // Remove hash
+	delete(pool.entries, key) // Remove entry
+
+	pool.journal = append(pool.journal, &persistedRecord{Removed: &hash}) // Record change
+}
+
+// sortedKeys gets all entry keys, sorted by sender and nonce.
+func (pool *Mempool) sortedKeys() []entryKey {
+	keys := []entryKey{} // Init buffer
+
+	for key := range pool.entries { // Iterate through entries
+		keys = append(keys, key) // Append key
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].sender != keys[j].sender { // Check different senders
+			return bytes.Compare(keys[i].sender[:], keys[j].sender[:]) < 0 // Order by sender
+		}
+
+		return keys[i].nonce < keys[j].nonce // Order by nonce
+	}) // Sort keys
+
+	return keys // Return keys
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,401 @@
+// Package mempool implements a bounded pool of pending (not yet included in any chain) transactions.
+package mempool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+// persistedEntry is the on-disk representation of a mempool entry.
+type persistedEntry struct {
+	Transaction []byte `json:"transaction"` // Canonically-encoded transaction
+
+	Received time.Time `json:"received"` // Time at which the transaction entered the mempool
+
+	LastBroadcast     time.Time `json:"last_broadcast"`     // Time of the last broadcast attempt
+	BroadcastAttempts int       `json:"broadcast_attempts"` // Number of broadcast attempts
+	Broadcast         bool      `json:"broadcast"`          // Whether or not the transaction has been broadcast successfully
+}
+
+// persistedRecord is a single mempool log record, either adding (or updating) an entry, or removing the entry holding a
+// transaction with a given hash.
+type persistedRecord struct {
+	Entry *persistedEntry `json:"entry,omitempty"` // Added or updated entry
+
+	Removed *common.Hash `json:"removed,omitempty"` // Hash of the removed transaction
+}
+
+// recordHeaderSize is the size of the length prefix of each log record.
+const recordHeaderSize = 4
+
+// minCompactionRecords is the minimum number of records a mempool log may hold beyond twice the number of pending
+// transactions before it is compacted.
+const minCompactionRecords = 1024
+
+var (
+	// ErrCorruptMempool is an error definition describing a mempool log that could not be read.
+	ErrCorruptMempool = errors.New("corrupt mempool")
+
+	workingMempool     *Mempool   // Working mempool
+	workingMempoolLock sync.Mutex // Working mempool lock
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// GetWorkingMempool gets the node's working mempool, reading it from the current data
+// directory (or initializing a new one there) if it has not been loaded yet. Pending
+// transactions left in the legacy pending transaction directory are moved into it.
+func GetWorkingMempool() (*Mempool, error) {
+	workingMempoolLock.Lock()         // Lock working mempool
+	defer workingMempoolLock.Unlock() // Unlock working mempool
+
+	path := filepath.FromSlash(fmt.Sprintf("%s/mem/mempool.log", common.DataDir)) // Get mempool path
+
+	if workingMempool != nil && workingMempool.Path == path { // Check already loaded
+		return workingMempool, nil // Return working mempool
+	}
+
+	pool, err := ReadMempoolFromMemory(path) // Read mempool
+	if os.IsNotExist(err) {                  // Check no mempool
+		pool = NewMempool(DefaultMaxSize, DefaultMaxAge) // Init mempool
+		pool.Path = path                                 // Set path
+	} else if err != nil { // Check for errors
+		return &Mempool{}, err // Return found error
+	}
+
+	err = pool.migratePendingTransactions(filepath.FromSlash(fmt.Sprintf("%s/mem/pending_tx", common.DataDir))) // Migrate legacy pending transactions
+
+	if err != nil { // Check for errors
+		return &Mempool{}, err // Return found error
+	}
+
+	workingMempool = pool // Set working mempool
+
+	return pool, nil // Return mempool
+}
+
+// WriteToMemory persists the mempool to its path, compacting its log.
+func (pool *Mempool) WriteToMemory() error {
+	pool.lock.Lock()         // Lock mempool
+	defer pool.lock.Unlock() // Unlock mempool
+
+	if pool.Path == "" { // Check in-memory only
+		return nil // Nothing to do
+	}
+
+	return pool.rewriteLog() // Rewrite log
+}
+
+// ReadMempoolFromMemory reads a mempool persisted at a given path, using the default
+// size and age bounds. A partially-written trailing record (e.g. from a crash
+// mid-write) is truncated.
+func ReadMempoolFromMemory(path string) (*Mempool, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0600) // Open log
+	if err != nil {                                 // Check for errors
+		return &Mempool{}, err // Return found error
+	}
+
+	defer file.Close() // Close log
+
+	info, err := file.Stat() // Get log info
+	if err != nil {          // Check for errors
+		return &Mempool{}, err // Return found error
+	}
+
+	pool := NewMempool(DefaultMaxSize, DefaultMaxAge) // Init mempool
+
+	reader := bufio.NewReader(file) // Init reader
+
+	offset := int64(0) // Init offset
+
+	for {
+		record, size, err := readRecord(reader, info.Size()-offset) // Read record
+		if err == io.EOF {                                          // Check done
+			break
+		} else if err == io.ErrUnexpectedEOF || (err == ErrCorruptMempool && offset+size == info.Size()) { // Check torn write
+			common.Logf("== MEMPOOL == truncating partial record at offset %d in mempool\n", offset) // Log truncate
+
+			if err = file.Truncate(offset); err != nil { // Truncate log
+				return &Mempool{}, err // Return found error
+			}
+
+			break
+		} else if err != nil { // Check for errors
+			return &Mempool{}, err // Return found error
+		}
+
+		err = pool.apply(record) // Apply record
+
+		if err != nil { // Check for errors
+			return &Mempool{}, err // Return found error
+		}
+
+		offset += size // Increment offset
+		pool.logged++  // Increment num logged records
+	}
+
+	pool.Path = path // Set path
+
+	return pool, nil // Return read mempool
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// migratePendingTransactions adds each transaction in a given legacy pending transaction
+// directory to the mempool, removing the directory once every transaction in it has
+// been read.
+func (pool *Mempool) migratePendingTransactions(dir string) error {
+	files, err := ioutil.ReadDir(dir) // Read legacy pending transactions
+	if os.IsNotExist(err) {           // Check nothing to migrate
+		return nil // Nothing to do
+	} else if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	for _, file := range files { // Iterate through legacy pending transactions
+		path := filepath.Join(dir, file.Name()) // Get transaction path
+
+		data, err := ioutil.ReadFile(path) // Read transaction
+		if err != nil {                    // Check for errors
+			return err // Return found error
+		}
+
+		transaction, err := types.TransactionFromJSON(data) // Decode transaction
+		if err != nil {                                     // Check for errors
+			common.Logf("== MEMPOOL == skipping unreadable legacy pending transaction %s: %s\n", file.Name(), err.Error()) // Log skip
+
+			continue // Keep file
+		}
+
+		if err = pool.Add(transaction); err != nil { // Add transaction
+			common.Logf("== MEMPOOL == dropping legacy pending transaction %s: %s\n", file.Name(), err.Error()) // Log drop
+		}
+
+		if err = os.Remove(path); err != nil { // Remove migrated transaction
+			return err // Return found error
+		}
+	}
+
+	if err = os.Remove(dir); err != nil { // Remove directory (kept if unreadable transactions remain)
+		common.Logf("== MEMPOOL == keeping legacy pending transaction directory: %s\n", err.Error()) // Log keep
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// save appends the changes made since the mempool was last persisted to its log, if it has a path, compacting the log
+// once it holds too many stale records. Assumes the mempool is locked.
+func (pool *Mempool) save() error {
+	if pool.Path == "" { // Check in-memory only
+		pool.journal = nil // Drop changes
+
+		return nil // Nothing to do
+	}
+
+	if _, err := os.Stat(pool.Path); err != nil || pool.logged+len(pool.journal) > 2*len(pool.entries)+minCompactionRecords { // Check must compact
+		return pool.rewriteLog() // Rewrite log
+	}
+
+	file, err := os.OpenFile(pool.Path, os.O_WRONLY|os.O_APPEND, 0600) // Open log
+	if err != nil {                                                    // Check for errors
+		return err // Return found error
+	}
+
+	err = writeRecords(file, pool.journal) // Append changes
+
+	if err != nil { // Check for errors
+		file.Close() // Close log
+
+		return err // Return found error
+	}
+
+	pool.logged += len(pool.journal) // Increment num logged records
+	pool.journal = nil               // Reset changes
+
+	return file.Close() // Close log
+}
+
+// rewriteLog writes an add record for each entry in the mempool to a new log, replacing its existing log. Assumes the
+// mempool is locked.
+func (pool *Mempool) rewriteLog() error {
+	err := common.CreateDirIfDoesNotExist(filepath.Dir(pool.Path)) // Create mem dir
+	if err != nil {                                                // Check for errors
+		return err // Return found error
+	}
+
+	records := []*persistedRecord{} // Init records buffer
+
+	for _, key := range pool.sortedKeys() { // Iterate through entries
+		records = append(records, newEntryRecord(pool.entries[key])) // Append record
+	}
+
+	file, err := os.OpenFile(pool.Path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600) // Create temporary log
+	if err != nil {                                                                      // Check for errors
+		return err // Return found error
+	}
+
+	err = writeRecords(file, records) // Write records
+
+	if err != nil { // Check for errors
+		file.Close() // Close log
+
+		return err // Return found error
+	}
+
+	err = file.Close() // Close log
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	err = os.Rename(pool.Path+".tmp", pool.Path) // Replace log
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	pool.logged = len(records) // Set num logged records
+	pool.journal = nil         // Reset changes
+
+	return nil // No error occurred, return nil
+}
+
+// apply applies a given log record to the mempool (ignoring its size and age bounds).
+func (pool *Mempool) apply(record *persistedRecord) error {
+	if record.Removed != nil { // Check is removal
+		if key, ok := pool.hashes[*record.Removed]; ok { // Check pending
+			delete(pool.entries, key)            // Remove entry
+			delete(pool.hashes, *record.Removed) // Remove hash
+		}
+
+		return nil // No error occurred, return nil
+	}
+
+	if record.Entry == nil { // Check empty record
+		return ErrCorruptMempool // Return error
+	}
+
+	transaction, err := types.TransactionFromBytes(record.Entry.Transaction) // Decode transaction
+	if err != nil {                                                          // Check for errors
+		return err // Return found error
+	}
+
+	if transaction.Sender == nil || transaction.Hash == nil { // Check invalid entry
+		return nil // Skip
+	}
+
+	key := entryKey{sender: *transaction.Sender, nonce: transaction.AccountNonce} // Get key
+
+	if existing, ok := pool.entries[key]; ok { // Check replacing entry
+		delete(pool.hashes, *existing.Transaction.Hash) // Remove replaced hash
+	}
+
+	pool.entries[key] = &Entry{
+		Transaction:       transaction,                    // Set transaction
+		Received:          record.Entry.Received,          // Set received
+		LastBroadcast:     record.Entry.LastBroadcast,     // Set last broadcast
+		BroadcastAttempts: record.Entry.BroadcastAttempts, // Set attempts
+		Broadcast:         record.Entry.Broadcast,         // Set broadcast
+	} // Set entry
+
+	pool.hashes[*transaction.Hash] = key // Index hash
+
+	return nil // No error occurred, return nil
+}
+
+// logEntry records the entry with a given key as added or updated, to be appended to the log when the mempool is next
+// saved. Assumes the mempool is locked.
+func (pool *Mempool) logEntry(key entryKey) {
+	pool.journal = append(pool.journal, newEntryRecord(pool.entries[key])) // Append change
+}
+
+// newEntryRecord encodes a given entry as a log record.
+func newEntryRecord(entry *Entry) *persistedRecord {
+	return &persistedRecord{
+		Entry: &persistedEntry{
+			Transaction:       entry.Transaction.Bytes(), // Set transaction
+			Received:          entry.Received,            // Set received
+			LastBroadcast:     entry.LastBroadcast,       // Set last broadcast
+			BroadcastAttempts: entry.BroadcastAttempts,   // Set attempts
+			Broadcast:         entry.Broadcast,           // Set broadcast
+		}, // Set entry
+	} // Return record
+}
+
+// writeRecords writes a length-prefixed encoding of each of a given set of records to a given writer.
+func writeRecords(writer io.Writer, records []*persistedRecord) error {
+	buffered := bufio.NewWriter(writer) // Init buffered writer
+
+	for _, record := range records { // Iterate through records
+		encoded, err := json.Marshal(record) // Encode record
+		if err != nil {                      // Check for errors
+			return err // Return found error
+		}
+
+		header := make([]byte, recordHeaderSize) // Init header buffer
+
+		binary.BigEndian.PutUint32(header, uint32(len(encoded))) // Write length
+
+		buffered.Write(header) // Write header
+
+		_, err = buffered.Write(encoded) // Write record
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+	}
+
+	return buffered.Flush() // Flush records
+}
+
+// readRecord reads a single length-prefixed record from a reader with a given number of bytes remaining, returning the
+// record and its size in the log. A record whose length exceeds the remaining bytes is treated as a torn write, and the
+// size of a record that can't be decoded is still returned.
+func readRecord(reader io.Reader, remaining int64) (*persistedRecord, int64, error) {
+	header := make([]byte, recordHeaderSize) // Init header buffer
+
+	_, err := io.ReadFull(reader, header) // Read header
+	if err != nil {                       // Check for errors
+		return nil, 0, err // Return found error
+	}
+
+	length := int64(binary.BigEndian.Uint32(header)) // Get record length
+
+	if length > remaining-recordHeaderSize { // Check record runs past end of log
+		return nil, 0, io.ErrUnexpectedEOF // Return torn write
+	}
+
+	encoded := make([]byte, length) // Init record buffer
+
+	_, err = io.ReadFull(reader, encoded) // Read record
+	if err == io.EOF {                    // Check header without body
+		return nil, 0, io.ErrUnexpectedEOF // Return torn write
+	} else if err != nil { // Check for errors
+		return nil, 0, err // Return found error
+	}
+
+	record := &persistedRecord{} // Init record buffer
+
+	err = json.Unmarshal(encoded, record) // Decode record
+	if err != nil {                       // Check for errors
+		return nil, recordHeaderSize + length, ErrCorruptMempool // Return error
+	}
+
+	return record, recordHeaderSize + length, nil // Return record
+}
+
+/* END INTERNAL METHODS */
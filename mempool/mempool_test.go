@@ -0,0 +1,378 @@
+package mempool
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/types"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestAdd - test functionality of mempool transaction addition, replacement
+func TestAdd(t *testing.T) {
+	pool := NewMempool(DefaultMaxSize, DefaultMaxAge) // Init mempool
+
+	privateKey, sender := newTestAccount(t) // Init account
+
+	transaction := newTestTransaction(t, privateKey, sender, 0, 1) // Init transaction
+
+	err := pool.Add(transaction) // Add transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err = pool.Add(newTestTransaction(t, privateKey, sender, 0, 1)); err != ErrReplacementUnderpriced { // Check underpriced replacement rejected
+		t.Fatalf("expected underpriced replacement to be rejected, got %v", err) // Panic
+	}
+
+	replacement := newTestTransaction(t, privateKey, sender, 0, 2) // Init replacement
+
+	err = pool.Add(replacement) // Replace transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if pool.Len() != 1 { // Check replaced
+		t.Fatalf("invalid mempool size: %d", pool.Len()) // Panic
+	}
+
+	if _, err = pool.Get(*transaction.Hash); err != ErrNotPending { // Check replaced transaction dropped
+		t.Fatal("replaced transaction still pending") // Panic
+	}
+
+	pending, err := pool.Get(*replacement.Hash) // Get replacement
+	if err != nil {                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	pending.Signature = nil // Modify copy
+
+	if pending, err = pool.Get(*replacement.Hash); err != nil || !pending.IsSigned() { // Check pooled transaction unchanged
+		t.Fatalf("expected pooled transaction to be unchanged by modifying a copy (%v)", err) // Panic
+	}
+}
+
+// TestEviction - test size- and age-based mempool eviction
+func TestEviction(t *testing.T) {
+	pool := NewMempool(2, time.Hour) // Init mempool
+
+	privateKey, sender := newTestAccount(t) // Init account
+
+	transactions := []*types.Transaction{} // Init buffer
+
+	for nonce := uint64(0); nonce < 3; nonce++ { // Add transactions
+		transaction := newTestTransaction(t, privateKey, sender, nonce, 1) // Init transaction
+
+		err := pool.Add(transaction) // Add transaction
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		transactions = append(transactions, transaction) // Append transaction
+
+		pool.entries[entryKey{sender: sender, nonce: nonce}].Received = time.Now().Add(time.Duration(int(nonce)-3) * time.Minute) // Order by age
+	}
+
+	if pool.Len() != 2 { // Check bounded
+		t.Fatalf("invalid mempool size: %d", pool.Len()) // Panic
+	}
+
+	if _, err := pool.Get(*transactions[0].Hash); err != ErrNotPending { // Check oldest evicted
+		t.Fatal("oldest transaction was not evicted") // Panic
+	}
+
+	pool.entries[entryKey{sender: sender, nonce: 1}].Received = time.Now().Add(-2 * time.Hour) // Expire transaction
+
+	if pending := pool.Pending(); len(pending) != 1 || *pending[0].Hash != *transactions[2].Hash { // Check expired transaction evicted
+		t.Fatalf("expected expired transaction to be evicted, got %d pending", len(pending)) // Panic
+	}
+}
+
+// TestPendingBySender - test functionality of per-sender pending transaction queries
+func TestPendingBySender(t *testing.T) {
+	pool := NewMempool(DefaultMaxSize, DefaultMaxAge) // Init mempool
+
+	privateKey, sender := newTestAccount(t)           // Init account
+	otherPrivateKey, otherSender := newTestAccount(t) // Init other account
+
+	for _, nonce := range []uint64{2, 0, 1} { // Add out of order
+		err := pool.Add(newTestTransaction(t, privateKey, sender, nonce, 1)) // Add transaction
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	err := pool.Add(newTestTransaction(t, otherPrivateKey, otherSender, 0, 1)) // Add other sender's transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	pending := pool.PendingBySender(sender) // Get pending
+
+	if len(pending) != 3 { // Check length
+		t.Fatalf("invalid number of pending transactions: %d", len(pending)) // Panic
+	}
+
+	for i, transaction := range pending { // Iterate through pending
+		if transaction.AccountNonce != uint64(i) { // Check ordered
+			t.Fatalf("pending transactions not ordered by nonce: %d at %d", transaction.AccountNonce, i) // Panic
+		}
+	}
+
+	if nonce := pool.NextNonce(sender, 0); nonce != 3 { // Check next nonce
+		t.Fatalf("invalid next nonce: %d", nonce) // Panic
+	}
+
+	if nonce := pool.NextNonce(sender, 5); nonce != 5 { // Check chain nonce respected
+		t.Fatalf("invalid next nonce: %d", nonce) // Panic
+	}
+}
+
+// TestMarkBroadcast - test broadcast retry bookkeeping
+func TestMarkBroadcast(t *testing.T) {
+	pool := NewMempool(DefaultMaxSize, DefaultMaxAge) // Init mempool
+
+	privateKey, sender := newTestAccount(t) // Init account
+
+	transaction := newTestTransaction(t, privateKey, sender, 0, 1) // Init transaction
+
+	err := pool.Add(transaction) // Add transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if due := pool.DueForBroadcast(0); len(due) != 1 { // Check due
+		t.Fatalf("invalid number of due transactions: %d", len(due)) // Panic
+	}
+
+	err = pool.MarkBroadcast(*transaction.Hash, false) // Record failed broadcast
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if due := pool.DueForBroadcast(time.Hour); len(due) != 0 { // Check retry waits for interval
+		t.Fatalf("invalid number of due transactions: %d", len(due)) // Panic
+	}
+
+	err = pool.MarkBroadcast(*transaction.Hash, true) // Record successful broadcast
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if due := pool.DueForBroadcast(0); len(due) != 0 { // Check no longer due
+		t.Fatalf("invalid number of due transactions: %d", len(due)) // Panic
+	}
+}
+
+// TestReadMempoolFromMemory - test mempool persistence
+func TestReadMempoolFromMemory(t *testing.T) {
+	path := filepath.FromSlash(fmt.Sprintf("%s/mem/test_mempool.log", common.DataDir)) // Get path
+
+	os.Remove(path) // Remove old mempool
+
+	pool := NewMempool(DefaultMaxSize, DefaultMaxAge) // Init mempool
+	pool.Path = path                                  // Set path
+
+	privateKey, sender := newTestAccount(t) // Init account
+
+	transaction := newTestTransaction(t, privateKey, sender, 0, 1) // Init transaction
+
+	err := pool.Add(transaction) // Add transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = pool.MarkBroadcast(*transaction.Hash, false) // Record failed broadcast
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	readPool, err := ReadMempoolFromMemory(path) // Read mempool
+	if err != nil {                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	readTransaction, err := readPool.Get(*transaction.Hash) // Get transaction
+	if err != nil {                                         // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if valid, err := types.VerifyTransactionSignature(readTransaction); !valid || err != nil { // Check signature survived encoding
+		t.Fatalf("invalid signature on read transaction: %v", err) // Panic
+	}
+
+	if attempts := readPool.entries[entryKey{sender: sender, nonce: 0}].BroadcastAttempts; attempts != 1 { // Check bookkeeping persisted
+		t.Fatalf("invalid number of broadcast attempts: %d", attempts) // Panic
+	}
+
+	os.Remove(path) // Clean up
+}
+
+// TestMempoolLog - test that mempool changes are appended to its log, replayed in order, and compacted
+func TestMempoolLog(t *testing.T) {
+	path := filepath.FromSlash(fmt.Sprintf("%s/mem/test_mempool_log.log", common.DataDir)) // Get path
+
+	os.Remove(path)       // Remove old mempool
+	defer os.Remove(path) // Clean up
+
+	pool := NewMempool(DefaultMaxSize, DefaultMaxAge) // Init mempool
+	pool.Path = path                                  // Set path
+
+	privateKey, sender := newTestAccount(t) // Init account
+
+	first := newTestTransaction(t, privateKey, sender, 0, 1)  // Init transaction
+	second := newTestTransaction(t, privateKey, sender, 1, 1) // Init transaction
+
+	for _, transaction := range []*types.Transaction{first, second} { // Iterate through transactions
+		if err := pool.Add(transaction); err != nil { // Add transaction
+			t.Fatal(err) // Panic
+		}
+	}
+
+	info, err := os.Stat(path) // Get log info
+	if err != nil {            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = pool.Remove(*first.Hash) // Remove first transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = pool.MarkBroadcast(*second.Hash, false) // Record failed broadcast
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if appended, err := os.Stat(path); err != nil || pool.logged != 4 || appended.Size() <= info.Size() { // Check changes appended
+		t.Fatalf("expected 4 logged records, found %d (%v)", pool.logged, err) // Panic
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600) // Open log
+	if err != nil {                                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	file.Write([]byte{0xff, 0xff, 0xff, 0xff, '{'}) // Simulate torn write
+	file.Close()                                    // Close log
+
+	readPool, err := ReadMempoolFromMemory(path) // Read mempool
+	if err != nil {                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := readPool.Get(*first.Hash); err != ErrNotPending || readPool.Len() != 1 || readPool.entries[entryKey{sender: sender, nonce: 1}].BroadcastAttempts != 1 { // Check changes replayed
+		t.Fatal("expected only the second transaction, with its broadcast attempt, to be replayed") // Panic
+	}
+
+	err = readPool.WriteToMemory() // Compact log
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if readPool.logged != 1 { // Check compacted
+		t.Fatalf("expected 1 logged record after compaction, found %d", readPool.logged) // Panic
+	}
+
+	readPool, err = ReadMempoolFromMemory(path) // Read compacted mempool
+	if err != nil {                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := readPool.Get(*second.Hash); err != nil || readPool.Len() != 1 { // Check compacted mempool
+		t.Fatalf("invalid compacted mempool: %v", err) // Panic
+	}
+}
+
+// TestMigratePendingTransactions - test that transactions in the legacy pending transaction directory are moved into the
+// mempool
+func TestMigratePendingTransactions(t *testing.T) {
+	dir := filepath.FromSlash(fmt.Sprintf("%s/mem/pending_tx", common.DataDir)) // Get legacy directory
+
+	os.RemoveAll(dir)       // Remove old transactions
+	defer os.RemoveAll(dir) // Clean up
+
+	privateKey, sender := newTestAccount(t) // Init account
+
+	transaction := newTestTransaction(t, privateKey, sender, 0, 1) // Init transaction
+
+	err := transaction.WriteToMemory() // Write legacy pending transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	pool := NewMempool(DefaultMaxSize, DefaultMaxAge) // Init mempool
+
+	err = pool.migratePendingTransactions(dir) // Migrate legacy pending transactions
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if migrated, err := pool.Get(*transaction.Hash); err != nil || !migrated.IsSigned() { // Check migrated
+		t.Fatalf("expected legacy pending transaction to be migrated (%v)", err) // Panic
+	}
+
+	if _, err = os.Stat(dir); !os.IsNotExist(err) { // Check directory removed
+		t.Fatalf("expected legacy pending transaction directory to be removed (%v)", err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newTestAccount generates a new private key and its address.
+func newTestAccount(t *testing.T) (*ecdsa.PrivateKey, common.Address) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	address, err := common.NewAddress(privateKey) // Generate address
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	return privateKey, address // Return key, address
+}
+
+// newTestTransaction initializes a new signed transaction with a given nonce and amount.
+func newTestTransaction(t *testing.T, privateKey *ecdsa.PrivateKey, sender common.Address, nonce uint64, amount int64) *types.Transaction {
+	transaction, err := types.NewTransaction(nonce, nil, &sender, &sender, common.Coins(amount), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = types.SignTransaction(transaction, privateKey) // Sign transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	return transaction // Return transaction
+}
+
+/* END INTERNAL METHODS */
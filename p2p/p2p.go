@@ -3,7 +3,6 @@ package p2p
 
 import (
 	"bufio"
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -14,6 +13,7 @@ import (
 	protocol "github.com/libp2p/go-libp2p-protocol"
 	routed "github.com/libp2p/go-libp2p/p2p/host/routed"
 
+	"github.com/SummerCash/go-summercash/common"
 	"github.com/SummerCash/go-summercash/config"
 )
 
@@ -33,15 +33,11 @@ func CheckPeerCompatible(ctx context.Context, host *routed.RoutedHost, peer peer
 		return false // Not compatible
 	}
 
-	reader := bufio.NewReader(stream) // Initialize reader
-
-	networkBytes, err := reader.ReadBytes('\r') // Read network
-	if err != nil {                             // Check for errors
+	networkBytes, err := common.ReadMessage(bufio.NewReader(stream)) // Read network
+	if err != nil {                                                  // Check for errors
 		return false // Not compatible
 	}
 
-	networkBytes = bytes.Replace(networkBytes, []byte{'\r'}, []byte{}, 1) // Remove delimiter
-
 	if string(networkBytes) != fmt.Sprintf("despacito: %s", config.Version) { // Check incompatible
 		return false // Not compatible
 	}
@@ -51,10 +47,6 @@ func CheckPeerCompatible(ctx context.Context, host *routed.RoutedHost, peer peer
 
 // BroadcastDht attempts to send a given message to all nodes in a dht at a given endpoint.
 func BroadcastDht(ctx context.Context, host *routed.RoutedHost, message []byte, streamProtocol string, dagIdentifier string) error {
-	if bytes.Contains(message, []byte{'\r'}) { // Check control char
-		return errors.New("message contains a restricted control character") // Return error
-	}
-
 	peers := host.Network().Peers() // Get peers
 
 	for _, peer := range peers { // Iterate through peers
@@ -69,7 +61,7 @@ func BroadcastDht(ctx context.Context, host *routed.RoutedHost, message []byte,
 
 		writer := bufio.NewWriter(stream) // Initialize writer
 
-		_, err = writer.Write(append(message, '\r')) // Write message
+		err = common.WriteMessage(writer, message) // Write message
 
 		if err != nil { // Check for errors
 			continue // Continue
@@ -83,10 +75,6 @@ func BroadcastDht(ctx context.Context, host *routed.RoutedHost, message []byte,
 
 // BroadcastDhtResult send a given message to all nodes in a dht, and returns the result from each node.
 func BroadcastDhtResult(ctx context.Context, host *routed.RoutedHost, message []byte, streamProtocol string, dagIdentifier string, nPeers int) (responses [][]byte, err error) {
-	if bytes.Contains(message, []byte{'\r'}) { // Check control char
-		return nil, errors.New("message contains a restricted control character") // Return error
-	}
-
 	peers := host.Network().Peers() // Get peers
 
 	results := [][]byte{} // Init results buffer
@@ -110,7 +98,7 @@ func BroadcastDhtResult(ctx context.Context, host *routed.RoutedHost, message []
 
 			readWriter := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream)) // Initialize reader/writer
 
-			_, err = readWriter.Write(append(message, byte('\r'))) // Write message
+			err = common.WriteMessage(readWriter, message) // Write message
 
 			if err != nil { // Check for errors
 				return // Continue
@@ -118,13 +106,11 @@ func BroadcastDhtResult(ctx context.Context, host *routed.RoutedHost, message []
 
 			readWriter.Flush() // Flush
 
-			responseBytes, err := readWriter.ReadBytes('\r') // Read up to delimiter
-			if err != nil {                                  // Check for errors
+			responseBytes, err := common.ReadMessage(readWriter.Reader) // Read response
+			if err != nil {                                             // Check for errors
 				return // Continue
 			}
 
-			responseBytes = bytes.Trim(responseBytes, "\r") // Trim delmiter
-
 			results = append(results, responseBytes) // Append response
 
 			readWriter.Flush() // Flush
@@ -3,7 +3,6 @@ package p2p
 
 import (
 	"bufio"
-	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -15,6 +14,7 @@ import (
 	routed "github.com/libp2p/go-libp2p/p2p/host/routed"
 	multiaddr "github.com/multiformats/go-multiaddr"
 
+	"github.com/SummerCash/go-summercash/common"
 	"github.com/SummerCash/go-summercash/config"
 )
 
@@ -73,8 +73,8 @@ func GetBestBootstrapAddress(ctx context.Context, host *routed.RoutedHost, netwo
 		timer := time.NewTimer(time.Second * time.Duration(15)) // Init timer
 
 		go func() {
-			network, err := reader.ReadBytes('\r') // Read
-			if err != nil {                        // Check for errors
+			network, err := common.ReadMessage(reader) // Read
+			if err != nil {                            // Check for errors
 				err = host.Network().ClosePeer(peerInfo.ID) // Disconnect from peer
 
 				if err != nil { // Check for errors
@@ -88,8 +88,6 @@ func GetBestBootstrapAddress(ctx context.Context, host *routed.RoutedHost, netwo
 				return // Return
 			}
 
-			network = bytes.Replace(network, []byte{'\r'}, []byte{}, 1) // Remove delimiter
-
 			if string(network) != fmt.Sprintf("despacito: %s", config.Version) { // Check networks not matching
 				errChan <- fmt.Errorf("network not matching for peer with multi-addr: %s", peerInfo.ID.Pretty()) // Write err
 			}
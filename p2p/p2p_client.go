@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	commonGoP2P "github.com/dowlandaiello/GoP2P/common"
@@ -18,6 +19,8 @@ import (
 	"github.com/SummerCash/go-summercash/accounts"
 	"github.com/SummerCash/go-summercash/common"
 	"github.com/SummerCash/go-summercash/crypto"
+	"github.com/SummerCash/go-summercash/db"
+	"github.com/SummerCash/go-summercash/mempool"
 	"github.com/SummerCash/go-summercash/types"
 	"github.com/SummerCash/go-summercash/validator"
 )
@@ -29,6 +32,8 @@ type Client struct {
 	Validator *validator.Validator `json:"validator"` // Validator
 
 	Network string `json:"network"` // Network
+
+	Witness *common.Address `json:"witness"` // Unlocked staking account used to witness received transactions (optional)
 }
 
 /* BEGIN EXPORTED METHODS */
@@ -52,16 +57,59 @@ func (client *Client) StartIntermittentSync(duration time.Duration) {
 	}
 }
 
+// StartIntermittentRebroadcast retries publishing pending mempool transactions at a given interval.
+func (client *Client) StartIntermittentRebroadcast(duration time.Duration) {
+	for range time.Tick(duration) { // Rebroadcast every duration seconds
+		err := client.RebroadcastPending(duration) // Rebroadcast
+		if err != nil {                            // Check for errors
+			common.Logf("== P2P == intermittent rebroadcast errored: %s\n", err.Error()) // Log error
+		}
+	}
+}
+
+// RebroadcastPending publishes every pending transaction in the working mempool that has not
+// yet been broadcast successfully, and has not been attempted within the given interval.
+func (client *Client) RebroadcastPending(interval time.Duration) error {
+	pool, err := mempool.GetWorkingMempool() // Get mempool
+	if err != nil {                          // Check for errors
+		return err // Return found error
+	}
+
+	err = pool.RemoveIncluded() // Drop included transactions
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	for _, transaction := range pool.DueForBroadcast(interval) { // Iterate through due transactions
+		common.Logf("== P2P == rebroadcasting pending tx %s\n", transaction.Hash.String()) // Log rebroadcast
+
+		ctx, cancel := context.WithCancel(context.Background()) // Get context
+
+		err = client.PublishTransaction(ctx, transaction) // Publish transaction
+
+		cancel() // Cancel
+
+		if err != nil { // Check for errors
+			common.Logf("== P2P == rebroadcast of tx %s failed: %s\n", transaction.Hash.String(), err.Error()) // Log error
+		}
+
+		err = pool.MarkBroadcast(*transaction.Hash, err == nil) // Record attempt
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
 // PublishTransaction publishes a given transaction.
 func (client *Client) PublishTransaction(ctx context.Context, transaction *types.Transaction) error {
 	peers := client.Host.Network().Peers() // Get peers
 
 	x := 0 // Init x buffer
 
-	if bytes.Contains(transaction.Bytes(), []byte{'\r'}) { // Check has invalid delimiter
-		return errors.New("message contains a restricted control character") // Return error
-	}
-
 	for _, currentPeer := range peers { // Iterate through peers
 		if currentPeer == (*client.Host).ID() { // Check not same node
 			continue // Continue
@@ -79,7 +127,7 @@ func (client *Client) PublishTransaction(ctx context.Context, transaction *types
 
 			writer := bufio.NewWriter(stream) // Initialize writer
 
-			_, err = writer.Write(append(transaction.Bytes(), '\r')) // Write message
+			err = common.WriteMessage(writer, transaction.Bytes()) // Write message
 
 			if err != nil { // Check for errors
 				return // Return
@@ -104,6 +152,76 @@ func (client *Client) PublishTransaction(ctx context.Context, transaction *types
 	return nil // No error occurred, return nil
 }
 
+// WitnessTransaction witnesses a given validated transaction with the client's witness account (if
+// it has one), recording the witness locally and publishing it to the network.
+func (client *Client) WitnessTransaction(ctx context.Context, transaction *types.Transaction) error {
+	if client.Witness == nil { // Check not witnessing
+		return nil // Nothing to do
+	}
+
+	account, err := accounts.GetUnlockedAccount(*client.Witness) // Get witness account
+	if err != nil {                                              // Check for errors
+		return err // Return found error
+	}
+
+	witness, err := types.WitnessTransaction(transaction, account.PrivateKey) // Witness transaction
+	if err == types.ErrCannotWitnessSelf {                                    // Check own transaction
+		return nil // Nothing to do
+	} else if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	added, err := types.AddWitness(transaction, witness) // Record witness
+	if err != nil || !added {                            // Check for errors
+		return err // Return found error
+	}
+
+	return client.PublishWitness(ctx, *transaction.Hash, witness) // Publish witness
+}
+
+// PublishWitness publishes a given witness of the transaction with a given hash to all peers.
+func (client *Client) PublishWitness(ctx context.Context, hash common.Hash, witness *types.Witness) error {
+	encoded, err := types.EncodeWitness(hash, witness) // Encode witness
+	if err != nil {                                    // Check for errors
+		return err // Return found error
+	}
+
+	var wg sync.WaitGroup // Init wait group
+
+	for _, currentPeer := range client.Host.Network().Peers() { // Iterate through peers
+		if currentPeer == (*client.Host).ID() { // Check not same node
+			continue // Continue
+		}
+
+		wg.Add(1) // Add send
+
+		go func(peer peer.ID) {
+			defer wg.Done() // Mark send done
+
+			stream, err := (*client.Host).NewStream(ctx, peer, protocol.ID(GetStreamHeaderProtocolPath(client.Network, PublishWitness))) // Connect
+			if err != nil {                                                                                                              // Check for errors
+				return // Return
+			}
+
+			writer := bufio.NewWriter(stream) // Initialize writer
+
+			err = common.WriteMessage(writer, encoded) // Write message
+
+			if err != nil { // Check for errors
+				return // Return
+			}
+
+			common.Logf("== P2P == wrote witness of tx %s to peer %s\n", hash.String(), peer.Pretty()) // Log send
+
+			writer.Flush() // Flush
+		}(currentPeer) // Send
+	}
+
+	wg.Wait() // Wait for sends (the context must outlive them)
+
+	return nil // No error occurred, return nil
+}
+
 // SyncNetwork syncs all available chains and state roots.
 func (client *Client) SyncNetwork() error {
 	common.Logf("== P2P == starting sync...\n") // Log sync chain
@@ -146,17 +264,19 @@ func (client *Client) SyncNetwork() error {
 			}
 		}
 
-		genesisAccount, err := accounts.ReadAccountFromMemory(genesisAddress) // Read genesis account
-		if err != nil {                                                       // Check for errors
-			return err // Return found error
-		}
-
 		if len(chain.Transactions) == 0 { // Check can make genesis
+			genesisAccount, err := accounts.GetUnlockedAccount(genesisAddress) // Get unlocked genesis account
+			if err != nil {                                                    // Check for errors
+				return fmt.Errorf("genesis account %s must be unlocked to make genesis: %v", genesisAddress.String(), err) // Return found error
+			}
+
 			_, err = chain.MakeGenesis((*client.Validator).GetWorkingConfig(), genesisAccount.PrivateKey) // Make genesis
 
 			if err != nil { // Check for errors
 				return err // Return found error
 			}
+
+			client.addChainToDag(chain) // Add genesis txs to dag
 		}
 	}
 
@@ -189,6 +309,8 @@ func (client *Client) SyncNetwork() error {
 				return err // Return found error
 			}
 
+			client.addChainToDag(chain) // Add downloaded txs to dag
+
 			common.Logf("== P2P == finished downloading chain %s\n", remoteChain) // Log finish download chain
 		}
 
@@ -246,6 +368,10 @@ func (client *Client) SyncNetwork() error {
 
 			err = (*client.Validator).ValidateTransaction(localBestTransaction) // Validate tx
 
+			if err == validator.ErrConflictingTransaction { // Check double spend
+				client.recordConflictingTransaction(localBestTransaction) // Record double spend
+			}
+
 			if err != nil { // Check for errors
 				continue // Continue
 			}
@@ -261,11 +387,23 @@ func (client *Client) SyncNetwork() error {
 			if err != nil { // Check for errors
 				return err // Return found error
 			}
+
+			err = db.AddToWorkingDag(client.Network, localBestTransaction) // Add transaction to dag
+
+			if err != nil { // Check for errors
+				common.Logf("== P2P == error while adding synced tx %s to dag: %s\n", localBestTransaction.Hash.String(), err.Error()) // Log error
+			}
 		}
 
 		common.Logf("== P2P == finished syncing chain %s\n", remoteChain) // Log sync up to
 	}
 
+	err = db.WriteWorkingDag() // Checkpoint synced dag
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
 	common.Logf("== P2P == 👏  sync finished successfully!\n") // Log sync chain
 
 	return nil // No error occurred, return nil
@@ -332,8 +470,8 @@ func (client *Client) RequestNextTransaction(lastTransactionHash common.Hash, ac
 		}
 	}
 
-	transaction, err := types.TransactionFromBytes(bestResponse) // Get transaction value
-	if err != nil {                                              // Check for errors
+	transaction, err := types.TransactionFromCommittedBytes(bestResponse) // Get transaction value
+	if err != nil {                                                       // Check for errors
 		return &types.Transaction{}, err // Return found error
 	}
 
@@ -408,3 +546,30 @@ func (client *Client) RequestChain(account common.Address, sampleSize uint) (*ty
 }
 
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// addChainToDag adds every transaction in a given chain to the client network's working dag, logging (rather than
+// returning) any transactions that can't be added, so that a single bad transaction doesn't halt sync.
+func (client *Client) addChainToDag(chain *types.Chain) {
+	for _, transaction := range chain.Transactions { // Iterate through transactions
+		err := db.AddToWorkingDag(client.Network, transaction) // Add transaction to dag
+		if err != nil {                                        // Check for errors
+			common.Logf("== P2P == error while adding tx %s in chain %s to dag: %s\n", transaction.Hash.String(), chain.Account.String(), err.Error()) // Log error
+		}
+	}
+}
+
+// recordConflictingTransaction adds a given validly signed transaction that double spends a transaction in the local account
+// chains to the client network's working dag, so that the conflict can be resolved and reported. The transaction isn't added
+// to the account chains.
+func (client *Client) recordConflictingTransaction(transaction *types.Transaction) {
+	common.Logf("== P2P == received conflicting tx %s from %s with nonce %d\n", transaction.Hash.String(), transaction.Sender.String(), transaction.AccountNonce) // Log double spend
+
+	err := db.AddToWorkingDag(client.Network, transaction) // Add transaction to dag
+	if err != nil {                                        // Check for errors
+		common.Logf("== P2P == error while adding conflicting tx %s to dag: %s\n", transaction.Hash.String(), err.Error()) // Log error
+	}
+}
+
+/* END INTERNAL METHODS */
@@ -14,7 +14,10 @@ import (
 	inet "github.com/libp2p/go-libp2p-net"
 
 	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/db"
+	"github.com/SummerCash/go-summercash/mempool"
 	"github.com/SummerCash/go-summercash/types"
+	"github.com/SummerCash/go-summercash/validator"
 )
 
 /* BEGIN EXPORTED METHODS */
@@ -27,7 +30,10 @@ func (client *Client) HandleReceiveConfigRequest(stream inet.Stream) {
 
 	config, _ := config.ReadChainConfigFromMemory() // Read config from memory
 
-	writer.Write(append(config.Bytes(), '\r')) // Write config bytes
+	err := common.WriteMessage(writer, config.Bytes()) // Write config bytes
+	if err != nil {                                    // Check for errors
+		common.Logf("== P2P == error while writing req_config stream: %s\n", err.Error()) // Log error
+	}
 
 	writer.Flush() // Flush
 }
@@ -36,17 +42,13 @@ func (client *Client) HandleReceiveConfigRequest(stream inet.Stream) {
 func (client *Client) HandleReceiveTransaction(stream inet.Stream) {
 	common.Logf("== P2P == handling pub_tx stream\n") // Log handle stream
 
-	reader := bufio.NewReader(stream) // Initialize reader
-
-	b, err := reader.ReadBytes('\r') // Read up to delimiter
-	if err != nil {                  // Check for errors
+	b, err := common.ReadMessage(bufio.NewReader(stream)) // Read transaction
+	if err != nil {                                       // Check for errors
 		common.Logf("== P2P == error while reading pub_tx stream: %s\n", err.Error()) // Log error
 
 		return // Return
 	}
 
-	b = bytes.Trim(b, "\r") // Trim delimiter
-
 	tx, err := types.TransactionFromBytes(b) // Marshal bytes to transaction
 	if err != nil {                          // Check for errors
 		common.Logf("== P2P == error while deserializing tx read from pub_tx stream: %s\n", err.Error()) // Log error
@@ -58,12 +60,31 @@ func (client *Client) HandleReceiveTransaction(stream inet.Stream) {
 
 	err = (*client.Validator).ValidateTransaction(tx) // Validate tx
 
+	if err == validator.ErrConflictingTransaction { // Check double spend
+		client.recordConflictingTransaction(tx) // Record double spend
+	}
+
 	if err != nil { // Check for errors
 		common.Logf("== P2P == error while validating given tx read from pub_tx stream: %s\n", err.Error()) // Log error
 
 		return // Return
 	}
 
+	pool, err := mempool.GetWorkingMempool() // Get mempool
+	if err != nil {                          // Check for errors
+		common.Logf("== P2P == error while reading mempool from pub_tx stream: %s\n", err.Error()) // Log error
+
+		return // Return
+	}
+
+	err = pool.Add(tx) // Add tx to mempool
+
+	if err != nil { // Check for errors
+		common.Logf("== P2P == error while adding tx from pub_tx stream to mempool: %s\n", err.Error()) // Log error
+
+		return // Return
+	}
+
 	ctx, cancel := context.WithCancel(context.Background()) // Get cancel context
 
 	defer cancel() // Cancel
@@ -71,11 +92,11 @@ func (client *Client) HandleReceiveTransaction(stream inet.Stream) {
 	err = client.PublishTransaction(ctx, tx) // Publish tx
 
 	if err != nil { // Check for errors
-		common.Logf("== P2P == error while broadcasting given tx from pub_tx stream: %s\n", err.Error()) // Log error
-
-		return // Return
+		common.Logf("== P2P == error while broadcasting given tx from pub_tx stream (will retry): %s\n", err.Error()) // Log error
 	}
 
+	pool.MarkBroadcast(*tx.Hash, err == nil) // Record broadcast attempt
+
 	senderChain, err := types.ReadChainFromMemory(*tx.Sender) // Read chain
 	if err != nil {                                           // Check for errors
 		common.Logf("== P2P == error while reading sender chain from pub_tx stream: %s\n", err.Error()) // Log error
@@ -105,6 +126,71 @@ func (client *Client) HandleReceiveTransaction(stream inet.Stream) {
 
 		return // Return
 	}
+
+	err = db.AddToWorkingDag(client.Network, tx) // Add transaction to dag
+
+	if err != nil { // Check for errors
+		common.Logf("== P2P == error while adding tx from pub_tx stream to dag: %s\n", err.Error()) // Log error
+	}
+
+	err = pool.RemoveIncluded() // Drop tx from mempool if broadcast
+
+	if err != nil { // Check for errors
+		common.Logf("== P2P == error while pruning mempool from pub_tx stream: %s\n", err.Error()) // Log error
+	}
+
+	err = client.WitnessTransaction(ctx, tx) // Witness tx
+
+	if err != nil { // Check for errors
+		common.Logf("== P2P == error while witnessing tx from pub_tx stream: %s\n", err.Error()) // Log error
+	}
+}
+
+// HandleReceiveWitness handles an incoming pub_witness stream.
+func (client *Client) HandleReceiveWitness(stream inet.Stream) {
+	common.Logf("== P2P == handling pub_witness stream\n") // Log handle stream
+
+	b, err := common.ReadMessage(bufio.NewReader(stream)) // Read witness
+	if err != nil {                                       // Check for errors
+		common.Logf("== P2P == error while reading pub_witness stream: %s\n", err.Error()) // Log error
+
+		return // Return
+	}
+
+	hash, witness, err := types.DecodeWitness(b) // Decode witness
+	if err != nil {                              // Check for errors
+		common.Logf("== P2P == error while deserializing witness read from pub_witness stream: %s\n", err.Error()) // Log error
+
+		return // Return
+	}
+
+	transaction, _, err := types.QueryTransactionByHash(hash) // Get witnessed transaction
+	if err != nil {                                           // Check for errors
+		common.Logf("== P2P == error while querying tx witnessed in pub_witness stream: %s\n", err.Error()) // Log error
+
+		return // Return
+	}
+
+	added, err := types.AddWitness(transaction, witness) // Record witness
+	if err != nil {                                      // Check for errors
+		common.Logf("== P2P == error while recording witness from pub_witness stream: %s\n", err.Error()) // Log error
+
+		return // Return
+	}
+
+	if !added { // Check already recorded
+		return // Don't relay known witnesses
+	}
+
+	ctx, cancel := context.WithCancel(context.Background()) // Get cancel context
+
+	defer cancel() // Cancel
+
+	err = client.PublishWitness(ctx, hash, witness) // Relay witness
+
+	if err != nil { // Check for errors
+		common.Logf("== P2P == error while relaying witness from pub_witness stream: %s\n", err.Error()) // Log error
+	}
 }
 
 // HandleReceiveBestTransaction handles an incoming req_best_tx stream.
@@ -113,15 +199,13 @@ func (client *Client) HandleReceiveBestTransaction(stream inet.Stream) {
 
 	readWriter := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream)) // Initialize reader/writer
 
-	accountString, err := readWriter.ReadBytes('\r') // Read
-	if err != nil {                                  // Check for errors
+	accountString, err := common.ReadMessage(readWriter.Reader) // Read
+	if err != nil {                                             // Check for errors
 		common.Logf("== P2P == error while reading req_best_tx stream: %s\n", err.Error()) // Log error
 	}
 
 	common.Logf("== P2P == parsed req_best_tx account: %s\n", accountString) // Log handle stream
 
-	accountString = bytes.Trim(accountString, "\r") // Trim delimiter
-
 	address, err := common.StringToAddress(string(accountString)) // Get address
 	if err != nil {                                               // Check for errors
 		common.Logf("== P2P == error while parsing req_best_tx stream: %s\n", err.Error()) // Log error
@@ -133,9 +217,9 @@ func (client *Client) HandleReceiveBestTransaction(stream inet.Stream) {
 	}
 
 	if len(chain.Transactions) > 0 { // Check has txs
-		readWriter.Write(append(chain.Transactions[len(chain.Transactions)-1].Hash.Bytes(), '\r')) // Write tx hash
+		common.WriteMessage(readWriter, chain.Transactions[len(chain.Transactions)-1].Hash.Bytes()) // Write tx hash
 	} else { // No txs
-		readWriter.Write(append(common.NewHash(crypto.Sha3(nil)).Bytes(), '\r')) // Write nil hash
+		common.WriteMessage(readWriter, common.NewHash(crypto.Sha3(nil)).Bytes()) // Write nil hash
 	}
 
 	readWriter.Flush() // Flush
@@ -147,13 +231,11 @@ func (client *Client) HandleReceiveNextTransactionRequest(stream inet.Stream) {
 
 	readWriter := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream)) // Initialize reader/writer
 
-	lastTxAccount, err := readWriter.ReadBytes('\r') // Read
-	if err != nil {                                  // Check for errors
+	lastTxAccount, err := common.ReadMessage(readWriter.Reader) // Read
+	if err != nil {                                             // Check for errors
 		common.Logf("== P2P == error while reading req_next_tx stream: %s\n", err.Error()) // Log error
 	}
 
-	lastTxAccount = bytes.Trim(lastTxAccount, "\r") // Trim delimiter
-
 	address, err := common.StringToAddress(strings.Split(string(lastTxAccount), "_")[0]) // Get address
 	if err != nil {                                                                      // Check for errors
 		common.Logf("== P2P == error while parsing req_next_tx stream: %s\n", err.Error()) // Log error
@@ -170,7 +252,7 @@ func (client *Client) HandleReceiveNextTransactionRequest(stream inet.Stream) {
 	}
 
 	if bytes.Equal(hash.Bytes(), common.NewHash(crypto.Sha3(nil)).Bytes()) { // Check is nil request
-		readWriter.Write(append(accountChain.Transactions[0].Bytes(), '\r')) // Write genesis bytes
+		common.WriteMessage(readWriter, accountChain.Transactions[0].CommittedBytes()) // Write genesis bytes
 
 		readWriter.Flush() // Flush
 
@@ -180,14 +262,14 @@ func (client *Client) HandleReceiveNextTransactionRequest(stream inet.Stream) {
 	for x, transaction := range accountChain.Transactions { // Iterate through transactions
 		if bytes.Equal(transaction.Hash.Bytes(), hash.Bytes()) { // Check hashes equal
 			if len(accountChain.Transactions) == x+1 { // Check no next
-				readWriter.Write(append(accountChain.Transactions[x].Bytes(), '\r')) // Write current transaction
+				common.WriteMessage(readWriter, accountChain.Transactions[x].CommittedBytes()) // Write current transaction
 
 				readWriter.Flush() // Flush
 
 				break // Break
 			}
 
-			readWriter.Write(append(accountChain.Transactions[x+1].Bytes(), '\r')) // Write next transaction
+			common.WriteMessage(readWriter, accountChain.Transactions[x+1].CommittedBytes()) // Write next transaction
 
 			readWriter.Flush() // Flush
 
@@ -209,7 +291,7 @@ func (client *Client) HandleReceiveAllChainsRequest(stream inet.Stream) {
 
 	common.Logf("== P2P == found local chains: %s\n", strings.Join(allLocalChains, ", ")) // Log error
 
-	_, err = writer.Write(append([]byte(strings.Join(allLocalChains, "_")), '\r')) // Write all local chains
+	err = common.WriteMessage(writer, []byte(strings.Join(allLocalChains, "_"))) // Write all local chains
 
 	if err != nil { // Check for errors
 		common.Logf("== P2P == error while writing req_chain stream: %s\n", err.Error()) // Log error
@@ -224,13 +306,11 @@ func (client *Client) HandleReceiveChainRequest(stream inet.Stream) {
 
 	readWriter := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream)) // Initialize reader/writer
 
-	addressBytes, err := readWriter.ReadBytes('\r') // Read up to delimiter
-	if err != nil {                                 // Check for errors
+	addressBytes, err := common.ReadMessage(readWriter.Reader) // Read address
+	if err != nil {                                            // Check for errors
 		common.Logf("== P2P == error while reading req_chain stream: %s\n", err.Error()) // Log error
 	}
 
-	addressBytes = bytes.Trim(addressBytes, "\r") // Trim delimiter
-
 	var address common.Address // Init buffer
 
 	copy(address[:], addressBytes) // Write to buffer
@@ -240,9 +320,7 @@ func (client *Client) HandleReceiveChainRequest(stream inet.Stream) {
 		common.Logf("== P2P == error while reading req_chain stream: %s\n", err.Error()) // Log error
 	}
 
-	chainBytes := bytes.Replace(chain.Bytes(), []byte{'\r'}, []byte{}, 1) // Remove \r
-
-	_, err = readWriter.Write(append(chainBytes, '\r')) // Write chain bytes
+	err = common.WriteMessage(readWriter, chain.Bytes()) // Write chain bytes
 
 	if err != nil { // Check for errors
 		common.Logf("== P2P == error while writing req_chain stream: %s\n", err.Error()) // Log error
@@ -259,8 +337,8 @@ func (client *Client) HandleReceiveAliveRequest(stream inet.Stream) {
 
 	config, _ := config.ReadChainConfigFromMemory() // Read chain config from persistent memory
 
-	_, err := writer.Write(append([]byte(fmt.Sprintf("despacito: %s", config.ChainVersion)), '\r')) // Write alive
-	if err != nil {                                                                                 // Check for errors
+	err := common.WriteMessage(writer, []byte(fmt.Sprintf("despacito: %s", config.ChainVersion))) // Write alive
+	if err != nil {                                                                               // Check for errors
 		common.Logf("== P2P == error while writing req_not_dead_lol stream: %s\n", err.Error()) // Log error
 	}
 
@@ -3,7 +3,6 @@ package p2p
 
 import (
 	"bufio"
-	"bytes"
 	"context"
 	"errors"
 	"strconv"
@@ -154,15 +153,13 @@ func BootstrapConfig(ctx context.Context, host *routed.RoutedHost, bootstrapAddr
 
 	reader := bufio.NewReader(stream) // Initialize reader from stream
 
-	dagConfigBytes, err := reader.ReadBytes('\r') // Read
-	if err != nil {                               // Check for errors
+	dagConfigBytes, err := common.ReadMessage(reader) // Read
+	if err != nil {                                   // Check for errors
 		cancel() // Cancel
 
 		return &config.ChainConfig{}, err // Return found error
 	}
 
-	dagConfigBytes = bytes.Trim(dagConfigBytes, "\r") // Trim delimiter
-
 	deserializedConfig, err := config.FromBytes(dagConfigBytes) // Deserialize
 	if err != nil {                                             // Check for errors
 		cancel() // Cancel
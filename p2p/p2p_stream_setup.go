@@ -31,6 +31,8 @@ const (
 	RequestNextTransaction
 
 	RequestAlive
+
+	PublishWitness
 )
 
 // StreamHeaderProtocolNames represents all stream header protocol names.
@@ -45,6 +47,7 @@ var StreamHeaderProtocolNames = []string{
 	"req_all_chains",
 	"req_next_transaction",
 	"req_not_dead_lol",
+	"pub_witness",
 }
 
 // StreamHeaderProtocol represents the stream protocol type enum.
@@ -99,6 +102,12 @@ func (client *Client) StartServingStreams() error {
 		return err // Return found error
 	}
 
+	err = client.StartServingStream(GetStreamHeaderProtocolPath(network, PublishWitness), client.HandleReceiveWitness) // Start serving pub witness
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
 	return nil // No error occurred, return nil
 }
 
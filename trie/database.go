@@ -0,0 +1,186 @@
+package trie
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// Database represents a generic store of encoded trie nodes, keyed by their hash.
+type Database interface {
+	Get(hash common.Hash) ([]byte, error)    // Read the node with a given hash
+	Put(hash common.Hash, node []byte) error // Persist a given node
+
+	Close() error // Close the database
+}
+
+// MemoryDatabase is an in-memory node database.
+type MemoryDatabase struct {
+	nodes map[common.Hash][]byte // Nodes
+
+	lock sync.RWMutex // Database lock
+}
+
+// LogDatabase is an embedded, append-only node database. Each node is appended to a single log file as a
+// hash | uvarint length | node record; the log is replayed into memory on open.
+type LogDatabase struct {
+	Path string `json:"path"` // Log path
+
+	file *os.File // Log file
+
+	*MemoryDatabase // Nodes in log
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMemoryDatabase initializes a new, empty in-memory node database.
+func NewMemoryDatabase() *MemoryDatabase {
+	return &MemoryDatabase{
+		nodes: make(map[common.Hash][]byte), // Init nodes
+	}
+}
+
+// Get reads the node with a given hash.
+func (db *MemoryDatabase) Get(hash common.Hash) ([]byte, error) {
+	db.lock.RLock()         // Lock db
+	defer db.lock.RUnlock() // Unlock db
+
+	node, ok := db.nodes[hash] // Get node
+
+	if !ok { // Check no node
+		return nil, ErrNilNode // Return error
+	}
+
+	return node, nil // Return node
+}
+
+// Put persists a given node.
+func (db *MemoryDatabase) Put(hash common.Hash, node []byte) error {
+	db.lock.Lock()         // Lock db
+	defer db.lock.Unlock() // Unlock db
+
+	db.nodes[hash] = node // Set node
+
+	return nil // No error occurred, return nil
+}
+
+// Close closes the database.
+func (db *MemoryDatabase) Close() error {
+	return nil // Nothing to close
+}
+
+// NewLogDatabase opens (or creates) an append-only node database at the given path.
+func NewLogDatabase(path string) (*LogDatabase, error) {
+	err := common.CreateDirIfDoesNotExist(filepath.Dir(path)) // Make db dir
+	if err != nil {                                           // Check for errors
+		return &LogDatabase{}, err // Return found error
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600) // Open log
+	if err != nil {                                             // Check for errors
+		return &LogDatabase{}, err // Return found error
+	}
+
+	db := &LogDatabase{
+		Path:           path,                // Set path
+		file:           file,                // Set file
+		MemoryDatabase: NewMemoryDatabase(), // Init nodes
+	}
+
+	err = db.replay() // Read nodes
+
+	if err != nil { // Check for errors
+		file.Close() // Close log
+
+		return &LogDatabase{}, err // Return found error
+	}
+
+	return db, nil // Return opened db
+}
+
+// Put persists a given node, appending it to the log if it isn't already stored.
+func (db *LogDatabase) Put(hash common.Hash, node []byte) error {
+	db.lock.Lock()         // Lock db
+	defer db.lock.Unlock() // Unlock db
+
+	if _, ok := db.nodes[hash]; ok { // Check already stored
+		return nil // Nothing to do
+	}
+
+	length := make([]byte, binary.MaxVarintLen64) // Init length buffer
+
+	record := append(append(hash[:], length[:binary.PutUvarint(length, uint64(len(node)))]...), node...) // Init record
+
+	_, err := db.file.Write(record) // Append record
+	if err != nil {                 // Check for errors
+		return err // Return found error
+	}
+
+	db.nodes[hash] = node // Set node
+
+	return nil // No error occurred, return nil
+}
+
+// Close closes the log.
+func (db *LogDatabase) Close() error {
+	return db.file.Close() // Close log
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// replay reads every node in the log into memory. A truncated trailing record (e.g. from a crash mid-write) is dropped.
+func (db *LogDatabase) replay() error {
+	reader := bufio.NewReader(db.file) // Init reader
+
+	offset := int64(0) // Init offset of last complete record
+
+	for { // Read records
+		hash := common.Hash{} // Init hash buffer
+
+		_, err := io.ReadFull(reader, hash[:]) // Read hash
+		if err != nil {                        // Check for errors
+			break // Reached end of log
+		}
+
+		length, err := binary.ReadUvarint(reader) // Read length
+		if err != nil {                           // Check for errors
+			break // Truncated record
+		}
+
+		node := make([]byte, length) // Init node buffer
+
+		_, err = io.ReadFull(reader, node) // Read node
+		if err != nil {                    // Check for errors
+			break // Truncated record
+		}
+
+		db.nodes[hash] = node // Set node
+
+		offset += int64(common.HashLength + uvarintLength(length) + len(node)) // Increment offset
+	}
+
+	err := db.file.Truncate(offset) // Drop any truncated record
+	if err != nil {                 // Check for errors
+		return err // Return found error
+	}
+
+	_, err = db.file.Seek(offset, io.SeekStart) // Seek to end of log
+
+	return err // Return any error
+}
+
+// uvarintLength gets the length of the uvarint encoding of a given integer.
+func uvarintLength(n uint64) int {
+	buffer := make([]byte, binary.MaxVarintLen64) // Init buffer
+
+	return binary.PutUvarint(buffer, n) // Return length
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,137 @@
+package trie
+
+import (
+	"encoding/binary"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// node is a decoded trie node.
+type node interface{}
+
+// leafNode is a node holding a value at the end of a path.
+type leafNode struct {
+	path  []byte // Remaining key nibbles
+	value []byte // Stored value
+}
+
+// extensionNode is a node holding a path shared by every key below it.
+type extensionNode struct {
+	path  []byte      // Shared key nibbles
+	child common.Hash // Branch below extension
+}
+
+// branchNode is a node branching on the next nibble of a key.
+type branchNode struct {
+	children [16]common.Hash // Children by nibble (zero if empty)
+	value    []byte          // Value stored at the key ending at the branch
+}
+
+const (
+	leafNodeType      byte = iota // Leaf node encoding tag
+	extensionNodeType             // Extension node encoding tag
+	branchNodeType                // Branch node encoding tag
+)
+
+/* BEGIN INTERNAL METHODS */
+
+// encodeNode encodes a given node. Leaves are encoded as tag | uvarint path length | path | value, extensions as
+// tag | uvarint path length | path | child hash, and branches as tag | bitmap of non-empty children | child hashes | value.
+func encodeNode(n node) []byte {
+	switch n := n.(type) { // Handle node types
+	case *leafNode:
+		return append(encodePath(leafNodeType, n.path), n.value...) // Return encoded leaf
+	case *extensionNode:
+		return append(encodePath(extensionNodeType, n.path), n.child[:]...) // Return encoded extension
+	case *branchNode:
+		encoded := []byte{branchNodeType, 0, 0} // Init encoded buffer
+
+		for x, child := range n.children { // Iterate through children
+			if child != (common.Hash{}) { // Check has child
+				encoded[1+x/8] |= 1 << uint(x%8)       // Set bitmap bit
+				encoded = append(encoded, child[:]...) // Append child
+			}
+		}
+
+		return append(encoded, n.value...) // Return encoded branch
+	}
+
+	return nil // Unknown node
+}
+
+// decodeNode decodes a given encoded node.
+func decodeNode(encoded []byte) (node, error) {
+	if len(encoded) == 0 { // Check empty
+		return nil, ErrInvalidNode // Return error
+	}
+
+	switch encoded[0] { // Handle node types
+	case leafNodeType, extensionNodeType:
+		pathLength, n := binary.Uvarint(encoded[1:])         // Read path length
+		if n <= 0 || uint64(len(encoded)-1-n) < pathLength { // Check invalid length
+			return nil, ErrInvalidNode // Return error
+		}
+
+		path := append([]byte{}, encoded[1+n:1+n+int(pathLength)]...) // Read path
+		rest := encoded[1+n+int(pathLength):]                         // Get rest of node
+
+		for _, nibble := range path { // Iterate through path
+			if nibble > 0x0f { // Check invalid nibble
+				return nil, ErrInvalidNode // Return error
+			}
+		}
+
+		if encoded[0] == leafNodeType { // Check is leaf
+			return &leafNode{path: path, value: append([]byte{}, rest...)}, nil // Return leaf
+		}
+
+		if len(rest) != common.HashLength || len(path) == 0 { // Check invalid extension
+			return nil, ErrInvalidNode // Return error
+		}
+
+		child := common.Hash{} // Init child buffer
+		copy(child[:], rest)   // Copy child
+
+		return &extensionNode{path: path, child: child}, nil // Return extension
+	case branchNodeType:
+		if len(encoded) < 3 { // Check no bitmap
+			return nil, ErrInvalidNode // Return error
+		}
+
+		branch := &branchNode{} // Init branch
+		offset := 3             // Init offset
+
+		for x := range branch.children { // Iterate through children
+			if encoded[1+x/8]&(1<<uint(x%8)) == 0 { // Check no child
+				continue // Continue
+			}
+
+			if len(encoded) < offset+common.HashLength { // Check truncated
+				return nil, ErrInvalidNode // Return error
+			}
+
+			copy(branch.children[x][:], encoded[offset:]) // Copy child
+			offset += common.HashLength                   // Increment offset
+		}
+
+		if offset < len(encoded) { // Check has value
+			branch.value = append([]byte{}, encoded[offset:]...) // Copy value
+		}
+
+		return branch, nil // Return branch
+	}
+
+	return nil, ErrInvalidNode // Unknown node type
+}
+
+// encodePath encodes a given node tag and path.
+func encodePath(tag byte, path []byte) []byte {
+	encoded := []byte{tag} // Init encoded buffer
+
+	length := make([]byte, binary.MaxVarintLen64)                                       // Init length buffer
+	encoded = append(encoded, length[:binary.PutUvarint(length, uint64(len(path)))]...) // Append length
+
+	return append(encoded, path...) // Append path
+}
+
+/* END INTERNAL METHODS */
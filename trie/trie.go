@@ -0,0 +1,419 @@
+// Package trie implements the Merkle Patricia trie used to commit to contract storage.
+package trie
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/crypto"
+)
+
+var (
+	// ErrNilNode is an error definition describing a node that could not be found in the trie's database.
+	ErrNilNode = errors.New("couldn't find trie node with given hash")
+
+	// ErrInvalidNode is an error definition describing a node that could not be decoded.
+	ErrInvalidNode = errors.New("invalid trie node")
+
+	// ErrInvalidProof is an error definition describing a proof that doesn't match the root it is verified against.
+	ErrInvalidProof = errors.New("invalid trie proof")
+)
+
+// Trie is a hexary Merkle Patricia trie. Nodes are content-addressed by the hash of their encoding; nodes created by
+// modifications are held in memory until the trie is committed to its database.
+type Trie struct {
+	root common.Hash // Root node hash (zero if the trie is empty)
+
+	db    Database               // Committed nodes
+	dirty map[common.Hash][]byte // Uncommitted nodes
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// New opens the trie with a given root in a given database. An empty root opens an empty trie.
+func New(root common.Hash, db Database) *Trie {
+	return &Trie{
+		root:  root,                         // Set root
+		db:    db,                           // Set db
+		dirty: make(map[common.Hash][]byte), // Init dirty nodes
+	}
+}
+
+// Root returns the hash of the trie's root node.
+func (trie *Trie) Root() common.Hash {
+	return trie.root // Return root
+}
+
+// Get gets the value stored at a given key. If no value is stored at the key, nil is returned.
+func (trie *Trie) Get(key []byte) ([]byte, error) {
+	return trie.get(trie.root, keyToNibbles(key), nil) // Get value
+}
+
+// Put stores a given value at a given key. Storing an empty value deletes the key.
+func (trie *Trie) Put(key []byte, value []byte) error {
+	if len(value) == 0 { // Check is delete
+		return trie.Delete(key) // Delete key
+	}
+
+	root, err := trie.insert(trie.root, keyToNibbles(key), append([]byte{}, value...)) // Insert value
+	if err != nil {                                                                    // Check for errors
+		return err // Return found error
+	}
+
+	trie.root = root // Set root
+
+	return nil // No error occurred, return nil
+}
+
+// Delete removes the value stored at a given key, if any.
+func (trie *Trie) Delete(key []byte) error {
+	root, err := trie.delete(trie.root, keyToNibbles(key)) // Delete value
+	if err != nil {                                        // Check for errors
+		return err // Return found error
+	}
+
+	trie.root = root // Set root
+
+	return nil // No error occurred, return nil
+}
+
+// Commit writes all of the nodes created since the trie was opened (or last committed) to its database.
+func (trie *Trie) Commit() error {
+	for hash, node := range trie.dirty { // Iterate through dirty nodes
+		err := trie.db.Put(hash, node) // Write node
+		if err != nil {                // Check for errors
+			return err // Return found error
+		}
+	}
+
+	trie.dirty = make(map[common.Hash][]byte) // Reset dirty nodes
+
+	return nil // No error occurred, return nil
+}
+
+// Prove gets the encoded nodes on the path from the root to a given key. The proof proves either the value stored at the
+// key, or that no value is stored at the key.
+func (trie *Trie) Prove(key []byte) ([][]byte, error) {
+	proof := [][]byte{} // Init proof buffer
+
+	_, err := trie.get(trie.root, keyToNibbles(key), &proof) // Walk to key
+	if err != nil {                                          // Check for errors
+		return nil, err // Return found error
+	}
+
+	return proof, nil // Return proof
+}
+
+// VerifyProof verifies a given proof for a given key against a given root, returning the value proven to be stored at the
+// key (nil if the proof proves that no value is stored at the key).
+func VerifyProof(root common.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	db := NewMemoryDatabase() // Init proof database
+
+	for _, node := range proof { // Iterate through proof
+		db.Put(hashNode(node), node) // Add node
+	}
+
+	value, err := New(root, db).Get(key) // Walk proof
+	if err == ErrNilNode {               // Check proof missing nodes
+		return nil, ErrInvalidProof // Return error
+	}
+
+	return value, err // Return value
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// get gets the value stored at a given path below a given node, appending each visited node to a given proof (if not nil).
+func (trie *Trie) get(hash common.Hash, path []byte, proof *[][]byte) ([]byte, error) {
+	for hash != (common.Hash{}) { // Walk down trie
+		encoded, n, err := trie.load(hash) // Load node
+		if err != nil {                    // Check for errors
+			return nil, err // Return found error
+		}
+
+		if proof != nil { // Check building proof
+			*proof = append(*proof, encoded) // Append node
+		}
+
+		switch n := n.(type) { // Handle node types
+		case *leafNode:
+			if !bytes.Equal(n.path, path) { // Check different key
+				return nil, nil // Not found
+			}
+
+			return n.value, nil // Return value
+		case *extensionNode:
+			if !bytes.HasPrefix(path, n.path) { // Check diverges
+				return nil, nil // Not found
+			}
+
+			hash, path = n.child, path[len(n.path):] // Descend
+		case *branchNode:
+			if len(path) == 0 { // Check is branch value
+				return n.value, nil // Return value
+			}
+
+			hash, path = n.children[path[0]], path[1:] // Descend
+		}
+	}
+
+	return nil, nil // Not found
+}
+
+// insert stores a given value at a given path below a given node, returning the hash of the resulting node.
+func (trie *Trie) insert(hash common.Hash, path []byte, value []byte) (common.Hash, error) {
+	if hash == (common.Hash{}) { // Check empty
+		return trie.store(&leafNode{path: path, value: value}), nil // Store leaf
+	}
+
+	_, n, err := trie.load(hash) // Load node
+	if err != nil {              // Check for errors
+		return common.Hash{}, err // Return found error
+	}
+
+	switch n := n.(type) { // Handle node types
+	case *leafNode:
+		if bytes.Equal(n.path, path) { // Check same key
+			return trie.store(&leafNode{path: path, value: value}), nil // Replace value
+		}
+
+		prefix := commonPrefixLength(n.path, path) // Get shared prefix length
+
+		branch := &branchNode{} // Init branch
+
+		trie.attach(branch, n.path[prefix:], n.value) // Move existing leaf below branch
+		trie.attach(branch, path[prefix:], value)     // Add new leaf below branch
+
+		return trie.wrap(path[:prefix], trie.store(branch)), nil // Return branch
+	case *extensionNode:
+		prefix := commonPrefixLength(n.path, path) // Get shared prefix length
+
+		if prefix == len(n.path) { // Check extension fully matches
+			child, err := trie.insert(n.child, path[prefix:], value) // Insert below extension
+			if err != nil {                                          // Check for errors
+				return common.Hash{}, err // Return found error
+			}
+
+			return trie.store(&extensionNode{path: n.path, child: child}), nil // Store extension
+		}
+
+		branch := &branchNode{} // Init branch
+
+		branch.children[n.path[prefix]] = trie.wrap(n.path[prefix+1:], n.child) // Move rest of extension below branch
+		trie.attach(branch, path[prefix:], value)                               // Add new leaf below branch
+
+		return trie.wrap(path[:prefix], trie.store(branch)), nil // Return branch
+	case *branchNode:
+		branch := *n // Copy branch
+
+		if len(path) == 0 { // Check is branch value
+			branch.value = value // Set value
+
+			return trie.store(&branch), nil // Store branch
+		}
+
+		child, err := trie.insert(n.children[path[0]], path[1:], value) // Insert below branch
+		if err != nil {                                                 // Check for errors
+			return common.Hash{}, err // Return found error
+		}
+
+		branch.children[path[0]] = child // Set child
+
+		return trie.store(&branch), nil // Store branch
+	}
+
+	return common.Hash{}, ErrInvalidNode // Return error
+}
+
+// delete removes the value stored at a given path below a given node, returning the hash of the resulting node (zero if
+// the node is now empty).
+func (trie *Trie) delete(hash common.Hash, path []byte) (common.Hash, error) {
+	if hash == (common.Hash{}) { // Check empty
+		return hash, nil // Nothing to delete
+	}
+
+	_, n, err := trie.load(hash) // Load node
+	if err != nil {              // Check for errors
+		return common.Hash{}, err // Return found error
+	}
+
+	switch n := n.(type) { // Handle node types
+	case *leafNode:
+		if !bytes.Equal(n.path, path) { // Check different key
+			return hash, nil // Nothing to delete
+		}
+
+		return common.Hash{}, nil // Delete leaf
+	case *extensionNode:
+		if !bytes.HasPrefix(path, n.path) { // Check diverges
+			return hash, nil // Nothing to delete
+		}
+
+		child, err := trie.delete(n.child, path[len(n.path):]) // Delete below extension
+		if err != nil || child == n.child {                    // Check for errors, unchanged
+			return hash, err // Return unchanged
+		}
+
+		return trie.prefix(n.path, child) // Merge extension into child
+	case *branchNode:
+		branch := *n // Copy branch
+
+		if len(path) == 0 { // Check is branch value
+			branch.value = nil // Delete value
+		} else {
+			child, err := trie.delete(n.children[path[0]], path[1:]) // Delete below branch
+			if err != nil {                                          // Check for errors
+				return common.Hash{}, err // Return found error
+			}
+
+			branch.children[path[0]] = child // Set child
+		}
+
+		return trie.collapse(&branch) // Collapse branch
+	}
+
+	return common.Hash{}, ErrInvalidNode // Return error
+}
+
+// attach adds a given value at a given path below a given (unstored) branch.
+func (trie *Trie) attach(branch *branchNode, path []byte, value []byte) {
+	if len(path) == 0 { // Check is branch value
+		branch.value = value // Set value
+
+		return // Return
+	}
+
+	branch.children[path[0]] = trie.store(&leafNode{path: path[1:], value: value}) // Set child
+}
+
+// wrap stores an extension with a given path pointing to a given node, returning the node itself if the path is empty.
+func (trie *Trie) wrap(path []byte, child common.Hash) common.Hash {
+	if len(path) == 0 { // Check no extension needed
+		return child // Return child
+	}
+
+	return trie.store(&extensionNode{path: append([]byte{}, path...), child: child}) // Store extension
+}
+
+// prefix prepends a given path to a given node, merging it into the node where possible.
+func (trie *Trie) prefix(path []byte, hash common.Hash) (common.Hash, error) {
+	if hash == (common.Hash{}) { // Check empty
+		return hash, nil // Nothing to prefix
+	}
+
+	_, n, err := trie.load(hash) // Load node
+	if err != nil {              // Check for errors
+		return common.Hash{}, err // Return found error
+	}
+
+	switch n := n.(type) { // Handle node types
+	case *leafNode:
+		return trie.store(&leafNode{path: concat(path, n.path), value: n.value}), nil // Merge into leaf
+	case *extensionNode:
+		return trie.store(&extensionNode{path: concat(path, n.path), child: n.child}), nil // Merge into extension
+	default:
+		return trie.wrap(path, hash), nil // Wrap branch
+	}
+}
+
+// collapse stores a given branch, replacing it with a simpler node if it has fewer than two entries.
+func (trie *Trie) collapse(branch *branchNode) (common.Hash, error) {
+	only := -1   // Init only child index
+	entries := 0 // Init entry count
+
+	for x, child := range branch.children { // Iterate through children
+		if child != (common.Hash{}) { // Check has child
+			only = x  // Set only child
+			entries++ // Increment entries
+		}
+	}
+
+	if len(branch.value) > 0 { // Check has value
+		entries++ // Increment entries
+	}
+
+	switch { // Handle entry counts
+	case entries == 0:
+		return common.Hash{}, nil // Branch is empty
+	case entries == 1 && only == -1:
+		return trie.store(&leafNode{path: []byte{}, value: branch.value}), nil // Branch is a value
+	case entries == 1:
+		return trie.prefix([]byte{byte(only)}, branch.children[only]) // Branch is a single child
+	default:
+		return trie.store(branch), nil // Store branch
+	}
+}
+
+// store encodes a given node, holding it until the trie is committed and returning its hash.
+func (trie *Trie) store(n node) common.Hash {
+	encoded := encodeNode(n) // Encode node
+
+	hash := hashNode(encoded) // Hash node
+
+	trie.dirty[hash] = encoded // Set dirty
+
+	return hash // Return hash
+}
+
+// load reads and decodes the node with a given hash.
+func (trie *Trie) load(hash common.Hash) ([]byte, node, error) {
+	encoded, ok := trie.dirty[hash] // Check dirty nodes
+
+	if !ok { // Check not dirty
+		var err error // Init error buffer
+
+		encoded, err = trie.db.Get(hash) // Read node
+
+		if err != nil { // Check for errors
+			return nil, nil, err // Return found error
+		}
+	}
+
+	n, err := decodeNode(encoded) // Decode node
+	if err != nil {               // Check for errors
+		return nil, nil, err // Return found error
+	}
+
+	return encoded, n, nil // Return node
+}
+
+// hashNode hashes a given encoded node.
+func hashNode(encoded []byte) common.Hash {
+	hash := common.Hash{}               // Init hash buffer
+	copy(hash[:], crypto.Sha3(encoded)) // Copy hash
+
+	return hash // Return hash
+}
+
+// keyToNibbles splits a given key into nibbles.
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2) // Init nibbles buffer
+
+	for x, b := range key { // Iterate through key
+		nibbles[x*2] = b >> 4     // Set high nibble
+		nibbles[x*2+1] = b & 0x0f // Set low nibble
+	}
+
+	return nibbles // Return nibbles
+}
+
+// commonPrefixLength gets the length of the longest shared prefix of two given paths.
+func commonPrefixLength(a []byte, b []byte) int {
+	x := 0 // Init length buffer
+
+	for x < len(a) && x < len(b) && a[x] == b[x] { // Iterate through shared prefix
+		x++ // Increment length
+	}
+
+	return x // Return length
+}
+
+// concat concatenates two given paths into a new path.
+func concat(a []byte, b []byte) []byte {
+	return append(append([]byte{}, a...), b...) // Return concatenated
+}
+
+/* END INTERNAL METHODS */
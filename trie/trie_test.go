@@ -0,0 +1,191 @@
+package trie
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestPut tests the functionality of the Put() and Get() methods.
+func TestPut(t *testing.T) {
+	trie := New(common.Hash{}, NewMemoryDatabase()) // Init trie
+
+	entries := testEntries() // Get entries
+
+	for key, value := range entries { // Iterate through entries
+		err := trie.Put([]byte(key), []byte(value)) // Put entry
+		if err != nil {                             // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	for key, value := range entries { // Iterate through entries
+		stored, err := trie.Get([]byte(key)) // Get entry
+		if err != nil {                      // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if string(stored) != value { // Check value
+			t.Fatalf("invalid value for key %q: %q", key, stored) // Panic
+		}
+	}
+
+	if stored, err := trie.Get([]byte("do")); err != nil || stored != nil { // Check missing key (prefix of stored keys)
+		t.Fatalf("expected no value, got %q, %v", stored, err) // Panic
+	}
+}
+
+// TestRootDeterministic tests that the trie root depends only on the stored entries, and not on the order of the
+// operations that produced them.
+func TestRootDeterministic(t *testing.T) {
+	entries := testEntries() // Get entries
+
+	keys := []string{} // Init keys buffer
+
+	for key := range entries { // Iterate through entries
+		keys = append(keys, key) // Append key
+	}
+
+	forwards := New(common.Hash{}, NewMemoryDatabase())  // Init trie
+	backwards := New(common.Hash{}, NewMemoryDatabase()) // Init trie
+
+	for x := range keys { // Iterate through keys
+		forwards.Put([]byte(keys[x]), []byte(entries[keys[x]]))                          // Put forwards
+		backwards.Put([]byte(keys[len(keys)-1-x]), []byte(entries[keys[len(keys)-1-x]])) // Put backwards
+	}
+
+	if forwards.Root() != backwards.Root() { // Check same root
+		t.Fatalf("roots differ: %s, %s", forwards.Root().String(), backwards.Root().String()) // Panic
+	}
+
+	root := forwards.Root() // Get root
+
+	forwards.Put([]byte("extra"), []byte("value")) // Put extra entry
+
+	if forwards.Root() == root { // Check root changed
+		t.Fatal("root unchanged after put") // Panic
+	}
+
+	forwards.Delete([]byte("extra")) // Delete extra entry
+
+	if forwards.Root() != root { // Check root restored
+		t.Fatalf("root not restored after delete: %s, %s", forwards.Root().String(), root.String()) // Panic
+	}
+
+	for _, key := range keys { // Delete every key
+		err := forwards.Put([]byte(key), nil) // Delete key
+		if err != nil {                       // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	if forwards.Root() != (common.Hash{}) { // Check empty
+		t.Fatalf("non-empty root after deleting every key: %s", forwards.Root().String()) // Panic
+	}
+}
+
+// TestProve tests the functionality of the Prove() and VerifyProof() methods.
+func TestProve(t *testing.T) {
+	trie := New(common.Hash{}, NewMemoryDatabase()) // Init trie
+
+	for key, value := range testEntries() { // Iterate through entries
+		trie.Put([]byte(key), []byte(value)) // Put entry
+	}
+
+	proof, err := trie.Prove([]byte("doge")) // Prove key
+	if err != nil {                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	value, err := VerifyProof(trie.Root(), []byte("doge"), proof) // Verify proof
+	if err != nil || string(value) != "coin" {                    // Check value proven
+		t.Fatalf("invalid proven value: %q, %v", value, err) // Panic
+	}
+
+	absenceProof, err := trie.Prove([]byte("dodo")) // Prove missing key
+	if err != nil {                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if value, err = VerifyProof(trie.Root(), []byte("dodo"), absenceProof); err != nil || value != nil { // Check absence proven
+		t.Fatalf("invalid absence proof: %q, %v", value, err) // Panic
+	}
+
+	proof[len(proof)-1] = append(append([]byte{}, proof[len(proof)-1]...), 'x') // Tamper with leaf
+
+	if _, err = VerifyProof(trie.Root(), []byte("doge"), proof); err != ErrInvalidProof { // Check tampered proof rejected
+		t.Fatalf("expected tampered proof to be rejected, got %v", err) // Panic
+	}
+}
+
+// TestLogDatabase tests that committed tries can be reopened from a log database.
+func TestLogDatabase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trie") // Make temp dir
+	if err != nil {                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dir) // Remove temp dir
+
+	path := filepath.Join(dir, "state.log") // Get db path
+
+	db, err := NewLogDatabase(path) // Open db
+	if err != nil {                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	trie := New(common.Hash{}, db) // Init trie
+
+	for key, value := range testEntries() { // Iterate through entries
+		trie.Put([]byte(key), []byte(value)) // Put entry
+	}
+
+	err = trie.Commit() // Commit trie
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	db.Close() // Close db
+
+	db, err = NewLogDatabase(path) // Reopen db
+	if err != nil {                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer db.Close() // Close db
+
+	value, err := New(trie.Root(), db).Get([]byte("horse")) // Get entry from reopened trie
+	if err != nil || string(value) != "stallion" {          // Check value
+		t.Fatalf("invalid value after reopening: %q, %v", value, err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// testEntries gets a set of test entries, including keys that are prefixes of each other.
+func testEntries() map[string]string {
+	entries := map[string]string{
+		"doe":   "reindeer",
+		"dog":   "puppy",
+		"doge":  "coin",
+		"horse": "stallion",
+		"":      "empty key",
+	}
+
+	for x := 0; x < 64; x++ { // Add numbered entries
+		entries[fmt.Sprintf("key%d", x)] = fmt.Sprintf("value%d", x) // Add entry
+	}
+
+	return entries // Return entries
+}
+
+/* END INTERNAL METHODS */
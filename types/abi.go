@@ -0,0 +1,403 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+const (
+	// ABIInt64 - signed 64-bit integer ABI type
+	ABIInt64 ABIType = "int64"
+
+	// ABIBytes - variable-length byte array ABI type
+	ABIBytes ABIType = "bytes"
+
+	// ABIString - variable-length string ABI type
+	ABIString ABIType = "string"
+
+	// ABIAddress - address ABI type
+	ABIAddress ABIType = "address"
+
+	// ABIHash - hash ABI type
+	ABIHash ABIType = "hash"
+)
+
+// ABIPayloadPrefix - prefix of every binary ABI-encoded contract call payload (never the first byte of a string method call)
+const ABIPayloadPrefix byte = 0x00
+
+var (
+	// ErrInvalidABIType - error definition describing an unknown ABI type
+	ErrInvalidABIType = errors.New("invalid ABI type")
+
+	// ErrInvalidABIValue - error definition describing a value that cannot be encoded as the requested ABI type
+	ErrInvalidABIValue = errors.New("invalid ABI value")
+
+	// ErrInvalidABIPayload - error definition describing a malformed ABI-encoded payload
+	ErrInvalidABIPayload = errors.New("invalid ABI payload")
+
+	// ErrNilABIMethod - error definition describing a method that isn't described by a contract's ABI
+	ErrNilABIMethod = errors.New("couldn't find method in ABI")
+
+	// ErrABIArgumentMismatch - error definition describing call arguments that don't match a method's ABI inputs
+	ErrABIArgumentMismatch = errors.New("arguments do not match ABI method inputs")
+)
+
+// ABIType - contract ABI value type
+type ABIType string
+
+// ABI - contract ABI descriptor
+type ABI struct {
+	Methods []*ABIMethod `json:"methods"` // Contract methods
+}
+
+// ABIMethod - contract method descriptor
+type ABIMethod struct {
+	Name string `json:"name"` // Method name (name of the WASM export)
+
+	Inputs  []*ABIArgument `json:"inputs"`  // Method inputs
+	Outputs []*ABIArgument `json:"outputs"` // Method outputs (at most one)
+}
+
+// ABIArgument - contract method argument descriptor
+type ABIArgument struct {
+	Name string  `json:"name"` // Argument name
+	Type ABIType `json:"type"` // Argument type
+}
+
+// abiTypeTags - tags used to identify the type of each argument in an encoded payload
+var abiTypeTags = []ABIType{ABIInt64, ABIBytes, ABIString, ABIAddress, ABIHash}
+
+/* BEGIN EXPORTED METHODS */
+
+// ABIFromBytes - decode given byte array to ABI
+func ABIFromBytes(b []byte) (*ABI, error) {
+	abi := ABI{} // Init buffer
+
+	err := json.NewDecoder(bytes.NewReader(b)).Decode(&abi) // Decode into buffer
+	if err != nil {                                         // Check for errors
+		return &ABI{}, err // Return found error
+	}
+
+	for _, method := range abi.Methods { // Iterate through methods
+		for _, argument := range append(method.Inputs, method.Outputs...) { // Iterate through arguments
+			if !argument.Type.Valid() { // Check invalid type
+				return &ABI{}, fmt.Errorf("%s: %s", ErrInvalidABIType.Error(), argument.Type) // Return error
+			}
+		}
+	}
+
+	return &abi, nil // Return decoded ABI
+}
+
+// Bytes - convert given ABI to byte array
+func (abi *ABI) Bytes() []byte {
+	buffer := new(bytes.Buffer) // Init buffer
+
+	json.NewEncoder(buffer).Encode(*abi) // Serialize ABI
+
+	return buffer.Bytes() // Return serialized
+}
+
+// String - convert given ABI to string
+func (abi *ABI) String() string {
+	marshaled, _ := json.MarshalIndent(*abi, "", "  ") // Marshal ABI
+
+	return string(marshaled) // Return marshaled
+}
+
+// Method - get the method in the ABI with a given name
+func (abi *ABI) Method(name string) (*ABIMethod, error) {
+	for _, method := range abi.Methods { // Iterate through methods
+		if method.Name == name { // Check match
+			return method, nil // Return method
+		}
+	}
+
+	return &ABIMethod{}, ErrNilABIMethod // Return error
+}
+
+// ValidateArguments - check that a given set of call arguments matches the method's inputs
+func (method *ABIMethod) ValidateArguments(args []interface{}) error {
+	if len(args) != len(method.Inputs) { // Check invalid number of args
+		return ErrABIArgumentMismatch // Return error
+	}
+
+	for x, arg := range args { // Iterate through args
+		if abiTypeOf(arg) != method.Inputs[x].Type { // Check type mismatch
+			return fmt.Errorf("%s: %s must be of type %s", ErrABIArgumentMismatch.Error(), method.Inputs[x].Name, method.Inputs[x].Type) // Return error
+		}
+	}
+
+	return nil // Arguments valid
+}
+
+// OutputType - get the type of the value returned by the method (int64 if the method has no declared outputs)
+func (method *ABIMethod) OutputType() ABIType {
+	if len(method.Outputs) == 0 { // Check no outputs
+		return ABIInt64 // Return raw VM return type
+	}
+
+	return method.Outputs[0].Type // Return output type
+}
+
+// Valid - check whether a given ABI type is known
+func (abiType ABIType) Valid() bool {
+	return abiTypeTag(abiType) >= 0 // Return is valid
+}
+
+// IsABIPayload - check whether a given contract call payload is ABI-encoded
+func IsABIPayload(payload []byte) bool {
+	return len(payload) > 0 && payload[0] == ABIPayloadPrefix // Return has prefix
+}
+
+// EncodeContractCall - encode a call to a given contract method with a given set of arguments (int64, []byte, string, common.Address or common.Hash)
+func EncodeContractCall(method string, args ...interface{}) ([]byte, error) {
+	buffer := bytes.NewBuffer([]byte{ABIPayloadPrefix}) // Init buffer
+
+	writeUvarint(buffer, uint64(len(method))) // Write method name length
+	buffer.WriteString(method)                // Write method name
+
+	writeUvarint(buffer, uint64(len(args))) // Write number of args
+
+	for _, arg := range args { // Iterate through args
+		abiType := abiTypeOf(arg) // Get arg type
+
+		if abiType == "" { // Check unsupported type
+			return nil, fmt.Errorf("%s: %T", ErrInvalidABIValue.Error(), arg) // Return error
+		}
+
+		buffer.WriteByte(byte(abiTypeTag(abiType))) // Write type tag
+
+		encoded, err := EncodeABIValue(abiType, arg) // Encode value
+		if err != nil {                              // Check for errors
+			return nil, err // Return found error
+		}
+
+		buffer.Write(encoded) // Write value
+	}
+
+	return buffer.Bytes(), nil // Return encoded call
+}
+
+// DecodeContractCall - decode an ABI-encoded contract call payload to its method name and arguments
+func DecodeContractCall(payload []byte) (string, []interface{}, error) {
+	if !IsABIPayload(payload) { // Check not ABI payload
+		return "", nil, ErrInvalidABIPayload // Return error
+	}
+
+	reader := bytes.NewReader(payload[1:]) // Init reader
+
+	method, err := readABIBytes(reader) // Read method name
+	if err != nil {                     // Check for errors
+		return "", nil, err // Return found error
+	}
+
+	argCount, err := binary.ReadUvarint(reader)        // Read number of args
+	if err != nil || argCount > uint64(reader.Len()) { // Check for errors
+		return "", nil, ErrInvalidABIPayload // Return error
+	}
+
+	args := []interface{}{} // Init args buffer
+
+	for x := uint64(0); x < argCount; x++ { // Read args
+		tag, err := reader.ReadByte()                   // Read type tag
+		if err != nil || int(tag) >= len(abiTypeTags) { // Check for errors
+			return "", nil, ErrInvalidABIPayload // Return error
+		}
+
+		arg, err := readABIValue(reader, abiTypeTags[tag]) // Read value
+		if err != nil {                                    // Check for errors
+			return "", nil, err // Return found error
+		}
+
+		args = append(args, arg) // Append arg
+	}
+
+	if reader.Len() != 0 { // Check trailing bytes
+		return "", nil, ErrInvalidABIPayload // Return error
+	}
+
+	return string(method), args, nil // Return decoded call
+}
+
+// EncodeABIValue - encode a given value as a given ABI type
+func EncodeABIValue(abiType ABIType, value interface{}) ([]byte, error) {
+	buffer := new(bytes.Buffer) // Init buffer
+
+	switch abiType { // Handle types
+	case ABIInt64:
+		intVal, ok := value.(int64) // Get int value
+
+		if !ok { // Check invalid value
+			return nil, ErrInvalidABIValue // Return error
+		}
+
+		binary.Write(buffer, binary.BigEndian, intVal) // Write value
+	case ABIBytes, ABIString:
+		var data []byte // Init data buffer
+
+		switch typedValue := value.(type) { // Handle value types
+		case []byte:
+			data = typedValue // Set data
+		case string:
+			data = []byte(typedValue) // Set data
+		default:
+			return nil, ErrInvalidABIValue // Return error
+		}
+
+		writeUvarint(buffer, uint64(len(data))) // Write length
+		buffer.Write(data)                      // Write data
+	case ABIAddress:
+		address, ok := value.(common.Address) // Get address value
+
+		if !ok { // Check invalid value
+			return nil, ErrInvalidABIValue // Return error
+		}
+
+		buffer.Write(address.Bytes()) // Write address
+	case ABIHash:
+		hash, ok := value.(common.Hash) // Get hash value
+
+		if !ok { // Check invalid value
+			return nil, ErrInvalidABIValue // Return error
+		}
+
+		buffer.Write(hash.Bytes()) // Write hash
+	default:
+		return nil, ErrInvalidABIType // Return error
+	}
+
+	return buffer.Bytes(), nil // Return encoded value
+}
+
+// DecodeABIValue - decode a value of a given ABI type
+func DecodeABIValue(abiType ABIType, b []byte) (interface{}, error) {
+	reader := bytes.NewReader(b) // Init reader
+
+	value, err := readABIValue(reader, abiType) // Read value
+	if err != nil {                             // Check for errors
+		return nil, err // Return found error
+	}
+
+	if reader.Len() != 0 { // Check trailing bytes
+		return nil, ErrInvalidABIPayload // Return error
+	}
+
+	return value, nil // Return value
+}
+
+// FormatABIValue - convert a decoded ABI value to a human-readable, JSON-friendly value
+func FormatABIValue(value interface{}) interface{} {
+	switch typedValue := value.(type) { // Handle value types
+	case []byte:
+		return fmt.Sprintf("0x%x", typedValue) // Return hex
+	case common.Address:
+		return typedValue.String() // Return address string
+	case common.Hash:
+		return typedValue.String() // Return hash string
+	default:
+		return value // Return value
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// abiTypeOf - get the ABI type of a given Go value
+func abiTypeOf(value interface{}) ABIType {
+	switch value.(type) { // Handle value types
+	case int64:
+		return ABIInt64 // Int
+	case []byte:
+		return ABIBytes // Bytes
+	case string:
+		return ABIString // String
+	case common.Address:
+		return ABIAddress // Address
+	case common.Hash:
+		return ABIHash // Hash
+	default:
+		return "" // Unsupported
+	}
+}
+
+// abiTypeTag - get the payload tag of a given ABI type (-1 if unknown)
+func abiTypeTag(abiType ABIType) int {
+	for tag, currentType := range abiTypeTags { // Iterate through types
+		if currentType == abiType { // Check match
+			return tag // Return tag
+		}
+	}
+
+	return -1 // Unknown type
+}
+
+// readABIValue - read a value of a given ABI type from a given reader
+func readABIValue(reader *bytes.Reader, abiType ABIType) (interface{}, error) {
+	switch abiType { // Handle types
+	case ABIInt64:
+		var intVal int64 // Init value buffer
+
+		if err := binary.Read(reader, binary.BigEndian, &intVal); err != nil { // Read value
+			return nil, ErrInvalidABIPayload // Return error
+		}
+
+		return intVal, nil // Return value
+	case ABIBytes:
+		return readABIBytes(reader) // Return bytes
+	case ABIString:
+		data, err := readABIBytes(reader) // Read bytes
+		if err != nil {                   // Check for errors
+			return nil, err // Return found error
+		}
+
+		return string(data), nil // Return string
+	case ABIAddress:
+		address := common.Address{} // Init value buffer
+
+		if n, _ := reader.Read(address[:]); n != common.AddressLength { // Read value
+			return nil, ErrInvalidABIPayload // Return error
+		}
+
+		return address, nil // Return value
+	case ABIHash:
+		hash := common.Hash{} // Init value buffer
+
+		if n, _ := reader.Read(hash[:]); n != common.HashLength { // Read value
+			return nil, ErrInvalidABIPayload // Return error
+		}
+
+		return hash, nil // Return value
+	default:
+		return nil, ErrInvalidABIType // Return error
+	}
+}
+
+// readABIBytes - read a length-prefixed byte array from a given reader
+func readABIBytes(reader *bytes.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)        // Read length
+	if err != nil || length > uint64(reader.Len()) { // Check for errors
+		return nil, ErrInvalidABIPayload // Return error
+	}
+
+	data := make([]byte, length) // Init data buffer
+
+	reader.Read(data) // Read data
+
+	return data, nil // Return data
+}
+
+// writeUvarint - write a given uvarint to a given buffer
+func writeUvarint(buffer *bytes.Buffer, value uint64) {
+	encoded := make([]byte, binary.MaxVarintLen64) // Init buffer
+
+	buffer.Write(encoded[:binary.PutUvarint(encoded, value)]) // Write value
+}
+
+/* END INTERNAL METHODS */
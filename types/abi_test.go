@@ -0,0 +1,103 @@
+package types
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestEncodeContractCall - test functionality of EncodeContractCall(), DecodeContractCall() methods
+func TestEncodeContractCall(t *testing.T) {
+	address := common.Address{1, 2, 3}   // Init address
+	hash := common.Hash{4, 5, 6}         // Init hash
+	data := []byte{0, 1, 2, 3, '\r'}     // Init bytes
+	text := "hello, world (with commas)" // Init string
+
+	payload, err := EncodeContractCall("transfer", int64(-42), data, text, address, hash) // Encode call
+	if err != nil {                                                                       // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if !IsABIPayload(payload) { // Check is ABI payload
+		t.Fatal("encoded call not recognized as ABI payload") // Panic
+	}
+
+	if IsABIPayload([]byte("transfer(1, 2)")) { // Check legacy payload not ABI payload
+		t.Fatal("legacy call recognized as ABI payload") // Panic
+	}
+
+	method, args, err := DecodeContractCall(payload) // Decode call
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if method != "transfer" || len(args) != 5 { // Check method, args
+		t.Fatalf("invalid decoded call: %s, %d args", method, len(args)) // Panic
+	}
+
+	if args[0].(int64) != -42 || !bytes.Equal(args[1].([]byte), data) || args[2].(string) != text || args[3].(common.Address) != address || args[4].(common.Hash) != hash { // Check args
+		t.Fatalf("invalid decoded args: %v", args) // Panic
+	}
+
+	if _, _, err = DecodeContractCall(payload[:len(payload)-1]); err != ErrInvalidABIPayload { // Check truncated payload rejected
+		t.Fatalf("expected truncated payload to be rejected, got %v", err) // Panic
+	}
+
+	if _, err = EncodeContractCall("transfer", 1.5); err == nil { // Check unsupported type rejected
+		t.Fatal("expected unsupported arg type to be rejected") // Panic
+	}
+}
+
+// TestABIFromBytes - test functionality of ABI decoding, validation
+func TestABIFromBytes(t *testing.T) {
+	abi, err := ABIFromBytes([]byte(`{"methods": [{"name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "int64"}], "outputs": [{"name": "memo", "type": "string"}]}]}`)) // Decode ABI
+	if err != nil {                                                                                                                                                                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	method, err := abi.Method("transfer") // Get method
+	if err != nil {                       // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if method.OutputType() != ABIString { // Check output type
+		t.Fatalf("invalid output type: %s", method.OutputType()) // Panic
+	}
+
+	if err = method.ValidateArguments([]interface{}{common.Address{}, int64(1)}); err != nil { // Check valid args accepted
+		t.Fatal(err) // Panic
+	}
+
+	if err = method.ValidateArguments([]interface{}{int64(1), common.Address{}}); err == nil { // Check invalid args rejected
+		t.Fatal("expected mismatched args to be rejected") // Panic
+	}
+
+	if _, err = abi.Method("burn"); err != ErrNilABIMethod { // Check unknown method
+		t.Fatalf("expected unknown method to be rejected, got %v", err) // Panic
+	}
+
+	if _, err = ABIFromBytes([]byte(`{"methods": [{"name": "f", "inputs": [{"name": "x", "type": "float"}]}]}`)); err == nil { // Check invalid type rejected
+		t.Fatal("expected invalid ABI type to be rejected") // Panic
+	}
+}
+
+// TestStringTypedLog - test decoding of typed return logs
+func TestStringTypedLog(t *testing.T) {
+	encoded, err := EncodeABIValue(ABIString, "typed return") // Encode value
+	if err != nil {                                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	log := NewLog("return", encoded, Return) // Init log
+	log.ValueType = ABIString                // Set value type
+
+	if !strings.Contains(log.String(), `"value": "typed return"`) { // Check decoded
+		t.Fatalf("invalid log string: %s", log.String()) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
@@ -3,17 +3,13 @@ package types
 import (
 	"bytes"
 	"crypto/ecdsa"
-	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"math/big"
-	"strconv"
 
 	"github.com/SummerCash/go-summercash/common"
 	"github.com/SummerCash/go-summercash/config"
 	"github.com/SummerCash/go-summercash/crypto"
-	"github.com/SummerCash/ursa/compiler"
 	"github.com/SummerCash/ursa/vm"
 )
 
@@ -26,6 +22,7 @@ type Chain struct {
 	Genesis common.Hash `json:"genesis"` // Genesis block hash
 
 	ContractSource []byte `json:"contract"` // Contract
+	ABI            *ABI   `json:"abi"`      // Contract ABI descriptor
 
 	NetworkID uint        `json:"network"` // Network ID (mainnet: 0, testnet: 1, etc...)
 	ID        common.Hash `json:"ID"`      // Chain ID
@@ -88,8 +85,8 @@ func NewChain(account common.Address) (*Chain, error) {
 	return chain, nil // Return initialized chain
 }
 
-// NewContractChain - initialize new contract chain
-func NewContractChain(controlingAccount common.Address, contractSource []byte, deploymentTransaction *Transaction) (*Chain, error) {
+// NewContractChain - initialize new contract chain (with an optional ABI descriptor)
+func NewContractChain(controlingAccount common.Address, contractSource []byte, abi *ABI, deploymentTransaction *Transaction) (*Chain, error) {
 	config, err := config.ReadChainConfigFromMemory() // Read config from memory
 	if err != nil {                                   // Check for errors
 		return &Chain{}, err // Return error
@@ -106,6 +103,7 @@ func NewContractChain(controlingAccount common.Address, contractSource []byte, d
 		Transactions:   []*Transaction{deploymentTransaction},
 		NetworkID:      config.NetworkID,
 		ContractSource: contractSource,
+		ABI:            abi,
 	}
 
 	(*chain).ID = common.NewHash(crypto.Sha3(chain.Bytes())) // Set ID
@@ -125,14 +123,20 @@ func NewContractChain(controlingAccount common.Address, contractSource []byte, d
 func (chain *Chain) AddTransaction(transaction *Transaction) error {
 	var err error // Init error buffer
 
-	if chain.ContractSource != nil && transaction.Payload != nil { // Check is contract call
-		gasPolicy := compiler.GasPolicy(common.GasPolicy) // Get gas policy
+	if transaction.State == nil && transaction.Payload != nil && !transaction.ContractCreation && chain.recipientIsContract(transaction) { // Check is unevaluated contract call
+		var context *ExecutionContext // Init context buffer
 
-		(*transaction).State, err = transaction.EvaluateNewState(&gasPolicy) // Evaluate new state
+		(*transaction).State, context, err = transaction.evaluateNewState() // Evaluate new state
 
-		if err != nil { // Check for errors
+		if err == ErrGasLimitExceeded { // Check ran out of gas
+			common.Logf("== VM == contract call %s exceeded its gas limit of %d\n", transaction.Hash.String(), transaction.GasLimit) // Log exceeded
+		} else if err != nil { // Check for errors
 			return err // Return found error
 		}
+
+		if context != nil { // Check call completed
+			context.apply(transaction) // Apply logs, return value, storage writes, transfers
+		}
 	}
 
 	chainConfig, err := config.ReadChainConfigFromMemory() // Read chain config
@@ -140,12 +144,20 @@ func (chain *Chain) AddTransaction(transaction *Transaction) error {
 		return err // Return found error
 	}
 
+	if transaction.Mint && (transaction.Sender != nil || transaction.IsSigned()) { // Check is sent or signed mint (mints are only ever derived locally)
+		return ErrInvalidMint // Return error
+	}
+
 	genesisChain, err := ReadGenesisChainFromMemory(chainConfig) // Read genesis with config
 	if err != nil {                                              // Check for errors
 		return err // Return found error
 	}
 
-	if transaction.Signature == nil && err == nil { // Check for nil signature
+	if transaction.Mint { // Check is mint
+		return chain.addMint(chainConfig, genesisChain, transaction) // Add mint
+	}
+
+	if !transaction.IsSigned() && err == nil { // Check for nil signature
 		return ErrNilSignature // Return error
 	} else if *transaction.Recipient != chain.Account && transaction.Sender != nil && *transaction.Sender != chain.Account { // Check irrelevant
 		return ErrIrrelevantTransaction // Return error
@@ -168,7 +180,7 @@ func (chain *Chain) AddTransaction(transaction *Transaction) error {
 	}
 
 	for _, currentTransaction := range chain.Transactions { // Check for duplicate transaction
-		if currentTransaction.Hash == transaction.Hash { // Check for matching hash
+		if currentTransaction.Hash != nil && transaction.Hash != nil && *currentTransaction.Hash == *transaction.Hash { // Check for matching hash
 			return ErrDuplicateTransaction // Return error
 		}
 	}
@@ -183,7 +195,23 @@ func (chain *Chain) AddTransaction(transaction *Transaction) error {
 		chain.Transactions = append(chain.Transactions, transaction) // Append transaction
 	}
 
-	return chain.WriteToMemory() // No error occurred, return nil
+	chain.Mint(chainConfig, transaction.Timestamp) // Mint any ended issuance periods
+
+	if *transaction.Recipient == chain.Account && len(transaction.InternalTransactions) > 0 { // Check is contract chain with transfers
+		err = chain.addInternalTransactions(transaction) // Commit transfers
+	} else {
+		err = chain.WriteToMemory() // Write chain
+	}
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	if *transaction.Recipient == chain.Account { // Check committed to contract chain
+		publishLogs(chain.Account, transaction) // Push logs to subscribers
+	}
+
+	return nil // No error occurred, return nil
 }
 
 // QueryTransaction - attempt to fetch transaction metadata in chain by hash
@@ -210,30 +238,33 @@ func (chain *Chain) QueryTransactionByParent(parentHash common.Hash) (*Transacti
 
 // CalculateTargetNonce - calculate the next target nonce for the given chain.
 func (chain *Chain) CalculateTargetNonce() uint64 {
-	lastNonce := uint64(0) // Init nonce buffer
+	targetNonce := uint64(0) // Init nonce buffer (0 if the account hasn't sent any transactions)
 
 	for _, currentTransaction := range chain.Transactions { // Iterate through sender txs
-		if currentTransaction.AccountNonce > lastNonce && bytes.Equal(currentTransaction.Sender.Bytes(), chain.Account.Bytes()) { // Check greater than last nonce
-			lastNonce = currentTransaction.AccountNonce + 1 // Set last nonce
+		if currentTransaction.AccountNonce >= targetNonce && currentTransaction.Sender != nil && bytes.Equal(currentTransaction.Sender.Bytes(), chain.Account.Bytes()) { // Check at least target nonce
+			targetNonce = currentTransaction.AccountNonce + 1 // Set target nonce
 		}
 	}
 
-	return lastNonce // Return nonce
+	return targetNonce // Return nonce
 }
 
 // CalculateBalance - iterate through tx set, return balance
-func (chain *Chain) CalculateBalance() *big.Float {
-	balance := big.NewFloat(0) // Init buffer
+func (chain *Chain) CalculateBalance() *common.Amount {
+	balance := common.NewAmount(nil) // Init buffer
 
 	for _, transaction := range chain.Transactions { // Iterate through transactions
-		if chain.Genesis != *transaction.Hash { // Check is not genesis
+		isMint := transaction.Mint && transaction.Sender == nil && transaction.Signature == nil // Check is mint (forged mints with a sender or signature are debited from the sender)
+
+		if chain.Genesis != *transaction.Hash && !isMint { // Check is not genesis or mint
 			if *transaction.Sender == chain.Account { // Check is sender
-				balance.Sub(balance, transaction.Amount) // Subtract value
+				balance = balance.Sub(transaction.Amount) // Subtract value
+				balance = balance.Sub(transaction.Fee())  // Subtract fee
 			} else if *transaction.Recipient == chain.Account { // Check is recipient
-				balance.Add(balance, transaction.Amount) // Add value
+				balance = balance.Add(transaction.Amount) // Add value
 			}
-		} else if chain.Genesis == *transaction.Hash { // Check is genesis
-			balance.Add(balance, transaction.Amount) // Add value
+		} else if chain.Genesis == *transaction.Hash || *transaction.Recipient == chain.Account { // Check is genesis or mint
+			balance = balance.Add(transaction.Amount) // Add value
 		}
 	}
 
@@ -296,6 +327,63 @@ func (chain *Chain) String() string {
 
 /* BEGIN INTERNAL METHODS */
 
+// recipientIsContract - check whether the recipient of a given transaction is a contract chain
+func (chain *Chain) recipientIsContract(transaction *Transaction) bool {
+	if transaction.Recipient == nil { // Check no recipient
+		return false // Not contract
+	}
+
+	if *transaction.Recipient == chain.Account { // Check is recipient chain
+		return chain.ContractSource != nil // Return is contract
+	}
+
+	recipientChain, err := ReadChainFromMemory(*transaction.Recipient) // Read recipient chain
+	if err != nil {                                                    // Check for errors
+		return false // Not contract
+	}
+
+	return recipientChain.ContractSource != nil // Return is contract
+}
+
+// addInternalTransactions - append the transfers made by the contract in a given call to the contract chain, and to each
+// transfer recipient's chain
+func (chain *Chain) addInternalTransactions(transaction *Transaction) error {
+	for _, internalTransaction := range transaction.InternalTransactions { // Iterate through transfers
+		if *internalTransaction.Sender != chain.Account || *internalTransaction.ParentTx != *transaction.Hash { // Check irrelevant
+			return ErrIrrelevantTransaction // Return error
+		}
+
+		chain.Transactions = append(chain.Transactions, internalTransaction) // Append transfer
+	}
+
+	err := chain.WriteToMemory() // Write contract chain to memory
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	for _, internalTransaction := range transaction.InternalTransactions { // Iterate through transfers
+		recipientChain, err := ReadChainFromMemory(*internalTransaction.Recipient) // Read recipient chain
+		if err != nil {                                                            // Check for errors
+			recipientChain, err = NewChain(*internalTransaction.Recipient) // Init recipient chain
+
+			if err != nil { // Check for errors
+				return err // Return found error
+			}
+		}
+
+		recipientChain.Transactions = append(recipientChain.Transactions, internalTransaction) // Append transfer
+
+		err = recipientChain.WriteToMemory() // Write recipient chain to memory
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
 // handleContractCall - handle given contract call
 func (chain *Chain) handleContractCall(transaction *Transaction) error {
 	env, err := vm.ReadEnvironmentFromMemory() // Read environment from memory
@@ -309,8 +397,13 @@ func (chain *Chain) handleContractCall(transaction *Transaction) error {
 		}
 	}
 
-	vm, err := vm.NewVirtualMachine(chain.ContractSource, common.VMConfig, new(TransactionMetaResolver), common.GasPolicy) // Init vm
-	if err != nil {                                                                                                        // Check for errors
+	context, err := NewExecutionContext(transaction, chain) // Init execution context
+	if err != nil {                                         // Check for errors
+		return err // Return found error
+	}
+
+	vm, err := vm.NewVirtualMachine(chain.ContractSource, transaction.gasMeteredEnvironment(), NewTransactionMetaResolver(context), common.GasPolicy) // Init vm
+	if err != nil {                                                                                                                                   // Check for errors
 		return err // Return found error
 	}
 
@@ -326,30 +419,23 @@ func (chain *Chain) handleContractCall(transaction *Transaction) error {
 		return err // Return found error
 	}
 
-	callMethod, callParams, err := common.ParseStringMethodCallNoReceiver(string(transaction.Payload)) // Parse payload method call
-	if err != nil {                                                                                    // Check for errors
+	call, err := parseContractCall(chain, transaction.Payload) // Parse payload method call
+	if err != nil {                                            // Check for errors
 		return err // Return found error
 	}
 
-	entryID, valid := vm.GetFunctionExport(callMethod) // Get function ID from payload
+	entryID, valid := vm.GetFunctionExport(call.method) // Get function ID from payload
 
 	if !valid { // Check for errors
 		return ErrInvalidPayload // Return error
 	}
 
-	var parsedCallParams []int64 // Init params buffer
+	memorySize := len(vm.Memory) // Get memory size before loading args
 
-	for _, param := range callParams { // Iterate through params
-		intVal, err := strconv.ParseInt(param, 10, 64) // Parse int
-		if err != nil {                                // Check for errors
-			return err // Return found error
-		}
+	callParams := call.vmParams(vm) // Load call params
 
-		parsedCallParams = append(parsedCallParams, intVal) // Append parse param
-	}
-
-	result, err := vm.Run(entryID, parsedCallParams...) // Run
-	if err != nil {                                     // Check for errors
+	result, err := vm.Run(entryID, callParams...) // Run
+	if err != nil {                               // Check for errors
 		errLog := NewLog("error", []byte(err.Error()), Error) // Init log
 
 		(*transaction).Logs = append((*transaction).Logs, errLog) // Append error log
@@ -365,13 +451,14 @@ func (chain *Chain) handleContractCall(transaction *Transaction) error {
 		return nil // Break
 	}
 
-	resultBuffer := make([]byte, 8) // Init result buffer
-
-	binary.LittleEndian.PutUint64(resultBuffer, uint64(result)) // Encode to []byte
+	returnLog, err := call.returnLog(vm.Memory, result) // Get typed return value
+	if err != nil {                                     // Check for errors
+		return err // Return found error
+	}
 
-	returnLog := NewLog("return", resultBuffer, Return) // Init log
+	call.unloadArgs(vm, memorySize) // Free memory used by call args
 
-	(*transaction).Logs = append((*transaction).Logs, returnLog) // Append return log TODO: custom logs, gas
+	(*transaction).Logs = append((*transaction).Logs, returnLog) // Append return log
 
 	err = chain.WriteToMemory() // Write chain to persistent memory
 
@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"strings"
 	"testing"
 
@@ -74,8 +73,8 @@ func TestAddTransaction(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
-	transaction, err := NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                               // Check for errors
+	transaction, err := NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                     // Check for errors
 		t.Error(err) // Log found error
 		t.FailNow()  // Panic
 	}
@@ -138,6 +137,68 @@ func TestAddTransaction(t *testing.T) {
 	t.Logf("genesis: %s, chain: %s", balance.String(), chain.String()) // Log chain state
 }
 
+// TestCalculateTargetNonce - test that consecutive transfers from one account are given consecutive nonces, and don't conflict
+func TestCalculateTargetNonce(t *testing.T) {
+	store, chain := newTestChainStore(t) // Initialize store
+	defer store.Close()                  // Close store
+
+	chain.Transactions = []*Transaction{} // Start with no sent transactions
+
+	if nonce := chain.CalculateTargetNonce(); nonce != 0 { // Check first nonce
+		t.Fatalf("expected first target nonce 0; found %d", nonce) // Panic
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	sender, err := common.NewAddress(privateKey) // Generate address
+	if err != nil {                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain.Account = sender // Set account
+
+	var lastTransaction *Transaction // Init parent buffer
+
+	for i := uint64(0); i < 2; i++ { // Send two consecutive transfers
+		nonce := chain.CalculateTargetNonce() // Get target nonce
+
+		if nonce != i { // Check consecutive
+			t.Fatalf("expected target nonce %d; found %d", i, nonce) // Panic
+		}
+
+		transaction, err := NewTransaction(nonce, lastTransaction, &sender, &common.Address{0x5}, common.NewAmount(nil), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                                  // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		err = SignTransaction(transaction, privateKey) // Sign transaction
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if existing, err := store.QueryTransactionByNonce(sender, nonce); err == nil && *existing.Hash != *transaction.Hash { // Check double spend
+			t.Fatalf("transfer %d conflicts with %s", i, existing.Hash.String()) // Panic
+		}
+
+		chain.Transactions = append(chain.Transactions, transaction) // Commit transaction
+		lastTransaction = transaction                                // Set parent
+
+		err = store.WriteChain(chain) // Write chain
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	if nonce := chain.CalculateTargetNonce(); nonce != 2 { // Check next nonce
+		t.Fatalf("expected target nonce 2; found %d", nonce) // Panic
+	}
+}
+
 // TestQueryTransaction - test tx querying
 func TestQueryTransaction(t *testing.T) {
 	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
@@ -159,8 +220,8 @@ func TestQueryTransaction(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
-	transaction, err := NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                               // Check for errors
+	transaction, err := NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                     // Check for errors
 		t.Error(err) // Log found error
 		t.FailNow()  // Panic
 	}
@@ -1,12 +1,6 @@
 package types
 
 import (
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"path/filepath"
-	"strings"
-
 	"github.com/SummerCash/go-summercash/common"
 	"github.com/SummerCash/go-summercash/config"
 )
@@ -15,20 +9,20 @@ import (
 
 // GetAllLocalizedChains gets a list of the locally-provided chains, and their addresses.
 func GetAllLocalizedChains() ([]string, error) {
-	err := common.CreateDirIfDoesNotExist(filepath.FromSlash(fmt.Sprintf("%s/db/chain", common.DataDir))) // Make chain dir
-	if err != nil {                                                                                       // Check for errors
+	store, err := GetChainStore() // Get working chain store
+	if err != nil {               // Check for errors
 		return []string{}, err // Return found error
 	}
 
-	buffer := []string{} // Init buffer
-
-	files, err := ioutil.ReadDir(filepath.FromSlash(fmt.Sprintf("%s/db/chain", common.DataDir))) // Walk keystore dir
-	if err != nil {                                                                              // Check for errors
+	addresses, err := store.GetChainAddresses() // Get chain addresses
+	if err != nil {                             // Check for errors
 		return []string{}, err // Return found error
 	}
 
-	for _, file := range files { // Iterate through files
-		buffer = append(buffer, strings.Split(strings.Split(file.Name(), "chain_")[1], ".json")[0]) // Append to buffer
+	buffer := []string{} // Init buffer
+
+	for _, address := range addresses { // Iterate through addresses
+		buffer = append(buffer, address.String()) // Append to buffer
 	}
 
 	return buffer, nil // No error occurred, return success
@@ -36,35 +30,12 @@ func GetAllLocalizedChains() ([]string, error) {
 
 // WriteToMemory - write given chain to memory
 func (chain *Chain) WriteToMemory() error {
-	err := common.CreateDirIfDoesNotExist(fmt.Sprintf("%s/db/chain", common.DataDir)) // Create dir if necessary
-	if err != nil {                                                                   // Check for errors
-		return err // Return error
-	}
-
-	err = chain.MakeEncodingSafe() // Make encoding safe
-
-	if err != nil { // Check for errors
-		return err // Return error
-	}
-
-	json, err := json.MarshalIndent(*chain, "", "  ") // Marshal chain
-	if err != nil {                                   // Check for errors
-		return err // Return error
-	}
-
-	err = ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/db/chain/chain_%s.json", common.DataDir, chain.Account.String())), json, 0644) // Write JSON
-
-	if err != nil { // Check for errors
+	store, err := GetChainStore() // Get working chain store
+	if err != nil {               // Check for errors
 		return err // Return found error
 	}
 
-	err = chain.RecoverSafeEncoding() // Recover
-
-	if err != nil { // Check for errors
-		return err // Return error
-	}
-
-	return nil // No error occurred, return nil
+	return store.WriteChain(chain) // Write chain
 }
 
 // ReadGenesisChainFromMemory reads a genesis chain based on a given chain config.
@@ -81,26 +52,33 @@ func ReadGenesisChainFromMemory(config *config.ChainConfig) (*Chain, error) {
 
 // ReadChainFromMemory - read chain from memory
 func ReadChainFromMemory(address common.Address) (*Chain, error) {
-	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/db/chain/chain_%s.json", common.DataDir, address.String()))) // Read chain
-	if err != nil {                                                                                                              // Check for errors
-		return &Chain{}, err // Return error
+	store, err := GetChainStore() // Get working chain store
+	if err != nil {               // Check for errors
+		return &Chain{}, err // Return found error
 	}
 
-	buffer := &Chain{} // Initialize buffer
-
-	err = json.Unmarshal(data, buffer) // Read json into buffer
+	return store.ReadChain(address) // Read chain
+}
 
-	if err != nil { // Check for errors
-		return &Chain{}, err // Return error
+// QueryTransactionByHash queries the working chain store's global transaction index for a
+// transaction with a given hash, returning the transaction and every chain position holding it.
+func QueryTransactionByHash(hash common.Hash) (*Transaction, []*TransactionLocation, error) {
+	store, err := GetChainStore() // Get working chain store
+	if err != nil {               // Check for errors
+		return &Transaction{}, []*TransactionLocation{}, err // Return found error
 	}
 
-	err = buffer.RecoverSafeEncoding() // Recover
+	locations, err := store.QueryTransactionLocations(hash) // Query locations
+	if err != nil {                                         // Check for errors
+		return &Transaction{}, []*TransactionLocation{}, err // Return found error
+	}
 
-	if err != nil { // Check for errors
-		return &Chain{}, err // Return error
+	transaction, err := store.QueryTransactionByHash(hash) // Query transaction
+	if err != nil {                                        // Check for errors
+		return &Transaction{}, []*TransactionLocation{}, err // Return found error
 	}
 
-	return buffer, nil // No error occurred, return read coordinationChain
+	return transaction, locations, nil // Return transaction, locations
 }
 
 /* END EXPORTED METHODS */
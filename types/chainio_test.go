@@ -4,7 +4,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-	"math/big"
 	"testing"
 
 	"github.com/SummerCash/go-summercash/common"
@@ -65,8 +64,8 @@ func TestReadChainFromMemory(t *testing.T) {
 
 	(*chain).ID = common.NewHash(crypto.Sha3(chain.Bytes())) // Set ID
 
-	transaction, err := NewTransaction(0, nil, &address, &address, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                                 // Check for errors
+	transaction, err := NewTransaction(0, nil, &address, &address, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                       // Check for errors
 		t.Error(err) // Log found error
 		t.FailNow()  // Panic
 	}
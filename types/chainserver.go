@@ -41,10 +41,6 @@ func HandleReceivedTransaction(b []byte) error {
 		return err // Return found error
 	}
 
-	oldNonce := tx.AccountNonce // Set old nonce
-
-	tx.AccountNonce = uint64(len(chain.Transactions)) // Reset nonce
-
 	common.Logf("== CHAIN == adding transaction %s to chain %s\n", tx.Hash.String(), chain.ID.String()) // Log add tx
 
 	err = chain.AddTransaction(tx) // Append tx
@@ -66,8 +62,6 @@ func HandleReceivedTransaction(b []byte) error {
 
 		common.Logf("== CHAIN == adding transaction %s to sender chain %s\n", tx.Hash.String(), chain.ID.String()) // Log add tx
 
-		tx.AccountNonce = oldNonce // Set to old nonce
-
 		err = chain.AddTransaction(tx) // Append tx
 
 		if err != nil { // Check for errors
@@ -96,10 +90,6 @@ func HandleReceivedContractCreation(b []byte) error {
 		return err // Return found error
 	}
 
-	oldNonce := tx.AccountNonce // Set old nonce
-
-	tx.AccountNonce = uint64(len(chain.Transactions)) // Reset nonce
-
 	common.Logf("== CHAIN == adding transaction %s to chain %s\n", tx.Hash.String(), chain.ID.String()) // Log add tx
 
 	err = chain.AddTransaction(tx) // Append tx
@@ -121,8 +111,6 @@ func HandleReceivedContractCreation(b []byte) error {
 
 		common.Logf("== CHAIN == adding transaction %s to sender chain %s\n", tx.Hash.String(), chain.ID.String()) // Log add tx
 
-		tx.AccountNonce = oldNonce // Set to old nonce
-
 		err = chain.AddTransaction(tx) // Append tx
 
 		if err != nil { // Check for errors
@@ -159,8 +147,13 @@ func HandleReceivedStateRequest(b []byte) ([]byte, error) {
 		}
 	}
 
-	virtualMachine, err := vm.NewVirtualMachine(chain.ContractSource, *env, new(TransactionMetaResolver), nil) // Init vm
-	if err != nil {                                                                                            // Check for errors
+	context, err := NewExecutionContext(&Transaction{}, chain) // Init execution context
+	if err != nil {                                            // Check for errors
+		return nil, err // Return found error
+	}
+
+	virtualMachine, err := vm.NewVirtualMachine(chain.ContractSource, *env, NewTransactionMetaResolver(context), nil) // Init vm
+	if err != nil {                                                                                                   // Check for errors
 		return nil, err // Return found error
 	}
 
@@ -4,7 +4,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-	"math/big"
 	"strings"
 	"testing"
 
@@ -72,8 +71,8 @@ func TestHandleReceivedTransaction(t *testing.T) {
 		t.FailNow()  // Panic
 	}
 
-	transaction, err := NewTransaction(0, nil, &sender, &sender, big.NewFloat(0), []byte("test")) // Initialize transaction
-	if err != nil {                                                                               // Check for errors
+	transaction, err := NewTransaction(0, nil, &sender, &sender, common.NewAmount(nil), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                     // Check for errors
 		t.Error(err) // Log found error
 		t.FailNow()  // Panic
 	}
@@ -0,0 +1,134 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// ChainStore represents a generic persistent account chain storage backend.
+type ChainStore interface {
+	ReadChain(address common.Address) (*Chain, error) // Read the chain belonging to a given address
+	WriteChain(chain *Chain) error                    // Persist a given chain (appending any new transactions)
+
+	GetChainAddresses() ([]common.Address, error) // Get a list of all stored chain addresses
+
+	QueryTransactionByHash(hash common.Hash) (*Transaction, error)                     // Query a stored transaction by its hash
+	QueryTransactionsByParent(parentHash common.Hash) ([]*Transaction, error)          // Query all stored transactions with a given parent
+	QueryTransactionByNonce(sender common.Address, nonce uint64) (*Transaction, error) // Query a stored transaction by its sender and account nonce
+
+	QueryTransactionLocations(hash common.Hash) ([]*TransactionLocation, error) // Query every chain position holding a transaction with a given hash
+
+	QueryLogs(filter *LogFilter) ([]*IndexedLog, error) // Query the committed contract logs matching a given filter
+
+	Close() error // Close the store
+}
+
+// TransactionLocation describes where a transaction is stored.
+type TransactionLocation struct {
+	Chain    common.Address `json:"chain"`    // Address of the chain containing the transaction
+	Position int            `json:"position"` // Index of the transaction in the chain
+	Leaf     bool           `json:"leaf"`     // Whether or not the transaction is the latest transaction in the chain
+}
+
+var (
+	// ErrNilChain is an error definition describing a chain that could not be found in the working chain store.
+	ErrNilChain = errors.New("couldn't find chain for given address")
+
+	// ErrNilChainStore is an error definition describing a nil chain store.
+	ErrNilChainStore = errors.New("nil chain store")
+)
+
+var (
+	workingChainStore     ChainStore // Working chain store
+	workingChainStorePath string     // Path of the working chain store (if default)
+
+	workingChainStoreLock sync.Mutex // Working chain store lock
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// SetChainStore sets the working chain store used by ReadChainFromMemory and WriteToMemory.
+// The previous working store, if any, is closed.
+func SetChainStore(store ChainStore) error {
+	if store == nil { // Check nil store
+		return ErrNilChainStore // Return error
+	}
+
+	workingChainStoreLock.Lock()         // Lock store
+	defer workingChainStoreLock.Unlock() // Unlock store
+
+	if workingChainStore != nil && workingChainStore != store { // Check must close old store
+		err := workingChainStore.Close() // Close old store
+		if err != nil {                  // Check for errors
+			return err // Return found error
+		}
+	}
+
+	workingChainStore = store  // Set store
+	workingChainStorePath = "" // Reset path
+
+	return nil // No error occurred, return nil
+}
+
+// GetChainStore gets the working chain store, opening the default embedded store in the
+// current data directory if no store has been set.
+func GetChainStore() (ChainStore, error) {
+	workingChainStoreLock.Lock()         // Lock store
+	defer workingChainStoreLock.Unlock() // Unlock store
+
+	path := filepath.FromSlash(fmt.Sprintf("%s/db/chain_store.log", common.DataDir)) // Get default store path
+
+	if workingChainStore != nil && (workingChainStorePath == "" || workingChainStorePath == path) { // Check already open
+		return workingChainStore, nil // Return working store
+	}
+
+	if workingChainStore != nil { // Check data dir has changed
+		workingChainStore.Close() // Close old store
+	}
+
+	store, err := NewLogChainStore(path) // Open default store
+	if err != nil {                      // Check for errors
+		return nil, err // Return found error
+	}
+
+	err = MigrateJSONChains(store, filepath.FromSlash(fmt.Sprintf("%s/db/chain", common.DataDir))) // Migrate any legacy chains
+
+	if err != nil { // Check for errors
+		store.Close() // Close store
+
+		return nil, err // Return found error
+	}
+
+	workingChainStore = store    // Set store
+	workingChainStorePath = path // Set path
+
+	return store, nil // Return opened store
+}
+
+// CloseChainStore closes the working chain store, if one is open.
+func CloseChainStore() error {
+	workingChainStoreLock.Lock()         // Lock store
+	defer workingChainStoreLock.Unlock() // Unlock store
+
+	if workingChainStore == nil { // Check nothing to close
+		return nil // Nothing to do
+	}
+
+	err := workingChainStore.Close() // Close store
+
+	workingChainStore = nil    // Reset store
+	workingChainStorePath = "" // Reset path
+
+	return err // Return any error
+}
+
+// String converts a given transaction location to a string.
+func (location *TransactionLocation) String() string {
+	return fmt.Sprintf("chain: %s, position: %d, leaf: %t", location.Chain.String(), location.Position, location.Leaf) // Return string
+}
+
+/* END EXPORTED METHODS */
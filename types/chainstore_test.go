@@ -0,0 +1,436 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestLogChainStore - test functionality of the append-only log chain store
+func TestLogChainStore(t *testing.T) {
+	store, chain := newTestChainStore(t) // Init store, chain
+	defer store.Close()                  // Close store
+
+	err := store.WriteChain(chain) // Write chain
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	readChain, err := store.ReadChain(chain.Account) // Read chain
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(readChain.Transactions) != 2 || *readChain.Transactions[1].Hash != *chain.Transactions[1].Hash { // Check transactions
+		t.Fatalf("invalid read chain: %s", readChain.String()) // Panic
+	}
+
+	if valid, err := VerifyTransactionSignature(readChain.Transactions[0]); !valid || err != nil { // Check signature survived encoding
+		t.Fatalf("invalid signature on read transaction: %v", err) // Panic
+	}
+
+	transaction, err := store.QueryTransactionByHash(*chain.Transactions[1].Hash) // Query by hash
+	if err != nil {                                                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if transaction.AccountNonce != 1 { // Check nonce
+		t.Fatalf("invalid transaction nonce: %d", transaction.AccountNonce) // Panic
+	}
+
+	children, err := store.QueryTransactionsByParent(*chain.Transactions[0].Hash) // Query by parent
+	if err != nil {                                                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(children) != 1 || *children[0].Hash != *chain.Transactions[1].Hash { // Check children
+		t.Fatalf("invalid children: %d", len(children)) // Panic
+	}
+
+	transaction, err = store.QueryTransactionByNonce(chain.Account, 0) // Query by nonce
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *transaction.Hash != *chain.Transactions[0].Hash { // Check hash
+		t.Fatal("invalid transaction for nonce") // Panic
+	}
+
+	locations, err := store.QueryTransactionLocations(*chain.Transactions[1].Hash) // Query locations
+	if err != nil {                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(locations) != 1 || locations[0].Chain != chain.Account || locations[0].Position != 1 || !locations[0].Leaf { // Check location
+		t.Fatalf("invalid transaction locations: %v", locations) // Panic
+	}
+
+	chain.Transactions = chain.Transactions[:1] // Diverge chain
+
+	err = store.WriteChain(chain) // Write diverged chain
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err = store.QueryTransactionByHash(*children[0].Hash); err != ErrNilTransaction { // Check stale index removed
+		t.Fatalf("expected stale transaction to be removed, got %v", err) // Panic
+	}
+
+	err = store.Close() // Close store
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	file, err := os.OpenFile(store.Path, os.O_WRONLY|os.O_APPEND, 0600) // Open log
+	if err != nil {                                                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	file.Write([]byte{0, 0, 1, 0, '{'}) // Simulate torn write
+	file.Close()                        // Close log
+
+	store, err = NewLogChainStore(store.Path) // Reopen store
+	if err != nil {                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	readChain, err = store.ReadChain(chain.Account) // Read replayed chain
+	if err != nil {                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(readChain.Transactions) != 1 || *readChain.Transactions[0].Hash != *chain.Transactions[0].Hash { // Check transactions
+		t.Fatalf("invalid replayed chain: %s", readChain.String()) // Panic
+	}
+
+	addresses, err := store.GetChainAddresses() // Get addresses
+	if err != nil {                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(addresses) != 1 || addresses[0] != chain.Account { // Check addresses
+		t.Fatal("invalid chain addresses") // Panic
+	}
+}
+
+// TestLogChainStoreBadTail - test that an oversized or undecodable trailing record is truncated rather than allocated or
+// failing the store
+func TestLogChainStoreBadTail(t *testing.T) {
+	store, chain := newTestChainStore(t) // Init store, chain
+
+	err := store.WriteChain(chain) // Write chain
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = store.Close() // Close store
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	info, err := os.Stat(store.Path) // Get log info
+	if err != nil {                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	for _, tail := range [][]byte{{0xff, 0xff, 0xff, 0xff, '{'}, {0, 0, 0, 3, 'x', 'y', 'z'}} { // Iterate through bad tails
+		file, err := os.OpenFile(store.Path, os.O_WRONLY|os.O_APPEND, 0600) // Open log
+		if err != nil {                                                     // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		file.Write(tail) // Write bad tail
+		file.Close()     // Close log
+
+		store, err = NewLogChainStore(store.Path) // Reopen store
+		if err != nil {                           // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		readChain, err := store.ReadChain(chain.Account) // Read replayed chain
+		if err != nil {                                  // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if len(readChain.Transactions) != 2 { // Check transactions
+			t.Fatalf("invalid replayed chain: %s", readChain.String()) // Panic
+		}
+
+		store.Close() // Close store
+
+		if truncated, err := os.Stat(store.Path); err != nil || truncated.Size() != info.Size() { // Check tail truncated
+			t.Fatalf("expected bad tail %x to be truncated (%v)", tail, err) // Panic
+		}
+	}
+}
+
+// TestLogChainStoreCompaction - test that dropped transactions leave no stale index entries, and that compaction
+// discards superseded records
+func TestLogChainStoreCompaction(t *testing.T) {
+	store, chain := newTestChainStore(t) // Init store, chain
+	defer func() { store.Close() }()     // Close store
+
+	err := store.WriteChain(chain) // Write chain
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain.Transactions = chain.Transactions[:1] // Diverge chain
+
+	err = store.WriteChain(chain) // Write diverged chain
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err = store.QueryTransactionByNonce(chain.Account, 1); err != ErrNilTransaction { // Check stale nonce removed
+		t.Fatalf("expected dropped nonce to be removed, got %v", err) // Panic
+	}
+
+	if len(store.parentIndex[*chain.Transactions[0].Hash]) != 0 { // Check stale child removed
+		t.Fatal("expected dropped child to be removed from parent index") // Panic
+	}
+
+	size := store.size // Get uncompacted size
+
+	err = store.compact() // Compact log
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if store.size >= size || store.waste != 0 { // Check superseded records discarded
+		t.Fatalf("expected log to shrink from %d bytes, got %d (%d wasted)", size, store.size, store.waste) // Panic
+	}
+
+	if info, err := os.Stat(store.Path); err != nil || info.Size() != store.size { // Check log replaced
+		t.Fatalf("invalid compacted log size (%v)", err) // Panic
+	}
+
+	transaction, err := store.QueryTransactionByNonce(chain.Account, 0) // Query by nonce
+	if err != nil {                                                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *transaction.Hash != *chain.Transactions[0].Hash { // Check hash
+		t.Fatal("invalid transaction for nonce") // Panic
+	}
+
+	err = store.WriteChain(chain) // Write chain to compacted log
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = store.Close() // Close store
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	store, err = NewLogChainStore(store.Path) // Reopen store
+	if err != nil {                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	readChain, err := store.ReadChain(chain.Account) // Read replayed chain
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(readChain.Transactions) != 1 || *readChain.Transactions[0].Hash != *chain.Transactions[0].Hash { // Check transactions
+		t.Fatalf("invalid replayed chain: %s", readChain.String()) // Panic
+	}
+}
+
+// TestMigrateJSONChains - test migration of legacy JSON chains
+func TestMigrateJSONChains(t *testing.T) {
+	store, chain := newTestChainStore(t) // Init store, chain
+	defer store.Close()                  // Close store
+
+	legacyDir := filepath.FromSlash(fmt.Sprintf("%s/db/test_legacy_chain", common.DataDir)) // Get legacy dir
+
+	os.RemoveAll(legacyDir)             // Remove old legacy dir
+	os.RemoveAll(legacyDir + "_legacy") // Remove old migrated dir
+
+	err := common.CreateDirIfDoesNotExist(legacyDir) // Create legacy dir
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	legacyChain := *chain                       // Copy chain
+	legacyChain.Transactions = []*Transaction{} // Reset transactions
+
+	for _, transaction := range chain.Transactions { // Iterate through transactions
+		encoded, err := encodeStoredTransaction(transaction) // Encode transaction
+		if err != nil {                                      // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		legacyChain.Transactions = append(legacyChain.Transactions, &Transaction{}) // Append buffer
+
+		err = json.Unmarshal(encoded, legacyChain.Transactions[len(legacyChain.Transactions)-1]) // Decode safe transaction
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	encoded, err := json.MarshalIndent(legacyChain, "", "  ") // Encode chain
+	if err != nil {                                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = ioutil.WriteFile(filepath.Join(legacyDir, fmt.Sprintf("chain_%s.json", chain.Account.String())), encoded, 0644) // Write legacy chain
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = MigrateJSONChains(store, legacyDir) // Migrate
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	readChain, err := store.ReadChain(chain.Account) // Read migrated chain
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(readChain.Transactions) != 2 { // Check transactions
+		t.Fatalf("invalid number of migrated transactions: %d", len(readChain.Transactions)) // Panic
+	}
+
+	if _, err = os.Stat(legacyDir); !os.IsNotExist(err) { // Check legacy dir moved
+		t.Fatal("legacy chain dir was not moved") // Panic
+	}
+}
+
+// TestLogChainStoreLogs - test functionality of the log chain store's contract log index
+func TestLogChainStoreLogs(t *testing.T) {
+	store, chain := newTestChainStore(t) // Init store, chain
+
+	topic, other := common.Hash{1}, common.Hash{2} // Init topics
+
+	call := chain.Transactions[1] // Get call
+
+	call.Logs = []*Log{NewLog("message", []byte("first"), Custom), NewLog("message", []byte("second"), Custom)} // Set logs
+	call.Logs[1].Topics = []common.Hash{topic, topic}                                                           // Index second log
+
+	err := store.WriteChain(chain) // Write chain
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	store.Close() // Close store
+
+	store, err = NewLogChainStore(store.Path) // Reopen store, rebuilding index
+	if err != nil {                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer store.Close() // Close store
+
+	logs, err := store.QueryLogs(&LogFilter{Contract: &chain.Account}) // Query by contract
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(logs) != 2 || string(logs[0].Log.Value) != "first" || logs[1].Index != 1 || logs[1].TransactionHash != *call.Hash { // Check logs
+		t.Fatalf("invalid logs by contract: %v", logs) // Panic
+	}
+
+	if logs, err = store.QueryLogs(&LogFilter{Topics: []common.Hash{topic}}); err != nil || len(logs) != 1 || string(logs[0].Log.Value) != "second" { // Query by topic
+		t.Fatalf("invalid logs by topic: %v, %v", logs, err) // Panic
+	}
+
+	if logs, err = store.QueryLogs(&LogFilter{Topics: []common.Hash{topic, other}}); err != nil || len(logs) != 0 { // Query by unmatched topics
+		t.Fatalf("invalid logs by unmatched topics: %v, %v", logs, err) // Panic
+	}
+
+	if logs, err = store.QueryLogs(&LogFilter{Since: call.Timestamp.Add(time.Second)}); err != nil || len(logs) != 0 { // Query by time range
+		t.Fatalf("invalid logs after call: %v, %v", logs, err) // Panic
+	}
+
+	chain.Transactions = chain.Transactions[:1] // Drop call
+
+	err = store.WriteChain(chain) // Rewrite chain
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if logs, err = store.QueryLogs(nil); err != nil || len(logs) != 0 { // Check logs removed
+		t.Fatalf("invalid logs after dropping call: %v, %v", logs, err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newTestChainStore initializes a fresh chain store, and a signed test chain with two transactions.
+func newTestChainStore(t *testing.T) (*LogChainStore, *Chain) {
+	path := filepath.FromSlash(fmt.Sprintf("%s/db/test_chain_%s.log", common.DataDir, t.Name())) // Get store path
+
+	os.Remove(path) // Remove old store
+
+	store, err := NewLogChainStore(path) // Open store
+	if err != nil {                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	address, err := common.NewAddress(privateKey) // Generate address
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain := &Chain{Account: address, Transactions: []*Transaction{}} // Init chain
+
+	var lastTransaction *Transaction // Init parent buffer
+
+	for nonce := uint64(0); nonce < 2; nonce++ { // Make transactions
+		transaction, err := NewTransaction(nonce, lastTransaction, &address, &address, common.NewAmount(nil), []byte("test")) // Initialize transaction
+		if err != nil {                                                                                                       // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		err = SignTransaction(transaction, privateKey) // Sign transaction
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		chain.Transactions = append(chain.Transactions, transaction) // Append transaction
+		lastTransaction = transaction                                // Set parent
+	}
+
+	return store, chain // Return store, chain
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,835 @@
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// LogChainStore is an embedded, append-only chain store. Every change to a chain is
+// appended to a single log file as a length-prefixed record; the log is replayed on
+// open to rebuild in-memory indexes of transactions by hash, parent hash, and sender
+// nonce, and of contract logs by contract and topic. Transactions themselves are read
+// from the log on demand. Once enough of the log is taken up by superseded headers and
+// dropped transactions, it is compacted.
+type LogChainStore struct {
+	Path string `json:"path"` // Log path
+
+	file  *os.File // Log file
+	size  int64    // Log size
+	waste int64    // Size of superseded records in log
+
+	chains map[common.Address]*storedChain // Chains in log
+
+	hashIndex   map[common.Hash][]storedLocation // Transaction locations by hash
+	parentIndex map[common.Hash][]common.Hash    // Transaction hashes by parent hash
+	nonceIndex  map[storedNonce]common.Hash      // Transaction hashes by sender and nonce
+
+	logIndex   map[common.Address][]storedLog // Contract log locations by contract
+	topicIndex map[common.Hash][]storedLog    // Contract log locations by topic
+
+	lock sync.RWMutex // Store lock
+}
+
+// chainStoreRecordType is a log record type.
+type chainStoreRecordType int
+
+const (
+	// headerRecord is a record setting a chain's header (everything but its transactions).
+	headerRecord chainStoreRecordType = iota
+
+	// transactionRecord is a record appending a transaction to a chain.
+	transactionRecord
+
+	// resetRecord is a record dropping all of a chain's transactions.
+	resetRecord
+)
+
+// chainStoreRecord is a single log record.
+type chainStoreRecord struct {
+	Type chainStoreRecordType `json:"type"` // Record type
+
+	Account common.Address `json:"account"` // Chain account
+
+	Header *Chain `json:"header,omitempty"` // Chain header
+
+	Transaction json.RawMessage `json:"transaction,omitempty"` // Safely-encoded transaction
+}
+
+// storedChain is the in-memory index of a chain in the log.
+type storedChain struct {
+	header       *Chain        // Chain header
+	headerRecord *storedRecord // Header record position (nil if the header is implied)
+
+	offsets []int64        // Log offsets of each transaction record
+	sizes   []int64        // Log sizes of each transaction record
+	hashes  []common.Hash  // Hashes of each transaction
+	parents []*common.Hash // Parent hashes of each transaction
+	nonces  []*storedNonce // Sender nonces of each transaction
+}
+
+// storedRecord is the position of a record in the log.
+type storedRecord struct {
+	offset int64 // Log offset
+	size   int64 // Record size, including its length prefix
+}
+
+// storedLocation is the position of a transaction in a chain.
+type storedLocation struct {
+	account  common.Address // Chain account
+	position int            // Index in chain
+}
+
+// storedNonce is a sender, nonce index key.
+type storedNonce struct {
+	sender common.Address // Transaction sender
+	nonce  uint64         // Transaction account nonce
+}
+
+// storedLog is the location of a contract log in a contract chain.
+type storedLog struct {
+	contract common.Address // Contract chain account
+	position int            // Index of the emitting call in the chain
+	index    int            // Index of the log in the call's logs
+
+	timestamp time.Time // Call timestamp
+}
+
+// recordHeaderSize is the size of the length prefix of each log record.
+const recordHeaderSize = 4
+
+// minCompactionWaste is the minimum size of superseded records a chain store log may
+// hold before it is compacted (it is also never compacted while mostly live).
+const minCompactionWaste = 1 << 20
+
+// ErrCorruptChainStore is an error definition describing a chain store log that could not be read.
+var ErrCorruptChainStore = errors.New("corrupt chain store")
+
+/* BEGIN EXPORTED METHODS */
+
+// NewLogChainStore opens (or creates) an append-only log chain store at the given path.
+func NewLogChainStore(path string) (*LogChainStore, error) {
+	err := common.CreateDirIfDoesNotExist(filepath.Dir(path)) // Make db dir
+	if err != nil {                                           // Check for errors
+		return &LogChainStore{}, err // Return found error
+	}
+
+	store := &LogChainStore{Path: path} // Init store
+
+	err = store.open() // Open log
+
+	if err != nil { // Check for errors
+		return &LogChainStore{}, err // Return found error
+	}
+
+	err = store.compactIfWasteful() // Compact log
+
+	if err != nil { // Check for errors
+		store.file.Close() // Close log
+
+		return &LogChainStore{}, err // Return found error
+	}
+
+	return store, nil // Return opened store
+}
+
+// ReadChain reads the chain belonging to a given address from the store.
+func (store *LogChainStore) ReadChain(address common.Address) (*Chain, error) {
+	store.lock.RLock()         // Lock store
+	defer store.lock.RUnlock() // Unlock store
+
+	stored, ok := store.chains[address] // Get chain
+
+	if !ok { // Check no chain
+		return &Chain{}, ErrNilChain // Return error
+	}
+
+	chain := *stored.header                                        // Copy header
+	chain.Transactions = make([]*Transaction, len(stored.offsets)) // Init transactions
+
+	for i, offset := range stored.offsets { // Iterate through transactions
+		transaction, err := store.readTransaction(offset) // Read transaction
+		if err != nil {                                   // Check for errors
+			return &Chain{}, err // Return found error
+		}
+
+		chain.Transactions[i] = transaction // Set transaction
+	}
+
+	return &chain, nil // Return read chain
+}
+
+// WriteChain persists a given chain. Transactions not yet present in the store are
+// appended; if the stored chain has diverged from the given chain, the stored chain's
+// transactions are dropped and rewritten.
+func (store *LogChainStore) WriteChain(chain *Chain) error {
+	store.lock.Lock()         // Lock store
+	defer store.lock.Unlock() // Unlock store
+
+	header := *chain          // Copy chain
+	header.Transactions = nil // Strip transactions
+
+	if stored, ok := store.chains[chain.Account]; !ok || !bytes.Equal(stored.header.Bytes(), header.Bytes()) { // Check header has changed
+		err := store.append(&chainStoreRecord{Type: headerRecord, Account: chain.Account, Header: &header}) // Write header
+		if err != nil {                                                                                     // Check for errors
+			return err // Return found error
+		}
+	}
+
+	stored := store.chains[chain.Account] // Get stored chain
+
+	var err error // Init error buffer
+
+	if !stored.isPrefixOf(chain) { // Check chain has diverged
+		err = store.append(&chainStoreRecord{Type: resetRecord, Account: chain.Account}) // Drop stored transactions
+		if err != nil {                                                                  // Check for errors
+			return err // Return found error
+		}
+	}
+
+	for i := len(stored.offsets); i < len(chain.Transactions); i++ { // Iterate through new transactions
+		encoded, err := encodeStoredTransaction(chain.Transactions[i]) // Encode transaction
+		if err != nil {                                                // Check for errors
+			return err // Return found error
+		}
+
+		err = store.append(&chainStoreRecord{Type: transactionRecord, Account: chain.Account, Transaction: encoded}) // Append transaction
+		if err != nil {                                                                                              // Check for errors
+			return err // Return found error
+		}
+	}
+
+	err = store.file.Sync() // Flush log
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	return store.compactIfWasteful() // Compact log
+}
+
+// GetChainAddresses gets a list of the addresses of all stored chains.
+func (store *LogChainStore) GetChainAddresses() ([]common.Address, error) {
+	store.lock.RLock()         // Lock store
+	defer store.lock.RUnlock() // Unlock store
+
+	addresses := []common.Address{} // Init buffer
+
+	for address := range store.chains { // Iterate through chains
+		addresses = append(addresses, address) // Append address
+	}
+
+	return addresses, nil // Return addresses
+}
+
+// QueryTransactionByHash queries the store for a transaction with a given hash.
+func (store *LogChainStore) QueryTransactionByHash(hash common.Hash) (*Transaction, error) {
+	store.lock.RLock()         // Lock store
+	defer store.lock.RUnlock() // Unlock store
+
+	return store.getTransactionByHash(hash) // Get transaction
+}
+
+// QueryTransactionsByParent queries the store for all transactions with a given parent hash.
+func (store *LogChainStore) QueryTransactionsByParent(parentHash common.Hash) ([]*Transaction, error) {
+	store.lock.RLock()         // Lock store
+	defer store.lock.RUnlock() // Unlock store
+
+	transactions := []*Transaction{} // Init buffer
+
+	for _, hash := range store.parentIndex[parentHash] { // Iterate through children
+		transaction, err := store.getTransactionByHash(hash) // Get transaction
+		if err == ErrNilTransaction {                        // Check child has been dropped
+			continue // Skip
+		} else if err != nil { // Check for errors
+			return []*Transaction{}, err // Return found error
+		}
+
+		transactions = append(transactions, transaction) // Append transaction
+	}
+
+	return transactions, nil // Return found transactions
+}
+
+// QueryTransactionByNonce queries the store for a transaction with a given sender and account nonce.
+func (store *LogChainStore) QueryTransactionByNonce(sender common.Address, nonce uint64) (*Transaction, error) {
+	store.lock.RLock()         // Lock store
+	defer store.lock.RUnlock() // Unlock store
+
+	hash, ok := store.nonceIndex[storedNonce{sender: sender, nonce: nonce}] // Get hash
+
+	if !ok { // Check no transaction
+		return &Transaction{}, ErrNilTransaction // Return error
+	}
+
+	return store.getTransactionByHash(hash) // Get transaction
+}
+
+// QueryTransactionLocations queries the store for every chain position holding a transaction with a given hash.
+func (store *LogChainStore) QueryTransactionLocations(hash common.Hash) ([]*TransactionLocation, error) {
+	store.lock.RLock()         // Lock store
+	defer store.lock.RUnlock() // Unlock store
+
+	locations := []*TransactionLocation{} // Init buffer
+
+	for _, location := range store.hashIndex[hash] { // Iterate through locations
+		locations = append(locations, &TransactionLocation{
+			Chain:    location.account,                                                  // Set chain
+			Position: location.position,                                                 // Set position
+			Leaf:     location.position == len(store.chains[location.account].hashes)-1, // Set is leaf
+		}) // Append location
+	}
+
+	if len(locations) == 0 { // Check no locations
+		return []*TransactionLocation{}, ErrNilTransaction // Return error
+	}
+
+	return locations, nil // Return locations
+}
+
+// QueryLogs queries the store's log index for every committed contract log matching a given filter, ordered by call timestamp.
+func (store *LogChainStore) QueryLogs(filter *LogFilter) ([]*IndexedLog, error) {
+	store.lock.RLock()         // Lock store
+	defer store.lock.RUnlock() // Unlock store
+
+	logs := []*IndexedLog{} // Init log buffer
+
+	transactions := make(map[storedLocation]*Transaction) // Init read transaction buffer
+
+	for _, stored := range store.logCandidates(filter) { // Iterate through candidate logs
+		if filter != nil && ((!filter.Since.IsZero() && stored.timestamp.Before(filter.Since)) || (!filter.Until.IsZero() && stored.timestamp.After(filter.Until))) { // Check out of time range
+			continue // Skip reading call
+		}
+
+		location := storedLocation{account: stored.contract, position: stored.position} // Get call location
+
+		transaction, ok := transactions[location] // Get read call
+
+		if !ok { // Check call not yet read
+			var err error // Init error buffer
+
+			transaction, err = store.readTransaction(store.chains[stored.contract].offsets[stored.position]) // Read call
+			if err != nil {                                                                                  // Check for errors
+				return []*IndexedLog{}, err // Return found error
+			}
+
+			transactions[location] = transaction // Set read call
+		}
+
+		if stored.index >= len(transaction.Logs) { // Check log missing
+			return []*IndexedLog{}, ErrCorruptChainStore // Return error
+		}
+
+		log := indexedLogs(stored.contract, transaction)[stored.index] // Get log
+
+		if filter.Matches(log) { // Check match
+			logs = append(logs, log) // Append log
+		}
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].Timestamp.Before(logs[j].Timestamp) // Order by timestamp
+	}) // Sort logs
+
+	return logs, nil // Return logs
+}
+
+// Close closes the underlying log file.
+func (store *LogChainStore) Close() error {
+	store.lock.Lock()         // Lock store
+	defer store.lock.Unlock() // Unlock store
+
+	return store.file.Close() // Close log
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// open opens the store's log, and rebuilds all indexes from it.
+func (store *LogChainStore) open() error {
+	file, err := os.OpenFile(store.Path, os.O_RDWR|os.O_CREATE, 0600) // Open log
+	if err != nil {                                                   // Check for errors
+		return err // Return found error
+	}
+
+	store.file = file                                        // Set file
+	store.size = 0                                           // Reset size
+	store.waste = 0                                          // Reset waste
+	store.chains = make(map[common.Address]*storedChain)     // Init chains
+	store.hashIndex = make(map[common.Hash][]storedLocation) // Init hash index
+	store.parentIndex = make(map[common.Hash][]common.Hash)  // Init parent index
+	store.nonceIndex = make(map[storedNonce]common.Hash)     // Init nonce index
+	store.logIndex = make(map[common.Address][]storedLog)    // Init log index
+	store.topicIndex = make(map[common.Hash][]storedLog)     // Init topic index
+
+	err = store.replay() // Rebuild indexes
+
+	if err != nil { // Check for errors
+		file.Close() // Close log
+
+		return err // Return found error
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// replay reads the entire log, rebuilding all indexes. A partially-written or
+// undecodable trailing record (e.g. from a crash mid-write) is truncated.
+func (store *LogChainStore) replay() error {
+	info, err := store.file.Stat() // Get log info
+	if err != nil {                // Check for errors
+		return err // Return found error
+	}
+
+	reader := bufio.NewReader(store.file) // Init reader
+
+	offset := int64(0) // Init offset
+
+	for {
+		record, size, err := readRecord(reader, info.Size()-offset) // Read record
+		if err == io.EOF {                                          // Check done
+			break
+		} else if err == io.ErrUnexpectedEOF || (err == ErrCorruptChainStore && offset+size == info.Size()) { // Check torn write
+			common.Logf("== DB == truncating partial record at offset %d in chain store\n", offset) // Log truncate
+
+			if err = store.file.Truncate(offset); err != nil { // Truncate log
+				return err // Return found error
+			}
+
+			break
+		} else if err != nil { // Check for errors
+			return err // Return found error
+		}
+
+		err = store.apply(record, offset, size) // Apply record
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+
+		offset += size // Increment offset
+	}
+
+	store.size = offset // Set size
+
+	_, err = store.file.Seek(offset, io.SeekStart) // Seek to end of valid log
+
+	return err // Return any error
+}
+
+// append appends a record to the log, and applies it to the indexes.
+func (store *LogChainStore) append(record *chainStoreRecord) error {
+	encoded, err := json.Marshal(record) // Encode record
+	if err != nil {                      // Check for errors
+		return err // Return found error
+	}
+
+	buffer := make([]byte, recordHeaderSize+len(encoded)) // Init buffer
+
+	binary.BigEndian.PutUint32(buffer, uint32(len(encoded))) // Write length
+	copy(buffer[recordHeaderSize:], encoded)                 // Write record
+
+	_, err = store.file.WriteAt(buffer, store.size) // Write record
+	if err != nil {                                 // Check for errors
+		return err // Return found error
+	}
+
+	err = store.apply(record, store.size, int64(len(buffer))) // Apply record
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	store.size += int64(len(buffer)) // Increment size
+
+	return nil // No error occurred, return nil
+}
+
+// apply applies a record of a given size at a given log offset to the in-memory indexes.
+func (store *LogChainStore) apply(record *chainStoreRecord, offset int64, size int64) error {
+	stored, ok := store.chains[record.Account] // Get stored chain
+
+	if !ok { // Check new chain
+		stored = &storedChain{header: &Chain{Account: record.Account}} // Init stored chain
+
+		store.chains[record.Account] = stored // Set stored chain
+	}
+
+	switch record.Type {
+	case headerRecord:
+		if record.Header == nil { // Check no header
+			return ErrCorruptChainStore // Return error
+		}
+
+		if stored.headerRecord != nil { // Check has superseded header
+			store.waste += stored.headerRecord.size // Add superseded header
+		}
+
+		stored.header = record.Header                                   // Set header
+		stored.headerRecord = &storedRecord{offset: offset, size: size} // Set header position
+	case resetRecord:
+		for i, hash := range stored.hashes { // Iterate through dropped transactions
+			store.removeLocation(hash, record.Account) // Remove location
+
+			if len(store.hashIndex[hash]) == 0 { // Check transaction no longer in any chain
+				store.removeParent(stored.parents[i], hash) // Remove parent
+				store.removeNonce(stored.nonces[i], hash)   // Remove nonce
+			}
+
+			store.waste += stored.sizes[i] // Add dropped transaction
+		}
+
+		store.removeLogs(record.Account) // Remove indexed logs
+
+		store.waste += size // Add reset record
+
+		stored.offsets = nil // Reset offsets
+		stored.sizes = nil   // Reset sizes
+		stored.hashes = nil  // Reset hashes
+		stored.parents = nil // Reset parents
+		stored.nonces = nil  // Reset nonces
+	case transactionRecord:
+		transaction, err := TransactionFromJSON(record.Transaction) // Decode transaction
+		if err != nil {                                             // Check for errors
+			return err // Return found error
+		}
+
+		var hash common.Hash // Init hash buffer
+
+		if transaction.Hash != nil { // Check has hash
+			hash = *transaction.Hash // Set hash
+		}
+
+		store.hashIndex[hash] = append(store.hashIndex[hash], storedLocation{account: record.Account, position: len(stored.offsets)}) // Index hash
+
+		if transaction.ParentTx != nil && !containsHash(store.parentIndex[*transaction.ParentTx], hash) { // Check has unindexed parent
+			store.parentIndex[*transaction.ParentTx] = append(store.parentIndex[*transaction.ParentTx], hash) // Index parent
+		}
+
+		var nonce *storedNonce // Init nonce buffer
+
+		if transaction.Sender != nil { // Check has sender
+			nonce = &storedNonce{sender: *transaction.Sender, nonce: transaction.AccountNonce} // Set nonce
+
+			store.nonceIndex[*nonce] = hash // Index nonce
+		}
+
+		if transaction.Recipient != nil && *transaction.Recipient == record.Account { // Check is call committed to contract chain
+			store.indexLogs(record.Account, len(stored.offsets), transaction) // Index logs
+		}
+
+		stored.offsets = append(stored.offsets, offset)               // Append offset
+		stored.sizes = append(stored.sizes, size)                     // Append size
+		stored.hashes = append(stored.hashes, hash)                   // Append hash
+		stored.parents = append(stored.parents, transaction.ParentTx) // Append parent
+		stored.nonces = append(stored.nonces, nonce)                  // Append nonce
+	default:
+		return ErrCorruptChainStore // Return error
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// removeLocation removes a chain from the locations of a given transaction hash.
+func (store *LogChainStore) removeLocation(hash common.Hash, account common.Address) {
+	locations := store.hashIndex[hash][:0] // Init filtered buffer
+
+	for _, location := range store.hashIndex[hash] { // Iterate through locations
+		if location.account != account { // Check not in chain
+			locations = append(locations, location) // Keep location
+		}
+	}
+
+	if len(locations) == 0 { // Check no remaining locations
+		delete(store.hashIndex, hash) // Remove hash
+
+		return
+	}
+
+	store.hashIndex[hash] = locations // Set locations
+}
+
+// removeParent removes a transaction hash from the children of a given parent hash.
+func (store *LogChainStore) removeParent(parent *common.Hash, hash common.Hash) {
+	if parent == nil { // Check no parent
+		return
+	}
+
+	children := store.parentIndex[*parent][:0] // Init filtered buffer
+
+	for _, child := range store.parentIndex[*parent] { // Iterate through children
+		if child != hash { // Check not removed transaction
+			children = append(children, child) // Keep child
+		}
+	}
+
+	if len(children) == 0 { // Check no remaining children
+		delete(store.parentIndex, *parent) // Remove parent
+
+		return
+	}
+
+	store.parentIndex[*parent] = children // Set children
+}
+
+// removeNonce removes a sender nonce from the nonce index if it still resolves to a given transaction hash.
+func (store *LogChainStore) removeNonce(nonce *storedNonce, hash common.Hash) {
+	if nonce != nil && store.nonceIndex[*nonce] == hash { // Check nonce resolves to transaction
+		delete(store.nonceIndex, *nonce) // Remove nonce
+	}
+}
+
+// compactIfWasteful compacts the log once it holds at least minCompactionWaste bytes
+// of superseded records, and those make up at least half of it.
+func (store *LogChainStore) compactIfWasteful() error {
+	if store.waste < minCompactionWaste || 2*store.waste < store.size { // Check log worth keeping
+		return nil // Nothing to do
+	}
+
+	return store.compact() // Compact log
+}
+
+// compact copies every live record into a new log, in log order, replaces the
+// existing log with it, and rebuilds all indexes from the new log.
+func (store *LogChainStore) compact() error {
+	records := []storedRecord{} // Init live record buffer
+
+	for _, stored := range store.chains { // Iterate through chains
+		if stored.headerRecord != nil { // Check has header record
+			records = append(records, *stored.headerRecord) // Append header
+		}
+
+		for i, offset := range stored.offsets { // Iterate through transactions
+			records = append(records, storedRecord{offset: offset, size: stored.sizes[i]}) // Append transaction
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].offset < records[j].offset // Order by offset
+	}) // Sort records
+
+	file, err := os.OpenFile(store.Path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600) // Create temporary log
+	if err != nil {                                                                       // Check for errors
+		return err // Return found error
+	}
+
+	writer := bufio.NewWriter(file) // Init writer
+
+	for _, record := range records { // Iterate through live records
+		_, err = io.Copy(writer, io.NewSectionReader(store.file, record.offset, record.size)) // Copy record
+		if err != nil {                                                                       // Check for errors
+			file.Close() // Close log
+
+			return err // Return found error
+		}
+	}
+
+	if err = writer.Flush(); err == nil { // Flush records
+		err = file.Sync() // Sync log
+	}
+
+	if err != nil { // Check for errors
+		file.Close() // Close log
+
+		return err // Return found error
+	}
+
+	err = file.Close() // Close log
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	err = os.Rename(store.Path+".tmp", store.Path) // Replace log
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	store.file.Close() // Close replaced log
+
+	common.Logf("== DB == compacted chain store from %d to %d bytes\n", store.size, store.size-store.waste) // Log compaction
+
+	return store.open() // Reopen log
+}
+
+// indexLogs indexes the logs of a call at a given position in a given contract chain.
+func (store *LogChainStore) indexLogs(contract common.Address, position int, transaction *Transaction) {
+	for x, log := range transaction.Logs { // Iterate through logs
+		stored := storedLog{contract: contract, position: position, index: x, timestamp: transaction.Timestamp} // Init stored log
+
+		store.logIndex[contract] = append(store.logIndex[contract], stored) // Index contract
+
+		for y, topic := range log.Topics { // Iterate through topics
+			if !containsHash(log.Topics[:y], topic) { // Check topic not already indexed for log
+				store.topicIndex[topic] = append(store.topicIndex[topic], stored) // Index topic
+			}
+		}
+	}
+}
+
+// removeLogs removes every indexed log of a given contract chain.
+func (store *LogChainStore) removeLogs(contract common.Address) {
+	delete(store.logIndex, contract) // Remove contract logs
+
+	for topic, logs := range store.topicIndex { // Iterate through topics
+		filtered := logs[:0] // Init filtered buffer
+
+		for _, log := range logs { // Iterate through logs
+			if log.contract != contract { // Check not in chain
+				filtered = append(filtered, log) // Keep log
+			}
+		}
+
+		if len(filtered) == 0 { // Check no remaining logs
+			delete(store.topicIndex, topic) // Remove topic
+
+			continue // Continue
+		}
+
+		store.topicIndex[topic] = filtered // Set logs
+	}
+}
+
+// logCandidates gets the indexed logs that may match a given filter, using the narrowest available index.
+func (store *LogChainStore) logCandidates(filter *LogFilter) []storedLog {
+	if filter != nil && filter.Contract != nil { // Check has contract
+		return store.logIndex[*filter.Contract] // Return contract logs
+	}
+
+	if filter != nil && len(filter.Topics) > 0 { // Check has topics
+		return store.topicIndex[filter.Topics[0]] // Return topic logs
+	}
+
+	candidates := []storedLog{} // Init candidate buffer
+
+	for _, logs := range store.logIndex { // Iterate through contracts
+		candidates = append(candidates, logs...) // Append logs
+	}
+
+	return candidates // Return every log
+}
+
+// getTransactionByHash gets a transaction with a given hash from the first chain containing it.
+func (store *LogChainStore) getTransactionByHash(hash common.Hash) (*Transaction, error) {
+	locations := store.hashIndex[hash] // Get locations
+
+	if len(locations) == 0 { // Check no locations
+		return &Transaction{}, ErrNilTransaction // Return error
+	}
+
+	return store.readTransaction(store.chains[locations[0].account].offsets[locations[0].position]) // Read transaction
+}
+
+// readTransaction reads the transaction record at a given offset.
+func (store *LogChainStore) readTransaction(offset int64) (*Transaction, error) {
+	record, _, err := readRecord(io.NewSectionReader(store.file, offset, store.size-offset), store.size-offset) // Read record
+	if err != nil {                                                                                             // Check for errors
+		return &Transaction{}, err // Return found error
+	}
+
+	if record.Type != transactionRecord { // Check not a transaction
+		return &Transaction{}, ErrCorruptChainStore // Return error
+	}
+
+	return TransactionFromJSON(record.Transaction) // Decode transaction
+}
+
+// isPrefixOf checks that a stored chain's transactions are a prefix of the given chain's transactions.
+func (stored *storedChain) isPrefixOf(chain *Chain) bool {
+	if stored == nil || len(stored.hashes) == 0 { // Check nothing stored
+		return true // Empty is always a prefix
+	}
+
+	if len(stored.hashes) > len(chain.Transactions) { // Check chain is shorter than stored
+		return false // Not a prefix
+	}
+
+	last := chain.Transactions[len(stored.hashes)-1] // Get last common transaction
+
+	return last.Hash != nil && *last.Hash == stored.hashes[len(stored.hashes)-1] // Check hashes match
+}
+
+// readRecord reads a single length-prefixed record from a reader with a given number
+// of bytes remaining, returning the record and its size in the log. A record whose
+// length exceeds the remaining bytes is treated as a torn write (rather than
+// allocated), and the size of a record that can't be decoded is still returned.
+func readRecord(reader io.Reader, remaining int64) (*chainStoreRecord, int64, error) {
+	header := make([]byte, recordHeaderSize) // Init header buffer
+
+	_, err := io.ReadFull(reader, header) // Read header
+	if err != nil {                       // Check for errors
+		return nil, 0, err // Return found error
+	}
+
+	length := int64(binary.BigEndian.Uint32(header)) // Get record length
+
+	if length > remaining-recordHeaderSize { // Check record runs past end of log
+		return nil, 0, io.ErrUnexpectedEOF // Return torn write
+	}
+
+	encoded := make([]byte, length) // Init record buffer
+
+	_, err = io.ReadFull(reader, encoded) // Read record
+	if err == io.EOF {                    // Check header without body
+		return nil, 0, io.ErrUnexpectedEOF // Return torn write
+	} else if err != nil { // Check for errors
+		return nil, 0, err // Return found error
+	}
+
+	record := &chainStoreRecord{} // Init record buffer
+
+	err = json.Unmarshal(encoded, record) // Decode record
+	if err != nil {                       // Check for errors
+		return nil, recordHeaderSize + length, ErrCorruptChainStore // Return error
+	}
+
+	return record, recordHeaderSize + length, nil // Return record
+}
+
+// encodeStoredTransaction encodes a transaction in its safe form without modifying
+// it (signing keys are frequently shared between transactions).
+func encodeStoredTransaction(transaction *Transaction) ([]byte, error) {
+	safeTransaction := *transaction // Copy transaction
+
+	if transaction.Signature != nil && transaction.Signature.PublicKey != nil { // Check has signature
+		signature := *transaction.Signature // Copy signature
+
+		encoded, err := x509.MarshalPKIXPublicKey(signature.PublicKey) // Encode public key
+		if err != nil {                                                // Check for errors
+			return nil, err // Return found error
+		}
+
+		signature.SerializedPublicKey = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encoded}) // Encode PEM
+
+		safeTransaction.Signature = &signature // Set signature
+	}
+
+	return json.Marshal(safeTransaction) // Encode transaction
+}
+
+// containsHash checks whether a given hash is in a list of hashes.
+func containsHash(hashes []common.Hash, hash common.Hash) bool {
+	for _, currentHash := range hashes { // Iterate through hashes
+		if currentHash == hash { // Check match
+			return true // Found
+		}
+	}
+
+	return false // Not found
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,203 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// MigrateJSONChains imports all chains stored in the legacy per-account JSON layout
+// (chain_<address>.json files in the given directory) into the given chain store.
+// Once every chain has been imported, the legacy directory is renamed so that the
+// migration only runs once.
+func MigrateJSONChains(store ChainStore, legacyDir string) error {
+	files, err := ioutil.ReadDir(legacyDir) // Walk legacy chain dir
+	if os.IsNotExist(err) {                 // Check nothing to migrate
+		return nil // Nothing to do
+	} else if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	migrated := 0 // Init migrated counter
+
+	for _, file := range files { // Iterate through files
+		if file.IsDir() || !strings.HasPrefix(file.Name(), "chain_") || !strings.HasSuffix(file.Name(), ".json") { // Check not a chain file
+			continue // Skip
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(legacyDir, file.Name())) // Read chain
+		if err != nil {                                                     // Check for errors
+			return err // Return found error
+		}
+
+		chain, err := fromFloatChain(data) // Decode chain (legacy chains store floating-point amounts)
+		if err != nil {                    // Check for errors
+			return err // Return found error
+		}
+
+		err = chain.RecoverSafeEncoding() // Recover
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+
+		err = store.WriteChain(chain) // Write chain to store
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+
+		migrated++ // Increment counter
+	}
+
+	if migrated > 0 { // Check migrated any chains
+		common.Logf("== DB == migrated %d legacy chains to the chain store\n", migrated) // Log migration
+	}
+
+	return moveLegacy(legacyDir) // Move legacy dir out of the way
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// floatAmountFields are the transaction fields that held floating-point numbers of coins before amounts were stored as
+// integer base units.
+var floatAmountFields = []string{"amount", "gas_price"}
+
+// fromFloatChain decodes a chain whose transactions hold floating-point amounts.
+func fromFloatChain(b []byte) (*Chain, error) {
+	var fields map[string]json.RawMessage // Init fields buffer
+
+	err := json.Unmarshal(b, &fields) // Decode fields
+	if err != nil {                   // Check for errors
+		return &Chain{}, err // Return found error
+	}
+
+	var legacyTransactions []json.RawMessage // Init transactions buffer
+
+	if encoded, ok := fields["transactions"]; ok && string(encoded) != "null" { // Check has transactions
+		err = json.Unmarshal(encoded, &legacyTransactions) // Decode transactions
+
+		if err != nil { // Check for errors
+			return &Chain{}, err // Return found error
+		}
+	}
+
+	delete(fields, "transactions") // Remove transactions
+
+	header, err := json.Marshal(fields) // Encode header
+	if err != nil {                     // Check for errors
+		return &Chain{}, err // Return found error
+	}
+
+	chain := &Chain{} // Init chain buffer
+
+	err = json.Unmarshal(header, chain) // Decode header
+
+	if err != nil { // Check for errors
+		return &Chain{}, err // Return found error
+	}
+
+	for _, legacyTransaction := range legacyTransactions { // Iterate through transactions
+		encoded, err := fromFloatAmounts(legacyTransaction) // Convert amounts
+		if err != nil {                                     // Check for errors
+			return &Chain{}, err // Return found error
+		}
+
+		transaction := &Transaction{} // Init transaction buffer
+
+		err = json.Unmarshal(encoded, transaction) // Decode transaction (without recovering signatures; done by caller)
+
+		if err != nil { // Check for errors
+			return &Chain{}, err // Return found error
+		}
+
+		chain.Transactions = append(chain.Transactions, transaction) // Append transaction
+	}
+
+	return chain, nil // Return chain
+}
+
+// fromFloatAmounts converts an encoded transaction holding floating-point numbers of coins (along with any internal
+// transactions) to one holding integer numbers of base units.
+func fromFloatAmounts(b []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage // Init fields buffer
+
+	err := json.Unmarshal(b, &fields) // Decode fields
+	if err != nil {                   // Check for errors
+		return nil, err // Return found error
+	}
+
+	for _, field := range floatAmountFields { // Iterate through amount fields
+		encoded, ok := fields[field] // Get amount
+
+		if !ok || string(encoded) == "null" { // Check no amount
+			continue // Skip
+		}
+
+		coins, _, err := big.ParseFloat(strings.Trim(string(encoded), `"`), 10, 350, big.ToNearestEven) // Parse legacy float
+		if err != nil {                                                                                 // Check for errors
+			return nil, err // Return found error
+		}
+
+		amount, err := common.AmountFromFloat(coins) // Convert to base units
+		if err != nil {                              // Check for errors
+			return nil, err // Return found error
+		}
+
+		fields[field], err = json.Marshal(amount) // Encode amount
+
+		if err != nil { // Check for errors
+			return nil, err // Return found error
+		}
+	}
+
+	if encoded, ok := fields["internal_transactions"]; ok && string(encoded) != "null" { // Check has internal transactions
+		var internalTransactions []json.RawMessage // Init internal transactions buffer
+
+		err = json.Unmarshal(encoded, &internalTransactions) // Decode internal transactions
+
+		if err != nil { // Check for errors
+			return nil, err // Return found error
+		}
+
+		for i, internalTransaction := range internalTransactions { // Iterate through internal transactions
+			internalTransactions[i], err = fromFloatAmounts(internalTransaction) // Convert amounts
+
+			if err != nil { // Check for errors
+				return nil, err // Return found error
+			}
+		}
+
+		fields["internal_transactions"], err = json.Marshal(internalTransactions) // Encode internal transactions
+
+		if err != nil { // Check for errors
+			return nil, err // Return found error
+		}
+	}
+
+	return json.Marshal(fields) // Encode transaction
+}
+
+// moveLegacy renames a migrated legacy file or directory, so that its migration only runs once.
+func moveLegacy(path string) error {
+	target := path + "_legacy" // Get target path
+
+	if _, err := os.Stat(target); err == nil { // Check already migrated before
+		target = fmt.Sprintf("%s_%d", target, time.Now().Unix()) // Make target unique
+	}
+
+	return os.Rename(path, target) // Move legacy path out of the way
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,190 @@
+package types
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/ursa/vm"
+)
+
+var (
+	// ErrInvalidReturnValue - error definition describing a contract return value that doesn't point to a valid region of memory
+	ErrInvalidReturnValue = errors.New("contract return value out of memory bounds")
+)
+
+// contractCall - a parsed contract call
+type contractCall struct {
+	method string        // Called method
+	args   []interface{} // Call args
+
+	outputType ABIType // Type of the value returned by the method
+
+	argsSize int // Size of the memory region holding non-integer args
+}
+
+/* BEGIN INTERNAL METHODS */
+
+// parseContractCall - parse a given contract call payload (either ABI-encoded or a legacy method(1, 2) string),
+// validating it against the contract chain's ABI if it has one
+func parseContractCall(contractChain *Chain, payload []byte) (*contractCall, error) {
+	call := &contractCall{outputType: ABIInt64} // Init call
+
+	if IsABIPayload(payload) { // Check is ABI payload
+		method, args, err := DecodeContractCall(payload) // Decode call
+		if err != nil {                                  // Check for errors
+			return &contractCall{}, err // Return found error
+		}
+
+		call.method = method // Set method
+		call.args = args     // Set args
+	} else {
+		method, params, err := common.ParseStringMethodCallNoReceiver(string(payload)) // Parse payload method call
+		if err != nil {                                                                // Check for errors
+			return &contractCall{}, err // Return found error
+		}
+
+		call.method = method // Set method
+
+		for _, param := range params { // Iterate through params
+			intVal, err := strconv.ParseInt(param, 10, 64) // Parse int
+			if err != nil {                                // Check for errors
+				return &contractCall{}, err // Return found error
+			}
+
+			call.args = append(call.args, intVal) // Append parsed param
+		}
+	}
+
+	if contractChain.ABI == nil { // Check no ABI
+		return call, nil // Return call
+	}
+
+	method, err := contractChain.ABI.Method(call.method) // Get method descriptor
+	if err != nil {                                      // Check for errors
+		return &contractCall{}, err // Return found error
+	}
+
+	err = method.ValidateArguments(call.args) // Validate args
+
+	if err != nil { // Check for errors
+		return &contractCall{}, err // Return found error
+	}
+
+	call.outputType = method.OutputType() // Set output type
+
+	return call, nil // Return call
+}
+
+// vmParams - convert the call's args to VM params. Integers are passed as-is, while all other args are copied
+// into fresh pages at the end of the VM's memory and passed as a pointer, length pair.
+func (call *contractCall) vmParams(workingVM *vm.VirtualMachine) []int64 {
+	params := []int64{} // Init params buffer
+
+	var data []byte // Init data buffer
+
+	for _, arg := range call.args { // Iterate through args
+		switch typedArg := arg.(type) { // Handle arg types
+		case int64:
+			params = append(params, typedArg) // Append param
+		default:
+			raw := abiRawBytes(arg) // Get raw bytes
+
+			params = append(params, int64(len(workingVM.Memory)+len(data)), int64(len(raw))) // Append pointer, length
+
+			data = append(data, raw...) // Append data
+		}
+	}
+
+	if len(data) == 0 { // Check no memory args
+		return params // Return params
+	}
+
+	pages := (len(data) + vm.DefaultPageSize - 1) / vm.DefaultPageSize // Get number of pages needed
+
+	call.argsSize = pages * vm.DefaultPageSize // Set args size
+
+	argsStart := len(workingVM.Memory) // Get start of args region
+
+	workingVM.Memory = append(workingVM.Memory, make([]byte, call.argsSize)...) // Grow memory
+
+	copy(workingVM.Memory[argsStart:], data) // Copy args
+
+	return params // Return params
+}
+
+// unloadArgs - free the pages loaded by vmParams, if the contract hasn't grown its memory since
+func (call *contractCall) unloadArgs(workingVM *vm.VirtualMachine, memorySize int) {
+	if call.argsSize != 0 && len(workingVM.Memory) == memorySize+call.argsSize { // Check can free args
+		workingVM.Memory = workingVM.Memory[:memorySize] // Free args
+	}
+}
+
+// returnLog - get a typed return log for a given VM result. Integer results are logged as-is, while all other
+// results are interpreted as a pointer (upper 32 bits), length (lower 32 bits) pair into the VM's memory.
+func (call *contractCall) returnLog(memory []byte, result int64) (*Log, error) {
+	value := interface{}(result) // Init value buffer
+
+	if call.outputType != ABIInt64 { // Check must read from memory
+		ptr, length := uint64(uint32(result>>32)), uint64(uint32(result)) // Unpack result
+
+		if ptr+length > uint64(len(memory)) { // Check out of bounds
+			return nil, ErrInvalidReturnValue // Return error
+		}
+
+		data := append([]byte{}, memory[ptr:ptr+length]...) // Copy value
+
+		switch call.outputType { // Handle output types
+		case ABIBytes:
+			value = data // Set value
+		case ABIString:
+			value = string(data) // Set value
+		case ABIAddress:
+			if len(data) != common.AddressLength { // Check invalid length
+				return nil, ErrInvalidReturnValue // Return error
+			}
+
+			address := common.Address{} // Init address buffer
+			copy(address[:], data)      // Copy address
+
+			value = address // Set value
+		case ABIHash:
+			if len(data) != common.HashLength { // Check invalid length
+				return nil, ErrInvalidReturnValue // Return error
+			}
+
+			hash := common.Hash{} // Init hash buffer
+			copy(hash[:], data)   // Copy hash
+
+			value = hash // Set value
+		}
+	}
+
+	encoded, err := EncodeABIValue(call.outputType, value) // Encode value
+	if err != nil {                                        // Check for errors
+		return nil, err // Return found error
+	}
+
+	returnLog := NewLog("return", encoded, Return) // Init log
+	returnLog.ValueType = call.outputType          // Set value type
+
+	return returnLog, nil // Return log
+}
+
+// abiRawBytes - get the raw bytes of a given non-integer ABI value
+func abiRawBytes(value interface{}) []byte {
+	switch typedValue := value.(type) { // Handle value types
+	case []byte:
+		return typedValue // Return bytes
+	case string:
+		return []byte(typedValue) // Return bytes
+	case common.Address:
+		return typedValue.Bytes() // Return bytes
+	case common.Hash:
+		return typedValue.Bytes() // Return bytes
+	default:
+		return nil // No bytes
+	}
+}
+
+/* END INTERNAL METHODS */
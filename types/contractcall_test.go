@@ -0,0 +1,123 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/ursa/vm"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestContractCallTypedArgs - test passing memory-backed args to, and reading typed return values from a contract
+func TestContractCallTypedArgs(t *testing.T) {
+	abi, err := ABIFromBytes([]byte(`{"methods": [{"name": "len", "inputs": [{"name": "data", "type": "bytes"}]}, {"name": "echo", "inputs": [{"name": "text", "type": "string"}], "outputs": [{"name": "text", "type": "string"}]}]}`)) // Decode ABI
+	if err != nil {                                                                                                                                                                                                                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain := &Chain{ContractSource: testContractSource(), ABI: abi} // Init contract chain
+
+	payload, err := EncodeContractCall("len", []byte("four")) // Encode call
+	if err != nil {                                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if log := runTestContractCall(t, chain, payload); log.ValueType != ABIInt64 || string(log.Value) != string(mustEncodeABIValue(t, ABIInt64, int64(4))) { // Check returned length
+		t.Fatalf("invalid return log: %s", log.String()) // Panic
+	}
+
+	payload, err = EncodeContractCall("echo", "hello") // Encode call
+	if err != nil {                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if log := runTestContractCall(t, chain, payload); log.ValueType != ABIString || string(log.Value[1:]) != "hello" { // Check echoed string
+		t.Fatalf("invalid return log: %s", log.String()) // Panic
+	}
+
+	if _, err = parseContractCall(chain, []byte("echo(1)")); err == nil { // Check mistyped legacy call rejected
+		t.Fatal("expected mistyped call to be rejected") // Panic
+	}
+
+	if _, err = parseContractCall(&Chain{}, []byte("add(2, 3)")); err != nil { // Check legacy calls supported without ABI
+		t.Fatal(err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// runTestContractCall - run a given contract call payload against a fresh VM
+func runTestContractCall(t *testing.T, chain *Chain, payload []byte) *Log {
+	call, err := parseContractCall(chain, payload) // Parse call
+	if err != nil {                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	workingVM, err := vm.NewVirtualMachine(chain.ContractSource, common.VMConfig, new(TransactionMetaResolver), common.GasPolicy) // Init vm
+	if err != nil {                                                                                                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	entryID, valid := workingVM.GetFunctionExport(call.method) // Get entry
+	if !valid {                                                // Check invalid
+		t.Fatalf("no export %s", call.method) // Panic
+	}
+
+	memorySize := len(workingVM.Memory) // Get memory size
+
+	result, err := workingVM.Run(entryID, call.vmParams(workingVM)...) // Run
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	log, err := call.returnLog(workingVM.Memory, result) // Get return log
+	if err != nil {                                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	call.unloadArgs(workingVM, memorySize) // Free args
+
+	if len(workingVM.Memory) != memorySize { // Check args freed
+		t.Fatalf("args not freed: %d != %d", len(workingVM.Memory), memorySize) // Panic
+	}
+
+	return log // Return log
+}
+
+// mustEncodeABIValue - encode a given ABI value, failing the test on error
+func mustEncodeABIValue(t *testing.T, abiType ABIType, value interface{}) []byte {
+	encoded, err := EncodeABIValue(abiType, value) // Encode
+	if err != nil {                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	return encoded // Return encoded
+}
+
+// testContractSource - get a minimal WASM test contract exporting add(a, b) (a + b), len(ptr, len) (len), echo(ptr, len)
+// (ptr << 32 | len) and loop() (never returns)
+func testContractSource() []byte {
+	return wasmModule(
+		[]int{2, 0},          // (i64, i64) -> i64, () -> i64
+		nil,                  // No imports
+		[]uint32{0, 0, 0, 1}, // Function types
+		[]wasmExport{
+			{"add", wasmFunc, 0},
+			{"len", wasmFunc, 1},
+			{"echo", wasmFunc, 2},
+			{"loop", wasmFunc, 3},
+			{"memory", wasmMemory, 0},
+		},
+		[][]byte{
+			{0x00, 0x20, 0x00, 0x20, 0x01, 0x7c},                   // add: local.get 0, local.get 1, i64.add
+			{0x00, 0x20, 0x01},                                     // len: local.get 1
+			{0x00, 0x20, 0x00, 0x42, 0x20, 0x86, 0x20, 0x01, 0x84}, // echo: local.get 0, i64.const 32, i64.shl, local.get 1, i64.or
+			{0x01, 0x01, wasmI64, 0x03, 0x40, 0x20, 0x00, 0x42, 0x01, 0x7c, 0x21, 0x00, 0x0c, 0x00, 0x0b, 0x42, 0x00}, // loop: loop { local.set 0 (local.get 0 + 1); br 0 }
+		},
+	)
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// DefaultCallGasLimit is the gas limit applied to read-only contract calls that don't specify one.
+const DefaultCallGasLimit uint64 = 10000000
+
+var (
+	// ErrNotContract is an error definition describing a chain that has no contract source.
+	ErrNotContract = errors.New("chain is not a contract")
+)
+
+// CallResult is the result of a read-only contract call.
+type CallResult struct {
+	ReturnValue *Log   `json:"return_value"` // Typed value returned by the call
+	Logs        []*Log `json:"logs"`         // Logs emitted by the call
+
+	Transfers []*Transaction `json:"transfers"` // Transfers the call would have made
+
+	Gas uint64 `json:"gas"` // Gas used by the call
+
+	StateRoot common.Hash `json:"state_root"` // Storage root the call was executed against
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// CallContract executes a given contract call payload against the contract chain's latest committed state in a throwaway VM.
+// Nothing touched by the call (storage writes, transfers) is persisted or broadcast. A gas limit of zero applies
+// DefaultCallGasLimit.
+func (chain *Chain) CallContract(sender *common.Address, payload []byte, gasLimit uint64) (*CallResult, error) {
+	if chain.ContractSource == nil { // Check not contract
+		return &CallResult{}, ErrNotContract // Return error
+	}
+
+	if gasLimit == 0 { // Check no gas limit
+		gasLimit = DefaultCallGasLimit // Set default gas limit
+	}
+
+	transaction := &Transaction{
+		Sender:    sender,                // Set sender
+		Recipient: &chain.Account,        // Set recipient
+		Amount:    common.NewAmount(nil), // Set amount
+		GasLimit:  gasLimit,              // Set gas limit
+		GasPrice:  common.NewAmount(nil), // Set gas price
+		Payload:   payload,               // Set payload
+		Timestamp: time.Now().UTC(),      // Set timestamp
+	} // Init unsigned, unhashed call (executed against the latest root)
+
+	_, context, err := transaction.execute(chain) // Execute call without committing
+	if err != nil {                               // Check for errors
+		return &CallResult{}, err // Return found error
+	}
+
+	return &CallResult{
+		ReturnValue: context.ReturnLog, // Set return value
+		Logs:        context.Logs,      // Set logs
+		Transfers:   context.Transfers, // Set transfers
+		Gas:         context.Gas,       // Set gas
+		StateRoot:   chain.StateRoot(), // Set root
+	}, nil // Return result
+}
+
+// String - convert given call result to string
+func (result *CallResult) String() string {
+	marshaled, _ := json.MarshalIndent(map[string]interface{}{
+		"return_value": json.RawMessage(result.stringReturnValue()),          // Set return value
+		"logs":         json.RawMessage("[" + StringLogs(result.Logs) + "]"), // Set logs
+		"transfers":    len(result.Transfers),                                // Set number of transfers
+		"gas":          result.Gas,                                           // Set gas
+		"state_root":   result.StateRoot.String(),                            // Set root
+	}, "", "  ") // Marshal result
+
+	return string(marshaled) // Return marshaled
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// stringReturnValue gets the JSON representation of the call's return value.
+func (result *CallResult) stringReturnValue() string {
+	if result.ReturnValue == nil { // Check no return value
+		return "null" // No return value
+	}
+
+	return result.ReturnValue.String() // Return typed value
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/trie"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestCallContract - test that read-only contract calls return results without persisting any state
+func TestCallContract(t *testing.T) {
+	contract, sender := common.Address{0xc}, common.Address{0x5} // Init addresses
+
+	err := SetStateDatabase(trie.NewMemoryDatabase()) // Use in-memory state db
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	abi, err := ABIFromBytes([]byte(`{"methods": [{"name": "set", "inputs": [{"name": "key", "type": "bytes"}]}]}`)) // Decode ABI
+	if err != nil {                                                                                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	genesis, err := NewTransaction(0, nil, nil, &contract, common.NewAmount(nil), []byte("genesis")) // Initialize genesis transaction
+	if err != nil {                                                                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain := &Chain{Account: contract, Genesis: *genesis.Hash, Transactions: []*Transaction{genesis}, ContractSource: testHostContractSource(), ABI: abi} // Init contract chain
+
+	payload, err := EncodeContractCall("set", []byte("key")) // Encode call
+	if err != nil {                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	result, err := chain.CallContract(&sender, payload, 0) // Call contract
+	if err != nil {                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if result.Gas == 0 || result.ReturnValue == nil || result.StateRoot != (common.Hash{}) { // Check result
+		t.Fatalf("invalid call result: %s", result.String()) // Panic
+	}
+
+	if len(chain.Transactions) != 1 || chain.StateRoot() != (common.Hash{}) { // Check chain untouched
+		t.Fatal("read-only call modified contract chain") // Panic
+	}
+
+	proof, err := chain.ProveState([]byte("key")) // Prove key
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if proof.Value != nil { // Check storage write discarded
+		t.Fatalf("read-only call persisted storage: %q", proof.Value) // Panic
+	}
+
+	if _, err = chain.CallContract(&sender, payload, 1); err != ErrGasLimitExceeded { // Check gas limit enforced
+		t.Fatalf("expected gas limit to be exceeded, got %v", err) // Panic
+	}
+
+	if _, err = (&Chain{Account: sender}).CallContract(nil, payload, 0); err != ErrNotContract { // Check non-contract chain rejected
+		t.Fatalf("expected non-contract chain to be rejected, got %v", err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
@@ -0,0 +1,195 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/trie"
+)
+
+// ContractState is the state committed to by a contract call: the root of the contract's storage trie after the call, along
+// with the gas consumed by the call.
+type ContractState struct {
+	Root common.Hash `json:"root"` // Storage trie root
+
+	Gas              uint64 `json:"gas"`                // Gas used by the call
+	GasLimitExceeded bool   `json:"gas_limit_exceeded"` // Whether the call ran out of gas
+}
+
+// StateProof is a proof of the value stored at a single key in a contract's storage, verifiable against the contract's state
+// root with trie.VerifyProof.
+type StateProof struct {
+	Root  common.Hash `json:"root"`  // Storage trie root
+	Key   []byte      `json:"key"`   // Proven key
+	Value []byte      `json:"value"` // Value stored at key (nil if none)
+
+	Nodes [][]byte `json:"nodes"` // Encoded trie nodes from the root to the key
+}
+
+var (
+	// ErrNilStateDatabase is an error definition describing a nil contract state database.
+	ErrNilStateDatabase = errors.New("nil state database")
+)
+
+var (
+	workingStateDatabase     trie.Database // Working contract state database
+	workingStateDatabasePath string        // Path of the working state database (if default)
+
+	workingStateDatabaseLock sync.Mutex // Working state database lock
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// SetStateDatabase sets the working database holding contract storage trie nodes.
+// The previous working database, if any, is closed.
+func SetStateDatabase(db trie.Database) error {
+	if db == nil { // Check nil db
+		return ErrNilStateDatabase // Return error
+	}
+
+	workingStateDatabaseLock.Lock()         // Lock db
+	defer workingStateDatabaseLock.Unlock() // Unlock db
+
+	if workingStateDatabase != nil && workingStateDatabase != db { // Check must close old db
+		err := workingStateDatabase.Close() // Close old db
+		if err != nil {                     // Check for errors
+			return err // Return found error
+		}
+	}
+
+	workingStateDatabase = db     // Set db
+	workingStateDatabasePath = "" // Reset path
+
+	return nil // No error occurred, return nil
+}
+
+// GetStateDatabase gets the working contract state database, opening the default embedded database in the
+// current data directory if no database has been set.
+func GetStateDatabase() (trie.Database, error) {
+	workingStateDatabaseLock.Lock()         // Lock db
+	defer workingStateDatabaseLock.Unlock() // Unlock db
+
+	path := filepath.FromSlash(fmt.Sprintf("%s/db/state.log", common.DataDir)) // Get default db path
+
+	if workingStateDatabase != nil && (workingStateDatabasePath == "" || workingStateDatabasePath == path) { // Check already open
+		return workingStateDatabase, nil // Return working db
+	}
+
+	if workingStateDatabase != nil { // Check data dir has changed
+		workingStateDatabase.Close() // Close old db
+	}
+
+	db, err := trie.NewLogDatabase(path) // Open default db
+	if err != nil {                      // Check for errors
+		return nil, err // Return found error
+	}
+
+	workingStateDatabase = db       // Set db
+	workingStateDatabasePath = path // Set path
+
+	return db, nil // Return opened db
+}
+
+// CloseStateDatabase closes the working contract state database, if one is open.
+func CloseStateDatabase() error {
+	workingStateDatabaseLock.Lock()         // Lock db
+	defer workingStateDatabaseLock.Unlock() // Unlock db
+
+	if workingStateDatabase == nil { // Check nothing to close
+		return nil // Nothing to do
+	}
+
+	err := workingStateDatabase.Close() // Close db
+
+	workingStateDatabase = nil    // Reset db
+	workingStateDatabasePath = "" // Reset path
+
+	return err // Return any error
+}
+
+// StateRoot gets the root of the contract chain's latest committed storage trie.
+func (chain *Chain) StateRoot() common.Hash {
+	return chain.stateRootBefore(nil) // Return latest root
+}
+
+// ProveState proves the value stored at a given key in the contract chain's latest committed storage.
+func (chain *Chain) ProveState(key []byte) (*StateProof, error) {
+	db, err := GetStateDatabase() // Get state db
+	if err != nil {               // Check for errors
+		return &StateProof{}, err // Return found error
+	}
+
+	storage := trie.New(chain.StateRoot(), db) // Open storage
+
+	value, err := storage.Get(key) // Get value
+	if err != nil {                // Check for errors
+		return &StateProof{}, err // Return found error
+	}
+
+	nodes, err := storage.Prove(key) // Prove key
+	if err != nil {                  // Check for errors
+		return &StateProof{}, err // Return found error
+	}
+
+	return &StateProof{
+		Root:  storage.Root(), // Set root
+		Key:   key,            // Set key
+		Value: value,          // Set value
+		Nodes: nodes,          // Set nodes
+	}, nil // Return proof
+}
+
+// Verify checks that the proof's nodes prove its value against its root.
+func (proof *StateProof) Verify() bool {
+	value, err := trie.VerifyProof(proof.Root, proof.Key, proof.Nodes) // Verify proof
+	if err != nil {                                                    // Check for errors
+		return false // Invalid
+	}
+
+	return bytes.Equal(value, proof.Value) // Return proves value
+}
+
+// Bytes - convert given contract state to byte array
+func (state *ContractState) Bytes() []byte {
+	buffer := new(bytes.Buffer) // Init buffer
+
+	json.NewEncoder(buffer).Encode(*state) // Serialize state
+
+	return buffer.Bytes() // Return serialized
+}
+
+// String - convert given contract state to string
+func (state *ContractState) String() string {
+	marshaled, _ := json.MarshalIndent(*state, "", "  ") // Marshal state
+
+	return string(marshaled) // Return marshaled
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// stateRootBefore gets the storage root committed by the last call preceding the transaction with a given hash in the
+// contract chain (or by the last call in the chain, if the hash is nil or the transaction isn't in the chain).
+func (chain *Chain) stateRootBefore(hash *common.Hash) common.Hash {
+	root := common.Hash{} // Init root buffer (empty storage)
+
+	for _, transaction := range chain.Transactions { // Iterate through transactions
+		if hash != nil && transaction.Hash != nil && *transaction.Hash == *hash { // Check reached transaction
+			break // Break
+		}
+
+		if transaction.State != nil { // Check committed state
+			root = transaction.State.Root // Set root
+		}
+	}
+
+	return root // Return root
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/trie"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestStateRoot - test that contract calls commit deterministic storage roots, and that committed storage can be proven
+func TestStateRoot(t *testing.T) {
+	contract, sender := common.Address{0xc}, common.Address{0x5} // Init addresses
+
+	err := SetStateDatabase(trie.NewMemoryDatabase()) // Use in-memory state db
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	abi, err := ABIFromBytes([]byte(`{"methods": [{"name": "set", "inputs": [{"name": "key", "type": "bytes"}]}]}`)) // Decode ABI
+	if err != nil {                                                                                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	genesis, err := NewTransaction(0, nil, nil, &contract, common.NewAmount(nil), []byte("genesis")) // Initialize genesis transaction
+	if err != nil {                                                                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain := &Chain{Account: contract, Genesis: *genesis.Hash, Transactions: []*Transaction{genesis}, ContractSource: testHostContractSource(), ABI: abi} // Init contract chain
+
+	first := newTestSetCall(t, genesis, &sender, &contract, "first") // Initialize first call
+
+	first.State, _, err = first.evaluate(chain) // Evaluate first call
+	if err != nil {                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if first.State.Root == (common.Hash{}) || first.State.Gas == 0 { // Check committed root and gas
+		t.Fatalf("invalid state: %s", first.State.String()) // Panic
+	}
+
+	chain.Transactions = append(chain.Transactions, first) // Commit first call
+
+	second := newTestSetCall(t, first, &sender, &contract, "second") // Initialize second call
+
+	second.State, _, err = second.evaluate(chain) // Evaluate second call
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain.Transactions = append(chain.Transactions, second) // Commit second call
+
+	if chain.StateRoot() != second.State.Root || second.State.Root == first.State.Root { // Check root advanced
+		t.Fatalf("invalid state root: %s", chain.StateRoot().String()) // Panic
+	}
+
+	replayed, _, err := first.evaluate(chain) // Re-evaluate first call (as a validator would)
+	if err != nil {                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if string(replayed.Bytes()) != string(first.State.Bytes()) { // Check deterministic
+		t.Fatalf("state not deterministic: %s != %s", replayed.String(), first.State.String()) // Panic
+	}
+
+	proof, err := chain.ProveState([]byte("first")) // Prove first key
+	if err != nil {                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if !proof.Verify() || string(proof.Value) != "first" || proof.Root != second.State.Root { // Check proof
+		t.Fatalf("invalid proof for value %q", proof.Value) // Panic
+	}
+
+	proof.Value = []byte("forged") // Forge value
+
+	if proof.Verify() { // Check forged proof rejected
+		t.Fatal("expected forged proof to be rejected") // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newTestSetCall - initialize a call to the test host contract, storing a given key as its own value
+func newTestSetCall(t *testing.T, parent *Transaction, sender *common.Address, contract *common.Address, key string) *Transaction {
+	payload, err := EncodeContractCall("set", []byte(key)) // Encode call
+	if err != nil {                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	call, err := NewTransactionWithGas(0, parent, sender, contract, common.NewAmount(nil), 100000, common.NewAmount(nil), payload) // Initialize call
+	if err != nil {                                                                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	return call // Return call
+}
+
+/* END INTERNAL METHODS */
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -631,10 +630,10 @@ func (coordinationChain *CoordinationChain) GetGenesis() (*CoordinationNode, err
 }
 
 // GetBalance - attempt to get balance of account
-func (coordinationChain *CoordinationChain) GetBalance(address common.Address) (*big.Float, error) {
+func (coordinationChain *CoordinationChain) GetBalance(address common.Address) (*common.Amount, error) {
 	node, err := coordinationChain.QueryAddress(address) // Get node
 	if err != nil {                                      // Check for errors
-		return big.NewFloat(0), err // Return found error
+		return common.NewAmount(nil), err // Return found error
 	}
 
 	var result []byte // Init buffer
@@ -649,7 +648,7 @@ func (coordinationChain *CoordinationChain) GetBalance(address common.Address) (
 
 	chain, err := FromBytes(result) // Get chain from bytes
 	if err != nil {                 // Check for errors
-		return big.NewFloat(0), err // Return found error
+		return common.NewAmount(nil), err // Return found error
 	}
 
 	return chain.CalculateBalance(), nil // No error occurred, return balance
@@ -118,6 +118,10 @@ func HandleReceivedCoordinationChainRequest() ([]byte, error) {
 		for _, address := range chainConfig.AllocAddresses { // Iterate through alloc
 			_, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/keystore/account_%s.json", common.DataDir, address.String()))) // Read account file
 
+			if err != nil { // Check for errors
+				_, err = ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/keystore/account_%s.json", common.DataDir, address.LegacyString()))) // Read account file stored under legacy address format
+			}
+
 			if err == nil { // Check for errors
 				_, err := NewChain(address) // Init chain
 				if err != nil {             // Check for err`ors
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/trie"
+)
+
+// ExecutionContext holds the state of a single contract call. Each call gets its own context (passed to the VM through its
+// TransactionMetaResolver), so concurrent calls never share mutable state.
+type ExecutionContext struct {
+	Transaction *Transaction // Transaction being evaluated
+	Contract    *Chain       // Called contract chain
+
+	Logs      []*Log // Logs emitted by the call
+	ReturnLog *Log   // Typed log of the value returned by the call
+
+	Storage *trie.Trie // Working contract storage (opened at the root committed before the call)
+
+	Transfers   []*Transaction // Internal transactions sent by the contract
+	Transferred *common.Amount // Total value transferred by the contract
+
+	Gas   uint64         // Gas used by the call
+	State *ContractState // State resulting from the call
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewExecutionContext initializes a new execution context for a given transaction calling a given contract. The contract's
+// storage is opened at the root committed by the last call preceding the transaction.
+func NewExecutionContext(transaction *Transaction, contract *Chain) (*ExecutionContext, error) {
+	db, err := GetStateDatabase() // Get state db
+	if err != nil {               // Check for errors
+		return &ExecutionContext{}, err // Return found error
+	}
+
+	return &ExecutionContext{
+		Transaction: transaction,                                              // Set transaction
+		Contract:    contract,                                                 // Set contract
+		Storage:     trie.New(contract.stateRootBefore(transaction.Hash), db), // Set storage
+		Transferred: common.NewAmount(nil),                                    // Set transferred
+	}, nil // Return initialized context
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// contractBalance calculates the balance of the contract available to the call (including the amount sent in the call, less any
+// coins transferred so far).
+func (context *ExecutionContext) contractBalance() *common.Amount {
+	balance := context.Contract.CalculateBalance() // Get committed balance
+
+	return balance.Add(context.Transaction.Amount).Sub(context.Transferred) // Add sent amount, subtract transferred
+}
+
+// apply appends the logs and internal transactions resulting from the call to a given transaction.
+func (context *ExecutionContext) apply(transaction *Transaction) {
+	(*transaction).Logs = append((*transaction).Logs, context.Logs...) // Append logs
+
+	if context.ReturnLog != nil { // Check has return value
+		(*transaction).Logs = append((*transaction).Logs, context.ReturnLog) // Append return log
+	}
+
+	(*transaction).InternalTransactions = context.Transfers // Set internal transactions
+}
+
+/* END INTERNAL METHODS */
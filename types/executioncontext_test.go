@@ -0,0 +1,122 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/trie"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestConcurrentEvaluate - test that concurrent contract calls (run with -race) each keep their own logs and state
+func TestConcurrentEvaluate(t *testing.T) {
+	contract, sender := common.Address{0xc}, common.Address{0x5} // Init addresses
+
+	abi, err := ABIFromBytes([]byte(`{"methods": [{"name": "log", "inputs": [{"name": "message", "type": "bytes"}]}]}`)) // Decode ABI
+	if err != nil {                                                                                                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	genesis, err := NewTransaction(0, nil, nil, &contract, common.NewAmount(nil), []byte("genesis")) // Initialize genesis transaction
+	if err != nil {                                                                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = SetStateDatabase(trie.NewMemoryDatabase()) // Use in-memory state db
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain := &Chain{Account: contract, Genesis: *genesis.Hash, Transactions: []*Transaction{genesis}, ContractSource: testLogContractSource(), ABI: abi} // Init contract chain
+
+	parent := newTestLogCall(t, genesis, &sender, &contract, "parent") // Initialize parent call
+
+	parent.State, _, err = parent.evaluate(chain) // Evaluate parent call
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain.Transactions = append(chain.Transactions, parent) // Commit parent call, shared by all calls below
+
+	var wg sync.WaitGroup // Init wait group
+
+	errs := make(chan error, 32) // Init error buffer
+
+	for x := 0; x < 32; x++ { // Run calls concurrently
+		message := fmt.Sprintf("call %d", x) // Get message
+
+		call := newTestLogCall(t, parent, &sender, &contract, message) // Initialize call
+
+		wg.Add(1) // Add call
+
+		go func() {
+			defer wg.Done() // Done
+
+			state, context, err := call.evaluate(chain) // Evaluate call
+			if err != nil {                             // Check for errors
+				errs <- err // Write error
+
+				return // Return
+			}
+
+			if len(context.Logs) != 1 || string(context.Logs[0].Value) != message { // Check logged own message only
+				errs <- fmt.Errorf("call %s got logs %v", message, context.Logs) // Write error
+
+				return // Return
+			}
+
+			if state.Gas == 0 || state.Gas != context.Gas { // Check gas metered per call
+				errs <- fmt.Errorf("invalid gas for call %s: %d", message, state.Gas) // Write error
+			}
+		}()
+	}
+
+	wg.Wait()   // Wait for calls to finish
+	close(errs) // Close error buffer
+
+	for err := range errs { // Iterate through errors
+		t.Error(err) // Log error
+	}
+
+	if len(parent.Logs) != 0 { // Check parent transaction untouched
+		t.Errorf("parent call modified: %v", parent.Logs) // Log error
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newTestLogCall - initialize a call to the test log contract, logging a given message
+func newTestLogCall(t *testing.T, parent *Transaction, sender *common.Address, contract *common.Address, message string) *Transaction {
+	payload, err := EncodeContractCall("log", []byte(message)) // Encode call
+	if err != nil {                                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	call, err := NewTransactionWithGas(0, parent, sender, contract, common.NewAmount(nil), 100000, common.NewAmount(nil), payload) // Initialize call
+	if err != nil {                                                                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	return call // Return call
+}
+
+// testLogContractSource - get a minimal WASM test contract exporting log(ptr, len), which logs the message at ptr
+func testLogContractSource() []byte {
+	return wasmModule(
+		[]int{2},                   // (i64, i64) -> i64
+		[]wasmImport{{"__log", 0}}, // Imports
+		[]uint32{0},                // Function types
+		[]wasmExport{{"log", wasmFunc, 1}, {"memory", wasmMemory, 0}}, // Exports
+		[][]byte{
+			{0x00, 0x20, 0x00, 0x20, 0x01, 0x10, 0x00}, // log: __log(ptr, len)
+		},
+	)
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,136 @@
+package types
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/config"
+)
+
+var (
+	// MintPayload is the payload carried by every minting transaction.
+	MintPayload = []byte("mint")
+
+	// ErrInvalidMint is an error definition describing a minting transaction that has a sender or signature, or that
+	// doesn't match the transaction minted for its issuance period.
+	ErrInvalidMint = errors.New("invalid mint transaction")
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMintTransaction initializes the transaction minting a given issuance period's coins to the genesis account. Minting
+// transactions are derived deterministically from the chain config and genesis time (rather than being signed and
+// broadcast), so every node mints identical transactions with identical hashes.
+func NewMintTransaction(chainConfig *config.ChainConfig, period uint64, genesis time.Time) *Transaction {
+	recipient := chainConfig.AllocAddresses[0] // Get genesis account
+
+	transaction := Transaction{ // Init tx
+		AccountNonce: period,                                                                           // Set nonce
+		Recipient:    &recipient,                                                                       // Set recipient
+		Amount:       chainConfig.Issuance(period),                                                     // Set amount
+		Payload:      MintPayload,                                                                      // Set payload
+		Timestamp:    genesis.Add(time.Duration(period) * chainConfig.IssuancePeriod()).UTC().Round(0), // Set timestamp
+		Mint:         true,                                                                             // Set is mint
+	}
+
+	hash := transaction.CalculateHash() // Hash transaction
+
+	transaction.Hash = &hash // Set hash
+
+	return &transaction // Return initialized transaction
+}
+
+// Mint appends a minting transaction to the genesis chain for every issuance period that has ended by a given time but has
+// not yet been minted. Returns the appended transactions. Does nothing for chains other than the genesis chain.
+func (chain *Chain) Mint(chainConfig *config.ChainConfig, now time.Time) []*Transaction {
+	if len(chain.Transactions) == 0 || len(chainConfig.AllocAddresses) == 0 || chain.Account != chainConfig.AllocAddresses[0] || chainConfig.InflationRate <= 0 { // Check nothing to mint
+		return []*Transaction{} // Nothing minted
+	}
+
+	genesis := chain.Transactions[0].Timestamp // Get genesis time
+
+	minted := uint64(0) // Init minted periods buffer
+
+	for _, transaction := range chain.Transactions { // Iterate through transactions
+		if transaction.Mint && ValidateMint(chainConfig, transaction, genesis) == nil { // Check is valid mint
+			minted++ // Increment minted
+		}
+	}
+
+	transactions := []*Transaction{} // Init minted transactions buffer
+
+	for period := minted + 1; period <= chainConfig.PeriodsElapsed(genesis, now); period++ { // Iterate through unminted periods
+		transaction := NewMintTransaction(chainConfig, period, genesis) // Initialize mint
+
+		chain.Transactions = append(chain.Transactions, transaction) // Append mint
+		transactions = append(transactions, transaction)             // Append mint
+	}
+
+	return transactions // Return minted transactions
+}
+
+// ValidateMint checks that a given minting transaction has no sender or signature, and is identical to the transaction
+// minted for its issuance period from the chain config and a given genesis time.
+func ValidateMint(chainConfig *config.ChainConfig, transaction *Transaction, genesis time.Time) error {
+	if !transaction.Mint || transaction.Sender != nil || transaction.Signature != nil || transaction.Hash == nil || transaction.AccountNonce == 0 || len(chainConfig.AllocAddresses) == 0 { // Check can't be a mint
+		return ErrInvalidMint // Return error
+	}
+
+	if *NewMintTransaction(chainConfig, transaction.AccountNonce, genesis).Hash != *transaction.Hash || transaction.CalculateHash() != *transaction.Hash { // Check doesn't match derived mint
+		return ErrInvalidMint // Return error
+	}
+
+	return nil // Valid mint
+}
+
+// Minted gets the total amount of coins minted by the valid minting transactions committed to the chain.
+func (chain *Chain) Minted(chainConfig *config.ChainConfig) *common.Amount {
+	minted := common.NewAmount(nil) // Init minted buffer
+
+	if len(chain.Transactions) == 0 { // Check no genesis
+		return minted // Nothing minted
+	}
+
+	for _, transaction := range chain.Transactions { // Iterate through transactions
+		if transaction.Mint && ValidateMint(chainConfig, transaction, chain.Transactions[0].Timestamp) == nil { // Check is valid mint
+			minted = minted.Add(transaction.Amount) // Add minted amount
+		}
+	}
+
+	return minted // Return minted amount
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// addMint adds a given minting transaction (e.g. one received while syncing the genesis chain) to the chain, minting
+// every ended issuance period up to and including it. Minting transactions are never signed, so a mint is only added
+// if it is identical to the transaction the chain would mint for its period.
+func (chain *Chain) addMint(chainConfig *config.ChainConfig, genesisChain *Chain, transaction *Transaction) error {
+	if len(genesisChain.Transactions) == 0 { // Check no genesis
+		return ErrInvalidMint // Return error
+	}
+
+	err := ValidateMint(chainConfig, transaction, genesisChain.Transactions[0].Timestamp) // Validate mint
+	if err != nil {                                                                       // Check for errors
+		return err // Return found error
+	}
+
+	if chain.Account != chainConfig.AllocAddresses[0] { // Check not genesis chain
+		return ErrIrrelevantTransaction // Return error
+	}
+
+	for _, currentTransaction := range chain.Transactions { // Check for duplicate transaction
+		if currentTransaction.Hash != nil && *currentTransaction.Hash == *transaction.Hash { // Check for matching hash
+			return ErrDuplicateTransaction // Return error
+		}
+	}
+
+	chain.Mint(chainConfig, transaction.Timestamp) // Mint every ended issuance period up to and including the given mint
+
+	return chain.WriteToMemory() // Write chain
+}
+
+/* END INTERNAL METHODS */
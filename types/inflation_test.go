@@ -0,0 +1,105 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/config"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestMint - test that the genesis chain mints each ended issuance period exactly once
+func TestMint(t *testing.T) {
+	address := common.Address{0x1} // Init genesis address
+
+	chainConfig := &config.ChainConfig{
+		Alloc:           map[string]*common.Amount{address.String(): common.Coins(1000)}, // Set alloc
+		AllocAddresses:  []common.Address{address},                                       // Set alloc addresses
+		InflationRate:   0.25,                                                            // Set inflation rate
+		InflationPeriod: 60,                                                              // Set inflation period
+	} // Init config
+
+	genesis, err := NewTransaction(0, nil, nil, &address, common.Coins(1000), []byte("genesis")) // Initialize genesis transaction
+	if err != nil {                                                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain := &Chain{Account: address, Genesis: *genesis.Hash, Transactions: []*Transaction{genesis}} // Init genesis chain
+
+	if minted := chain.Mint(chainConfig, genesis.Timestamp.Add(59*time.Second)); len(minted) != 0 { // Check nothing minted before first period ends
+		t.Fatalf("minted %d transactions before first period ended", len(minted)) // Panic
+	}
+
+	if minted := chain.Mint(chainConfig, genesis.Timestamp.Add(150*time.Second)); len(minted) != 2 { // Check two periods minted
+		t.Fatalf("invalid number of minted transactions: %d", len(minted)) // Panic
+	}
+
+	if minted := chain.Mint(chainConfig, genesis.Timestamp.Add(179*time.Second)); len(minted) != 0 { // Check periods minted only once
+		t.Fatalf("minted %d transactions twice", len(minted)) // Panic
+	}
+
+	if balance := chain.CalculateBalance(); balance.Cmp(chainConfig.SupplyAfter(2)) != 0 { // Check balance
+		t.Fatalf("invalid balance: %s", balance.String()) // Panic
+	}
+
+	if *chain.Transactions[2].Hash != *NewMintTransaction(chainConfig, 2, genesis.Timestamp).Hash { // Check deterministic
+		t.Fatal("mint transaction not deterministic") // Panic
+	}
+
+	if minted := (&Chain{Account: common.Address{0x2}, Transactions: []*Transaction{genesis}}).Mint(chainConfig, genesis.Timestamp.Add(time.Hour)); len(minted) != 0 { // Check non-genesis chain
+		t.Fatal("minted to non-genesis chain") // Panic
+	}
+}
+
+// TestAddForgedMint - test that a signed transfer posing as a mint is refused, and never credited without a debit
+func TestAddForgedMint(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	sender, err := common.NewAddress(privateKey) // Initialize address from private key
+	if err != nil {                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = makeChainConfig(sender) // Make config
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	recipient := common.Address{0x3} // Init recipient
+
+	transaction, err := NewTransaction(0, nil, &sender, &recipient, common.Coins(1000), MintPayload) // Initialize transaction
+	if err != nil {                                                                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transaction.Mint = true // Pose as mint
+
+	err = SignTransaction(transaction, privateKey) // Sign transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain := &Chain{Account: recipient} // Init recipient chain
+
+	if err := chain.AddTransaction(transaction); err != ErrInvalidMint { // Check forged mint refused
+		t.Fatalf("expected forged mint to be refused, got %v", err) // Panic
+	}
+
+	senderChain := &Chain{Account: sender, Transactions: []*Transaction{transaction}} // Init chain containing forged mint
+
+	if balance := senderChain.CalculateBalance(); balance.Sign() != -1 { // Check sender debited
+		t.Fatalf("expected forged mint to be debited from its sender; balance %s", balance.String()) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
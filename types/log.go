@@ -4,6 +4,8 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"strings"
+
+	"github.com/SummerCash/go-summercash/common"
 )
 
 const (
@@ -25,6 +27,10 @@ type Log struct {
 	Type  LogKeyType `json:"type"`  // Log type
 	Key   string     `json:"key"`   // Log key
 	Value []byte     `json:"value"` // Log val
+
+	ValueType ABIType `json:"value_type,omitempty"` // ABI type of the log val (return logs only)
+
+	Topics []common.Hash `json:"topics,omitempty"` // Topics the log is indexed by
 }
 
 /* BEGIN EXPORTED METHODS */
@@ -49,7 +55,23 @@ func (log *Log) String() string {
 	marshaledString["value"] = string(log.Value) // Get string representation
 	marshaledString["type"] = log.Type.String()  // Get type string representation
 
-	if log.Type == Return { // Check is return
+	if len(log.Topics) > 0 { // Check has topics
+		topics := []string{} // Init topic buffer
+
+		for _, topic := range log.Topics { // Iterate through topics
+			topics = append(topics, topic.String()) // Append topic
+		}
+
+		marshaledString["topics"] = topics // Get topic string representations
+	}
+
+	if log.Type == Return && log.ValueType != "" { // Check is typed return
+		value, err := DecodeABIValue(log.ValueType, log.Value) // Decode value
+
+		if err == nil { // Check decoded
+			marshaledString["value"] = FormatABIValue(value) // Get typed representation
+		}
+	} else if log.Type == Return && len(log.Value) == 8 { // Check is legacy return
 		marshaledString["value"] = binary.LittleEndian.Uint64(log.Value) // Get integer representation
 	}
 
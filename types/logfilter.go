@@ -0,0 +1,189 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// MaxLogTopics is the maximum number of topics a single log may be indexed by.
+const MaxLogTopics = 4
+
+// logSubscriptionBuffer is the number of logs buffered for each subscriber before new logs are dropped.
+const logSubscriptionBuffer = 256
+
+var (
+	// ErrTooManyTopics is an error definition describing a log indexed by more than MaxLogTopics topics.
+	ErrTooManyTopics = errors.New("too many log topics")
+)
+
+// LogFilter describes a set of contract logs.
+type LogFilter struct {
+	Contract *common.Address `json:"contract"` // Contract that emitted the logs (nil matches any contract)
+
+	Topics []common.Hash `json:"topics"` // Topics every matching log must be indexed by
+
+	Since time.Time `json:"since"` // Earliest matching transaction timestamp (zero if unbounded)
+	Until time.Time `json:"until"` // Latest matching transaction timestamp (zero if unbounded)
+}
+
+// IndexedLog is a log, along with the position at which it was committed.
+type IndexedLog struct {
+	Log *Log `json:"log"` // Log
+
+	Contract        common.Address `json:"contract"`         // Contract that emitted the log
+	TransactionHash common.Hash    `json:"transaction_hash"` // Hash of the contract call that emitted the log
+	Index           int            `json:"index"`            // Index of the log in the call's logs
+
+	Timestamp time.Time `json:"time"` // Contract call timestamp
+}
+
+// LogSubscription is a subscription to newly committed logs matching a filter.
+type LogSubscription struct {
+	Logs chan *IndexedLog // Matching logs, in commit order
+
+	filter *LogFilter // Subscription filter
+}
+
+var (
+	logSubscriptions     = make(map[*LogSubscription]struct{}) // Working log subscriptions
+	logSubscriptionsLock sync.Mutex                            // Log subscriptions lock
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// QueryLogs queries the working chain store's log index for every committed log matching a given filter.
+func QueryLogs(filter *LogFilter) ([]*IndexedLog, error) {
+	store, err := GetChainStore() // Get working chain store
+	if err != nil {               // Check for errors
+		return []*IndexedLog{}, err // Return found error
+	}
+
+	return store.QueryLogs(filter) // Query logs
+}
+
+// SubscribeLogs subscribes to logs matching a given filter as they are committed by Chain.AddTransaction. Logs are dropped
+// (rather than blocking AddTransaction) while a subscriber's buffer is full.
+func SubscribeLogs(filter *LogFilter) *LogSubscription {
+	subscription := &LogSubscription{
+		Logs:   make(chan *IndexedLog, logSubscriptionBuffer), // Init logs
+		filter: filter,                                        // Set filter
+	}
+
+	logSubscriptionsLock.Lock()         // Lock subscriptions
+	defer logSubscriptionsLock.Unlock() // Unlock subscriptions
+
+	logSubscriptions[subscription] = struct{}{} // Add subscription
+
+	return subscription // Return subscription
+}
+
+// Unsubscribe cancels the subscription, closing its logs channel.
+func (subscription *LogSubscription) Unsubscribe() {
+	logSubscriptionsLock.Lock()         // Lock subscriptions
+	defer logSubscriptionsLock.Unlock() // Unlock subscriptions
+
+	if _, ok := logSubscriptions[subscription]; !ok { // Check already cancelled
+		return // Nothing to do
+	}
+
+	delete(logSubscriptions, subscription) // Remove subscription
+
+	close(subscription.Logs) // Close logs
+}
+
+// Matches checks whether a given indexed log matches the filter.
+func (filter *LogFilter) Matches(log *IndexedLog) bool {
+	if filter == nil { // Check no filter
+		return true // Match everything
+	}
+
+	if filter.Contract != nil && *filter.Contract != log.Contract { // Check different contract
+		return false // No match
+	}
+
+	if !filter.Since.IsZero() && log.Timestamp.Before(filter.Since) { // Check too early
+		return false // No match
+	}
+
+	if !filter.Until.IsZero() && log.Timestamp.After(filter.Until) { // Check too late
+		return false // No match
+	}
+
+	for _, topic := range filter.Topics { // Iterate through topics
+		if !containsHash(log.Log.Topics, topic) { // Check not indexed by topic
+			return false // No match
+		}
+	}
+
+	return true // Match
+}
+
+// String - convert given indexed log to string
+func (log *IndexedLog) String() string {
+	marshaled, _ := json.MarshalIndent(map[string]interface{}{
+		"log":              json.RawMessage(log.Log.String()),             // Set log
+		"contract":         log.Contract.String(),                         // Set contract
+		"transaction_hash": log.TransactionHash.String(),                  // Set transaction hash
+		"index":            log.Index,                                     // Set index
+		"time":             log.Timestamp.Format("01/02/2006 3:04:05 PM"), // Set timestamp
+	}, "", "  ") // Marshal log
+
+	return string(marshaled) // Return marshaled
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// indexedLogs gets the logs emitted by a contract call committed to a given contract chain.
+func indexedLogs(contract common.Address, transaction *Transaction) []*IndexedLog {
+	logs := []*IndexedLog{} // Init log buffer
+
+	var hash common.Hash // Init hash buffer
+
+	if transaction.Hash != nil { // Check has hash
+		hash = *transaction.Hash // Set hash
+	}
+
+	for x, log := range transaction.Logs { // Iterate through logs
+		logs = append(logs, &IndexedLog{
+			Log:             log,                   // Set log
+			Contract:        contract,              // Set contract
+			TransactionHash: hash,                  // Set hash
+			Index:           x,                     // Set index
+			Timestamp:       transaction.Timestamp, // Set timestamp
+		}) // Append log
+	}
+
+	return logs // Return logs
+}
+
+// publishLogs pushes the logs emitted by a contract call committed to a given contract chain to every matching subscriber.
+func publishLogs(contract common.Address, transaction *Transaction) {
+	if len(transaction.Logs) == 0 { // Check no logs
+		return // Nothing to publish
+	}
+
+	logSubscriptionsLock.Lock()         // Lock subscriptions
+	defer logSubscriptionsLock.Unlock() // Unlock subscriptions
+
+	for _, log := range indexedLogs(contract, transaction) { // Iterate through logs
+		for subscription := range logSubscriptions { // Iterate through subscriptions
+			if !subscription.filter.Matches(log) { // Check no match
+				continue // Continue
+			}
+
+			select {
+			case subscription.Logs <- log: // Push log
+			default:
+				common.Logf("== LOGS == dropping log %d of %s for slow subscriber\n", log.Index, log.TransactionHash.String()) // Log drop
+			}
+		}
+	}
+}
+
+/* END INTERNAL METHODS */
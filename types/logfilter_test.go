@@ -0,0 +1,105 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/ursa/vm"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestSubscribeLogs - test that log subscribers receive only matching committed logs
+func TestSubscribeLogs(t *testing.T) {
+	contract, topic := common.Address{0xc}, common.Hash{1} // Init contract, topic
+
+	call, err := NewTransaction(0, nil, &common.Address{0x5}, &contract, common.NewAmount(nil), []byte("log()")) // Initialize call
+	if err != nil {                                                                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	call.Logs = []*Log{NewLog("message", []byte("unindexed"), Custom), {Type: Custom, Key: "message", Value: []byte("indexed"), Topics: []common.Hash{topic}}} // Set logs
+
+	matching := SubscribeLogs(&LogFilter{Contract: &contract, Topics: []common.Hash{topic}}) // Subscribe to indexed logs
+	defer matching.Unsubscribe()                                                             // Unsubscribe
+
+	other := SubscribeLogs(&LogFilter{Contract: &common.Address{0xd}}) // Subscribe to other contract
+	defer other.Unsubscribe()                                          // Unsubscribe
+
+	publishLogs(contract, call) // Publish logs
+
+	select {
+	case log := <-matching.Logs:
+		if string(log.Log.Value) != "indexed" || log.Index != 1 || log.TransactionHash != *call.Hash { // Check log
+			t.Fatalf("invalid log: %s", log.String()) // Panic
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no log received") // Panic
+	}
+
+	select {
+	case log := <-matching.Logs:
+		t.Fatalf("unexpected log: %s", log.String()) // Panic
+	case log := <-other.Logs:
+		t.Fatalf("unexpected log for other contract: %s", log.String()) // Panic
+	default:
+	}
+
+	other.Unsubscribe() // Unsubscribe
+
+	if _, ok := <-other.Logs; ok { // Check closed
+		t.Fatal("expected logs to be closed after unsubscribing") // Panic
+	}
+}
+
+// TestLogIndexed - test functionality of the __log_indexed host function
+func TestLogIndexed(t *testing.T) {
+	call, err := NewTransaction(0, nil, &common.Address{0x5}, &common.Address{0xc}, common.NewAmount(nil), []byte("log()")) // Initialize call
+	if err != nil {                                                                                                         // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	context := &ExecutionContext{Transaction: call, Transferred: common.NewAmount(nil)} // Init context
+
+	workingVM, err := vm.NewVirtualMachine(testIndexedLogContractSource(), common.VMConfig, NewTransactionMetaResolver(context), common.GasPolicy) // Init vm
+	if err != nil {                                                                                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	copy(workingVM.Memory, []byte{1})                     // Write first topic
+	copy(workingVM.Memory[common.HashLength:], []byte{2}) // Write second topic
+	copy(workingVM.Memory[1024:], "event")                // Write message
+
+	if result := runTestExport(t, workingVM, "log", 0, 2, 1024, 5); result != 0 { // Log indexed message
+		t.Fatalf("invalid log result: %d", result) // Panic
+	}
+
+	if len(context.Logs) != 1 || string(context.Logs[0].Value) != "event" || len(context.Logs[0].Topics) != 2 || context.Logs[0].Topics[1] != (common.Hash{2}) { // Check log
+		t.Fatalf("invalid logs: %s", StringLogs(context.Logs)) // Panic
+	}
+
+	if _, err = workingVM.Run(mustGetExport(t, workingVM, "log"), 0, MaxLogTopics+1, 1024, 5); err == nil { // Log with too many topics
+		t.Fatal("expected too many topics to fail") // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// testIndexedLogContractSource - get a minimal WASM test contract exporting log(topicsPtr, numTopics, ptr, len), which logs the
+// message at ptr, indexed by the topics at topicsPtr
+func testIndexedLogContractSource() []byte {
+	return wasmModule(
+		[]int{4},                           // (i64, i64, i64, i64) -> i64
+		[]wasmImport{{"__log_indexed", 0}}, // Imports
+		[]uint32{0},                        // Function types
+		[]wasmExport{{"log", wasmFunc, 1}, {"memory", wasmMemory, 0}}, // Exports
+		[][]byte{
+			{0x00, 0x20, 0x00, 0x20, 0x01, 0x20, 0x02, 0x20, 0x03, 0x10, 0x00}, // log: __log_indexed(topicsPtr, numTopics, ptr, len)
+		},
+	)
+}
+
+/* END INTERNAL METHODS */
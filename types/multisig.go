@@ -0,0 +1,337 @@
+package types
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/crypto"
+)
+
+// MaxMultisigKeys - maximum number of public keys in a multisig spending policy
+const MaxMultisigKeys = 16
+
+// multisigAddressPrefix - domain separator prepended to an encoded policy before hashing it to an address
+const multisigAddressPrefix = "multisig"
+
+// MultisigPolicy - M-of-N spending policy controlling a multisig account. The account's address commits to the policy (see Address),
+// so the policy cannot be changed without changing the address.
+type MultisigPolicy struct {
+	Threshold uint32 `json:"threshold"` // Number of signatures required to spend (M)
+
+	PublicKeys [][]byte `json:"public_keys"` // PKIX-encoded public keys of the signers (N), in ascending byte order
+}
+
+// PartialSignature - signature by one of the keys in a multisig spending policy
+type PartialSignature struct {
+	KeyIndex uint32 `json:"key_index"` // Index of the signing key in the policy
+
+	R *big.Int `json:"r"` // Signature R
+	S *big.Int `json:"s"` // Signature S
+}
+
+// MultisigSignature - spending policy of a multisig transaction sender, and the partial signatures collected so far
+type MultisigSignature struct {
+	Policy *MultisigPolicy `json:"policy"` // Sender spending policy
+
+	Signatures []*PartialSignature `json:"signatures"` // Partial signatures, in ascending key index order
+}
+
+var (
+	// ErrInvalidMultisigThreshold - error definition describing a multisig threshold of zero, or greater than the number of keys
+	ErrInvalidMultisigThreshold = errors.New("multisig threshold must be between 1 and the number of public keys")
+
+	// ErrTooManyMultisigKeys - error definition describing a multisig policy with more than MaxMultisigKeys public keys
+	ErrTooManyMultisigKeys = fmt.Errorf("multisig policy cannot have more than %d public keys", MaxMultisigKeys)
+
+	// ErrDuplicateMultisigKey - error definition describing a multisig policy listing the same public key more than once
+	ErrDuplicateMultisigKey = errors.New("duplicate multisig public key")
+
+	// ErrNotMultisig - error definition describing a transaction that does not carry a multisig spending policy
+	ErrNotMultisig = errors.New("transaction sender is not a multisig account")
+
+	// ErrMultisigPolicyMismatch - error definition describing a multisig policy that does not belong to the transaction sender
+	ErrMultisigPolicyMismatch = errors.New("multisig policy does not match transaction sender")
+
+	// ErrNotMultisigSigner - error definition describing a signing key that is not part of a multisig policy
+	ErrNotMultisigSigner = errors.New("signing key is not part of the sender's multisig policy")
+
+	// ErrInsufficientSignatures - error definition describing a multisig transaction with fewer signatures than its threshold
+	ErrInsufficientSignatures = errors.New("multisig transaction has fewer signatures than its threshold")
+
+	// ErrMismatchedTransaction - error definition describing partial signatures collected for a different transaction
+	ErrMismatchedTransaction = errors.New("partial signatures belong to a different transaction")
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMultisigPolicy - initialize a spending policy requiring a given number of signatures from a given set of public keys
+func NewMultisigPolicy(threshold uint32, publicKeys []*ecdsa.PublicKey) (*MultisigPolicy, error) {
+	policy := &MultisigPolicy{Threshold: threshold} // Init policy
+
+	for _, publicKey := range publicKeys { // Iterate through public keys
+		encoded, err := x509.MarshalPKIXPublicKey(publicKey) // Encode public key
+		if err != nil {                                      // Check for errors
+			return &MultisigPolicy{}, err // Return found error
+		}
+
+		policy.PublicKeys = append(policy.PublicKeys, encoded) // Append public key
+	}
+
+	sort.Slice(policy.PublicKeys, func(i, j int) bool { return bytes.Compare(policy.PublicKeys[i], policy.PublicKeys[j]) < 0 }) // Sort public keys, so the address doesn't depend on their order
+
+	if err := policy.Validate(); err != nil { // Check invalid policy
+		return &MultisigPolicy{}, err // Return found error
+	}
+
+	return policy, nil // Return policy
+}
+
+// Validate - check that given policy is well-formed (a valid threshold, and distinct, sorted, parseable ECDSA public keys)
+func (policy *MultisigPolicy) Validate() error {
+	if len(policy.PublicKeys) > MaxMultisigKeys { // Check too many keys
+		return ErrTooManyMultisigKeys // Return error
+	}
+
+	if policy.Threshold == 0 || int(policy.Threshold) > len(policy.PublicKeys) { // Check invalid threshold
+		return ErrInvalidMultisigThreshold // Return error
+	}
+
+	for i := range policy.PublicKeys { // Iterate through public keys
+		if _, err := policy.PublicKey(uint32(i)); err != nil { // Check invalid key
+			return err // Return found error
+		}
+
+		if i > 0 && bytes.Compare(policy.PublicKeys[i-1], policy.PublicKeys[i]) >= 0 { // Check duplicate or unsorted
+			return ErrDuplicateMultisigKey // Return error
+		}
+	}
+
+	return nil // Valid
+}
+
+// PublicKey - get the public key at a given index in given policy
+func (policy *MultisigPolicy) PublicKey(index uint32) (*ecdsa.PublicKey, error) {
+	if int(index) >= len(policy.PublicKeys) { // Check out of bounds
+		return nil, ErrNotMultisigSigner // Return error
+	}
+
+	genericPublicKey, err := x509.ParsePKIXPublicKey(policy.PublicKeys[index]) // Parse public key
+	if err != nil {                                                            // Check for errors
+		return nil, err // Return found error
+	}
+
+	publicKey, ok := genericPublicKey.(*ecdsa.PublicKey) // Get public key value
+	if !ok {                                             // Check not ecdsa
+		return nil, ErrInvalidPublicKey // Return error
+	}
+
+	return publicKey, nil // Return public key
+}
+
+// KeyIndex - get the index of a given public key in given policy
+func (policy *MultisigPolicy) KeyIndex(publicKey *ecdsa.PublicKey) (uint32, error) {
+	encoded, err := x509.MarshalPKIXPublicKey(publicKey) // Encode public key
+	if err != nil {                                      // Check for errors
+		return 0, err // Return found error
+	}
+
+	for i, key := range policy.PublicKeys { // Iterate through public keys
+		if bytes.Equal(key, encoded) { // Check match
+			return uint32(i), nil // Return index
+		}
+	}
+
+	return 0, ErrNotMultisigSigner // Not found
+}
+
+// Bytes - encode given policy (its threshold, followed by its public keys, in the canonical transaction encoding)
+func (policy *MultisigPolicy) Bytes() []byte {
+	buffer := new(bytes.Buffer) // Init buffer
+
+	writeUint32(buffer, policy.Threshold)               // Write threshold
+	writeUint32(buffer, uint32(len(policy.PublicKeys))) // Write number of keys
+
+	for _, publicKey := range policy.PublicKeys { // Iterate through public keys
+		writeBytes(buffer, publicKey) // Write public key
+	}
+
+	return buffer.Bytes() // Return encoded
+}
+
+// Address - get the address of the multisig account controlled by given policy (the last AddressLength bytes of the sha3 hash of
+// the encoded policy)
+func (policy *MultisigPolicy) Address() common.Address {
+	var address common.Address // Init buffer
+
+	hash := crypto.Sha3(append([]byte(multisigAddressPrefix), policy.Bytes()...)) // Hash encoded policy
+
+	copy(address[:], hash[len(hash)-common.AddressLength:]) // Copy hash suffix
+
+	return address // Return address
+}
+
+// String - convert given policy to a string
+func (policy *MultisigPolicy) String() string {
+	return fmt.Sprintf("%d-of-%d multisig %s", policy.Threshold, len(policy.PublicKeys), policy.Address().String()) // Return string
+}
+
+// IsSigned - check whether given transaction carries a signature, or (for multisig senders) at least as many partial signatures as
+// its policy requires. Signatures are not verified (see VerifyTransactionSignature).
+func (transaction *Transaction) IsSigned() bool {
+	if transaction.Signature != nil { // Check signed
+		return true // Signed
+	}
+
+	return transaction.Multisig != nil && transaction.Multisig.Policy != nil && len(transaction.Multisig.Signatures) >= int(transaction.Multisig.Policy.Threshold) // Check threshold met
+}
+
+// AddPartialSignature - sign given multisig transaction with a given private key belonging to one of the keys in the sender's policy
+func AddPartialSignature(transaction *Transaction, privateKey *ecdsa.PrivateKey) error {
+	if err := checkMultisigSender(transaction); err != nil { // Check not multisig
+		return err // Return found error
+	}
+
+	index, err := transaction.Multisig.Policy.KeyIndex(&privateKey.PublicKey) // Get key index
+	if err != nil {                                                           // Check for errors
+		return err // Return found error
+	}
+
+	if transaction.Multisig.hasSignature(index) { // Check already signed by key
+		return ErrAlreadySigned // Return error
+	}
+
+	pkCopy := *privateKey // Get copy
+
+	r, s, err := ecdsa.Sign(rand.Reader, &pkCopy, crypto.Sha3(transaction.SigningBytes())) // Sign signed fields
+	if err != nil {                                                                        // Check for errors
+		return err // Return found error
+	}
+
+	transaction.Multisig.addSignature(&PartialSignature{KeyIndex: index, R: r, S: s}) // Add signature
+
+	return nil // No error occurred, return nil
+}
+
+// MergePartialSignatures - add the valid partial signatures collected in another copy of given multisig transaction, returning the
+// number of signatures added
+func MergePartialSignatures(transaction *Transaction, other *Transaction) (int, error) {
+	if err := checkMultisigSender(other); err != nil { // Check not multisig
+		return 0, err // Return found error
+	}
+
+	if transaction.Hash == nil || other.Hash == nil || *transaction.Hash != *other.Hash || transaction.CalculateHash() != other.CalculateHash() { // Check different transactions
+		return 0, ErrMismatchedTransaction // Return error
+	}
+
+	if transaction.Multisig == nil || transaction.Multisig.Policy == nil { // Check no policy
+		transaction.Multisig = &MultisigSignature{Policy: other.Multisig.Policy} // Set policy
+	}
+
+	signed := crypto.Sha3(transaction.SigningBytes()) // Hash signed fields
+
+	added := 0 // Init added counter
+
+	for _, signature := range other.Multisig.Signatures { // Iterate through signatures
+		if transaction.Multisig.hasSignature(signature.KeyIndex) { // Check already collected
+			continue // Skip
+		}
+
+		publicKey, err := transaction.Multisig.Policy.PublicKey(signature.KeyIndex) // Get signing key
+		if err != nil {                                                             // Check for errors
+			return added, err // Return found error
+		}
+
+		if !ecdsa.Verify(publicKey, signed, signature.R, signature.S) { // Check invalid signature
+			return added, ErrInvalidSignature // Return error
+		}
+
+		transaction.Multisig.addSignature(signature) // Add signature
+
+		added++ // Increment added
+	}
+
+	return added, nil // Return number of signatures added
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// checkMultisigSender - check that given transaction carries a well-formed policy matching its sender
+func checkMultisigSender(transaction *Transaction) error {
+	if transaction.Multisig == nil || transaction.Multisig.Policy == nil { // Check no policy
+		return ErrNotMultisig // Return error
+	}
+
+	if err := transaction.Multisig.Policy.Validate(); err != nil { // Check invalid policy
+		return err // Return found error
+	}
+
+	if transaction.Sender == nil || *transaction.Sender != transaction.Multisig.Policy.Address() { // Check policy not sender's
+		return ErrMultisigPolicyMismatch // Return error
+	}
+
+	return nil // Valid
+}
+
+// verifyMultisigSignature - verify that given multisig transaction carries valid, distinct signatures from at least as many keys as
+// its sender's policy requires
+func verifyMultisigSignature(transaction *Transaction) (bool, error) {
+	if err := checkMultisigSender(transaction); err != nil { // Check invalid policy
+		return false, ErrInvalidSignature // Return invalid signature error
+	}
+
+	if len(transaction.Multisig.Signatures) < int(transaction.Multisig.Policy.Threshold) { // Check threshold not met
+		return false, ErrInsufficientSignatures // Return error
+	}
+
+	signed := crypto.Sha3(transaction.SigningBytes()) // Hash signed fields
+
+	if transaction.Hash == nil || *transaction.Hash != common.NewHash(signed) { // Check hash doesn't match signed fields
+		return false, nil // Invalid
+	}
+
+	for i, signature := range transaction.Multisig.Signatures { // Iterate through signatures
+		if i > 0 && transaction.Multisig.Signatures[i-1].KeyIndex >= signature.KeyIndex { // Check duplicate signer
+			return false, nil // Invalid
+		}
+
+		publicKey, err := transaction.Multisig.Policy.PublicKey(signature.KeyIndex) // Get signing key
+		if err != nil || signature.R == nil || signature.S == nil {                 // Check unknown signer
+			return false, nil // Invalid
+		}
+
+		if !ecdsa.Verify(publicKey, signed, signature.R, signature.S) { // Check invalid signature
+			return false, nil // Invalid
+		}
+	}
+
+	return true, nil // Valid
+}
+
+// hasSignature - check whether given multisig signature contains a partial signature by the key at a given index
+func (multisig *MultisigSignature) hasSignature(index uint32) bool {
+	for _, signature := range multisig.Signatures { // Iterate through signatures
+		if signature.KeyIndex == index { // Check match
+			return true // Found
+		}
+	}
+
+	return false // Not found
+}
+
+// addSignature - add a given partial signature, keeping signatures in ascending key index order
+func (multisig *MultisigSignature) addSignature(signature *PartialSignature) {
+	multisig.Signatures = append(multisig.Signatures, signature) // Append signature
+
+	sort.Slice(multisig.Signatures, func(i, j int) bool { return multisig.Signatures[i].KeyIndex < multisig.Signatures[j].KeyIndex }) // Sort signatures
+}
+
+/* END INTERNAL METHODS */
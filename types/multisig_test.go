@@ -0,0 +1,166 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestNewMultisigPolicy - test multisig policy initialization and address derivation
+func TestNewMultisigPolicy(t *testing.T) {
+	keys := newTestMultisigKeys(t, 3) // Generate keys
+
+	policy, err := NewMultisigPolicy(2, []*ecdsa.PublicKey{&keys[0].PublicKey, &keys[1].PublicKey, &keys[2].PublicKey}) // Init policy
+	if err != nil {                                                                                                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	reordered, err := NewMultisigPolicy(2, []*ecdsa.PublicKey{&keys[2].PublicKey, &keys[0].PublicKey, &keys[1].PublicKey}) // Init policy with keys in different order
+	if err != nil {                                                                                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if policy.Address() != reordered.Address() { // Check order independent
+		t.Fatal("expected multisig address not to depend on key order") // Panic
+	}
+
+	stricter, _ := NewMultisigPolicy(3, []*ecdsa.PublicKey{&keys[0].PublicKey, &keys[1].PublicKey, &keys[2].PublicKey}) // Init policy with different threshold
+
+	if policy.Address() == stricter.Address() || policy.Address() == common.PublicKeyToAddress(&keys[0].PublicKey) { // Check address commits to policy
+		t.Fatal("expected multisig address to commit to threshold and keys") // Panic
+	}
+
+	if _, err := NewMultisigPolicy(4, []*ecdsa.PublicKey{&keys[0].PublicKey, &keys[1].PublicKey, &keys[2].PublicKey}); err != ErrInvalidMultisigThreshold { // Check unreachable threshold rejected
+		t.Fatalf("expected invalid threshold, got %v", err) // Panic
+	}
+
+	if _, err := NewMultisigPolicy(0, []*ecdsa.PublicKey{&keys[0].PublicKey}); err != ErrInvalidMultisigThreshold { // Check zero threshold rejected
+		t.Fatalf("expected invalid threshold, got %v", err) // Panic
+	}
+
+	if _, err := NewMultisigPolicy(1, []*ecdsa.PublicKey{&keys[0].PublicKey, &keys[0].PublicKey}); err != ErrDuplicateMultisigKey { // Check duplicate key rejected
+		t.Fatalf("expected duplicate key, got %v", err) // Panic
+	}
+}
+
+// TestAddPartialSignature - test collecting and verifying M-of-N signatures
+func TestAddPartialSignature(t *testing.T) {
+	keys := newTestMultisigKeys(t, 3) // Generate keys
+
+	policy, err := NewMultisigPolicy(2, []*ecdsa.PublicKey{&keys[0].PublicKey, &keys[1].PublicKey, &keys[2].PublicKey}) // Init policy
+	if err != nil {                                                                                                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	sender := policy.Address() // Get multisig address
+
+	transaction, err := NewTransaction(0, nil, &sender, &common.Address{0x2}, common.Coins(1), nil) // Initialize transaction
+	if err != nil {                                                                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err := AddPartialSignature(transaction, keys[0]); err != ErrNotMultisig { // Check policy required
+		t.Fatalf("expected not multisig, got %v", err) // Panic
+	}
+
+	transaction.Multisig = &MultisigSignature{Policy: policy} // Attach policy
+
+	outsider := newTestMultisigKeys(t, 1)[0] // Generate unrelated key
+
+	if err := AddPartialSignature(transaction, outsider); err != ErrNotMultisigSigner { // Check unrelated key rejected
+		t.Fatalf("expected not signer, got %v", err) // Panic
+	}
+
+	if err := AddPartialSignature(transaction, keys[0]); err != nil { // Add first signature
+		t.Fatal(err) // Panic
+	}
+
+	if err := AddPartialSignature(transaction, keys[0]); err != ErrAlreadySigned { // Check same key rejected twice
+		t.Fatalf("expected already signed, got %v", err) // Panic
+	}
+
+	if valid, err := VerifyTransactionSignature(transaction); valid || err != ErrInsufficientSignatures { // Check threshold not met
+		t.Fatalf("expected insufficient signatures, got %t, %v", valid, err) // Panic
+	}
+
+	cosigned, err := TransactionFromBytes(transaction.Bytes()) // Pass transaction to co-signer
+	if err != nil {                                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err := AddPartialSignature(cosigned, keys[2]); err != nil { // Add second signature
+		t.Fatal(err) // Panic
+	}
+
+	added, err := MergePartialSignatures(transaction, cosigned) // Collect co-signer signature
+	if err != nil {                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if added != 1 || !transaction.IsSigned() { // Check collected
+		t.Fatalf("expected 1 signature added, got %d", added) // Panic
+	}
+
+	if valid, err := VerifyTransactionSignature(transaction); !valid || err != nil { // Check threshold met
+		t.Fatalf("expected valid multisig transaction, got %t, %v", valid, err) // Panic
+	}
+
+	decoded, err := TransactionFromSignedBlob(hex.EncodeToString(transaction.Bytes())) // Round trip signed blob
+	if err != nil {                                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(decoded.Multisig.Signatures) != 2 || decoded.Multisig.Policy.Address() != sender { // Check signatures survived
+		t.Fatal("expected multisig signatures to survive encoding") // Panic
+	}
+
+	decoded.Multisig.Signatures[1].KeyIndex = decoded.Multisig.Signatures[0].KeyIndex // Forge duplicate signer
+
+	if valid, _ := VerifyTransactionSignature(decoded); valid { // Check duplicate signer rejected
+		t.Fatal("expected duplicate signer to be rejected") // Panic
+	}
+
+	decoded.Multisig.Policy = stricterPolicy(t, keys) // Swap in policy for a different address
+
+	if valid, _ := VerifyTransactionSignature(decoded); valid { // Check policy must match sender
+		t.Fatal("expected mismatched policy to be rejected") // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newTestMultisigKeys generates a given number of private keys.
+func newTestMultisigKeys(t *testing.T, n int) []*ecdsa.PrivateKey {
+	keys := []*ecdsa.PrivateKey{} // Init keys buffer
+
+	for i := 0; i < n; i++ { // Generate keys
+		privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+		if err != nil {                                                    // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		keys = append(keys, privateKey) // Append key
+	}
+
+	return keys // Return keys
+}
+
+// stricterPolicy initializes a 3-of-3 policy over given keys.
+func stricterPolicy(t *testing.T, keys []*ecdsa.PrivateKey) *MultisigPolicy {
+	policy, err := NewMultisigPolicy(3, []*ecdsa.PublicKey{&keys[0].PublicKey, &keys[1].PublicKey, &keys[2].PublicKey}) // Init policy
+	if err != nil {                                                                                                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	return policy // Return policy
+}
+
+/* END INTERNAL METHODS */
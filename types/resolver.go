@@ -1,22 +1,41 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 
+	"github.com/SummerCash/go-summercash/common"
 	"github.com/SummerCash/ursa/vm"
 )
 
-// workingVMTransaction is the working virtual machine transaction.
-// Useful for tx state logging.
-var workingVMTransaction *Transaction
+// AmountLength - length of an amount exchanged with a contract (a big-endian number of base units)
+const AmountLength = 32
+
+var (
+	// ErrOutOfBounds - error definition describing a host function call referencing memory outside of the VM's memory
+	ErrOutOfBounds = errors.New("host function call out of memory bounds")
+
+	// ErrAmountTooLarge - error definition describing an amount that doesn't fit in AmountLength bytes
+	ErrAmountTooLarge = errors.New("amount too large to pass to contract")
+)
 
 // TransactionMetaResolver outlines the default go-summercash WASM tx meta resolver.
 type TransactionMetaResolver struct {
 	tempRet0 int64
+
+	context *ExecutionContext // Working call context
 }
 
 /* BEGIN EXPORTED METHODS */
 
+// NewTransactionMetaResolver initializes a new resolver resolving imports against a given execution context.
+func NewTransactionMetaResolver(context *ExecutionContext) *TransactionMetaResolver {
+	return &TransactionMetaResolver{
+		context: context, // Set context
+	}
+}
+
 // ResolveFunc defines a set of import functions that may be called within a WebAssembly module.
 func (r *TransactionMetaResolver) ResolveFunc(module, field string) vm.FunctionImport {
 	switch module { // Handle module types
@@ -27,43 +46,35 @@ func (r *TransactionMetaResolver) ResolveFunc(module, field string) vm.FunctionI
 				return vm.GetCurrentFrame().Locals[0] + 1
 			}
 		case "__log":
-			return func(vm *vm.VirtualMachine) int64 {
-				ptr := int(uint32(vm.GetCurrentFrame().Locals[0]))
-				msgLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
-				msg := vm.Memory[ptr : ptr+msgLen]
-
-				(*workingVMTransaction).Logs = append((*workingVMTransaction).Logs, NewLog("message", msg, Custom)) // Append log
-
-				return 0
-			}
+			return r.log("message", Custom)
 		case "__log_err":
-			return func(vm *vm.VirtualMachine) int64 {
-				ptr := int(uint32(vm.GetCurrentFrame().Locals[0]))
-				msgLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
-				msg := vm.Memory[ptr : ptr+msgLen]
-
-				(*workingVMTransaction).Logs = append((*workingVMTransaction).Logs, NewLog("error", msg, Error)) // Append log
-
-				return 0
-			}
+			return r.log("error", Error)
 		case "__log_return":
-			return func(vm *vm.VirtualMachine) int64 {
-				ptr := int(uint32(vm.GetCurrentFrame().Locals[0]))
-				msgLen := int(uint32(vm.GetCurrentFrame().Locals[1]))
-				msg := vm.Memory[ptr : ptr+msgLen]
-
-				(*workingVMTransaction).Logs = append((*workingVMTransaction).Logs, NewLog("return", msg, Return)) // Append log
-
-				return 0
-			}
+			return r.log("return", Return)
+		case "__log_indexed":
+			return r.logIndexed
 		case "__transaction_get_nonce":
 			return func(vm *vm.VirtualMachine) int64 {
-				return int64(workingVMTransaction.AccountNonce)
+				return int64(r.context.Transaction.AccountNonce)
 			}
 		case "__transaction_get_hash_nonce":
 			return func(vm *vm.VirtualMachine) int64 {
-				return int64(workingVMTransaction.HashNonce)
+				return int64(r.context.Transaction.HashNonce)
 			}
+		case "__get_sender":
+			return r.getSender
+		case "__get_amount":
+			return r.getAmount
+		case "__get_balance":
+			return r.getBalance
+		case "__get_timestamp":
+			return r.getTimestamp
+		case "__storage_get":
+			return r.storageGet
+		case "__storage_set":
+			return r.storageSet
+		case "__transfer":
+			return r.transfer
 		default:
 			panic(fmt.Errorf("unknown field: %s", field)) // Panic
 		}
@@ -80,15 +91,13 @@ func (r *TransactionMetaResolver) ResolveGlobal(module, field string) int64 {
 		case "__ursa_magic":
 			return 424 // Return magic
 		case "__transaction_nonce":
-			return int64(workingVMTransaction.AccountNonce) // Return nonce
+			return int64(r.context.Transaction.AccountNonce) // Return nonce
 		case "__transaction_hash_nonce":
-			return int64(workingVMTransaction.HashNonce) // Return nonce
+			return int64(r.context.Transaction.HashNonce) // Return nonce
 		case "__transaction_amount":
-			floatVal, _ := workingVMTransaction.Amount.Float64() // Get float val
-
-			return int64(floatVal) // Return amount
+			return r.context.Transaction.Amount.WholeCoins().Int64() // Return amount (truncated to whole coins to fit a global; __get_amount gets the exact amount)
 		case "__transaction_timestamp":
-			return int64(workingVMTransaction.Timestamp.Unix()) // Return timestamp
+			return int64(r.context.Transaction.Timestamp.Unix()) // Return timestamp
 		default:
 			panic(fmt.Errorf("unknown field: %s", field)) // Panic
 		}
@@ -98,3 +107,232 @@ func (r *TransactionMetaResolver) ResolveGlobal(module, field string) int64 {
 }
 
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// log returns a host function appending the message at a given pointer (ptr, len) to the call's logs.
+func (r *TransactionMetaResolver) log(key string, logType LogKeyType) vm.FunctionImport {
+	return func(vm *vm.VirtualMachine) int64 {
+		msg, err := memorySlice(vm, param(vm, 0), param(vm, 1)) // Get message
+		if err != nil {                                         // Check for errors
+			return exitVM(vm, err) // Exit
+		}
+
+		r.context.Logs = append(r.context.Logs, NewLog(key, append([]byte{}, msg...), logType)) // Append log
+
+		return 0 // Success
+	}
+}
+
+// logIndexed appends the message at a given pointer (ptr, len) to the call's logs, indexed by a given number of 32-byte topics
+// stored in memory at a given pointer (topicsPtr, numTopics, ptr, len).
+func (r *TransactionMetaResolver) logIndexed(vm *vm.VirtualMachine) int64 {
+	if param(vm, 1) > MaxLogTopics { // Check too many topics
+		return exitVM(vm, ErrTooManyTopics) // Exit
+	}
+
+	buffer, err := memorySlice(vm, param(vm, 0), param(vm, 1)*common.HashLength) // Get topics
+	if err != nil {                                                              // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	msg, err := memorySlice(vm, param(vm, 2), param(vm, 3)) // Get message
+	if err != nil {                                         // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	log := NewLog("message", append([]byte{}, msg...), Custom) // Init log
+
+	for x := 0; x < len(buffer); x += common.HashLength { // Iterate through topics
+		topic := common.Hash{} // Init topic buffer
+
+		copy(topic[:], buffer[x:]) // Copy topic
+
+		log.Topics = append(log.Topics, topic) // Append topic
+	}
+
+	r.context.Logs = append(r.context.Logs, log) // Append log
+
+	return 0 // Success
+}
+
+// getSender writes the address of the transaction sender to memory at a given pointer (ptr), returning the length of the address.
+func (r *TransactionMetaResolver) getSender(vm *vm.VirtualMachine) int64 {
+	sender := common.Address{} // Init sender buffer
+
+	if r.context.Transaction.Sender != nil { // Check has sender
+		sender = *r.context.Transaction.Sender // Set sender
+	}
+
+	buffer, err := memorySlice(vm, param(vm, 0), common.AddressLength) // Get buffer
+	if err != nil {                                                    // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	copy(buffer, sender[:]) // Write sender
+
+	return common.AddressLength // Return length
+}
+
+// getAmount writes the amount sent to the contract in the transaction to memory at a given pointer (ptr), returning the length
+// of the amount.
+func (r *TransactionMetaResolver) getAmount(vm *vm.VirtualMachine) int64 {
+	return writeMemoryAmount(vm, param(vm, 0), r.context.Transaction.Amount) // Write amount
+}
+
+// getBalance writes the balance of the account whose address is stored in memory at a given pointer to memory at a given
+// pointer (ptr, balancePtr), returning the length of the balance.
+func (r *TransactionMetaResolver) getBalance(vm *vm.VirtualMachine) int64 {
+	buffer, err := memorySlice(vm, param(vm, 0), common.AddressLength) // Get buffer
+	if err != nil {                                                    // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	address := common.Address{} // Init address buffer
+	copy(address[:], buffer)    // Read address
+
+	balance := common.NewAmount(nil) // Init balance buffer
+
+	if address == r.context.Contract.Account { // Check is contract
+		balance = r.context.contractBalance() // Set balance
+	} else if chain, err := ReadChainFromMemory(address); err == nil { // Check has chain
+		balance = chain.CalculateBalance() // Set balance
+	}
+
+	return writeMemoryAmount(vm, param(vm, 1), balance) // Write balance
+}
+
+// getTimestamp returns the unix timestamp of the transaction.
+func (r *TransactionMetaResolver) getTimestamp(vm *vm.VirtualMachine) int64 {
+	return r.context.Transaction.Timestamp.Unix() // Return timestamp
+}
+
+// storageGet copies the value stored at a given key (keyPtr, keyLen) into a given buffer (valuePtr, valueLen), returning the
+// full length of the value, or -1 if no value is stored at the key.
+func (r *TransactionMetaResolver) storageGet(vm *vm.VirtualMachine) int64 {
+	key, err := memorySlice(vm, param(vm, 0), param(vm, 1)) // Get key
+	if err != nil {                                         // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	buffer, err := memorySlice(vm, param(vm, 2), param(vm, 3)) // Get value buffer
+	if err != nil {                                            // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	value, err := r.context.Storage.Get(key) // Get value
+	if err != nil {                          // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	if value == nil { // Check not found
+		return -1 // Not found
+	}
+
+	copy(buffer, value) // Write value
+
+	return int64(len(value)) // Return length
+}
+
+// storageSet stores a given value (valuePtr, valueLen) at a given key (keyPtr, keyLen). Storing an empty value deletes the key.
+func (r *TransactionMetaResolver) storageSet(vm *vm.VirtualMachine) int64 {
+	key, err := memorySlice(vm, param(vm, 0), param(vm, 1)) // Get key
+	if err != nil {                                         // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	value, err := memorySlice(vm, param(vm, 2), param(vm, 3)) // Get value
+	if err != nil {                                           // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	err = r.context.Storage.Put(key, value) // Set value
+	if err != nil {                         // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	return 0 // Success
+}
+
+// transfer sends the amount stored in memory at a given pointer from the contract to the account whose address is stored in memory
+// at a given pointer (ptr, amountPtr), returning 0 if the transfer succeeded, or -1 if the contract's balance is insufficient.
+func (r *TransactionMetaResolver) transfer(vm *vm.VirtualMachine) int64 {
+	buffer, err := memorySlice(vm, param(vm, 0), common.AddressLength) // Get buffer
+	if err != nil {                                                    // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	recipient := common.Address{} // Init recipient buffer
+	copy(recipient[:], buffer)    // Read recipient
+
+	amount, err := readMemoryAmount(vm, param(vm, 1)) // Get amount
+	if err != nil {                                   // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	if amount.Sign() <= 0 || recipient == (common.Address{}) || r.context.contractBalance().Cmp(amount) < 0 { // Check invalid transfer
+		return -1 // Failed
+	}
+
+	transaction := r.context.Transaction.newInternalTransaction(uint64(len(r.context.Transfers)), recipient, amount) // Init internal transaction
+
+	r.context.Transfers = append(r.context.Transfers, transaction) // Append transfer
+	r.context.Transferred = r.context.Transferred.Add(amount)      // Add to transferred
+
+	return 0 // Success
+}
+
+// writeMemoryAmount writes a given amount to memory at a given pointer, returning its length.
+func writeMemoryAmount(vm *vm.VirtualMachine, ptr int64, amount *common.Amount) int64 {
+	buffer, err := memorySlice(vm, ptr, AmountLength) // Get buffer
+	if err != nil {                                   // Check for errors
+		return exitVM(vm, err) // Exit
+	}
+
+	units := amount.BaseUnits().Bytes() // Get units
+
+	if len(units) > AmountLength { // Check too large
+		return exitVM(vm, ErrAmountTooLarge) // Exit
+	}
+
+	copy(buffer, make([]byte, AmountLength-len(units))) // Write padding
+	copy(buffer[AmountLength-len(units):], units)       // Write units
+
+	return AmountLength // Return length
+}
+
+// readMemoryAmount reads an amount from memory at a given pointer.
+func readMemoryAmount(vm *vm.VirtualMachine, ptr int64) (*common.Amount, error) {
+	buffer, err := memorySlice(vm, ptr, AmountLength) // Get buffer
+	if err != nil {                                   // Check for errors
+		return common.NewAmount(nil), err // Return found error
+	}
+
+	return common.NewAmount(new(big.Int).SetBytes(buffer)), nil // Return amount
+}
+
+// param gets the i32 parameter (a pointer or length) at a given index of the current host function call. i32 parameters are
+// passed as i64 locals, so only their low 32 bits are meaningful.
+func param(vm *vm.VirtualMachine, index int) int64 {
+	return int64(uint32(vm.GetCurrentFrame().Locals[index])) // Return param
+}
+
+// memorySlice gets a slice of a given VM's memory.
+func memorySlice(vm *vm.VirtualMachine, ptr int64, length int64) ([]byte, error) {
+	if ptr < 0 || length < 0 || ptr > int64(len(vm.Memory)) || length > int64(len(vm.Memory))-ptr { // Check out of bounds (without overflowing ptr+length)
+		return nil, ErrOutOfBounds // Return error
+	}
+
+	return vm.Memory[ptr : ptr+length], nil // Return slice
+}
+
+// exitVM stops a given VM with a given error. Host functions run outside of the VM's panic recovery, so must exit this way
+// rather than panicking.
+func exitVM(vm *vm.VirtualMachine, err error) int64 {
+	vm.Exited = true   // Set exited
+	vm.ExitError = err // Set exit error
+
+	return -1 // Return error code
+}
+
+/* END INTERNAL METHODS */
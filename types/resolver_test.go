@@ -0,0 +1,262 @@
+package types
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/trie"
+	"github.com/SummerCash/ursa/vm"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestHostFunctions - test functionality of the storage, transfer and transaction meta host functions
+func TestHostFunctions(t *testing.T) {
+	contract, sender, recipient := common.Address{0xc}, common.Address{0x5}, common.Address{0x7} // Init addresses
+
+	genesis, err := NewTransaction(0, nil, nil, &contract, common.Coins(100), []byte("genesis")) // Initialize genesis transaction
+	if err != nil {                                                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain := &Chain{Account: contract, Genesis: *genesis.Hash, Transactions: []*Transaction{genesis}, ContractSource: testHostContractSource()} // Init contract chain
+
+	amount, err := common.ParseAmount("5.5") // Parse fractional call amount
+	if err != nil {                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	call, err := NewTransaction(0, nil, &sender, &contract, amount, []byte("pay()")) // Initialize call
+	if err != nil {                                                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = SetStateDatabase(trie.NewMemoryDatabase()) // Use in-memory state db
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	context, err := NewExecutionContext(call, chain) // Init context
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	workingVM, err := vm.NewVirtualMachine(chain.ContractSource, common.VMConfig, NewTransactionMetaResolver(context), common.GasPolicy) // Init vm
+	if err != nil {                                                                                                                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transferred, err := common.ParseAmount("50.25") // Parse fractional transfer amount
+	if err != nil {                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	copy(workingVM.Memory, "key")              // Write storage key
+	copy(workingVM.Memory[100:], recipient[:]) // Write transfer recipient
+	copy(workingVM.Memory[200:], contract[:])  // Write contract address
+
+	writeMemoryAmount(workingVM, 300, transferred)       // Write transfer amount
+	writeMemoryAmount(workingVM, 400, common.Coins(100)) // Write overdrawing transfer amount
+
+	if result := runTestExport(t, workingVM, "set", 0, 3); result != 0 { // Store key
+		t.Fatalf("invalid set result: %d", result) // Panic
+	}
+
+	if result := runTestExport(t, workingVM, "get", 0, 3); result != 3 || string(workingVM.Memory[1024:1027]) != "key" { // Load key
+		t.Fatalf("invalid get result: %d", result) // Panic
+	}
+
+	if result := runTestExport(t, workingVM, "get", 1, 2); result != -1 { // Load unknown key
+		t.Fatalf("invalid get result for unknown key: %d", result) // Panic
+	}
+
+	if result := runTestExport(t, workingVM, "amount"); result != AmountLength || readTestAmount(workingVM, 2048).Cmp(amount) != 0 { // Get amount
+		t.Fatalf("invalid amount: %x", workingVM.Memory[2048:2048+AmountLength]) // Panic
+	}
+
+	if result := runTestExport(t, workingVM, "sender"); result != common.AddressLength || !bytes.Equal(workingVM.Memory[:common.AddressLength], sender[:]) { // Get sender
+		t.Fatalf("invalid sender: %x", workingVM.Memory[:common.AddressLength]) // Panic
+	}
+
+	if result := runTestExport(t, workingVM, "pay", 100, 300); result != 0 { // Transfer 50.25 of 105.5
+		t.Fatalf("invalid transfer result: %d", result) // Panic
+	}
+
+	if result := runTestExport(t, workingVM, "pay", 100, 400); result != -1 { // Transfer 100 of remaining 55.25
+		t.Fatalf("expected overdrawing transfer to fail, got %d", result) // Panic
+	}
+
+	if result := runTestExport(t, workingVM, "balance", 200, 2048); result != AmountLength || readTestAmount(workingVM, 2048).Cmp(common.Coins(100).Add(amount).Sub(transferred)) != 0 { // Get remaining balance
+		t.Fatalf("invalid balance: %x", workingVM.Memory[2048:2048+AmountLength]) // Panic
+	}
+
+	if _, err = workingVM.Run(mustGetExport(t, workingVM, "get"), int64(len(workingVM.Memory)-1), 2); err == nil { // Read out of bounds
+		t.Fatal("expected out of bounds storage key to fail") // Panic
+	}
+
+	context.apply(call) // Apply call results
+
+	if value, err := context.Storage.Get([]byte("key")); err != nil || string(value) != "key" { // Check storage
+		t.Fatalf("invalid storage: %q, %v", value, err) // Panic
+	}
+
+	if len(call.InternalTransactions) != 1 { // Check transfers
+		t.Fatalf("invalid number of transfers: %d", len(call.InternalTransactions)) // Panic
+	}
+
+	transfer := call.InternalTransactions[0] // Get transfer
+
+	if *transfer.Sender != contract || *transfer.Recipient != recipient || *transfer.ParentTx != *call.Hash || transfer.Amount.Cmp(transferred) != 0 { // Check transfer
+		t.Fatalf("invalid transfer: %s", transfer.String()) // Panic
+	}
+
+	chain.Transactions = append(chain.Transactions, transfer) // Commit transfer
+
+	if balance := chain.CalculateBalance(); balance.Cmp(common.Coins(100).Sub(transferred)) != 0 { // Check contract balance (100 - 50.25)
+		t.Fatalf("invalid contract balance: %s", balance.String()) // Panic
+	}
+}
+
+// TestHostFunctionParams - test that every host function reads its pointer and length params as i32s: a param with its high bit
+// set is out of bounds, and the high 32 bits of a param are ignored
+func TestHostFunctionParams(t *testing.T) {
+	contract, recipient := common.Address{0xc}, common.Address{0x7} // Init addresses
+
+	genesis, err := NewTransaction(0, nil, nil, &contract, common.Coins(100), []byte("genesis")) // Initialize genesis transaction
+	if err != nil {                                                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain := &Chain{Account: contract, Genesis: *genesis.Hash, Transactions: []*Transaction{genesis}} // Init contract chain
+
+	call, err := NewTransaction(0, nil, &recipient, &contract, common.Coins(1), []byte("pay()")) // Initialize call
+	if err != nil {                                                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = SetStateDatabase(trie.NewMemoryDatabase()) // Use in-memory state db
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	context, err := NewExecutionContext(call, chain) // Init context
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	resolver := NewTransactionMetaResolver(context) // Init resolver
+
+	for field, params := range map[string][]int64{
+		"__log":         {0, 3},
+		"__log_indexed": {100, 1, 0, 3},
+		"__get_sender":  {100},
+		"__get_amount":  {300},
+		"__get_balance": {200, 300},
+		"__storage_get": {0, 3, 1024, 64},
+		"__storage_set": {0, 3, 0, 3},
+		"__transfer":    {100, 300},
+	} { // Iterate through host functions
+		for x := range params { // Iterate through params
+			for _, param := range []int64{math.MinInt32 | params[x], -1<<32 | params[x]} { // Iterate through high bit set, high 32 bits set
+				workingVM := &vm.VirtualMachine{Memory: make([]byte, 4096), CallStack: []vm.Frame{{Locals: append([]int64{}, params...)}}} // Init vm
+
+				copy(workingVM.Memory, "key")                      // Write storage key
+				copy(workingVM.Memory[100:], recipient[:])         // Write recipient
+				copy(workingVM.Memory[200:], contract[:])          // Write contract address
+				writeMemoryAmount(workingVM, 300, common.Coins(1)) // Write transfer amount
+				workingVM.CallStack[0].Locals[x] = param           // Set param
+				resolver.ResolveFunc("env", field)(workingVM)      // Call host function
+
+				if outOfBounds := uint32(param)&(1<<31) != 0; workingVM.Exited != outOfBounds { // Check only the low 32 bits were read
+					t.Fatalf("%s with param %d set to %#x: expected exited %t, got %t (%v)", field, x, param, outOfBounds, workingVM.Exited, workingVM.ExitError) // Panic
+				}
+			}
+		}
+	}
+}
+
+// TestMemorySlice - test that host function memory accesses are bounds checked, even when ptr+length overflows
+func TestMemorySlice(t *testing.T) {
+	workingVM := &vm.VirtualMachine{Memory: make([]byte, 64)} // Init vm
+
+	if slice, err := memorySlice(workingVM, 8, 56); err != nil || len(slice) != 56 { // Check slice to end of memory
+		t.Fatalf("invalid slice to end of memory: %v", err) // Panic
+	}
+
+	for _, bounds := range [][2]int64{{8, 57}, {65, 0}, {-1, 1}, {1, -1}, {1, math.MaxInt64}, {math.MaxInt64, 1}} { // Iterate through out of bounds accesses
+		if _, err := memorySlice(workingVM, bounds[0], bounds[1]); err != ErrOutOfBounds { // Check rejected
+			t.Fatalf("expected out of bounds error for ptr %d, length %d; got %v", bounds[0], bounds[1], err) // Panic
+		}
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// runTestExport - run a given export, failing the test on error
+func runTestExport(t *testing.T, workingVM *vm.VirtualMachine, export string, params ...int64) int64 {
+	result, err := workingVM.Run(mustGetExport(t, workingVM, export), params...) // Run
+	if err != nil {                                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	return result // Return result
+}
+
+// mustGetExport - get the ID of a given export, failing the test if it doesn't exist
+func mustGetExport(t *testing.T, workingVM *vm.VirtualMachine, export string) int {
+	entryID, valid := workingVM.GetFunctionExport(export) // Get entry
+	if !valid {                                           // Check invalid
+		t.Fatalf("no export %s", export) // Panic
+	}
+
+	return entryID // Return entry
+}
+
+// readTestAmount - read an amount from memory at a given pointer
+func readTestAmount(workingVM *vm.VirtualMachine, ptr int) *common.Amount {
+	return common.NewAmount(new(big.Int).SetBytes(workingVM.Memory[ptr : ptr+AmountLength])) // Return amount
+}
+
+// testHostContractSource - get a minimal WASM test contract exporting set(ptr, len) (stores the key at ptr as its own value),
+// get(ptr, len) (loads the value at a key into memory at 1024), pay(ptr, amountPtr), sender() (writes the sender to memory at
+// 0), amount() (writes the amount to memory at 2048) and balance(ptr, balancePtr)
+func testHostContractSource() []byte {
+	return wasmModule(
+		[]int{2, 4, 1, 0}, // (i64, i64) -> i64, (i64, i64, i64, i64) -> i64, (i64) -> i64, () -> i64
+		[]wasmImport{
+			{"__storage_set", 1},
+			{"__storage_get", 1},
+			{"__transfer", 0},
+			{"__get_sender", 2},
+			{"__get_amount", 2},
+			{"__get_balance", 0},
+		},
+		[]uint32{0, 0, 0, 3, 3, 0}, // Function types
+		[]wasmExport{
+			{"set", wasmFunc, 6},
+			{"get", wasmFunc, 7},
+			{"pay", wasmFunc, 8},
+			{"sender", wasmFunc, 9},
+			{"amount", wasmFunc, 10},
+			{"balance", wasmFunc, 11},
+			{"memory", wasmMemory, 0},
+		},
+		[][]byte{
+			{0x00, 0x20, 0x00, 0x20, 0x01, 0x20, 0x00, 0x20, 0x01, 0x10, 0x00},                                                   // set: __storage_set(ptr, len, ptr, len)
+			append(append([]byte{0x00, 0x20, 0x00, 0x20, 0x01}, append(wasmI64Const(1024), wasmI64Const(64)...)...), 0x10, 0x01), // get: __storage_get(ptr, len, 1024, 64)
+			{0x00, 0x20, 0x00, 0x20, 0x01, 0x10, 0x02},                                                                           // pay: __transfer(ptr, amountPtr)
+			append(append([]byte{0x00}, wasmI64Const(0)...), 0x10, 0x03),                                                         // sender: __get_sender(0)
+			append(append([]byte{0x00}, wasmI64Const(2048)...), 0x10, 0x04),                                                      // amount: __get_amount(2048)
+			{0x00, 0x20, 0x00, 0x20, 0x01, 0x10, 0x05},                                                                           // balance: __get_balance(ptr, balancePtr)
+		},
+	)
+}
+
+/* END INTERNAL METHODS */
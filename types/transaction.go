@@ -9,13 +9,11 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"path/filepath"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/SummerCash/go-summercash/common"
-	"github.com/SummerCash/go-summercash/crypto"
 	"github.com/SummerCash/ursa/compiler"
 	"github.com/SummerCash/ursa/vm"
 )
@@ -43,22 +41,32 @@ type Transaction struct {
 	Sender    *common.Address `json:"sender"`    // Transaction sender
 	Recipient *common.Address `json:"recipient"` // Transaction recipient
 
-	Amount *big.Float `json:"amount"` // Amount of coins sent in transaction
+	Amount *common.Amount `json:"amount"` // Amount of coins sent in transaction
+
+	GasLimit uint64         `json:"gas_limit"` // Maximum amount of gas a contract call may consume
+	GasPrice *common.Amount `json:"gas_price"` // Amount of coins paid per unit of gas consumed
 
 	Payload []byte `json:"payload"` // Misc. data transported with transaction
 
 	Signature *Signature `json:"signature"` // Transaction signature meta
 
+	Multisig *MultisigSignature `json:"multisig,omitempty"` // Multisig sender policy and partial signatures
+
 	ParentTx *common.Hash `json:"parent_hash"` // Parent transaction
 
+	ParentTxs []common.Hash `json:"parent_hashes,omitempty"` // Additional parent transactions (dag tips approved by the transaction)
+
 	Timestamp time.Time `json:"time"` // Transaction timestamp
 
 	DeployedContractAddress *common.Address `json:"contract"` // Contract instance
 
 	ContractCreation bool `json:"is-init-contract"` // Should init contract
 	Genesis          bool `json:"genesis"`          // Genesis
+	Mint             bool `json:"mint"`             // Issuance period mint
+
+	State *ContractState `json:"state"` // State
 
-	State *vm.State `json:"state"` // State
+	InternalTransactions []*Transaction `json:"internal_transactions"` // Transfers made by the called contract
 
 	Logs []*Log `json:"logs"` // Logs
 
@@ -74,22 +82,30 @@ type StringTransaction struct {
 	SenderHex    string `json:"sender"`    // Transaction sender
 	RecipientHex string `json:"recipient"` // Transaction recipient
 
-	Amount float64 `json:"amount"` // Amount of coins sent in transaction
+	Amount string `json:"amount"` // Amount of coins sent in transaction
+
+	GasLimit uint64 `json:"gas_limit"` // Maximum amount of gas a contract call may consume
+	GasPrice string `json:"gas_price"` // Amount of coins paid per unit of gas consumed
 
 	Payload []byte `json:"payload"` // Misc. data transported with transaction
 
 	Signature *Signature `json:"signature"` // Transaction signature meta
 
+	Multisig *MultisigSignature `json:"multisig,omitempty"` // Multisig sender policy and partial signatures
+
 	ParentTx string `json:"parent_hash"` // Parent transaction
 
+	ParentTxs []string `json:"parent_hashes,omitempty"` // Additional parent transactions (dag tips approved by the transaction)
+
 	Timestamp string `json:"time"` // Transaction timestamp
 
 	DeployedContractAddress *common.Address `json:"contract"` // Contract instance
 
 	ContractCreation bool `json:"is-init-contract"` // Should init contract
 	Genesis          bool `json:"genesis"`          // Genesis
+	Mint             bool `json:"mint"`             // Issuance period mint
 
-	State *vm.State `json:"state"` // State
+	State *ContractState `json:"state"` // State
 
 	Logs []*Log `json:"logs"` // Logs
 
@@ -98,8 +114,13 @@ type StringTransaction struct {
 
 /* BEGIN EXPORTED METHODS */
 
-// NewTransaction - attempt to initialize transaction primitive
-func NewTransaction(nonce uint64, parentTx *Transaction, sender *common.Address, destination *common.Address, amount *big.Float, payload []byte) (*Transaction, error) {
+// NewTransaction - attempt to initialize transaction primitive (without any gas allowance)
+func NewTransaction(nonce uint64, parentTx *Transaction, sender *common.Address, destination *common.Address, amount *common.Amount, payload []byte) (*Transaction, error) {
+	return NewTransactionWithGas(nonce, parentTx, sender, destination, amount, 0, common.NewAmount(nil), payload) // Init transaction
+}
+
+// NewTransactionWithGas - attempt to initialize transaction primitive with a given gas limit and gas price
+func NewTransactionWithGas(nonce uint64, parentTx *Transaction, sender *common.Address, destination *common.Address, amount *common.Amount, gasLimit uint64, gasPrice *common.Amount, payload []byte) (*Transaction, error) {
 	parentHash := &common.Hash{} // Init hash buffer
 
 	if parentTx != nil { // Check has parent
@@ -112,19 +133,15 @@ func NewTransaction(nonce uint64, parentTx *Transaction, sender *common.Address,
 		Sender:           sender,           // Set sender
 		Recipient:        destination,      // Set recipient
 		Amount:           amount,           // Set amount
+		GasLimit:         gasLimit,         // Set gas limit
+		GasPrice:         gasPrice,         // Set gas price
 		Payload:          payload,          // Set tx payload
 		ParentTx:         parentHash,       // Set parent
 		Timestamp:        time.Now().UTC(), // Set timestamp
 		ContractCreation: false,            // Set should init contract
 	}
 
-	hash := common.NewHash(crypto.Sha3(transaction.Bytes())) // Hash transaction
-
-	for bytes.Contains(hash.Bytes(), []byte{'\r'}) { // Do until does not contain escape character
-		transaction.HashNonce++ // Increment hash nonce
-
-		hash = common.NewHash(crypto.Sha3(transaction.Bytes())) // Set hash
-	}
+	hash := transaction.CalculateHash() // Hash transaction
 
 	transaction.Hash = &hash // Set hash
 
@@ -132,7 +149,7 @@ func NewTransaction(nonce uint64, parentTx *Transaction, sender *common.Address,
 }
 
 // NewContractCreation - initialize contract designated to an initialized contract, calling contract constructor/provided constructor
-func NewContractCreation(nonce uint64, parentTx *Transaction, sender *common.Address, contractInstance *common.Address, amount *big.Float, payload []byte) (*Transaction, error) {
+func NewContractCreation(nonce uint64, parentTx *Transaction, sender *common.Address, contractInstance *common.Address, amount *common.Amount, payload []byte) (*Transaction, error) {
 	transaction := Transaction{ // Init tx
 		AccountNonce:            nonce,            // Set nonce
 		Sender:                  sender,           // Set sender
@@ -145,15 +162,15 @@ func NewContractCreation(nonce uint64, parentTx *Transaction, sender *common.Add
 		DeployedContractAddress: contractInstance, // Set deployed
 	}
 
-	hash := common.NewHash(crypto.Sha3(transaction.Bytes())) // Hash transaction
+	hash := transaction.CalculateHash() // Hash transaction
 
 	transaction.Hash = &hash // Set hash
 
 	return &transaction, nil // Return initialized transaction
 }
 
-// TransactionFromBytes - serialize transaction from byte array
-func TransactionFromBytes(b []byte) (*Transaction, error) {
+// TransactionFromJSON - decode a transaction from its (safely-encoded) JSON representation
+func TransactionFromJSON(b []byte) (*Transaction, error) {
 	transaction := Transaction{} // Init buffer
 
 	err := json.NewDecoder(bytes.NewReader(b)).Decode(&transaction) // Decode into buffer
@@ -173,95 +190,15 @@ func TransactionFromBytes(b []byte) (*Transaction, error) {
 
 // EvaluateNewState evaluates the new state for a given transaction.
 // Does not set the transactions' state, but does return pointer to new state.
-func (transaction *Transaction) EvaluateNewState(gasPolicy *compiler.GasPolicy) (*vm.State, error) {
-	if transaction.Payload == nil && !bytes.Contains(transaction.Payload, []byte("(")) { // Check is not contract call
-		return &vm.State{}, ErrIsNotContractCall // Return error
-	}
-
-	recipientChain, err := ReadChainFromMemory(*transaction.Recipient) // Read recipient chain
-	if err != nil {                                                    // Check for errors
-		return &vm.State{}, err // Return found error
-	}
-
-	workingVM, err := vm.NewVirtualMachine(recipientChain.ContractSource, common.VMConfig, new(TransactionMetaResolver), common.GasPolicy) // Init vm
-	if err != nil {                                                                                                                        // Check for errors
-		return nil, err // Return found error
-	}
-
-	workingVMTransaction = transaction // Set working vm tx
-
-	parentTx, err := recipientChain.QueryTransaction(*transaction.ParentTx) // Query parent
-	if err != nil {                                                         // Check for errors
-		return &vm.State{}, err // Return found error
-	}
-
-	if transaction.ParentTx != nil { // Check has parent
-		workingVM.CallStack = parentTx.State.CallStack               // Set call stack
-		workingVM.CurrentFrame = parentTx.State.CurrentFrame         // Set current frame
-		workingVM.Table = parentTx.State.Table                       // Set table
-		workingVM.Globals = parentTx.State.Globals                   // Set globals
-		workingVM.Memory = parentTx.State.Memory                     // Set memory
-		workingVM.NumValueSlots = parentTx.State.NumValueSlots       // Set num value slots
-		workingVM.Yielded = parentTx.State.Yielded                   // Set yielded
-		workingVM.InsideExecute = parentTx.State.InsideExecute       // Set inside execute
-		workingVM.Exited = parentTx.State.Exited                     // Set has exited
-		workingVM.ExitError = parentTx.State.ExitError               // Set exit error
-		workingVM.ReturnValue = parentTx.State.ReturnValue           // Set return value
-		workingVM.Gas = parentTx.State.Gas                           // Set gas
-		workingVM.GasLimitExceeded = parentTx.State.GasLimitExceeded // Set gas limit exceeded
-	}
-
-	callMethod, callParams, err := common.ParseStringMethodCallNoReceiver(string(transaction.Payload)) // Parse payload method call
-	if err != nil {                                                                                    // Check for errors
-		return &vm.State{}, err // Return found error
-	}
-
-	entryID, valid := workingVM.GetFunctionExport(callMethod) // Get function ID from payload
-
-	if !valid { // Check for errors
-		return &vm.State{}, ErrInvalidPayload // Return found error
-	}
-
-	var parsedCallParams []int64 // Init params buffer
-
-	for _, param := range callParams { // Iterate through params
-		intVal, err := strconv.ParseInt(param, 10, 64) // Parse int
-		if err != nil {                                // Check for errors
-			return nil, err // Return found error
-		}
-
-		parsedCallParams = append(parsedCallParams, intVal) // Append parse param
-	}
-
-	result, err := workingVM.Run(entryID, parsedCallParams...) // Run
-	if err != nil {                                            // Check for errors
-		common.Logf("== VM == Contract call exited with code %d and error %s", result, err.Error()) // Log err
+func (transaction *Transaction) EvaluateNewState(gasPolicy *compiler.GasPolicy) (*ContractState, error) {
+	state, _, err := transaction.evaluateNewState() // Evaluate new state
 
-		return &vm.State{}, err // Return found error
-	}
-
-	common.Logf("== VM == Contract call exited with code %d", result) // Log finish
-
-	return &vm.State{
-		CallStack:        workingVM.CallStack,        // Set call stack
-		CurrentFrame:     workingVM.CurrentFrame,     // Set current frame
-		Table:            workingVM.Table,            // Set table
-		Globals:          workingVM.Globals,          // Set globals
-		Memory:           workingVM.Memory,           // Set memory
-		NumValueSlots:    workingVM.NumValueSlots,    // Set num value slots
-		Yielded:          workingVM.Yielded,          // Set yielded
-		InsideExecute:    workingVM.InsideExecute,    // Set inside execute
-		Exited:           workingVM.Exited,           // Set has exited
-		ExitError:        workingVM.ExitError,        // Set exit error
-		ReturnValue:      workingVM.ReturnValue,      // Set return value
-		Gas:              workingVM.Gas,              // Set gas
-		GasLimitExceeded: workingVM.GasLimitExceeded, // Set gas limit exceeded
-	}, nil // Return state
+	return state, err // Return state
 }
 
 // Publish - publish given transaction
 func (transaction *Transaction) Publish() error {
-	if transaction.Signature == nil { // Check nil pointer
+	if !transaction.IsSigned() { // Check unsigned
 		return ErrNilSignature // Return error
 	}
 
@@ -332,42 +269,8 @@ func (transaction *Transaction) RecoverSafeEncoding() error {
 	return nil // No error occurred, return nil
 }
 
-// Bytes - convert given transaction to byte array
-func (transaction *Transaction) Bytes() []byte {
-	err := transaction.RecoverSafeEncoding() // Recover safe encoding
-	if err != nil { // Check for errors
-		return nil // Invalid encoding
-	}
-
-	publicKey := ecdsa.PublicKey{} // Init buffer
-
-	if transaction.Signature != nil {
-		publicKey = *(*(*transaction).Signature).PublicKey // Set public key
-
-		encoded, _ := x509.MarshalPKIXPublicKey(publicKey) // Encode
-
-		pemEncodedPub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encoded}) // Encode PEM
-
-		(*(*transaction).Signature).SerializedPublicKey = pemEncodedPub // Write encoded
-
-		*(*transaction).Signature.PublicKey = ecdsa.PublicKey{} // Set nil
-	}
-
-	buffer := new(bytes.Buffer) // Init buffer
-
-	json.NewEncoder(buffer).Encode(*transaction) // Serialize tx
-
-	if transaction.Signature != nil {
-		*(*(*transaction).Signature).PublicKey = publicKey // Reset public key
-	}
-
-	return buffer.Bytes() // Return serialized
-}
-
 // String - convert given transaction to string
 func (transaction *Transaction) String() string {
-	floatVal, _ := transaction.Amount.Float64() // Get float value
-
 	var senderHex, recipientHex string // Init hex buffer
 
 	parent := "" // Init parent buffer
@@ -384,18 +287,30 @@ func (transaction *Transaction) String() string {
 		parent = transaction.ParentTx.String() // Set parent
 	}
 
+	var parents []string // Init additional parents buffer
+
+	for _, hash := range transaction.ParentTxs { // Iterate through additional parents
+		parents = append(parents, hash.String()) // Append parent
+	}
+
 	stringTransaction := &StringTransaction{
 		AccountNonce:            transaction.AccountNonce,                           // Set account nonce
 		SenderHex:               senderHex,                                          // Set sender hex
 		RecipientHex:            recipientHex,                                       // Set recipient hex
-		Amount:                  floatVal,                                           // Set amount
+		Amount:                  transaction.Amount.String(),                        // Set amount
+		GasLimit:                transaction.GasLimit,                               // Set gas limit
+		GasPrice:                transaction.GasPrice.String(),                      // Set gas price
 		Payload:                 transaction.Payload,                                // Set payload
 		Signature:               transaction.Signature,                              // Set signature
+		Multisig:                transaction.Multisig,                               // Set multisig signatures
 		ParentTx:                parent,                                             // Set parent
+		ParentTxs:               parents,                                            // Set additional parents
 		Timestamp:               transaction.Timestamp.Format("01/02/2006 3:04 PM"), // Set timestamp
 		DeployedContractAddress: transaction.DeployedContractAddress,                // Set deployed contract address
 		ContractCreation:        transaction.ContractCreation,                       // Set is contract creation
 		Genesis:                 transaction.Genesis,                                // Set is genesis
+		Mint:                    transaction.Mint,                                   // Set is mint
+		State:                   transaction.State,                                  // Set state
 		Logs:                    transaction.Logs,                                   // Set logs
 		HashHex:                 transaction.Hash.String(),                          // Set hash hex
 	}
@@ -463,3 +378,121 @@ func ReadTransactionFromMemory(hash common.Hash) (*Transaction, error) {
 }
 
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newInternalTransaction initializes a transfer of a given amount from the contract called by the transaction to a given recipient.
+// Internal transactions are unsigned, and are derived deterministically from the calling transaction.
+func (transaction *Transaction) newInternalTransaction(index uint64, recipient common.Address, amount *common.Amount) *Transaction {
+	internalTransaction := &Transaction{ // Init tx
+		AccountNonce: index,                 // Set nonce
+		Sender:       transaction.Recipient, // Set sender
+		Recipient:    &recipient,            // Set recipient
+		Amount:       amount,                // Set amount
+		ParentTx:     transaction.Hash,      // Set parent
+		Timestamp:    transaction.Timestamp, // Set timestamp
+	}
+
+	hash := internalTransaction.CalculateHash() // Hash transaction
+
+	internalTransaction.Hash = &hash // Set hash
+
+	return internalTransaction // Return initialized transaction
+}
+
+// evaluateNewState evaluates the new state for a given transaction, along with the execution context holding the call's
+// logs, return value, storage writes and transfers (nil if the call didn't complete).
+func (transaction *Transaction) evaluateNewState() (*ContractState, *ExecutionContext, error) {
+	if transaction.Payload == nil && !bytes.Contains(transaction.Payload, []byte("(")) { // Check is not contract call
+		return &ContractState{}, nil, ErrIsNotContractCall // Return error
+	}
+
+	recipientChain, err := ReadChainFromMemory(*transaction.Recipient) // Read recipient chain
+	if err != nil {                                                    // Check for errors
+		return &ContractState{}, nil, err // Return found error
+	}
+
+	return transaction.evaluate(recipientChain) // Evaluate against recipient chain
+}
+
+// evaluate runs the transaction's contract call against a given contract chain, writing the storage trie nodes created by the
+// call to the state database once the call completes.
+func (transaction *Transaction) evaluate(contractChain *Chain) (*ContractState, *ExecutionContext, error) {
+	state, context, err := transaction.execute(contractChain) // Execute call
+	if err != nil || context == nil {                         // Check call didn't complete
+		return state, context, err // Return result
+	}
+
+	err = context.Storage.Commit() // Persist storage writes
+
+	if err != nil { // Check for errors
+		return &ContractState{}, nil, err // Return found error
+	}
+
+	return state, context, nil // Return state
+}
+
+// execute runs the transaction's contract call against a given contract chain in a fresh VM, without persisting anything. All
+// state touched by the call is held in its own execution context, so concurrent calls (even against the same contract) are
+// safe. Contracts persist state only through their storage trie; the resulting state commits to the trie's root.
+func (transaction *Transaction) execute(contractChain *Chain) (*ContractState, *ExecutionContext, error) {
+	context, err := NewExecutionContext(transaction, contractChain) // Init execution context
+	if err != nil {                                                 // Check for errors
+		return &ContractState{}, nil, err // Return found error
+	}
+
+	parentRoot := context.Storage.Root() // Get root before call
+
+	if transaction.GasLimit == 0 { // Check no gas allowance
+		return transaction.outOfGasState(parentRoot), nil, ErrGasLimitExceeded // Return error
+	}
+
+	workingVM, err := vm.NewVirtualMachine(contractChain.ContractSource, transaction.gasMeteredEnvironment(), NewTransactionMetaResolver(context), common.GasPolicy) // Init vm
+	if err != nil {                                                                                                                                                  // Check for errors
+		return &ContractState{}, nil, err // Return found error
+	}
+
+	call, err := parseContractCall(contractChain, transaction.Payload) // Parse payload method call
+	if err != nil {                                                    // Check for errors
+		return &ContractState{}, nil, err // Return found error
+	}
+
+	entryID, valid := workingVM.GetFunctionExport(call.method) // Get function ID from payload
+
+	if !valid { // Check for errors
+		return &ContractState{}, nil, ErrInvalidPayload // Return found error
+	}
+
+	callParams := call.vmParams(workingVM) // Load call params
+
+	result, err := workingVM.Run(entryID, callParams...) // Run
+	if err != nil {                                      // Check for errors
+		common.Logf("== VM == Contract call exited with code %d and error %s", result, err.Error()) // Log err
+
+		if strings.Contains(err.Error(), "gas limit exceeded") { // Check ran out of gas
+			return transaction.outOfGasState(parentRoot), nil, ErrGasLimitExceeded // Return error (the full gas limit is still charged)
+		}
+
+		return &ContractState{}, nil, err // Return found error
+	}
+
+	common.Logf("== VM == Contract call exited with code %d", result) // Log finish
+
+	context.ReturnLog, err = call.returnLog(workingVM.Memory, result) // Get typed return value
+
+	if err != nil { // Check for errors
+		return &ContractState{}, nil, err // Return found error
+	}
+
+	context.Gas = workingVM.Gas // Set gas used
+
+	context.State = &ContractState{
+		Root:             context.Storage.Root(),     // Set storage root
+		Gas:              workingVM.Gas,              // Set gas
+		GasLimitExceeded: workingVM.GasLimitExceeded, // Set gas limit exceeded
+	} // Set state
+
+	return context.State, context, nil // Return state
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,554 @@
+package types
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/crypto"
+)
+
+// TransactionEncodingVersion is the version of the canonical binary transaction encoding produced by SigningBytes and Bytes.
+//
+// Version 1 encodes the following fields, in order (integers are big-endian, and "bytes" are a uint32 length followed by
+// that many bytes):
+//
+//	version                    uint8 (1)
+//	account nonce              uint64
+//	hash nonce                 uint64
+//	sender                     uint8 (0 if nil, 1 if set), followed by 20 bytes if set
+//	recipient                  uint8 (0 if nil, 1 if set), followed by 20 bytes if set
+//	amount                     uint8 (1 if negative, else 0), followed by the base unit magnitude as bytes
+//	gas limit                  uint64
+//	gas price                  encoded like amount
+//	payload                    bytes
+//	parent hash                uint8 (0 if nil, 1 if set), followed by 32 bytes if set
+//	timestamp                  int64 seconds since the unix epoch, followed by uint32 nanoseconds
+//	contract creation          uint8 (0 or 1)
+//	deployed contract address  uint8 (0 if nil, 1 if set), followed by 20 bytes if set
+//	mint                       uint8 (0 or 1)
+//
+// These are the fields covered by the transaction hash and signature. Fields set while the transaction is being committed
+// (genesis, state, logs, internal transactions) are not encoded (see CommittedBytes). The wire encoding (Bytes) appends the signature:
+//
+//	signature                  uint8 (0 if unsigned, 1 if signed), followed by the PKIX public key, R and S as bytes if signed
+//
+// Transactions sent by multisig accounts then append their sender's spending policy and the partial signatures collected so far
+// (the section is omitted entirely for other transactions):
+//
+//	multisig                   uint8 (1)
+//	threshold                  uint32
+//	public keys                uint32 count, followed by each PKIX public key as bytes (in ascending byte order)
+//	partial signatures         uint32 count, followed by each signature's uint32 key index, R and S as bytes (in ascending key
+//	                           index order)
+const TransactionEncodingVersion byte = 1
+
+// MultiParentTransactionEncodingVersion is the version of the canonical binary encoding of transactions that approve
+// additional dag tips (see ParentTxs). Version 2 is identical to version 1, apart from its version byte and a list of
+// additional parents following the mint flag (and therefore covered by the transaction hash and signature):
+//
+//	additional parent hashes   uint32 count (between 1 and MaxParentTxs), followed by each 32-byte hash
+//
+// Transactions without additional parents are always encoded with version 1, so their hashes are unaffected.
+const MultiParentTransactionEncodingVersion byte = 2
+
+var (
+	// ErrUnsupportedTransactionEncoding is an error definition describing a transaction encoded with an unknown encoding version.
+	ErrUnsupportedTransactionEncoding = errors.New("unsupported transaction encoding version")
+
+	// ErrMalformedTransaction is an error definition describing a transaction encoding that could not be decoded.
+	ErrMalformedTransaction = errors.New("malformed transaction encoding")
+)
+
+// transactionDecoder reads the fields of a canonically-encoded transaction, retaining the first error encountered.
+type transactionDecoder struct {
+	reader *bytes.Reader // Encoded transaction
+
+	err error // First error encountered
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// SigningBytes encodes the fields of a given transaction covered by its hash and signature in the canonical binary
+// encoding (see TransactionEncodingVersion). The encoding is deterministic: equal transactions always encode identically.
+func (transaction *Transaction) SigningBytes() []byte {
+	buffer := new(bytes.Buffer) // Init buffer
+
+	if len(transaction.ParentTxs) > 0 { // Check has additional parents
+		buffer.WriteByte(MultiParentTransactionEncodingVersion) // Write version
+	} else {
+		buffer.WriteByte(TransactionEncodingVersion) // Write version
+	}
+
+	writeUint64(buffer, transaction.AccountNonce)                   // Write account nonce
+	writeUint64(buffer, transaction.HashNonce)                      // Write hash nonce
+	writeAddress(buffer, transaction.Sender)                        // Write sender
+	writeAddress(buffer, transaction.Recipient)                     // Write recipient
+	writeAmount(buffer, transaction.Amount)                         // Write amount
+	writeUint64(buffer, transaction.GasLimit)                       // Write gas limit
+	writeAmount(buffer, transaction.GasPrice)                       // Write gas price
+	writeBytes(buffer, transaction.Payload)                         // Write payload
+	writeHash(buffer, transaction.ParentTx)                         // Write parent
+	writeUint64(buffer, uint64(transaction.Timestamp.Unix()))       // Write timestamp seconds
+	writeUint32(buffer, uint32(transaction.Timestamp.Nanosecond())) // Write timestamp nanoseconds
+	writeBool(buffer, transaction.ContractCreation)                 // Write is contract creation
+	writeAddress(buffer, transaction.DeployedContractAddress)       // Write deployed contract address
+	writeBool(buffer, transaction.Mint)                             // Write is mint
+
+	if len(transaction.ParentTxs) > 0 { // Check has additional parents
+		writeUint32(buffer, uint32(len(transaction.ParentTxs))) // Write number of additional parents
+
+		for _, hash := range transaction.ParentTxs { // Iterate through additional parents
+			buffer.Write(hash[:]) // Write parent
+		}
+	}
+
+	return buffer.Bytes() // Return encoded
+}
+
+// CalculateHash calculates the hash of a given transaction's canonical signing bytes.
+func (transaction *Transaction) CalculateHash() common.Hash {
+	return common.NewHash(crypto.Sha3(transaction.SigningBytes())) // Return hash
+}
+
+// Bytes - convert given transaction to its canonical binary wire encoding (its signing bytes, followed by its signature).
+// Does not modify the transaction.
+func (transaction *Transaction) Bytes() []byte {
+	signaturePublicKey, err := transaction.signaturePublicKey() // Get signature public key
+	if err != nil {                                             // Check for errors
+		return nil // Invalid encoding
+	}
+
+	buffer := bytes.NewBuffer(transaction.SigningBytes()) // Init buffer
+
+	if signaturePublicKey == nil { // Check unsigned
+		writeBool(buffer, false) // Write no signature
+	} else {
+		publicKey, err := x509.MarshalPKIXPublicKey(signaturePublicKey) // Encode public key
+		if err != nil {                                                 // Check for errors
+			return nil // Invalid public key
+		}
+
+		writeBool(buffer, true)                             // Write has signature
+		writeBytes(buffer, publicKey)                       // Write public key
+		writeBytes(buffer, transaction.Signature.R.Bytes()) // Write R
+		writeBytes(buffer, transaction.Signature.S.Bytes()) // Write S
+	}
+
+	if transaction.Multisig != nil && transaction.Multisig.Policy != nil { // Check multisig
+		writeBool(buffer, true)                           // Write has multisig
+		buffer.Write(transaction.Multisig.Policy.Bytes()) // Write policy
+
+		writeUint32(buffer, uint32(len(transaction.Multisig.Signatures))) // Write number of signatures
+
+		for _, signature := range transaction.Multisig.Signatures { // Iterate through signatures
+			writeUint32(buffer, signature.KeyIndex) // Write key index
+			writeBytes(buffer, signature.R.Bytes()) // Write R
+			writeBytes(buffer, signature.S.Bytes()) // Write S
+		}
+	}
+
+	return buffer.Bytes() // Return encoded
+}
+
+// TransactionFromBytes - decode a transaction from its canonical binary wire encoding, recalculating its hash
+func TransactionFromBytes(b []byte) (*Transaction, error) {
+	if len(b) == 0 { // Check empty
+		return &Transaction{}, ErrMalformedTransaction // Return error
+	}
+
+	if b[0] != TransactionEncodingVersion && b[0] != MultiParentTransactionEncodingVersion { // Check unknown version
+		return &Transaction{}, ErrUnsupportedTransactionEncoding // Return error
+	}
+
+	decoder := &transactionDecoder{reader: bytes.NewReader(b[1:])} // Init decoder
+
+	transaction := &Transaction{
+		AccountNonce: decoder.readUint64(),  // Read account nonce
+		HashNonce:    decoder.readUint64(),  // Read hash nonce
+		Sender:       decoder.readAddress(), // Read sender
+		Recipient:    decoder.readAddress(), // Read recipient
+		Amount:       decoder.readAmount(),  // Read amount
+		GasLimit:     decoder.readUint64(),  // Read gas limit
+		GasPrice:     decoder.readAmount(),  // Read gas price
+		Payload:      decoder.readBytes(),   // Read payload
+		ParentTx:     decoder.readHash(),    // Read parent
+	} // Init transaction
+
+	seconds, nanoseconds := int64(decoder.readUint64()), int64(decoder.readUint32()) // Read timestamp
+
+	transaction.Timestamp = time.Unix(seconds, nanoseconds).UTC() // Set timestamp
+	transaction.ContractCreation = decoder.readBool()             // Read is contract creation
+	transaction.DeployedContractAddress = decoder.readAddress()   // Read deployed contract address
+	transaction.Mint = decoder.readBool()                         // Read is mint
+
+	if b[0] == MultiParentTransactionEncodingVersion { // Check has additional parents
+		transaction.ParentTxs = decoder.readParents(transaction.ParentTx) // Read additional parents
+	}
+
+	hash := transaction.CalculateHash() // Calculate hash
+
+	transaction.Hash = &hash // Set hash
+
+	if decoder.readBool() { // Check signed
+		encodedPublicKey, r, s := decoder.readBytes(), decoder.readBytes(), decoder.readBytes() // Read signature
+
+		if decoder.err != nil { // Check for errors
+			return &Transaction{}, decoder.err // Return found error
+		}
+
+		genericPublicKey, err := x509.ParsePKIXPublicKey(encodedPublicKey) // Parse public key
+		if err != nil {                                                    // Check for errors
+			return &Transaction{}, err // Return found error
+		}
+
+		publicKey, ok := genericPublicKey.(*ecdsa.PublicKey) // Get public key value
+		if !ok {                                             // Check not ecdsa
+			return &Transaction{}, ErrMalformedTransaction // Return error
+		}
+
+		transaction.Signature = &Signature{
+			PublicKey: publicKey,                               // Set public key
+			V:         crypto.Sha3(transaction.SigningBytes()), // Set signed value
+			R:         new(big.Int).SetBytes(r),                // Set R
+			S:         new(big.Int).SetBytes(s),                // Set S
+		} // Set signature
+	}
+
+	if decoder.err == nil && decoder.reader.Len() != 0 { // Check multisig
+		if !decoder.readBool() { // Check flag not set (the flag is only written for multisig transactions)
+			decoder.err = ErrMalformedTransaction // Set error
+		} else {
+			transaction.Multisig = decoder.readMultisig() // Read multisig policy and signatures
+		}
+	}
+
+	if decoder.err == nil && decoder.reader.Len() != 0 { // Check trailing bytes
+		decoder.err = ErrMalformedTransaction // Set error
+	}
+
+	if decoder.err != nil { // Check for errors
+		return &Transaction{}, decoder.err // Return found error
+	}
+
+	return transaction, nil // No error occurred, return read value
+}
+
+// CommittedBytes - convert given transaction to its committed encoding: the safely-encoded JSON representation of every
+// field, including those set while the transaction was committed to its chain (genesis, state, logs and internal
+// transactions), which the canonical wire encoding (Bytes) omits. Does not modify the transaction.
+func (transaction *Transaction) CommittedBytes() []byte {
+	signaturePublicKey, err := transaction.signaturePublicKey() // Get signature public key
+	if err != nil {                                             // Check for errors
+		return nil // Invalid encoding
+	}
+
+	safeTransaction := *transaction // Copy transaction
+
+	if signaturePublicKey != nil { // Check signed
+		signature := *transaction.Signature // Copy signature
+
+		encoded, err := x509.MarshalPKIXPublicKey(signaturePublicKey) // Encode public key
+		if err != nil {                                               // Check for errors
+			return nil // Invalid public key
+		}
+
+		signature.SerializedPublicKey = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encoded}) // Encode PEM
+
+		safeTransaction.Signature = &signature // Set signature
+	}
+
+	encoded, err := json.Marshal(safeTransaction) // Encode transaction
+	if err != nil {                               // Check for errors
+		return nil // Invalid encoding
+	}
+
+	return encoded // Return encoded
+}
+
+// TransactionFromCommittedBytes - decode a committed transaction from its committed encoding (see CommittedBytes), checking
+// that its hash matches its canonical fields
+func TransactionFromCommittedBytes(b []byte) (*Transaction, error) {
+	transaction, err := TransactionFromJSON(b) // Decode transaction
+	if err != nil {                            // Check for errors
+		return &Transaction{}, err // Return found error
+	}
+
+	if transaction.Hash == nil || transaction.CalculateHash() != *transaction.Hash { // Check hash doesn't match
+		return &Transaction{}, ErrMalformedTransaction // Return error
+	}
+
+	return transaction, nil // No error occurred, return read value
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// signaturePublicKey gets the public key of a given transaction's signature, decoding it from its safe encoding if
+// necessary (without modifying the transaction). Returns nil if the transaction is unsigned.
+func (transaction *Transaction) signaturePublicKey() (*ecdsa.PublicKey, error) {
+	if transaction.Signature == nil { // Check unsigned
+		return nil, nil // No public key
+	}
+
+	if transaction.Signature.PublicKey != nil && transaction.Signature.PublicKey.Curve != nil { // Check has public key
+		return transaction.Signature.PublicKey, nil // Return public key
+	}
+
+	if transaction.Signature.SerializedPublicKey == nil { // Check no serialized public key
+		return nil, nil // No public key
+	}
+
+	block, _ := pem.Decode(transaction.Signature.SerializedPublicKey) // Decode PEM
+	if block == nil {                                                 // Check invalid PEM
+		return nil, ErrMalformedTransaction // Return error
+	}
+
+	genericPublicKey, err := x509.ParsePKIXPublicKey(block.Bytes) // Parse public key
+	if err != nil {                                               // Check for errors
+		return nil, err // Return found error
+	}
+
+	publicKey, ok := genericPublicKey.(*ecdsa.PublicKey) // Get public key value
+	if !ok {                                             // Check not ecdsa
+		return nil, ErrMalformedTransaction // Return error
+	}
+
+	return publicKey, nil // Return public key
+}
+
+// writeUint64 writes a given big-endian uint64 to a given buffer.
+func writeUint64(buffer *bytes.Buffer, n uint64) {
+	encoded := make([]byte, 8) // Init encoded buffer
+
+	binary.BigEndian.PutUint64(encoded, n) // Encode
+
+	buffer.Write(encoded) // Write
+}
+
+// writeUint32 writes a given big-endian uint32 to a given buffer.
+func writeUint32(buffer *bytes.Buffer, n uint32) {
+	encoded := make([]byte, 4) // Init encoded buffer
+
+	binary.BigEndian.PutUint32(encoded, n) // Encode
+
+	buffer.Write(encoded) // Write
+}
+
+// writeBool writes a given bool as a single byte to a given buffer.
+func writeBool(buffer *bytes.Buffer, b bool) {
+	if b { // Check true
+		buffer.WriteByte(1) // Write true
+	} else {
+		buffer.WriteByte(0) // Write false
+	}
+}
+
+// writeBytes writes a given length-prefixed byte slice to a given buffer.
+func writeBytes(buffer *bytes.Buffer, b []byte) {
+	writeUint32(buffer, uint32(len(b))) // Write length
+
+	buffer.Write(b) // Write bytes
+}
+
+// writeAddress writes a given optional address to a given buffer.
+func writeAddress(buffer *bytes.Buffer, address *common.Address) {
+	writeBool(buffer, address != nil) // Write is set
+
+	if address != nil { // Check set
+		buffer.Write(address[:]) // Write address
+	}
+}
+
+// writeHash writes a given optional hash to a given buffer.
+func writeHash(buffer *bytes.Buffer, hash *common.Hash) {
+	writeBool(buffer, hash != nil) // Write is set
+
+	if hash != nil { // Check set
+		buffer.Write(hash[:]) // Write hash
+	}
+}
+
+// writeAmount writes a given amount's sign and base unit magnitude to a given buffer. A nil amount is written as zero.
+func writeAmount(buffer *bytes.Buffer, amount *common.Amount) {
+	units := amount.BaseUnits() // Get units
+
+	writeBool(buffer, units.Sign() < 0) // Write is negative
+
+	writeBytes(buffer, units.Abs(units).Bytes()) // Write magnitude
+}
+
+// read reads exactly n bytes, recording an error if fewer remain.
+func (decoder *transactionDecoder) read(n int) []byte {
+	if decoder.err != nil || n > decoder.reader.Len() { // Check cannot read
+		if decoder.err == nil { // Check first error
+			decoder.err = ErrMalformedTransaction // Set error
+		}
+
+		return nil // Nothing read
+	}
+
+	b := make([]byte, n) // Init buffer
+
+	decoder.reader.Read(b) // Read
+
+	return b // Return read bytes
+}
+
+// readUint64 reads a big-endian uint64.
+func (decoder *transactionDecoder) readUint64() uint64 {
+	if b := decoder.read(8); b != nil { // Check read
+		return binary.BigEndian.Uint64(b) // Return decoded
+	}
+
+	return 0 // Nothing read
+}
+
+// readUint32 reads a big-endian uint32.
+func (decoder *transactionDecoder) readUint32() uint32 {
+	if b := decoder.read(4); b != nil { // Check read
+		return binary.BigEndian.Uint32(b) // Return decoded
+	}
+
+	return 0 // Nothing read
+}
+
+// readBool reads a single-byte bool, recording an error if the byte is neither 0 nor 1.
+func (decoder *transactionDecoder) readBool() bool {
+	b := decoder.read(1) // Read byte
+
+	if b == nil { // Check nothing read
+		return false // Nothing read
+	}
+
+	if b[0] > 1 { // Check not canonical
+		decoder.err = ErrMalformedTransaction // Set error
+	}
+
+	return b[0] == 1 // Return decoded
+}
+
+// readBytes reads a length-prefixed byte slice.
+func (decoder *transactionDecoder) readBytes() []byte {
+	length := decoder.readUint32() // Read length
+
+	if decoder.err != nil || length == 0 { // Check nothing to read
+		return nil // Nothing read
+	}
+
+	return decoder.read(int(length)) // Read bytes
+}
+
+// readAddress reads an optional address.
+func (decoder *transactionDecoder) readAddress() *common.Address {
+	if !decoder.readBool() { // Check not set
+		return nil // Nil address
+	}
+
+	address := common.Address{} // Init address buffer
+
+	copy(address[:], decoder.read(common.AddressLength)) // Read address
+
+	return &address // Return address
+}
+
+// readHash reads an optional hash.
+func (decoder *transactionDecoder) readHash() *common.Hash {
+	if !decoder.readBool() { // Check not set
+		return nil // Nil hash
+	}
+
+	hash := common.Hash{} // Init hash buffer
+
+	copy(hash[:], decoder.read(common.HashLength)) // Read hash
+
+	return &hash // Return hash
+}
+
+// readAmount reads an amount's sign and base unit magnitude.
+func (decoder *transactionDecoder) readAmount() *common.Amount {
+	negative := decoder.readBool() // Read sign
+
+	magnitude := decoder.readBytes() // Read magnitude
+
+	if len(magnitude) > 0 && magnitude[0] == 0 || negative && len(magnitude) == 0 { // Check not canonical
+		decoder.err = ErrMalformedTransaction // Set error
+	}
+
+	units := new(big.Int).SetBytes(magnitude) // Decode magnitude
+
+	if negative { // Check negative
+		units.Neg(units) // Negate
+	}
+
+	return common.NewAmount(units) // Return amount
+}
+
+// readParents reads a list of additional parent hashes, recording an error if the list is empty, too long, or contains
+// duplicates (or a given primary parent).
+func (decoder *transactionDecoder) readParents(parentTx *common.Hash) []common.Hash {
+	count := decoder.readUint32() // Read number of parents
+
+	if decoder.err == nil && (count == 0 || count > MaxParentTxs) { // Check not canonical
+		decoder.err = ErrMalformedTransaction // Set error
+	}
+
+	parents := []common.Hash{} // Init parents buffer
+
+	for i := uint32(0); i < count && decoder.err == nil; i++ { // Read parents
+		hash := common.Hash{} // Init hash buffer
+
+		copy(hash[:], decoder.read(common.HashLength)) // Read hash
+
+		if parentTx != nil && hash == *parentTx || containsHash(parents, hash) { // Check duplicate
+			decoder.err = ErrMalformedTransaction // Set error
+		}
+
+		parents = append(parents, hash) // Append parent
+	}
+
+	return parents // Return parents
+}
+
+// readMultisig reads a multisig spending policy and its partial signatures, recording an error if either is not canonical.
+func (decoder *transactionDecoder) readMultisig() *MultisigSignature {
+	policy := &MultisigPolicy{Threshold: decoder.readUint32()} // Read threshold
+
+	keys := decoder.readUint32() // Read number of keys
+
+	for i := uint32(0); i < keys && i <= MaxMultisigKeys && decoder.err == nil; i++ { // Read keys
+		policy.PublicKeys = append(policy.PublicKeys, decoder.readBytes()) // Read public key
+	}
+
+	if decoder.err == nil && policy.Validate() != nil { // Check invalid policy
+		decoder.err = ErrMalformedTransaction // Set error
+	}
+
+	multisig := &MultisigSignature{Policy: policy} // Init multisig
+
+	signatures := decoder.readUint32() // Read number of signatures
+
+	for i := uint32(0); i < signatures && decoder.err == nil; i++ { // Read signatures
+		signature := &PartialSignature{KeyIndex: decoder.readUint32()} // Read key index
+
+		signature.R, signature.S = new(big.Int).SetBytes(decoder.readBytes()), new(big.Int).SetBytes(decoder.readBytes()) // Read R, S
+
+		if int(signature.KeyIndex) >= len(policy.PublicKeys) || i > 0 && multisig.Signatures[i-1].KeyIndex >= signature.KeyIndex { // Check unknown, duplicate or unsorted signer
+			decoder.err = ErrMalformedTransaction // Set error
+		}
+
+		multisig.Signatures = append(multisig.Signatures, signature) // Append signature
+	}
+
+	return multisig // Return multisig
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,211 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// transactionVector is a transaction, along with its expected canonical encoding and hash.
+type transactionVector struct {
+	transaction *Transaction // Transaction
+
+	signingBytes string // Expected hex-encoded signing bytes
+	hash         string // Expected hash
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// TestSigningBytes - test that transactions encode to the golden canonical encoding vectors
+func TestSigningBytes(t *testing.T) {
+	for x, vector := range transactionVectors() { // Iterate through vectors
+		if encoded := hex.EncodeToString(vector.transaction.SigningBytes()); encoded != vector.signingBytes { // Check encoding
+			t.Fatalf("vector %d: expected signing bytes %s, got %s", x, vector.signingBytes, encoded) // Panic
+		}
+
+		if hash := vector.transaction.CalculateHash(); hash.String() != vector.hash { // Check hash
+			t.Fatalf("vector %d: expected hash %s, got %s", x, vector.hash, hash.String()) // Panic
+		}
+
+		decoded, err := TransactionFromBytes(vector.transaction.Bytes()) // Decode transaction
+		if err != nil {                                                  // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if encoded := hex.EncodeToString(decoded.SigningBytes()); encoded != vector.signingBytes || decoded.Hash.String() != vector.hash { // Check round trip
+			t.Fatalf("vector %d: decoded transaction encodes to %s (%s)", x, encoded, decoded.Hash.String()) // Panic
+		}
+	}
+}
+
+// TestTransactionFromBytesSigned - test that signed transactions survive the wire encoding, and that any change to a signed
+// field invalidates the signature
+func TestTransactionFromBytesSigned(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	sender, err := common.NewAddress(privateKey) // Initialize address from private key
+	if err != nil {                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transaction, err := NewTransactionWithGas(3, nil, &sender, &common.Address{0x2}, common.Coins(5), 100, common.Units(7), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                                                         // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = SignTransaction(transaction, privateKey) // Sign transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	decoded, err := TransactionFromBytes(transaction.Bytes()) // Decode transaction
+	if err != nil {                                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *decoded.Hash != *transaction.Hash || !decoded.Timestamp.Equal(transaction.Timestamp) || decoded.Amount.Cmp(transaction.Amount) != 0 { // Check decoded fields
+		t.Fatalf("decoded transaction %s does not match %s", decoded.String(), transaction.String()) // Panic
+	}
+
+	if valid, err := VerifyTransactionSignature(decoded); !valid || err != nil { // Check signature survived encoding
+		t.Fatalf("decoded signature invalid: %v", err) // Panic
+	}
+
+	decoded.Amount = common.Coins(500) // Tamper with amount
+
+	if valid, _ := VerifyTransactionSignature(decoded); valid { // Check signature covers amount
+		t.Fatal("signature valid for tampered transaction") // Panic
+	}
+
+	decoded.Hash = &common.Hash{} // Set hash buffer
+
+	*decoded.Hash = decoded.CalculateHash() // Recalculate hash
+
+	if valid, _ := VerifyTransactionSignature(decoded); valid { // Check signature covers amount even with a matching hash
+		t.Fatal("signature valid for re-hashed tampered transaction") // Panic
+	}
+}
+
+// TestTransactionFromBytesMalformed - test that malformed encodings are rejected
+func TestTransactionFromBytesMalformed(t *testing.T) {
+	encoded := transactionVectors()[1].transaction.Bytes() // Encode transaction
+
+	if _, err := TransactionFromBytes(append([]byte{MultiParentTransactionEncodingVersion + 1}, encoded[1:]...)); err != ErrUnsupportedTransactionEncoding { // Check unknown version rejected
+		t.Fatalf("expected unsupported version, got %v", err) // Panic
+	}
+
+	if _, err := TransactionFromBytes(encoded[:len(encoded)-2]); err != ErrMalformedTransaction { // Check truncated encoding rejected
+		t.Fatalf("expected malformed encoding, got %v", err) // Panic
+	}
+
+	if _, err := TransactionFromBytes(append(encoded, 0)); err != ErrMalformedTransaction { // Check trailing bytes rejected
+		t.Fatalf("expected malformed encoding, got %v", err) // Panic
+	}
+}
+
+// TestCommittedBytes - test that committed transactions survive the committed encoding (including fields the wire encoding
+// omits), and that encoding a safely-encoded transaction doesn't modify it
+func TestCommittedBytes(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	sender, err := common.NewAddress(privateKey) // Initialize address from private key
+	if err != nil {                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transaction, err := NewTransaction(1, nil, &sender, &common.Address{0x2}, common.Coins(5), []byte("test")) // Initialize transaction
+	if err != nil {                                                                                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = SignTransaction(transaction, privateKey) // Sign transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transaction.Logs = []*Log{{Type: Return, Key: "result", Value: []byte("ok")}}                                                  // Set logs
+	transaction.InternalTransactions = []*Transaction{transaction.newInternalTransaction(0, common.Address{0x3}, common.Coins(1))} // Set internal transactions
+
+	err = transaction.MakeEncodingSafe() // Make encoding safe
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if transaction.Bytes() == nil || transaction.Signature.PublicKey.Curve != nil { // Check wire encoding doesn't recover the receiver
+		t.Fatal("expected wire encoding not to modify a safely-encoded transaction") // Panic
+	}
+
+	decoded, err := TransactionFromCommittedBytes(transaction.CommittedBytes()) // Decode transaction
+	if err != nil {                                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *decoded.Hash != *transaction.Hash || len(decoded.Logs) != 1 || decoded.Logs[0].Key != "result" || len(decoded.InternalTransactions) != 1 || *decoded.InternalTransactions[0].Hash != *transaction.InternalTransactions[0].Hash { // Check committed fields
+		t.Fatalf("decoded transaction %s does not match %s", decoded.String(), transaction.String()) // Panic
+	}
+
+	if valid, err := VerifyTransactionSignature(decoded); !valid || err != nil { // Check signature survived encoding
+		t.Fatalf("decoded signature invalid: %v", err) // Panic
+	}
+
+	decoded.Amount = common.Coins(6) // Change signed field
+
+	if _, err := TransactionFromCommittedBytes(decoded.CommittedBytes()); err != ErrMalformedTransaction { // Check mismatched hash rejected
+		t.Fatalf("expected malformed encoding, got %v", err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// transactionVectors gets the golden canonical encoding vectors. Other implementations may use these to check that they encode
+// and hash transactions identically.
+func transactionVectors() []transactionVector {
+	sender, recipient, parent := common.Address{0x1}, common.Address{0x2}, common.Hash{0x3} // Init addresses
+
+	return []transactionVector{
+		{
+			transaction:  &Transaction{},
+			signingBytes: "010000000000000000000000000000000000000000000000000000000000000000000000000000000000fffffff1886e090000000000000000",
+			hash:         "0x307878c96cd5dd09bf46b694436c8f371800cd936cb997c19e50923886947103",
+		}, // Empty transaction
+		{
+			transaction: &Transaction{
+				AccountNonce:            1,                                           // Set nonce
+				HashNonce:               2,                                           // Set hash nonce
+				Sender:                  &sender,                                     // Set sender
+				Recipient:               &recipient,                                  // Set recipient
+				Amount:                  common.Units(1500000000000000000),           // Set amount (1.5 coins)
+				GasLimit:                21000,                                       // Set gas limit
+				GasPrice:                common.Units(-1),                            // Set gas price
+				Payload:                 []byte("test"),                              // Set payload
+				ParentTx:                &parent,                                     // Set parent
+				Timestamp:               time.Date(2019, 1, 1, 0, 0, 0, 5, time.UTC), // Set timestamp
+				ContractCreation:        true,                                        // Set is contract creation
+				DeployedContractAddress: &recipient,                                  // Set deployed contract
+				Mint:                    true,                                        // Set is mint
+				Genesis:                 true,                                        // Set is genesis (not encoded)
+				Logs:                    []*Log{{Key: "not encoded"}},                // Set logs (not encoded)
+			},
+			signingBytes: "0100000000000000010000000000000002010100000000000000000000000000000000000000010200000000000000000000000000000000000000000000000814d1120d7b16000000000000000052080100000001010000000474657374010300000000000000000000000000000000000000000000000000000000000000000000005c2aad80000000050101020000000000000000000000000000000000000001",
+			hash:         "0x30786549c7e0f2a7d6cdac231da8ba5b435b515d4f197144c2837ab9654c33b9",
+		}, // Every field set
+	}
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/ursa/vm"
+)
+
+var (
+	// ErrGasLimitExceeded - error definition describing a contract call that consumed more gas than its limit allowed
+	ErrGasLimitExceeded = errors.New("transaction gas limit exceeded")
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// Fee - calculate the fee paid by the sender of a given transaction (gas used * gas price)
+func (transaction *Transaction) Fee() *common.Amount {
+	if transaction.State == nil || transaction.GasPrice == nil { // Check no gas used
+		return common.NewAmount(nil) // No fee
+	}
+
+	return transaction.GasPrice.Mul(transaction.State.Gas) // Return fee
+}
+
+// MaxFee - calculate the maximum fee that may be paid by the sender of a given transaction (gas limit * gas price)
+func (transaction *Transaction) MaxFee() *common.Amount {
+	if transaction.GasPrice == nil { // Check no gas price
+		return common.NewAmount(nil) // No fee
+	}
+
+	return transaction.GasPrice.Mul(transaction.GasLimit) // Return max fee
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// gasMeteredEnvironment - get a copy of the global VM config, limited to the transaction's gas limit
+func (transaction *Transaction) gasMeteredEnvironment() vm.Environment {
+	environment := common.VMConfig // Copy global config
+
+	environment.GasLimit = transaction.GasLimit  // Set gas limit
+	environment.ReturnOnGasLimitExceeded = false // Exit with an error on exceeding the limit
+
+	return environment // Return environment
+}
+
+// outOfGasState - get the state resulting from a call that ran out of gas (the unmodified storage root, with the full gas limit used)
+func (transaction *Transaction) outOfGasState(root common.Hash) *ContractState {
+	return &ContractState{
+		Root:             root,                 // Revert to parent root
+		Gas:              transaction.GasLimit, // Charge full gas limit
+		GasLimitExceeded: true,                 // Set gas limit exceeded
+	} // Return state
+}
+
+/* END INTERNAL METHODS */
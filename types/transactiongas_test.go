@@ -0,0 +1,62 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestNewTransactionWithGas - test functionality of NewTransactionWithGas() method
+func TestNewTransactionWithGas(t *testing.T) {
+	sender, recipient := common.Address{1}, common.Address{2} // Init addresses
+
+	transaction, err := NewTransactionWithGas(0, nil, &sender, &recipient, common.Coins(1), 1000, common.Units(500000000000000000), []byte("test()")) // Initialize transaction
+	if err != nil {                                                                                                                                   // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if transaction.GasLimit != 1000 || transaction.GasPrice.Cmp(common.Units(500000000000000000)) != 0 { // Check gas params
+		t.Fatalf("invalid gas params: %d, %s", transaction.GasLimit, transaction.GasPrice.String()) // Panic
+	}
+
+	if transaction.MaxFee().Cmp(common.Coins(500)) != 0 { // Check max fee
+		t.Fatalf("invalid max fee: %s", transaction.MaxFee().String()) // Panic
+	}
+
+	if transaction.Fee().Cmp(common.NewAmount(nil)) != 0 { // Check no fee before evaluation
+		t.Fatalf("invalid fee: %s", transaction.Fee().String()) // Panic
+	}
+}
+
+// TestCalculateBalanceFee - test that CalculateBalance() debits contract call fees from the sender
+func TestCalculateBalanceFee(t *testing.T) {
+	account, contract := common.Address{1}, common.Address{2} // Init addresses
+
+	genesis, err := NewTransaction(0, nil, nil, &account, common.Coins(100), []byte("genesis")) // Initialize genesis transaction
+	if err != nil {                                                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	call, err := NewTransactionWithGas(0, genesis, &account, &contract, common.Coins(10), 1000, common.Units(10000000000000000), []byte("test()")) // Initialize contract call
+	if err != nil {                                                                                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	call.State = &ContractState{Gas: 300} // Set evaluated state
+
+	chain := &Chain{Account: account, Genesis: *genesis.Hash, Transactions: []*Transaction{genesis, call}} // Init chain
+
+	if balance := chain.CalculateBalance(); balance.Cmp(common.Coins(87)) != 0 { // Check balance (100 - 10 - 300 * 0.01)
+		t.Fatalf("invalid balance: %s", balance.String()) // Panic
+	}
+
+	call.State = call.outOfGasState(common.Hash{}) // Run out of gas
+
+	if balance := chain.CalculateBalance(); balance.Cmp(common.Coins(80)) != 0 { // Check full gas limit charged (100 - 10 - 1000 * 0.01)
+		t.Fatalf("invalid balance: %s", balance.String()) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrNotSender - error definition describing an offline signing key that does not belong to the transaction sender
+	ErrNotSender = errors.New("signing key does not belong to transaction sender")
+
+	// ErrInvalidTransactionBlob - error definition describing a transaction blob that is not hex-encoded
+	ErrInvalidTransactionBlob = errors.New("invalid transaction blob")
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// UnsignedBlob - export given unsigned transaction for offline signing (see SignTransactionBlob). The blob is the hex-encoded
+// canonical transaction encoding, so it carries the nonce and parent chosen by the exporting node (and, for multisig senders, the
+// spending policy and any partial signatures collected so far).
+func (transaction *Transaction) UnsignedBlob() (string, error) {
+	if transaction.Signature != nil { // Check already signed
+		return "", ErrAlreadySigned // Return error
+	}
+
+	return hex.EncodeToString(transaction.Bytes()), nil // Return blob
+}
+
+// SignTransactionBlob - sign a given unsigned transaction blob (see UnsignedBlob) with a given private key, returning the signed
+// blob. Requires no node or network access. Blobs sent by multisig accounts gain a partial signature (see AddPartialSignature).
+func SignTransactionBlob(blob string, privateKey *ecdsa.PrivateKey) (string, error) {
+	transaction, err := TransactionFromBlob(blob) // Decode blob
+	if err != nil {                               // Check for errors
+		return "", err // Return found error
+	}
+
+	if transaction.Multisig != nil { // Check multisig
+		err = AddPartialSignature(transaction, privateKey) // Add partial signature
+
+		if err != nil { // Check for errors
+			return "", err // Return found error
+		}
+
+		return hex.EncodeToString(transaction.Bytes()), nil // Return partially-signed blob
+	}
+
+	if transaction.Sender == nil || !transaction.Sender.IsDerivedFrom(&privateKey.PublicKey) { // Check key does not belong to sender
+		return "", ErrNotSender // Return error
+	}
+
+	err = SignTransaction(transaction, privateKey) // Sign transaction
+
+	if err != nil { // Check for errors
+		return "", err // Return found error
+	}
+
+	return hex.EncodeToString(transaction.Bytes()), nil // Return signed blob
+}
+
+// TransactionFromSignedBlob - decode a given signed transaction blob (see SignTransactionBlob), verifying its signature
+func TransactionFromSignedBlob(blob string) (*Transaction, error) {
+	transaction, err := TransactionFromBlob(blob) // Decode blob
+	if err != nil {                               // Check for errors
+		return &Transaction{}, err // Return found error
+	}
+
+	valid, err := VerifyTransactionSignature(transaction) // Verify signature
+	if err != nil {                                       // Check for errors
+		return &Transaction{}, err // Return found error
+	}
+
+	if !valid { // Check invalid signature
+		return &Transaction{}, ErrInvalidSignature // Return error
+	}
+
+	return transaction, nil // Return transaction
+}
+
+// TransactionFromBlob - decode a given hex-encoded transaction blob, without verifying its signature
+func TransactionFromBlob(blob string) (*Transaction, error) {
+	encoded, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(blob), "0x")) // Decode blob
+	if err != nil {                                                                     // Check for errors
+		return &Transaction{}, ErrInvalidTransactionBlob // Return error
+	}
+
+	return TransactionFromBytes(encoded) // Decode transaction
+}
+
+/* END EXPORTED METHODS */
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestSignTransactionBlob - test the offline signing round trip
+func TestSignTransactionBlob(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	sender, err := common.NewAddress(privateKey) // Initialize address from private key
+	if err != nil {                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	parent := &Transaction{Hash: &common.Hash{0x3}} // Init parent
+
+	transaction, err := NewTransactionWithGas(4, parent, &sender, &common.Address{0x2}, common.Coins(1), 100, common.Units(1), nil) // Initialize transaction
+	if err != nil {                                                                                                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	blob, err := transaction.UnsignedBlob() // Export transaction
+	if err != nil {                         // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := TransactionFromSignedBlob(blob); err != ErrNilSignature { // Check unsigned blob rejected
+		t.Fatalf("expected unsigned blob to be rejected, got %v", err) // Panic
+	}
+
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate unrelated key
+
+	if _, err := SignTransactionBlob(blob, otherKey); err != ErrNotSender { // Check unrelated key rejected
+		t.Fatalf("expected unrelated key to be rejected, got %v", err) // Panic
+	}
+
+	signedBlob, err := SignTransactionBlob(blob, privateKey) // Sign offline
+	if err != nil {                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := SignTransactionBlob(signedBlob, privateKey); err != ErrAlreadySigned { // Check signed blob not re-signed
+		t.Fatalf("expected signed blob to be rejected, got %v", err) // Panic
+	}
+
+	signed, err := TransactionFromSignedBlob(signedBlob) // Import signed transaction
+	if err != nil {                                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *signed.Hash != *transaction.Hash || signed.AccountNonce != 4 || *signed.ParentTx != *parent.Hash { // Check nonce, parent survived
+		t.Fatalf("signed transaction %s does not match exported transaction", signed.String()) // Panic
+	}
+
+	tampered := []byte(signedBlob) // Copy blob
+
+	tampered[3] ^= 1 // Tamper with nonce (first byte after version)
+
+	if _, err := TransactionFromSignedBlob(string(tampered)); err == nil { // Check tampered blob rejected
+		t.Fatal("expected tampered blob to be rejected") // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
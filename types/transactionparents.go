@@ -0,0 +1,65 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+// MaxParentTxs - maximum number of additional parents (approved dag tips) a transaction may reference
+const MaxParentTxs = 8
+
+var (
+	// ErrTooManyParents - error definition describing a transaction referencing more than MaxParentTxs additional parents
+	ErrTooManyParents = errors.New("transaction references too many parents")
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// Parents - get the hashes of all of a given transaction's parents (its account chain parent, if it has one, followed by any
+// approved dag tips)
+func (transaction *Transaction) Parents() []common.Hash {
+	parents := []common.Hash{} // Init parents buffer
+
+	if transaction.ParentTx != nil && *transaction.ParentTx != (common.Hash{}) { // Check has parent
+		parents = append(parents, *transaction.ParentTx) // Append parent
+	}
+
+	return append(parents, transaction.ParentTxs...) // Return parents
+}
+
+// ApproveTips - reference a given set of dag tips as additional parents of a given unsigned transaction, recalculating its
+// hash. Tips that are already parents of the transaction are ignored.
+func (transaction *Transaction) ApproveTips(tips []common.Hash) error {
+	if transaction.IsSigned() || transaction.Multisig != nil && len(transaction.Multisig.Signatures) > 0 { // Check already signed
+		return ErrAlreadySigned // Return error
+	}
+
+	parents := []common.Hash{} // Init additional parents buffer
+
+	for _, tip := range tips { // Iterate through tips
+		if tip == (common.Hash{}) || transaction.ParentTx != nil && tip == *transaction.ParentTx || containsHash(parents, tip) { // Check already a parent
+			continue // Continue
+		}
+
+		parents = append(parents, tip) // Append tip
+	}
+
+	if len(parents) > MaxParentTxs { // Check too many parents
+		return ErrTooManyParents // Return error
+	}
+
+	transaction.ParentTxs = nil // Reset additional parents
+
+	if len(parents) > 0 { // Check has additional parents
+		transaction.ParentTxs = parents // Set additional parents
+	}
+
+	hash := transaction.CalculateHash() // Recalculate hash
+
+	transaction.Hash = &hash // Set hash
+
+	return nil // No error occurred, return nil
+}
+
+/* END EXPORTED METHODS */
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestApproveTips - test referencing additional dag tips, and their canonical encoding
+func TestApproveTips(t *testing.T) {
+	parent, err := NewTransaction(0, nil, nil, &common.Address{0x1}, common.Coins(1), nil) // Initialize parent
+	if err != nil {                                                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transaction, err := NewTransaction(1, parent, &common.Address{0x1}, &common.Address{0x2}, common.Coins(1), nil) // Initialize transaction
+	if err != nil {                                                                                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if transaction.SigningBytes()[0] != TransactionEncodingVersion { // Check single-parent encoding unchanged
+		t.Fatal("expected transaction without tips to use the original encoding") // Panic
+	}
+
+	hash := *transaction.Hash // Get hash without tips
+
+	err = transaction.ApproveTips([]common.Hash{*parent.Hash, {0x3}, {0x3}, {0x4}}) // Approve tips
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(transaction.ParentTxs) != 2 || len(transaction.Parents()) != 3 || *transaction.Hash == hash { // Check duplicate tips ignored, hash updated
+		t.Fatalf("unexpected parents %v", transaction.Parents()) // Panic
+	}
+
+	decoded, err := TransactionFromBytes(transaction.Bytes()) // Round trip
+	if err != nil {                                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *decoded.Hash != *transaction.Hash || len(decoded.ParentTxs) != 2 || decoded.ParentTxs[1] != (common.Hash{0x4}) { // Check parents survived encoding
+		t.Fatal("expected additional parents to survive encoding") // Panic
+	}
+
+	transaction.ParentTxs = []common.Hash{{0x3}, {0x3}} // Set duplicate parents
+
+	if _, err := TransactionFromBytes(transaction.Bytes()); err != ErrMalformedTransaction { // Check duplicate parents rejected
+		t.Fatalf("expected malformed transaction, got %v", err) // Panic
+	}
+
+	if err := transaction.ApproveTips(make([]common.Hash, MaxParentTxs+1)); err != nil { // Check zero hashes ignored
+		t.Fatal(err) // Panic
+	}
+
+	tips := []common.Hash{} // Init tips buffer
+
+	for i := 0; i <= MaxParentTxs; i++ { // Make too many tips
+		tips = append(tips, common.Hash{byte(i + 1)}) // Append tip
+	}
+
+	if err := transaction.ApproveTips(tips); err != ErrTooManyParents { // Check too many tips rejected
+		t.Fatalf("expected too many parents, got %v", err) // Panic
+	}
+
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+
+	if err := SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+		t.Fatal(err) // Panic
+	}
+
+	if err := transaction.ApproveTips(tips[:1]); err != ErrAlreadySigned { // Check signed transactions can't change parents
+		t.Fatalf("expected already signed, got %v", err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
@@ -28,7 +28,7 @@ type Witness struct {
 
 	Address common.Address // Witnessing address
 
-	Weight float64 // Witness weight (stake)
+	Weight *common.Amount // Witnessing account's balance when the witness was recorded (informational; see GetFinality)
 }
 
 var (
@@ -46,15 +46,26 @@ func SignTransaction(transaction *Transaction, privateKey *ecdsa.PrivateKey) err
 	return selfSignTransaction(transaction, privateKey) // Sign tx
 }
 
-// VerifyTransactionSignature - verify given transaction signature, returning false if signature invalid
+// VerifyTransactionSignature - verify given transaction signature (and hash) against its canonical signing bytes, returning false if signature invalid.
+// Unsigned transactions from multisig senders are valid once they carry valid signatures from as many policy keys as the policy requires.
 func VerifyTransactionSignature(transaction *Transaction) (bool, error) {
+	if transaction.Signature == nil && transaction.Multisig != nil { // Check multisig
+		return verifyMultisigSignature(transaction) // Verify partial signatures
+	}
+
 	if transaction.Signature == nil { // Check nil signature
 		return false, ErrNilSignature // Return nil signature error
-	} else if common.PublicKeyToAddress(transaction.Signature.PublicKey) != *transaction.Sender { // Check for invalid public key
+	} else if transaction.Sender == nil || !transaction.Sender.IsDerivedFrom(transaction.Signature.PublicKey) { // Check for invalid public key
 		return false, ErrInvalidSignature // Return invalid signature error
 	}
 
-	return ecdsa.Verify(transaction.Signature.PublicKey, transaction.Signature.V, transaction.Signature.R, transaction.Signature.S), nil // Check signature valid
+	signed := crypto.Sha3(transaction.SigningBytes()) // Hash signed fields
+
+	if transaction.Hash == nil || *transaction.Hash != common.NewHash(signed) { // Check hash doesn't match signed fields
+		return false, nil // Invalid
+	}
+
+	return ecdsa.Verify(transaction.Signature.PublicKey, signed, transaction.Signature.R, transaction.Signature.S), nil // Check signature valid
 }
 
 // Bytes - convert given signature to byte array
@@ -101,16 +112,18 @@ func selfSignTransaction(transaction *Transaction, privateKey *ecdsa.PrivateKey)
 		return ErrAlreadySigned // Return already signed error
 	}
 
-	r, s, err := ecdsa.Sign(rand.Reader, &pkCopy, crypto.Sha3(transaction.Bytes())) // Sign tx
-	if err != nil {                                                                 // Check for errors
+	signed := crypto.Sha3(transaction.SigningBytes()) // Hash signed fields
+
+	r, s, err := ecdsa.Sign(rand.Reader, &pkCopy, signed) // Sign tx
+	if err != nil {                                       // Check for errors
 		return err // Return found error
 	}
 
 	txSignature := Signature{ // Initialize signature
-		PublicKey: &privateKey.PublicKey,            // Set public key
-		V:         crypto.Sha3(transaction.Bytes()), // Set val
-		R:         r,                                // Set R
-		S:         s,                                // Set S
+		PublicKey: &privateKey.PublicKey, // Set public key
+		V:         signed,                // Set val
+		R:         r,                     // Set R
+		S:         s,                     // Set S
 	}
 
 	(*transaction).Signature = &txSignature // Set signature
@@ -0,0 +1,135 @@
+package types
+
+/* BEGIN INTERNAL METHODS */
+
+// Test contracts are assembled by hand (rather than compiled), so that tests don't depend on an external toolchain.
+
+const (
+	wasmI64 = 0x7e // i64 value type
+
+	wasmFunc   = 0x00 // Function import/export kind
+	wasmMemory = 0x02 // Memory export kind
+)
+
+// wasmImport - a function imported from the env module
+type wasmImport struct {
+	field string // Imported field
+	typ   uint32 // Function type index
+}
+
+// wasmExport - an exported function or memory
+type wasmExport struct {
+	name  string // Export name
+	kind  byte   // Export kind
+	index uint32 // Function/memory index
+}
+
+// wasmModule - assemble a WASM module with one page of memory from a given set of function types (param counts; all values
+// are i64, and every function returns one value), imports, function type indexes, exports and function bodies (each starting
+// with its locals declarations, and without the trailing end instruction)
+func wasmModule(types []int, imports []wasmImport, funcs []uint32, exports []wasmExport, bodies [][]byte) []byte {
+	module := []byte{0x00, 'a', 's', 'm', 0x01, 0x00, 0x00, 0x00} // Magic, version
+
+	typeSection := wasmLEB(uint64(len(types))) // Init type section
+
+	for _, params := range types { // Iterate through types
+		typeSection = append(typeSection, 0x60)                                                      // Func type
+		typeSection = append(append(typeSection, wasmLEB(uint64(params))...), wasmRepeat(params)...) // Params
+		typeSection = append(typeSection, 0x01, wasmI64)                                             // Result
+	}
+
+	module = wasmSection(module, 1, typeSection) // Append type section
+
+	if len(imports) > 0 { // Check has imports
+		importSection := wasmLEB(uint64(len(imports))) // Init import section
+
+		for _, imported := range imports { // Iterate through imports
+			importSection = append(append(importSection, wasmName("env")...), wasmName(imported.field)...) // Module, field
+			importSection = append(append(importSection, wasmFunc), wasmLEB(uint64(imported.typ))...)      // Kind, type
+		}
+
+		module = wasmSection(module, 2, importSection) // Append import section
+	}
+
+	funcSection := wasmLEB(uint64(len(funcs))) // Init function section
+
+	for _, typ := range funcs { // Iterate through funcs
+		funcSection = append(funcSection, wasmLEB(uint64(typ))...) // Type index
+	}
+
+	module = wasmSection(module, 3, funcSection)           // Append function section
+	module = wasmSection(module, 5, []byte{1, 0x00, 0x01}) // Append memory section (1 page)
+
+	exportSection := wasmLEB(uint64(len(exports))) // Init export section
+
+	for _, exported := range exports { // Iterate through exports
+		exportSection = append(append(exportSection, wasmName(exported.name)...), exported.kind) // Name, kind
+		exportSection = append(exportSection, wasmLEB(uint64(exported.index))...)                // Index
+	}
+
+	module = wasmSection(module, 7, exportSection) // Append export section
+
+	codeSection := wasmLEB(uint64(len(bodies))) // Init code section
+
+	for _, body := range bodies { // Iterate through bodies
+		codeSection = append(append(codeSection, wasmLEB(uint64(len(body)+1))...), body...) // Body
+		codeSection = append(codeSection, 0x0b)                                             // End
+	}
+
+	return wasmSection(module, 10, codeSection) // Append code section
+}
+
+// wasmSection - append a section with a given ID, payload to a given module
+func wasmSection(module []byte, id byte, payload []byte) []byte {
+	return append(append(append(module, id), wasmLEB(uint64(len(payload)))...), payload...) // Append section
+}
+
+// wasmName - encode a given name
+func wasmName(name string) []byte {
+	return append(wasmLEB(uint64(len(name))), name...) // Return name
+}
+
+// wasmRepeat - get a given number of i64 value types
+func wasmRepeat(n int) []byte {
+	values := []byte{} // Init values buffer
+
+	for x := 0; x < n; x++ { // Repeat
+		values = append(values, wasmI64) // Append value type
+	}
+
+	return values // Return values
+}
+
+// wasmLEB - encode a given unsigned integer as unsigned LEB128
+func wasmLEB(n uint64) []byte {
+	encoded := []byte{} // Init encoded buffer
+
+	for { // Encode 7 bits at a time
+		b := byte(n & 0x7f) // Get low bits
+		n >>= 7             // Shift
+
+		if n == 0 { // Check last byte
+			return append(encoded, b) // Return encoded
+		}
+
+		encoded = append(encoded, b|0x80) // Append continued byte
+	}
+}
+
+// wasmI64Const - encode an i64.const instruction for a given (signed LEB128 encoded) value
+func wasmI64Const(n int64) []byte {
+	encoded := []byte{0x42} // Init encoded buffer
+
+	for { // Encode 7 bits at a time
+		b := byte(n & 0x7f) // Get low bits
+		n >>= 7             // Shift (arithmetic)
+
+		if (n == 0 && b&0x40 == 0) || (n == -1 && b&0x40 != 0) { // Check last byte
+			return append(encoded, b) // Return encoded
+		}
+
+		encoded = append(encoded, b|0x80) // Append continued byte
+	}
+}
+
+/* END INTERNAL METHODS */
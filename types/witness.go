@@ -0,0 +1,376 @@
+package types
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/config"
+	"github.com/SummerCash/go-summercash/crypto"
+)
+
+// witnessPrefix - domain separator prepended to a transaction hash before it is signed by a witness (so witnesses can't be
+// replayed as transaction signatures)
+const witnessPrefix = "witness"
+
+// Finality - finality status of a transaction
+type Finality struct {
+	Hash common.Hash `json:"hash"` // Transaction hash
+
+	Witnesses int `json:"witnesses"` // Number of witnesses
+
+	Weight         *common.Amount `json:"weight"`          // Accumulated witness weight
+	RequiredWeight *common.Amount `json:"required_weight"` // Witness weight at which the transaction is final
+
+	Final bool `json:"final"` // Whether the transaction is final
+}
+
+var (
+	// ErrNoStake - error definition describing a witness whose account holds no stake
+	ErrNoStake = errors.New("witnessing account holds no stake")
+
+	// ErrInvalidWitness - error definition describing a witness with an invalid signature
+	ErrInvalidWitness = errors.New("invalid witness signature")
+
+	// ErrMalformedWitness - error definition describing a witness encoding that could not be decoded
+	ErrMalformedWitness = errors.New("malformed witness encoding")
+)
+
+// witnessMutex - lock guarding the witness store
+var witnessMutex sync.Mutex
+
+/* BEGIN EXPORTED METHODS */
+
+// WitnessTransaction - witness given (validated) transaction with a given private key. The witness' weight is set by each node
+// recording it (see AddWitness).
+func WitnessTransaction(transaction *Transaction, privateKey *ecdsa.PrivateKey) (*Witness, error) {
+	if transaction.Hash == nil { // Check no hash
+		return &Witness{}, ErrNilTransaction // Return error
+	}
+
+	if isSelfWitness(transaction, &privateKey.PublicKey) { // Check witnessing own transaction
+		return &Witness{}, ErrCannotWitnessSelf // Return error
+	}
+
+	pkCopy := *privateKey // Get copy
+
+	signed := witnessSigningHash(*transaction.Hash) // Hash witnessed transaction
+
+	r, s, err := ecdsa.Sign(rand.Reader, &pkCopy, signed) // Sign hash
+	if err != nil {                                       // Check for errors
+		return &Witness{}, err // Return found error
+	}
+
+	return &Witness{
+		Signature: &Signature{
+			PublicKey: &privateKey.PublicKey, // Set public key
+			V:         signed,                // Set val
+			R:         r,                     // Set R
+			S:         s,                     // Set S
+		}, // Set signature
+		Address: common.PublicKeyToAddress(&privateKey.PublicKey), // Set witnessing address
+	}, nil // Return witness
+}
+
+// VerifyWitness - verify given witness' signature of the transaction with a given hash
+func VerifyWitness(witness *Witness, hash common.Hash) bool {
+	if witness.Signature == nil || witness.Signature.PublicKey == nil || witness.Signature.R == nil || witness.Signature.S == nil { // Check nil signature
+		return false // Invalid
+	}
+
+	if !witness.Address.IsDerivedFrom(witness.Signature.PublicKey) { // Check public key not witness'
+		return false // Invalid
+	}
+
+	return ecdsa.Verify(witness.Signature.PublicKey, witnessSigningHash(hash), witness.Signature.R, witness.Signature.S) // Check signature valid
+}
+
+// EncodeWitness - encode a given witness of the transaction with a given hash for transport (the hash, followed by the witness'
+// PKIX public key, R and S as length-prefixed bytes)
+func EncodeWitness(hash common.Hash, witness *Witness) ([]byte, error) {
+	publicKey, err := x509.MarshalPKIXPublicKey(witness.Signature.PublicKey) // Encode public key
+	if err != nil {                                                          // Check for errors
+		return nil, err // Return found error
+	}
+
+	buffer := bytes.NewBuffer(hash.Bytes()) // Init buffer
+
+	writeBytes(buffer, publicKey)                   // Write public key
+	writeBytes(buffer, witness.Signature.R.Bytes()) // Write R
+	writeBytes(buffer, witness.Signature.S.Bytes()) // Write S
+
+	return buffer.Bytes(), nil // Return encoded
+}
+
+// DecodeWitness - decode a witness encoded with EncodeWitness, returning the witnessed transaction hash and the witness
+func DecodeWitness(b []byte) (common.Hash, *Witness, error) {
+	decoder := &transactionDecoder{reader: bytes.NewReader(b)} // Init decoder
+
+	hash := common.Hash{} // Init hash buffer
+
+	copy(hash[:], decoder.read(common.HashLength)) // Read hash
+
+	encodedPublicKey, r, s := decoder.readBytes(), decoder.readBytes(), decoder.readBytes() // Read signature
+
+	if decoder.err != nil || decoder.reader.Len() != 0 { // Check for errors
+		return common.Hash{}, &Witness{}, ErrMalformedWitness // Return error
+	}
+
+	publicKey, err := parseWitnessPublicKey(encodedPublicKey) // Parse public key
+	if err != nil {                                           // Check for errors
+		return common.Hash{}, &Witness{}, err // Return found error
+	}
+
+	return hash, &Witness{
+		Signature: &Signature{
+			PublicKey: publicKey,                // Set public key
+			V:         witnessSigningHash(hash), // Set signed value
+			R:         new(big.Int).SetBytes(r), // Set R
+			S:         new(big.Int).SetBytes(s), // Set S
+		}, // Set signature
+		Address: common.PublicKeyToAddress(publicKey), // Set witnessing address
+	}, nil // Return witness
+}
+
+// AddWitness - verify a given witness of a given staked account of a given transaction, and record it in persistent memory. Returns
+// false if the account has already witnessed the transaction.
+func AddWitness(transaction *Transaction, witness *Witness) (bool, error) {
+	if transaction.Hash == nil { // Check no hash
+		return false, ErrNilTransaction // Return error
+	}
+
+	if !VerifyWitness(witness, *transaction.Hash) { // Check invalid witness
+		return false, ErrInvalidWitness // Return error
+	}
+
+	if isSelfWitness(transaction, witness.Signature.PublicKey) { // Check witnessing own transaction
+		return false, ErrCannotWitnessSelf // Return error
+	}
+
+	weight := witnessStake(witness.Address) // Get stake
+
+	if weight.Sign() <= 0 { // Check no stake
+		return false, ErrNoStake // Return error
+	}
+
+	witnessMutex.Lock()         // Lock witness store
+	defer witnessMutex.Unlock() // Unlock witness store
+
+	witnesses, err := readWitnesses(*transaction.Hash) // Read recorded witnesses
+	if err != nil {                                    // Check for errors
+		return false, err // Return found error
+	}
+
+	for _, recorded := range witnesses { // Iterate through recorded witnesses
+		if recorded.Address == witness.Address { // Check already witnessed
+			return false, nil // Already witnessed
+		}
+	}
+
+	recorded := *witness // Copy witness
+
+	recorded.Weight = weight // Set weight
+
+	return true, writeWitnesses(*transaction.Hash, append(witnesses, &recorded)) // Write witnesses
+}
+
+// GetWitnesses - get all of the recorded witnesses of the transaction with a given hash
+func GetWitnesses(hash common.Hash) ([]*Witness, error) {
+	witnessMutex.Lock()         // Lock witness store
+	defer witnessMutex.Unlock() // Unlock witness store
+
+	return readWitnesses(hash) // Read witnesses
+}
+
+// GetFinality - get the finality status of the transaction with a given hash. A transaction is final once the accumulated weight of
+// its witnesses reaches the chain config's finality threshold of the current supply. Each witness is weighed by its account's
+// balance at the time finality is calculated (rather than when it witnessed), so coins moved to another account and used to
+// witness again are only counted once.
+func GetFinality(hash common.Hash, chainConfig *config.ChainConfig) (*Finality, error) {
+	witnesses, err := GetWitnesses(hash) // Get witnesses
+	if err != nil {                      // Check for errors
+		return &Finality{}, err // Return found error
+	}
+
+	supply := chainConfig.InitialSupply() // Init supply buffer
+
+	if genesisChain, err := ReadGenesisChainFromMemory(chainConfig); err == nil { // Check has genesis
+		supply = supply.Add(genesisChain.Minted(chainConfig)) // Add committed mints
+	}
+
+	finality := &Finality{
+		Hash:           hash,                               // Set hash
+		Witnesses:      len(witnesses),                     // Set number of witnesses
+		Weight:         common.NewAmount(nil),              // Set weight
+		RequiredWeight: chainConfig.FinalityWeight(supply), // Set required weight
+	} // Init finality
+
+	counted := make(map[common.Address]bool) // Init counted witnesses buffer
+
+	for _, witness := range witnesses { // Iterate through witnesses
+		if counted[witness.Address] { // Check already counted
+			continue // Continue
+		}
+
+		counted[witness.Address] = true // Set counted
+
+		finality.Weight = finality.Weight.Add(witnessStake(witness.Address)) // Accumulate weight
+	}
+
+	finality.Final = finality.Weight.Sign() > 0 && finality.Weight.Cmp(finality.RequiredWeight) >= 0 // Check final
+
+	return finality, nil // Return finality
+}
+
+// String - convert given finality status to string
+func (finality *Finality) String() string {
+	status := "pending" // Init status
+
+	if finality.Final { // Check final
+		status = "final" // Set status
+	}
+
+	return fmt.Sprintf("%s: %s (%d witnesses, weight %s of %s required)", finality.Hash.String(), status, finality.Witnesses, finality.Weight.String(), finality.RequiredWeight.String()) // Return string
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// witnessSigningHash - get the value signed by witnesses of the transaction with a given hash
+func witnessSigningHash(hash common.Hash) []byte {
+	return crypto.Sha3(append([]byte(witnessPrefix), hash.Bytes()...)) // Return hash
+}
+
+// isSelfWitness - check whether a given public key controls given transaction's sender
+func isSelfWitness(transaction *Transaction, publicKey *ecdsa.PublicKey) bool {
+	if transaction.Sender != nil && transaction.Sender.IsDerivedFrom(publicKey) { // Check is sender
+		return true // Self
+	}
+
+	if transaction.Multisig != nil && transaction.Multisig.Policy != nil { // Check multisig
+		if _, err := transaction.Multisig.Policy.KeyIndex(publicKey); err == nil { // Check is co-signer
+			return true // Self
+		}
+	}
+
+	return false // Not self
+}
+
+// witnessStake - get the current stake (balance) of a given witnessing account (zero if the account has no chain)
+func witnessStake(address common.Address) *common.Amount {
+	witnessChain, err := ReadChainFromMemory(address) // Read witness chain
+	if err != nil {                                   // Check for errors
+		return common.NewAmount(nil) // No stake
+	}
+
+	stake := witnessChain.CalculateBalance() // Get stake
+
+	if stake.Sign() < 0 { // Check negative balance
+		return common.NewAmount(nil) // No stake
+	}
+
+	return stake // Return stake
+}
+
+// parseWitnessPublicKey - parse a given PKIX-encoded ECDSA public key
+func parseWitnessPublicKey(encoded []byte) (*ecdsa.PublicKey, error) {
+	genericPublicKey, err := x509.ParsePKIXPublicKey(encoded) // Parse public key
+	if err != nil {                                           // Check for errors
+		return nil, ErrMalformedWitness // Return error
+	}
+
+	publicKey, ok := genericPublicKey.(*ecdsa.PublicKey) // Get public key value
+	if !ok {                                             // Check not ecdsa
+		return nil, ErrMalformedWitness // Return error
+	}
+
+	return publicKey, nil // Return public key
+}
+
+// witnessPath - get the path of the witness file of the transaction with a given hash
+func witnessPath(hash common.Hash) string {
+	return filepath.FromSlash(fmt.Sprintf("%s/witnesses/witnesses_%s.json", common.DataDir, hash.String())) // Return path
+}
+
+// readWitnesses - read the recorded witnesses of the transaction with a given hash from persistent memory
+func readWitnesses(hash common.Hash) ([]*Witness, error) {
+	data, err := ioutil.ReadFile(witnessPath(hash)) // Read witnesses
+	if os.IsNotExist(err) {                         // Check no witnesses
+		return []*Witness{}, nil // No witnesses
+	} else if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	witnesses := []*Witness{} // Init witnesses buffer
+
+	err = json.Unmarshal(data, &witnesses) // Unmarshal into buffer
+
+	if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	for _, witness := range witnesses { // Iterate through witnesses
+		block, _ := pem.Decode(witness.Signature.SerializedPublicKey) // Decode PEM
+
+		if block == nil { // Check invalid PEM
+			return nil, ErrMalformedWitness // Return error
+		}
+
+		witness.Signature.PublicKey, err = parseWitnessPublicKey(block.Bytes) // Recover public key
+
+		if err != nil { // Check for errors
+			return nil, err // Return found error
+		}
+	}
+
+	return witnesses, nil // Return witnesses
+}
+
+// writeWitnesses - write the witnesses of the transaction with a given hash to persistent memory
+func writeWitnesses(hash common.Hash, witnesses []*Witness) error {
+	safeWitnesses := []Witness{} // Init safely-encodable witnesses buffer
+
+	for _, witness := range witnesses { // Iterate through witnesses
+		signature := *witness.Signature // Copy signature (public keys are frequently shared)
+
+		encoded, err := x509.MarshalPKIXPublicKey(signature.PublicKey) // Encode public key
+		if err != nil {                                                // Check for errors
+			return err // Return found error
+		}
+
+		signature.SerializedPublicKey = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encoded}) // Encode PEM
+
+		safeWitness := *witness // Copy witness
+
+		safeWitness.Signature = &signature // Set signature
+
+		safeWitnesses = append(safeWitnesses, safeWitness) // Append witness
+	}
+
+	json, err := json.MarshalIndent(safeWitnesses, "", "  ") // Marshal witnesses
+	if err != nil {                                          // Check for errors
+		return err // Return found error
+	}
+
+	err = common.CreateDirIfDoesNotExist(filepath.FromSlash(fmt.Sprintf("%s/witnesses", common.DataDir))) // Create dir if necessary
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	return ioutil.WriteFile(witnessPath(hash), json, 0644) // Write witnesses
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,175 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"testing"
+
+	"github.com/SummerCash/go-summercash/common"
+	"github.com/SummerCash/go-summercash/config"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// TestWitnessTransaction - test witness signing, verification and encoding
+func TestWitnessTransaction(t *testing.T) {
+	senderKey, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)  // Generate sender key
+	witnessKey, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate witness key
+
+	sender := common.PublicKeyToAddress(&senderKey.PublicKey) // Get sender address
+
+	transaction, err := NewTransaction(0, nil, &sender, &common.Address{0x2}, common.Coins(1), nil) // Initialize transaction
+	if err != nil {                                                                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := WitnessTransaction(transaction, senderKey); err != ErrCannotWitnessSelf { // Check self-witness rejected
+		t.Fatalf("expected cannot witness self, got %v", err) // Panic
+	}
+
+	witness, err := WitnessTransaction(transaction, witnessKey) // Witness transaction
+	if err != nil {                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	encoded, err := EncodeWitness(*transaction.Hash, witness) // Encode witness
+	if err != nil {                                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	hash, decoded, err := DecodeWitness(encoded) // Decode witness
+	if err != nil {                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if hash != *transaction.Hash || decoded.Address != witness.Address || !VerifyWitness(decoded, hash) { // Check round trip
+		t.Fatal("expected witness to survive encoding") // Panic
+	}
+
+	if VerifyWitness(decoded, common.Hash{0x1}) { // Check witness bound to transaction
+		t.Fatal("expected witness of another transaction to be rejected") // Panic
+	}
+
+	if _, _, err := DecodeWitness(encoded[:len(encoded)-1]); err != ErrMalformedWitness { // Check truncated witness rejected
+		t.Fatalf("expected malformed witness, got %v", err) // Panic
+	}
+}
+
+// TestGetFinality - test that transactions become final once enough stake has witnessed them
+func TestGetFinality(t *testing.T) {
+	transaction, err := NewTransaction(0, nil, &common.Address{0x1}, &common.Address{0x2}, common.Coins(1), []byte("finality")) // Initialize transaction
+	if err != nil {                                                                                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.Remove(witnessPath(*transaction.Hash)) // Remove witnesses
+
+	chainConfig := &config.ChainConfig{
+		Alloc:          map[string]*common.Amount{common.Address{0x1}.String(): common.Coins(30)}, // Set alloc
+		AllocAddresses: []common.Address{{0x1}},                                                   // Set alloc addresses
+	} // Init config (two thirds of 30 coins must witness)
+
+	unstakedKey, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate key without stake
+
+	unstaked, _ := WitnessTransaction(transaction, unstakedKey) // Witness without stake
+
+	if _, err := AddWitness(transaction, unstaked); err != ErrNoStake { // Check unstaked witness rejected
+		t.Fatalf("expected no stake, got %v", err) // Panic
+	}
+
+	for x := 0; x < 2; x++ { // Add staked witnesses
+		witnessKey := newTestStakedKey(t, common.Coins(10)) // Generate staked key
+
+		witness, _ := WitnessTransaction(transaction, witnessKey) // Witness transaction
+
+		if added, err := AddWitness(transaction, witness); !added || err != nil { // Record witness
+			t.Fatalf("expected witness to be recorded, got %t, %v", added, err) // Panic
+		}
+
+		if added, _ := AddWitness(transaction, witness); added { // Check witness only counted once
+			t.Fatal("expected duplicate witness to be ignored") // Panic
+		}
+
+		finality, err := GetFinality(*transaction.Hash, chainConfig) // Get finality
+		if err != nil {                                              // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if finality.Final != (x == 1) || finality.Weight.Cmp(common.Coins(int64(10*(x+1)))) != 0 { // Check final after 20 of 30 coins witnessed
+			t.Fatalf("unexpected finality after %d witnesses: %s", x+1, finality.String()) // Panic
+		}
+	}
+}
+
+// TestGetFinalityMovedStake - test that coins moved to another account and used to witness again are only counted once
+func TestGetFinalityMovedStake(t *testing.T) {
+	transaction, err := NewTransaction(0, nil, &common.Address{0x1}, &common.Address{0x2}, common.Coins(1), []byte("moved stake")) // Initialize transaction
+	if err != nil {                                                                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.Remove(witnessPath(*transaction.Hash)) // Remove witnesses
+
+	chainConfig := &config.ChainConfig{
+		Alloc:          map[string]*common.Amount{common.Address{0x1}.String(): common.Coins(30)}, // Set alloc
+		AllocAddresses: []common.Address{{0x1}},                                                   // Set alloc addresses
+	} // Init config (two thirds of 30 coins must witness)
+
+	for x := 0; x < 2; x++ { // Witness twice with the same coins
+		witnessKey := newTestStakedKey(t, common.Coins(15)) // Generate staked key
+
+		witness, _ := WitnessTransaction(transaction, witnessKey) // Witness transaction
+
+		if added, err := AddWitness(transaction, witness); !added || err != nil { // Record witness
+			t.Fatalf("expected witness to be recorded, got %t, %v", added, err) // Panic
+		}
+
+		err = (&Chain{Account: witness.Address}).WriteToMemory() // Move coins out of witnessing account
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	newTestStakedKey(t, common.Coins(15)) // Move coins into an account that hasn't witnessed
+
+	finality, err := GetFinality(*transaction.Hash, chainConfig) // Get finality
+	if err != nil {                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if finality.Final || finality.Weight.Sign() != 0 { // Check moved coins not counted
+		t.Fatalf("expected moved stake not to count towards finality: %s", finality.String()) // Panic
+	}
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newTestStakedKey generates a private key whose account chain holds a given balance.
+func newTestStakedKey(t *testing.T, stake *common.Amount) *ecdsa.PrivateKey {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	address := common.PublicKeyToAddress(&privateKey.PublicKey) // Get address
+
+	genesis, err := NewTransaction(0, nil, nil, &address, stake, []byte("stake")) // Initialize genesis transaction
+	if err != nil {                                                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	chain := &Chain{Account: address, Genesis: *genesis.Hash, Transactions: []*Transaction{genesis}} // Init funded chain
+
+	if err := chain.WriteToMemory(); err != nil { // Write chain
+		t.Fatal(err) // Panic
+	}
+
+	return privateKey // Return key
+}
+
+/* END INTERNAL METHODS */
@@ -7,14 +7,12 @@ package validator
 import (
 	"bytes"
 	"errors"
-	"math/big"
 
 	"github.com/SummerCash/ursa/compiler"
 
 	"github.com/SummerCash/go-summercash/common"
 
 	"github.com/SummerCash/go-summercash/config"
-	"github.com/SummerCash/go-summercash/crypto"
 	"github.com/SummerCash/go-summercash/types"
 )
 
@@ -42,6 +40,14 @@ var (
 
 	// ErrInvalidNonce is an error definition representing a transaction of invalid nonce value.
 	ErrInvalidNonce = errors.New("invalid transaction nonce")
+
+	// ErrConflictingTransaction is an error definition representing a transaction whose sender has already sent a different
+	// transaction with the same nonce (a double spend).
+	ErrConflictingTransaction = errors.New("sender has already sent a different transaction with the same nonce (double spend)")
+
+	// ErrMintTransaction is an error definition representing a received minting transaction. Minting transactions are derived
+	// by each node from the chain config (see types.Chain.Mint), and are never sent or signed.
+	ErrMintTransaction = errors.New("minting transactions cannot be sent")
 )
 
 // StandardValidator represents a standard validator implementing the validator interface.
@@ -61,6 +67,10 @@ func NewStandardValidator(config *config.ChainConfig) *StandardValidator {
 // ValidateTransaction validates the given transaction via the standard validator.
 // Each validation issue is returned as an error.
 func (validator *StandardValidator) ValidateTransaction(transaction *types.Transaction) error {
+	if !validator.ValidateTransactionIsNotMint(transaction) { // Check is mint
+		return ErrMintTransaction // Mint
+	}
+
 	err := validator.PerformChainSafetyChecks(transaction) // Perform safety checks
 	if err != nil {                                        // Check for errors
 		return err // Return found error
@@ -90,6 +100,10 @@ func (validator *StandardValidator) ValidateTransaction(transaction *types.Trans
 		return ErrDuplicateTransaction // Duplicate
 	}
 
+	if !validator.ValidateTransactionIsNotConflicting(transaction) { // Check double spend
+		return ErrConflictingTransaction // Double spend
+	}
+
 	if !validator.ValidateTransactionNonce(transaction) { // Check valid nonce
 		return ErrInvalidNonce // Invalid nonce
 	}
@@ -122,16 +136,11 @@ func (validator *StandardValidator) PerformChainSafetyChecks(transaction *types.
 
 // ValidateTransactionHash checks that a given transaction's hash is equivalent to the calculated hash of that given transaction.
 func (validator *StandardValidator) ValidateTransactionHash(transaction *types.Transaction) bool {
-	unsignedTx := *transaction // Init unsigned buffer
-
-	unsignedTx.Signature = nil // Set signature to nil
-	unsignedTx.Hash = nil      // Set hash to nil
-
-	return bytes.Equal(transaction.Hash.Bytes(), common.NewHash(crypto.Sha3(unsignedTx.Bytes())).Bytes()) // Return hashes equivalent
+	return transaction.Hash != nil && *transaction.Hash == transaction.CalculateHash() // Return hashes equivalent
 }
 
-// ValidateTransactionState checks that a given transaction's working state is equivalent to that of the parent, with the given
-// transition applied.
+// ValidateTransactionState checks that a given transaction's state (the contract's storage root and gas used) is equivalent to
+// that resulting from re-executing the call against the root committed before it.
 func (validator *StandardValidator) ValidateTransactionState(transaction *types.Transaction) bool {
 	if transaction.State == nil { // Check no state
 		return true // Valid
@@ -140,7 +149,7 @@ func (validator *StandardValidator) ValidateTransactionState(transaction *types.
 	gasPolicy := compiler.GasPolicy(common.GasPolicy) // Get gas policy
 
 	localState, err := transaction.EvaluateNewState(&gasPolicy) // Calculate local state
-	if err != nil {                                             // Check for errors
+	if err != nil && err != types.ErrGasLimitExceeded {         // Check for errors
 		return false // Invalid
 	}
 
@@ -172,9 +181,10 @@ func (validator *StandardValidator) ValidateTransactionTimestamp(transaction *ty
 }
 
 // ValidateTransactionSignature validates the given transaction's signature against the transaction sender's public key.
-// If the transaction's signature is nil, false is returned.
+// Transactions sent by multisig accounts must instead carry valid signatures from at least as many of the keys in the
+// sender's spending policy as the policy requires. If the transaction is unsigned, false is returned.
 func (validator *StandardValidator) ValidateTransactionSignature(transaction *types.Transaction) bool {
-	if transaction.Signature == nil { // Check has no signature
+	if !transaction.IsSigned() { // Check has no signature
 		return false // Nil signature
 	}
 
@@ -188,10 +198,14 @@ func (validator *StandardValidator) ValidateTransactionSignature(transaction *ty
 
 // ValidateTransactionSenderBalance checks that a given transaction's sender has a balance greater than or equal to the transaction's total value (including gas costs).
 func (validator *StandardValidator) ValidateTransactionSenderBalance(transaction *types.Transaction) bool {
-	if transaction.Amount.Cmp(big.NewFloat(0)) == -1 { // Check is negative number
+	if transaction.Amount == nil || transaction.Amount.Sign() == -1 { // Check is nil or negative number
 		return false // Invalid tx amount
 	}
 
+	if transaction.GasPrice.Sign() == -1 { // Check is negative gas price
+		return false // Invalid tx gas price
+	}
+
 	chain, err := types.ReadChainFromMemory(*transaction.Sender) // Read sender chain
 	if err != nil {
 		return false // Invalid
@@ -199,7 +213,9 @@ func (validator *StandardValidator) ValidateTransactionSenderBalance(transaction
 
 	balance := chain.CalculateBalance() // Calculate balance
 
-	return balance.Cmp(transaction.Amount) == 0 || balance.Cmp(transaction.Amount) == 1 // Return sender balance adequate
+	totalValue := transaction.Amount.Add(transaction.MaxFee()) // Calculate amount + max fee
+
+	return balance.Cmp(totalValue) == 0 || balance.Cmp(totalValue) == 1 // Return sender balance adequate
 }
 
 // ValidateTransactionIsNotDuplicate checks that a given transaction does not already exist in the working dag.
@@ -218,6 +234,28 @@ func (validator *StandardValidator) ValidateTransactionIsNotDuplicate(transactio
 	return true // Transaction is unique
 }
 
+// ValidateTransactionIsNotConflicting checks that a given transaction's sender hasn't already sent a different transaction with the
+// same nonce.
+func (validator *StandardValidator) ValidateTransactionIsNotConflicting(transaction *types.Transaction) bool {
+	store, err := types.GetChainStore() // Get working chain store
+	if err != nil {                     // Check for errors
+		return false // Invalid
+	}
+
+	existing, err := store.QueryTransactionByNonce(*transaction.Sender, transaction.AccountNonce) // Query tx with same nonce
+
+	if err != nil { // Check no tx with same nonce
+		return true // Transaction doesn't conflict
+	}
+
+	return existing.Hash == nil || *existing.Hash == *transaction.Hash // Return same tx
+}
+
+// ValidateTransactionIsNotMint checks that a given transaction is not a minting transaction.
+func (validator *StandardValidator) ValidateTransactionIsNotMint(transaction *types.Transaction) bool {
+	return !transaction.Mint // Return is not mint
+}
+
 // ValidateTransactionNonce checks that a given transaction's nonce is equivalent to the sending account's last nonce + 1.
 func (validator *StandardValidator) ValidateTransactionNonce(transaction *types.Transaction) bool {
 	chain, err := types.ReadChainFromMemory(*transaction.Sender) // Read sender chain
@@ -25,6 +25,10 @@ type Validator interface {
 
 	ValidateTransactionIsNotDuplicate(transaction *types.Transaction) bool // Validate that a given transaction does not already exist in the working chain
 
+	ValidateTransactionIsNotConflicting(transaction *types.Transaction) bool // Validate that a given transaction's sender hasn't sent a different transaction with the same nonce
+
+	ValidateTransactionIsNotMint(transaction *types.Transaction) bool // Validate that a given transaction is not a minting transaction
+
 	// ValidateTransactionReward(transaction *types.Transaction) bool // Validate a given transaction reward
 
 	ValidateTransactionNonce(transaction *types.Transaction) bool // Validate that a given transaction's nonce is equivalent to the current account index + 1